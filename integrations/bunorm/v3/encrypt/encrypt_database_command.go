@@ -1,7 +1,11 @@
 package encrypt
 
 import (
+    "fmt"
+    "strings"
+
     clicontract "github.com/precision-soft/melody/v3/cli/contract"
+    "github.com/precision-soft/melody/v3/cli/interaction"
     "github.com/precision-soft/melody/v3/exception"
     "github.com/precision-soft/melody/v3/logging"
     runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
@@ -41,6 +45,7 @@ func (instance *EncryptDatabaseCommand) Flags() []clicontract.Flag {
         &clicontract.StringFlag{Name: "target-key", Usage: "key id to re-encrypt under (mode=reencrypt)"},
         &clicontract.IntFlag{Name: "batch", Value: defaultMigrateBatchSize, Usage: "rows per batch"},
         &clicontract.BoolFlag{Name: "deterministic", Usage: "use deterministic (searchable) encryption for the columns"},
+        interaction.ForceFlag(),
     }
 }
 
@@ -48,6 +53,8 @@ func (instance *EncryptDatabaseCommand) Run(
     runtimeInstance runtimecontract.Runtime,
     commandContext *clicontract.CommandContext,
 ) error {
+    console := interaction.FromCommand(commandContext)
+
     spec := TableSpec{
         Table:         commandContext.String("table"),
         PrimaryKey:    commandContext.String("primary-key"),
@@ -57,6 +64,52 @@ func (instance *EncryptDatabaseCommand) Run(
     }
 
     mode := commandContext.String("mode")
+    if migrateModeEncrypt != mode && migrateModeReencrypt != mode && migrateModeDecrypt != mode {
+        return exception.NewError("unknown mode", map[string]any{"mode": mode}, nil)
+    }
+
+    /* @info missing input is asked for when the command may prompt; under --no-interaction or without a terminal the prompt fails and names the flag. */
+    if "" == spec.Table {
+        table, askErr := console.Ask("table to process (--table)", "")
+        if nil != askErr {
+            return askErr
+        }
+
+        spec.Table = table
+    }
+
+    if 0 == len(spec.Columns) {
+        columns, askErr := console.Ask("comma-separated columns to process (--column)", "")
+        if nil != askErr {
+            return askErr
+        }
+
+        spec.Columns = splitColumns(columns)
+    }
+
+    targetKey := commandContext.String("target-key")
+    if migrateModeReencrypt == mode && "" == targetKey {
+        askedTargetKey, askErr := console.Ask("key id to re-encrypt under (--target-key)", "")
+        if nil != askErr {
+            return exception.NewError("mode reencrypt requires --target-key", nil, askErr)
+        }
+
+        targetKey = askedTargetKey
+    }
+
+    confirmErr := console.ConfirmDestructive(
+        fmt.Sprintf("%s the columns %s of table %s?", mode, strings.Join(spec.Columns, ", "), spec.Table),
+    )
+    if nil != confirmErr {
+        return confirmErr
+    }
+
+    progressBar := console.ProgressBar(0)
+    progressBar.SetMessage(spec.Table)
+    spec.Progress = func(processed int) {
+        progressBar.Advance(processed - progressBar.Current())
+    }
+
     ctx := runtimeInstance.Context()
 
     var processed int
@@ -66,17 +119,13 @@ func (instance *EncryptDatabaseCommand) Run(
     case migrateModeEncrypt:
         processed, runErr = instance.migrator.MigrateEncrypt(ctx, spec)
     case migrateModeReencrypt:
-        targetKey := commandContext.String("target-key")
-        if "" == targetKey {
-            return exception.NewError("mode reencrypt requires --target-key", nil, nil)
-        }
         processed, runErr = instance.migrator.MigrateReencrypt(ctx, spec, targetKey)
     case migrateModeDecrypt:
         processed, runErr = instance.migrator.MigrateDecrypt(ctx, spec)
-    default:
-        return exception.NewError("unknown mode", map[string]any{"mode": mode}, nil)
     }
 
+    progressBar.Finish()
+
     if nil != runErr {
         return runErr
     }
@@ -88,4 +137,16 @@ func (instance *EncryptDatabaseCommand) Run(
     return nil
 }
 
+func splitColumns(columns string) []string {
+    result := make([]string, 0)
+    for _, column := range strings.Split(columns, ",") {
+        column = strings.TrimSpace(column)
+        if "" != column {
+            result = append(result, column)
+        }
+    }
+
+    return result
+}
+
 var _ clicontract.Command = (*EncryptDatabaseCommand)(nil)
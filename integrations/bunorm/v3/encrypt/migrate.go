@@ -20,6 +20,9 @@ type TableSpec struct {
     BatchSize  int
 
     Deterministic bool
+
+    /* @info called after every batch with the number of rows processed so far, e.g. to advance a cli progress bar. */
+    Progress func(processed int)
 }
 
 type Migrator struct {
@@ -165,6 +168,10 @@ func (instance *Migrator) run(ctx context.Context, spec TableSpec, transform fun
             processed++
         }
 
+        if nil != spec.Progress {
+            spec.Progress(processed)
+        }
+
         if len(batch) < batchSize {
             break
         }
@@ -0,0 +1,56 @@
+package bunorm
+
+import (
+    "context"
+    "time"
+
+    "github.com/uptrace/bun"
+
+    "github.com/precision-soft/melody/v3/profiler"
+)
+
+/*
+ * NewProfilerQueryHook returns a bun query hook recording every query run with a request context into the profile of
+ * that request (see profiler.FromContext). Queries run without a profiled context are ignored, so the hook can stay
+ * registered in every environment.
+ */
+func NewProfilerQueryHook(connectionName string) *ProfilerQueryHook {
+    return &ProfilerQueryHook{
+        connectionName: connectionName,
+    }
+}
+
+type ProfilerQueryHook struct {
+    connectionName string
+}
+
+func (instance *ProfilerQueryHook) BeforeQuery(ctx context.Context, event *bun.QueryEvent) context.Context {
+    return ctx
+}
+
+func (instance *ProfilerQueryHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
+    profile := profiler.FromContext(ctx)
+    if nil == profile {
+        return
+    }
+
+    profile.RecordQuery(
+        profiler.QueryRecord{
+            Connection: instance.connectionName,
+            Query:      event.Query,
+            StartedAt:  event.StartTime,
+            Duration:   time.Since(event.StartTime),
+            Error:      errorString(event.Err),
+        },
+    )
+}
+
+func errorString(err error) string {
+    if nil == err {
+        return ""
+    }
+
+    return err.Error()
+}
+
+var _ bun.QueryHook = (*ProfilerQueryHook)(nil)
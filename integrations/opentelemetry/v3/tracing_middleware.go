@@ -9,6 +9,7 @@
     "go.opentelemetry.io/otel/trace"
 
     httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    "github.com/precision-soft/melody/v3/logging"
     "github.com/precision-soft/melody/v3/runtime"
     runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
 )
@@ -45,6 +46,15 @@ func NewTracingMiddleware(tracer trace.Tracer, propagator propagation.TextMapPro
                 panic(recovered)
             }()
 
+            /* @info every log call of the request carries the trace, so log lines can be joined with the trace */
+            if true == span.SpanContext().IsValid() {
+                logContext := logging.LogContextFromRuntime(runtimeInstance)
+                if nil != logContext {
+                    logContext.Set("traceId", span.SpanContext().TraceID().String())
+                    logContext.Set("spanId", span.SpanContext().SpanID().String())
+                }
+            }
+
             tracedRuntime := runtime.New(spanContext, runtimeInstance.Scope(), runtimeInstance.Container())
 
             response, handlerErr := next(tracedRuntime, writer, request)
@@ -8,6 +8,8 @@
 
     sdktrace "go.opentelemetry.io/otel/sdk/trace"
     "go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+    "github.com/precision-soft/melody/v3/logging"
 )
 
 func TestTracingMiddleware_RecordsServerSpan(t *testing.T) {
@@ -48,3 +50,26 @@ func TestTracingMiddleware_RecordsServerSpan(t *testing.T) {
         t.Fatalf("expected method and status attributes on the span")
     }
 }
+
+func TestTracingMiddleware_AddsTraceToRequestLogContext(t *testing.T) {
+    recorder := tracetest.NewSpanRecorder()
+    provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+    request, runtimeInstance := testRequestAndRuntime()
+
+    logContext := logging.NewLogContext()
+    runtimeInstance.Scope().MustOverrideProtectedInstance(logging.ServiceLogContext, logContext)
+
+    handler := NewTracingMiddleware(provider.Tracer("melody-test"), nil)(okHandler())
+    if _, handlerErr := handler(runtimeInstance, httptest.NewRecorder(), request); nil != handlerErr {
+        t.Fatalf("handler: %v", handlerErr)
+    }
+
+    spans := recorder.Ended()
+    traceId, _ := logContext.Get("traceId")
+    spanId, _ := logContext.Get("spanId")
+
+    if spans[0].SpanContext().TraceID().String() != traceId || spans[0].SpanContext().SpanID().String() != spanId {
+        t.Fatalf("expected trace and span id in the log context, got %v %v", traceId, spanId)
+    }
+}
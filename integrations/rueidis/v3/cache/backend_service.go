@@ -114,7 +114,10 @@ func (instance *BackendService) Close() error {
     return instance.backend.Close()
 }
 
-var _ cachecontract.Backend = (*BackendService)(nil)
+var (
+    _ cachecontract.Backend       = (*BackendService)(nil)
+    _ cachecontract.PrefixClearer = (*BackendService)(nil)
+)
 
 func BackendFromRuntime(runtimeInstance runtimecontract.Runtime, serviceName string) *Backend {
     return runtime.MustFromRuntime[*BackendService](runtimeInstance, serviceName).WithContext(runtimeInstance.Context())
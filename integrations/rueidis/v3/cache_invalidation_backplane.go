@@ -0,0 +1,222 @@
+package rueidis
+
+import (
+    "context"
+    "encoding/json"
+    "sync"
+    "time"
+
+    melodycache "github.com/precision-soft/melody/v3/cache"
+    "github.com/precision-soft/melody/v3/exception"
+    loggingcontract "github.com/precision-soft/melody/v3/logging/contract"
+    "github.com/redis/rueidis"
+)
+
+const defaultCacheInvalidationBackplaneChannel = "melody:cache:invalidation"
+
+type cacheInvalidationWireMessage struct {
+    Origin string   `json:"origin"`
+    Keys   []string `json:"keys,omitempty"`
+    Clear  bool     `json:"clear,omitempty"`
+}
+
+type CacheInvalidationBackplane struct {
+    client     rueidis.Client
+    chainCache *melodycache.ChainCache
+    channel    string
+    origin     string
+    logger     loggingcontract.Logger
+    reconnect  ReconnectConfig
+
+    ctx    context.Context
+    cancel context.CancelFunc
+    wait   sync.WaitGroup
+}
+
+type CacheInvalidationBackplaneOption func(*CacheInvalidationBackplane)
+
+func WithCacheInvalidationBackplaneChannel(channel string) CacheInvalidationBackplaneOption {
+    return func(backplane *CacheInvalidationBackplane) {
+        backplane.channel = channel
+    }
+}
+
+func WithCacheInvalidationBackplaneLogger(logger loggingcontract.Logger) CacheInvalidationBackplaneOption {
+    return func(backplane *CacheInvalidationBackplane) {
+        backplane.logger = logger
+    }
+}
+
+func WithCacheInvalidationBackplaneReconnectConfig(reconnectConfig *ReconnectConfig) CacheInvalidationBackplaneOption {
+    return func(backplane *CacheInvalidationBackplane) {
+        backplane.reconnect = resolveReconnectConfig(reconnectConfig)
+    }
+}
+
+/*
+ * NewCacheInvalidationBackplane attaches itself to chainCache and keeps the local levels of every replica coherent:
+ * keys written, deleted or cleared on one instance are published on a redis channel and dropped from the local level
+ * of the others. Messages of the instance's own origin are ignored, its local level is already up to date.
+ */
+func NewCacheInvalidationBackplane(client rueidis.Client, chainCache *melodycache.ChainCache, options ...CacheInvalidationBackplaneOption) *CacheInvalidationBackplane {
+    if nil == client {
+        exception.Panic(exception.NewError("redis cache invalidation backplane client is nil", nil, nil))
+    }
+
+    if nil == chainCache {
+        exception.Panic(exception.NewError("redis cache invalidation backplane chain cache is nil", nil, nil))
+    }
+
+    ctx, cancel := context.WithCancel(context.Background())
+
+    backplane := &CacheInvalidationBackplane{
+        client:     client,
+        chainCache: chainCache,
+        channel:    defaultCacheInvalidationBackplaneChannel,
+        origin:     newBackplaneOrigin(),
+        reconnect:  resolveReconnectConfig(nil),
+        ctx:        ctx,
+        cancel:     cancel,
+    }
+
+    for _, option := range options {
+        option(backplane)
+    }
+
+    if "" == backplane.channel {
+        backplane.channel = defaultCacheInvalidationBackplaneChannel
+    }
+
+    chainCache.SetBackplane(backplane)
+
+    backplane.wait.Add(1)
+    go backplane.listen()
+
+    return backplane
+}
+
+func (instance *CacheInvalidationBackplane) PublishInvalidation(keys []string) error {
+    return instance.publish(cacheInvalidationWireMessage{Origin: instance.origin, Keys: keys})
+}
+
+func (instance *CacheInvalidationBackplane) PublishClear() error {
+    return instance.publish(cacheInvalidationWireMessage{Origin: instance.origin, Clear: true})
+}
+
+func (instance *CacheInvalidationBackplane) Close() error {
+    instance.chainCache.SetBackplane(nil)
+    instance.cancel()
+    instance.wait.Wait()
+
+    return nil
+}
+
+func (instance *CacheInvalidationBackplane) publish(message cacheInvalidationWireMessage) error {
+    payload, marshalErr := json.Marshal(message)
+    if nil != marshalErr {
+        return exception.NewError("redis cache invalidation backplane could not encode the message", nil, marshalErr)
+    }
+
+    result := instance.client.Do(
+        instance.ctx,
+        instance.client.B().Publish().Channel(instance.channel).Message(string(payload)).Build(),
+    )
+    if resultErr := result.Error(); nil != resultErr {
+        return exception.NewError("redis cache invalidation backplane publish failed", map[string]any{"keys": len(message.Keys)}, resultErr)
+    }
+
+    return nil
+}
+
+/* @important a replica that missed messages while resubscribing clears its local level, since it cannot tell which keys changed. */
+func (instance *CacheInvalidationBackplane) listen() {
+    defer instance.wait.Done()
+
+    backoff := instance.reconnect.InitialBackoff
+    subscribedBefore := false
+
+    for {
+        if nil != instance.ctx.Err() {
+            return
+        }
+
+        if true == subscribedBefore {
+            if clearErr := instance.chainCache.ClearLocal(); nil != clearErr {
+                instance.logError("redis cache invalidation backplane could not clear the local cache", clearErr)
+            }
+        }
+
+        subscribedBefore = true
+        startedAt := time.Now()
+
+        receiveErr := instance.client.Receive(
+            instance.ctx,
+            instance.client.B().Subscribe().Channel(instance.channel).Build(),
+            instance.handle,
+        )
+        if nil != instance.ctx.Err() {
+            return
+        }
+
+        if nil != receiveErr {
+            instance.logError("redis cache invalidation backplane subscription lost, resubscribing", receiveErr)
+        }
+
+        if instance.reconnect.InitialBackoff <= time.Since(startedAt) {
+            backoff = instance.reconnect.InitialBackoff
+
+            continue
+        }
+
+        select {
+        case <-time.After(backoff):
+        case <-instance.ctx.Done():
+            return
+        }
+
+        backoff = instance.nextBackoff(backoff)
+    }
+}
+
+func (instance *CacheInvalidationBackplane) handle(message rueidis.PubSubMessage) {
+    wire := cacheInvalidationWireMessage{}
+    if unmarshalErr := json.Unmarshal([]byte(message.Message), &wire); nil != unmarshalErr {
+        instance.logError("redis cache invalidation backplane could not decode a message", unmarshalErr)
+
+        return
+    }
+
+    if wire.Origin == instance.origin {
+        return
+    }
+
+    var invalidateErr error
+    if true == wire.Clear {
+        invalidateErr = instance.chainCache.ClearLocal()
+    } else {
+        invalidateErr = instance.chainCache.InvalidateLocal(wire.Keys)
+    }
+
+    if nil != invalidateErr {
+        instance.logError("redis cache invalidation backplane could not invalidate the local cache", invalidateErr)
+    }
+}
+
+func (instance *CacheInvalidationBackplane) logError(message string, err error) {
+    if nil == instance.logger {
+        return
+    }
+
+    instance.logger.Error(message, exception.LogContext(err))
+}
+
+func (instance *CacheInvalidationBackplane) nextBackoff(current time.Duration) time.Duration {
+    next := time.Duration(float64(current) * instance.reconnect.BackoffFactor)
+    if next > instance.reconnect.MaxBackoff {
+        return instance.reconnect.MaxBackoff
+    }
+
+    return next
+}
+
+var _ melodycache.InvalidationBackplane = (*CacheInvalidationBackplane)(nil)
@@ -0,0 +1,78 @@
+package rueidis
+
+import (
+    "testing"
+    "time"
+
+    melodycache "github.com/precision-soft/melody/v3/cache"
+    "github.com/precision-soft/melody/v3/clock"
+)
+
+func newCacheInvalidationTestManager() *melodycache.Manager {
+    return melodycache.NewManager(
+        melodycache.NewInMemoryBackend(100, time.Minute, clock.NewSystemClock()),
+        melodycache.NewJsonSerializer(),
+    )
+}
+
+func TestCacheInvalidationBackplane_InvalidatesAnotherInstance(t *testing.T) {
+    client := newTokenStoreClient(t)
+
+    channel := WithCacheInvalidationBackplaneChannel("melody:cache:invalidation:test")
+    remote := newCacheInvalidationTestManager()
+
+    chainCacheA := melodycache.NewChainCache(newCacheInvalidationTestManager(), remote, time.Minute)
+    backplaneA := NewCacheInvalidationBackplane(client, chainCacheA, channel)
+    defer backplaneA.Close()
+
+    chainCacheB := melodycache.NewChainCache(newCacheInvalidationTestManager(), remote, time.Minute)
+    backplaneB := NewCacheInvalidationBackplane(client, chainCacheB, channel)
+    defer backplaneB.Close()
+
+    if setErr := chainCacheA.Set("product:42", "v1", 0); nil != setErr {
+        t.Fatalf("set: %v", setErr)
+    }
+
+    if value, _, _ := chainCacheB.Get("product:42"); "v1" != value {
+        t.Fatalf("expected the shared value, got: %v", value)
+    }
+
+    deadline := time.After(5 * time.Second)
+    tick := time.NewTicker(100 * time.Millisecond)
+    defer tick.Stop()
+
+    for {
+        if setErr := chainCacheA.Set("product:42", "v2", 0); nil != setErr {
+            t.Fatalf("set: %v", setErr)
+        }
+
+        if exists, _ := chainCacheB.Local().Has("product:42"); false == exists {
+            return
+        }
+
+        select {
+        case <-tick.C:
+        case <-deadline:
+            t.Fatalf("expected the write to invalidate the other instance's local copy")
+        }
+    }
+}
+
+func TestCacheInvalidationBackplane_CloseDetachesFromChainCache(t *testing.T) {
+    client := newTokenStoreClient(t)
+
+    chainCache := melodycache.NewChainCache(newCacheInvalidationTestManager(), newCacheInvalidationTestManager(), time.Minute)
+    backplane := NewCacheInvalidationBackplane(client, chainCache)
+
+    if closeErr := backplane.Close(); nil != closeErr {
+        t.Fatalf("close: %v", closeErr)
+    }
+
+    if setErr := chainCache.Set("key", "value", 0); nil != setErr {
+        t.Fatalf("set: %v", setErr)
+    }
+
+    if 0 != chainCache.BackplaneFailures() {
+        t.Fatalf("expected no publish through a closed backplane")
+    }
+}
@@ -3,6 +3,7 @@
 import (
     "context"
     "io/fs"
+    nethttp "net/http"
 
     applicationcontract "github.com/precision-soft/melody/v3/application/contract"
     clicontract "github.com/precision-soft/melody/v3/cli/contract"
@@ -13,7 +14,10 @@
     httpcontract "github.com/precision-soft/melody/v3/http/contract"
     kernelcontract "github.com/precision-soft/melody/v3/kernel/contract"
     "github.com/precision-soft/melody/v3/logging"
+    "github.com/precision-soft/melody/v3/profiler"
     "github.com/precision-soft/melody/v3/security"
+    serializercontract "github.com/precision-soft/melody/v3/serializer/contract"
+    "github.com/precision-soft/melody/v3/shutdown"
 )
 
 type RouteRegistrar func(kernelInstance kernelcontract.Kernel)
@@ -32,6 +36,14 @@ type Application struct {
     securityConfiguration *security.CompiledConfiguration
     routeRegistry         httpcontract.RouteRegistry
     moduleConfigurations  map[string]any
+    containerValidation   *containerValidationOption
+    serializersByMime     map[string]serializercontract.Serializer
+    problemDetails        bool
+    profiler              *profiler.Profiler
+    httpHandler           nethttp.Handler
+    shutdownCoordinator   *shutdown.Coordinator
+    readiness             *shutdown.Readiness
+    closed                bool
 }
 
 func (instance *Application) Boot() kernelcontract.Kernel {
@@ -62,6 +74,8 @@ func (instance *Application) Boot() kernelcontract.Kernel {
 
     instance.bootHttp()
 
+    instance.validateContainer()
+
     instance.booted = true
 
     return instance.kernel
@@ -154,6 +168,11 @@ func (instance *Application) RegisterConfiguration(name string, configuration an
     instance.moduleConfigurations[name] = configuration
 }
 
+/* Configuration exposes the application configuration before boot, e.g. to register typed bindings with config.Bind that Boot resolves and validates. */
+func (instance *Application) Configuration() configcontract.Configuration {
+    return instance.configuration
+}
+
 func (instance *Application) ensureRuntimeDirectories() {
     configuration := instance.configuration
 
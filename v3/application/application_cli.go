@@ -1,6 +1,7 @@
 package application
 
 import (
+    "context"
     "fmt"
     "io"
     "os"
@@ -12,11 +13,15 @@
     "github.com/precision-soft/melody/v3/cli"
     clicontract "github.com/precision-soft/melody/v3/cli/contract"
     "github.com/precision-soft/melody/v3/cli/output"
+    "github.com/precision-soft/melody/v3/cli/reference"
     "github.com/precision-soft/melody/v3/config"
     "github.com/precision-soft/melody/v3/debug"
     "github.com/precision-soft/melody/v3/exception"
     exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+    "github.com/precision-soft/melody/v3/http/compression"
     httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    middlewarepipeline "github.com/precision-soft/melody/v3/http/middleware/pipeline"
+    "github.com/precision-soft/melody/v3/http/static"
     "github.com/precision-soft/melody/v3/logging"
     "github.com/precision-soft/melody/v3/runtime"
     "github.com/precision-soft/melody/v3/version"
@@ -91,9 +96,14 @@ func (instance *Application) bootCli() {
             &debug.ContainerCommand{},
             &debug.ParameterCommand{},
             &debug.EventCommand{},
-            debug.NewMiddlewareCommand(func() []httpcontract.Middleware {
-                return instance.httpMiddlewares.all(instance.kernel)
-            }),
+            debug.NewMiddlewareCommandWithRouteReports(
+                func() []httpcontract.Middleware {
+                    return instance.httpMiddlewares.all(instance.kernel)
+                },
+                func() ([]*middlewarepipeline.RouteMiddlewareReport, error) {
+                    return instance.httpMiddlewares.routeReports(instance.kernel)
+                },
+            ),
             &debug.VersionCommand{ApplicationVersion: version.BuildVersion()},
         )
     }
@@ -101,10 +111,24 @@ func (instance *Application) bootCli() {
     for _, commandInstance := range debugCommands {
         instance.RegisterCliCommand(commandInstance)
     }
+
+    instance.RegisterCliCommand(static.NewPrecompressCommand(compression.DefaultEncoderRegistry()))
+    instance.RegisterCliCommand(reference.NewCompletionCommand())
+    instance.RegisterCliCommand(reference.NewDocumentationCommand())
 }
 
 func (instance *Application) runCli() error {
-    kernelInstance := instance.kernel
+    return instance.RunCli(instance.ctx, os.Args, os.Stdout, os.Stderr)
+}
+
+/* RunCli boots the application and runs the cli command named by arguments (shaped like os.Args, the program name first), writing to stdout and stderr. As in a cli process, the command closes the service container once it finishes. */
+func (instance *Application) RunCli(
+    ctx context.Context,
+    arguments []string,
+    stdout io.Writer,
+    stderr io.Writer,
+) error {
+    kernelInstance := instance.Boot()
     configuration := instance.configuration
 
     serviceContainer := kernelInstance.ServiceContainer()
@@ -119,7 +143,7 @@ func (instance *Application) runCli() error {
         }
     }()
 
-    runtimeInstance := runtime.New(instance.ctx, scope, serviceContainer)
+    runtimeInstance := runtime.New(ctx, scope, serviceContainer)
 
     processId := logging.GenerateProcessId()
     loggerWithProcess := logging.NewRequestLogger(logger, processId, "processId")
@@ -129,8 +153,8 @@ func (instance *Application) runCli() error {
     loggerWithProcess.Info("starting cli application", nil)
 
     rootCli := cli.NewCommandContext(configuration.Cli().Name(), configuration.Cli().Description())
-    rootCli.Writer = os.Stdout
-    rootCli.ErrWriter = os.Stderr
+    rootCli.Writer = stdout
+    rootCli.ErrWriter = stderr
 
     availableCommands := make([]commandSuggestion, 0, len(instance.cliCommands))
 
@@ -146,14 +170,20 @@ func (instance *Application) runCli() error {
         cli.Register(rootCli, command, runtimeInstance)
     }
 
-    normalizedArguments := normalizeCliVerbosityArguments(os.Args)
+    /* @important subcommands left without writers default to the process streams, so the given ones are handed down explicitly */
+    for _, registeredCommand := range rootCli.Commands {
+        registeredCommand.Writer = stdout
+        registeredCommand.ErrWriter = stderr
+    }
+
+    normalizedArguments := normalizeCliVerbosityArguments(arguments)
 
-    suggestCliCommandErr := suggestCliCommand(normalizedArguments, availableCommands)
+    suggestCliCommandErr := suggestCliCommand(stderr, normalizedArguments, availableCommands)
     if nil != suggestCliCommandErr {
         return suggestCliCommandErr
     }
 
-    return rootCli.Run(instance.ctx, normalizedArguments)
+    return rootCli.Run(ctx, normalizedArguments)
 }
 
 func normalizeCliVerbosityArguments(arguments []string) []string {
@@ -218,6 +248,7 @@ func normalizeCliVerbosityArguments(arguments []string) []string {
 }
 
 func suggestCliCommand(
+    writer io.Writer,
     arguments []string,
     availableCommands []commandSuggestion,
 ) error {
@@ -322,9 +353,9 @@ func suggestCliCommand(
 
         envelope.Meta.DurationMilliseconds = time.Since(startedAt).Milliseconds()
 
-        printCliCommandNotFoundHeader(os.Stderr, commandName, startedAt)
+        printCliCommandNotFoundHeader(writer, commandName, startedAt)
 
-        _ = output.Render(os.Stderr, envelope, option)
+        _ = output.Render(writer, envelope, option)
 
         commandNotFoundErr := exception.NewError(
             "cli command not found",
@@ -392,9 +423,9 @@ func suggestCliCommand(
 
     envelope.Meta.DurationMilliseconds = time.Since(startedAt).Milliseconds()
 
-    printCliCommandNotFoundHeader(os.Stderr, commandName, startedAt)
+    printCliCommandNotFoundHeader(writer, commandName, startedAt)
 
-    _ = output.Render(os.Stderr, envelope, option)
+    _ = output.Render(writer, envelope, option)
 
     matchesFoundErr := exception.NewError(
         "cli command not found, matches found",
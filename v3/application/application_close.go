@@ -1,17 +1,61 @@
 package application
 
 import (
-    "github.com/precision-soft/melody/v3/exception"
+    "context"
+
+    "github.com/precision-soft/melody/v3/config"
+    eventcontract "github.com/precision-soft/melody/v3/event/contract"
     "github.com/precision-soft/melody/v3/logging"
+    "github.com/precision-soft/melody/v3/shutdown"
 )
 
+/*
+ * Close runs the shutdown phases once: the hooks registered by the application and its modules, then the async event
+ * listener drain (flush) and the service container close (close connections). The report is logged as a warning when a
+ * hook failed or timed out, and as info after a clean http server shutdown.
+ */
 func (instance *Application) Close() {
-    emergencyLogger := logging.EmergencyLogger()
+    if true == instance.closed {
+        return
+    }
+
+    instance.closed = true
+
+    coordinator := instance.ShutdownCoordinator()
+
+    /* @important async listeners still resolve services, so they are drained before the container closes */
+    asyncEventDispatcher, isAsyncEventDispatcher := instance.kernel.EventDispatcher().(eventcontract.AsyncEventDispatcher)
+    if true == isAsyncEventDispatcher {
+        coordinator.AddHook(shutdown.PhaseFlush, "event.async_listeners", asyncEventDispatcher.Drain)
+    }
 
-    serviceContainerCloseErr := instance.kernel.ServiceContainer().Close()
-    if nil != serviceContainerCloseErr {
-        emergencyLogger.Emergency("failed to close service container", exception.LogContext(serviceContainerCloseErr))
+    serviceContainer := instance.kernel.ServiceContainer()
+    coordinator.AddHook(
+        shutdown.PhaseCloseConnections,
+        "container",
+        func(ctx context.Context) error {
+            return serviceContainer.Close()
+        },
+    )
+
+    ctx := instance.ctx
+    if nil == ctx {
+        ctx = context.Background()
+    }
+
+    report := coordinator.Run(ctx)
+
+    emergencyLogger := logging.EmergencyLogger()
+    if false == report.Clean() {
+        emergencyLogger.Warning("shutdown completed with failed or timed out hooks", report.LogContext())
+    } else if true == instance.servesHttp() {
+        /* @info a clean report is only worth a line for the long-running http server, not for every cli command. */
+        emergencyLogger.Info("shutdown completed", report.LogContext())
     }
 
     logging.CloseEmergencyLogger()
 }
+
+func (instance *Application) servesHttp() bool {
+    return nil != instance.runtimeFlags && config.ModeCli != instance.runtimeFlags.Mode()
+}
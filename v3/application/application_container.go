@@ -1,6 +1,7 @@
 package application
 
 import (
+    "io"
     "os"
 
     applicationcontract "github.com/precision-soft/melody/v3/application/contract"
@@ -10,6 +11,7 @@
     clockcontract "github.com/precision-soft/melody/v3/clock/contract"
     "github.com/precision-soft/melody/v3/config"
     configcontract "github.com/precision-soft/melody/v3/config/contract"
+    "github.com/precision-soft/melody/v3/container"
     containercontract "github.com/precision-soft/melody/v3/container/contract"
     "github.com/precision-soft/melody/v3/event"
     eventcontract "github.com/precision-soft/melody/v3/event/contract"
@@ -40,6 +42,66 @@ func (instance *Application) RegisterService(
     instance.kernel.ServiceContainer().MustRegister(serviceName, provider, options...)
 }
 
+/*
+ * @info adds a serializer to the default serializer manager next to json and text/plain. A registered type takes part in
+ * every negotiation, e.g. browsers list application/xml ahead of the any type wildcard and would then get xml.
+ */
+func (instance *Application) RegisterSerializer(mime string, serializerInstance serializercontract.Serializer) {
+    if true == instance.booted {
+        exception.Panic(exception.NewError("may not register serializers after boot", nil, nil))
+    }
+
+    if nil == instance.serializersByMime {
+        instance.serializersByMime = map[string]serializercontract.Serializer{}
+    }
+
+    instance.serializersByMime[mime] = serializerInstance
+}
+
+func (instance *Application) EnableContainerValidation() {
+    if true == instance.booted {
+        exception.Panic(exception.NewError("may not enable container validation after boot", nil, nil))
+    }
+
+    if nil == instance.containerValidation {
+        instance.containerValidation = &containerValidationOption{
+            warmUp: false,
+        }
+    }
+}
+
+/* @important warm-up constructs every non-scoped singleton during Boot(), including for CLI commands that never use them; enable it only where that cost and those side effects are acceptable */
+func (instance *Application) EnableContainerWarmUp() {
+    if true == instance.booted {
+        exception.Panic(exception.NewError("may not enable container warm-up after boot", nil, nil))
+    }
+
+    instance.containerValidation = &containerValidationOption{
+        warmUp: true,
+    }
+}
+
+func (instance *Application) validateContainer() {
+    if nil == instance.containerValidation {
+        return
+    }
+
+    validateErr := container.Validate(instance.kernel.ServiceContainer())
+    if true == instance.containerValidation.warmUp {
+        validateErr = container.ValidateWithWarmUp(instance.kernel.ServiceContainer())
+    }
+
+    if nil != validateErr {
+        exception.Panic(
+            exception.NewError("container validation failed on boot", nil, validateErr),
+        )
+    }
+}
+
+type containerValidationOption struct {
+    warmUp bool
+}
+
 func (instance *Application) bootContainer() {
     kernelInstance := instance.kernel
     configuration := instance.configuration
@@ -47,11 +109,29 @@ func (instance *Application) bootContainer() {
     instance.RegisterService(
         logging.ServiceLogger,
         func(resolver containercontract.Resolver) (loggingcontract.Logger, error) {
-            writer := os.Stdout
+            var writer io.Writer = os.Stdout
+
+            loggingConfigurationInstance := logging.LoggingConfigurationFromModules(instance.moduleConfigurations)
 
             logPath := configuration.Kernel().LogPath()
+            fileRotation := logging.FileRotationFromConfiguration(loggingConfigurationInstance)
+
+            if "" != logPath && nil != fileRotation {
+                rotatingFileWriter, newWriterErr := logging.NewRotatingFileWriter(logPath, fileRotation)
+                if nil != newWriterErr {
+                    exception.Panic(
+                        exception.NewError(
+                            "failed to open rotating log file",
+                            exceptioncontract.Context{
+                                "path": logPath,
+                            },
+                            newWriterErr,
+                        ),
+                    )
+                }
 
-            if "" != logPath {
+                writer = rotatingFileWriter
+            } else if "" != logPath {
                 file, openFileErr := os.OpenFile(
                     logPath,
                     os.O_CREATE|os.O_APPEND|os.O_WRONLY,
@@ -72,8 +152,6 @@ func(resolver containercontract.Resolver) (loggingcontract.Logger, error) {
                 writer = file
             }
 
-            loggingConfigurationInstance := logging.LoggingConfigurationFromModules(instance.moduleConfigurations)
-
             return logging.NewJsonLoggerWithLabels(writer, configuration.Kernel().LogLevel(), loggingConfigurationInstance.LevelLabels()), nil
         },
     )
@@ -116,12 +194,16 @@ func(resolver containercontract.Resolver) (eventcontract.EventDispatcher, error)
     instance.RegisterService(
         serializer.ServiceSerializerManager,
         func(resolver containercontract.Resolver) (*serializer.SerializerManager, error) {
-            return serializer.NewSerializerManager(
-                map[string]serializercontract.Serializer{
-                    "application/json": serializer.NewJsonSerializer(),
-                    "text/plain":       serializer.NewPlainTextSerializer(),
-                },
-            )
+            serializersByMime := map[string]serializercontract.Serializer{
+                serializer.MimeApplicationJson: serializer.NewJsonSerializer(),
+                serializer.MimeTextPlain:       serializer.NewPlainTextSerializer(),
+            }
+
+            for mime, serializerInstance := range instance.serializersByMime {
+                serializersByMime[mime] = serializerInstance
+            }
+
+            return serializer.NewSerializerManager(serializersByMime)
         },
     )
 
@@ -139,6 +221,12 @@ func(resolver containercontract.Resolver) (clockcontract.Clock, error) {
         },
     )
 
+    graphInspector, isGraphInspector := kernelInstance.ServiceContainer().(containercontract.GraphInspector)
+    if true == isGraphInspector {
+        graphInspector.MarkScoped(http.ServiceRequestContext)
+        graphInspector.MarkScoped(logging.ServiceLogContext)
+    }
+
     instance.registerCache()
 
     instance.registerHttpSession()
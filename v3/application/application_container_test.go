@@ -6,8 +6,10 @@
     "testing"
 
     "github.com/precision-soft/melody/v3/config"
+    "github.com/precision-soft/melody/v3/container"
     containercontract "github.com/precision-soft/melody/v3/container/contract"
     "github.com/precision-soft/melody/v3/internal/testhelper"
+    "github.com/precision-soft/melody/v3/serializer"
 )
 
 func TestApplicationRegisterService_RegistersInContainerBeforeBoot(t *testing.T) {
@@ -57,3 +59,137 @@ func(resolver containercontract.Resolver) (*os.File, error) {
         )
     })
 }
+
+func TestApplicationEnableContainerValidation_BootsWithValidGraph(t *testing.T) {
+    applicationInstance := NewApplication(
+        context.Background(),
+        testhelper.NewEmbeddedEnvFs(),
+        testhelper.NewEmbeddedStaticFs(),
+    )
+
+    applicationInstance.EnableContainerValidation()
+
+    kernelInstance := applicationInstance.Boot()
+
+    inspector, isInspector := kernelInstance.ServiceContainer().(containercontract.GraphInspector)
+    if false == isInspector {
+        t.Fatalf("expected the container to support graph inspection")
+    }
+
+    isRequestContextScoped := false
+    for _, node := range inspector.DependencyGraph().Nodes {
+        if "service:service.http.request.context" == node.Key && containercontract.LifetimeScoped == node.Lifetime {
+            isRequestContextScoped = true
+        }
+    }
+
+    if false == isRequestContextScoped {
+        t.Fatalf("expected the request context to be marked scoped")
+    }
+}
+
+type containerWarmUpProbe struct{}
+
+func TestApplicationEnableContainerValidation_DoesNotConstructServices(t *testing.T) {
+    for _, warmUp := range []bool{false, true} {
+        applicationInstance := NewApplication(
+            context.Background(),
+            testhelper.NewEmbeddedEnvFs(),
+            testhelper.NewEmbeddedStaticFs(),
+        )
+
+        isConstructed := false
+        applicationInstance.RegisterService(
+            "app.warm_up_probe",
+            func(resolver containercontract.Resolver) (*containerWarmUpProbe, error) {
+                isConstructed = true
+
+                return &containerWarmUpProbe{}, nil
+            },
+        )
+
+        applicationInstance.EnableContainerValidation()
+        if true == warmUp {
+            applicationInstance.EnableContainerWarmUp()
+        }
+
+        applicationInstance.Boot()
+
+        if warmUp != isConstructed {
+            t.Fatalf("with warm-up %v, got constructed %v", warmUp, isConstructed)
+        }
+    }
+}
+
+func TestApplicationEnableContainerValidation_PanicsAfterBoot(t *testing.T) {
+    applicationInstance := NewApplication(
+        context.Background(),
+        testhelper.NewEmbeddedEnvFs(),
+        testhelper.NewEmbeddedStaticFs(),
+    )
+
+    applicationInstance.Boot()
+
+    testhelper.AssertPanics(t, func() {
+        applicationInstance.EnableContainerValidation()
+    })
+
+    testhelper.AssertPanics(t, func() {
+        applicationInstance.EnableContainerWarmUp()
+    })
+}
+
+func TestApplicationSerializerManager_BrowserAcceptHeaderResolvesJson(t *testing.T) {
+    applicationInstance := NewApplication(
+        context.Background(),
+        testhelper.NewEmbeddedEnvFs(),
+        testhelper.NewEmbeddedStaticFs(),
+    )
+
+    kernelInstance := applicationInstance.Boot()
+
+    serializerManager := container.MustFromResolver[*serializer.SerializerManager](kernelInstance.ServiceContainer(), serializer.ServiceSerializerManager)
+
+    serializerInstance, resolveErr := serializerManager.ResolveByAcceptHeader(
+        "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8",
+    )
+    if nil != resolveErr {
+        t.Fatalf("unexpected error: %v", resolveErr)
+    }
+
+    if _, ok := serializerInstance.(*serializer.JsonSerializer); false == ok {
+        t.Fatalf("expected json serializer, got %T", serializerInstance)
+    }
+
+    serializerInstance, resolveErr = serializerManager.ResolveByAcceptHeader("text/*")
+    if nil != resolveErr {
+        t.Fatalf("unexpected error: %v", resolveErr)
+    }
+
+    if _, ok := serializerInstance.(*serializer.PlainTextSerializer); false == ok {
+        t.Fatalf("expected plain text serializer, got %T", serializerInstance)
+    }
+}
+
+func TestApplicationRegisterSerializer_AddsToDefaultManager(t *testing.T) {
+    applicationInstance := NewApplication(
+        context.Background(),
+        testhelper.NewEmbeddedEnvFs(),
+        testhelper.NewEmbeddedStaticFs(),
+    )
+
+    applicationInstance.RegisterSerializer(serializer.MimeApplicationXml, serializer.NewXmlSerializer())
+
+    kernelInstance := applicationInstance.Boot()
+
+    serializerManager := container.MustFromResolver[*serializer.SerializerManager](kernelInstance.ServiceContainer(), serializer.ServiceSerializerManager)
+
+    serializerInstance, resolveErr := serializerManager.ResolveByAcceptHeader(serializer.MimeApplicationXml)
+    if nil != resolveErr {
+        t.Fatalf("unexpected error: %v", resolveErr)
+    }
+
+    if _, ok := serializerInstance.(*serializer.XmlSerializer); false == ok {
+        t.Fatalf("expected xml serializer, got %T", serializerInstance)
+    }
+}
@@ -5,11 +5,14 @@
     "errors"
     nethttp "net/http"
 
+    eventcontract "github.com/precision-soft/melody/v3/event/contract"
     "github.com/precision-soft/melody/v3/exception"
     "github.com/precision-soft/melody/v3/http"
     httpcontract "github.com/precision-soft/melody/v3/http/contract"
     kernelcontract "github.com/precision-soft/melody/v3/kernel/contract"
     "github.com/precision-soft/melody/v3/logging"
+    "github.com/precision-soft/melody/v3/profiler"
+    "github.com/precision-soft/melody/v3/shutdown"
 )
 
 func (instance *Application) RegisterHttpRoute(
@@ -47,6 +50,39 @@ func (instance *Application) RegisterHttpMiddlewareFactories(
     instance.httpMiddlewares.UseFactories(factories...)
 }
 
+/* @info renders http error responses as RFC 9457 problem details (application/problem+json) instead of the default error payload. */
+func (instance *Application) EnableProblemDetails() {
+    if true == instance.booted {
+        exception.Panic(exception.NewError("may not enable problem details after boot", nil, nil))
+    }
+
+    instance.problemDetails = true
+}
+
+/*
+ * EnableProfiler turns the web profiler on when the kernel runs in debug mode (it is ignored otherwise): every request
+ * gets a profile and an X-Debug-Token header, and the profiles are served under the configured path prefix.
+ */
+func (instance *Application) EnableProfiler(config *profiler.ProfilerConfig) {
+    if true == instance.booted {
+        exception.Panic(exception.NewError("may not enable profiler after boot", nil, nil))
+    }
+
+    profilerInstance := profiler.NewProfiler(config)
+    instance.profiler = profilerInstance
+
+    instance.httpRouteRegistrars = append(
+        instance.httpRouteRegistrars,
+        func(kernelInstance kernelcontract.Kernel) {
+            if false == kernelInstance.DebugMode() {
+                return
+            }
+
+            profilerInstance.RegisterRoutes(kernelInstance.HttpRouter())
+        },
+    )
+}
+
 func (instance *Application) bootHttp() {
     kernelInstance := instance.kernel
 
@@ -55,27 +91,70 @@ func (instance *Application) bootHttp() {
     }
 }
 
-func (instance *Application) runHttp(
-    ctx context.Context,
-) error {
-    eventDispatcher := instance.kernel.EventDispatcher()
+/* HttpHandler boots the application and returns the handler Run serves; the kernel listeners and middlewares are registered on the first call only, so in-process callers (e.g. functional tests) get exactly the production pipeline. */
+func (instance *Application) HttpHandler() nethttp.Handler {
+    if nil != instance.httpHandler {
+        return instance.httpHandler
+    }
+
+    kernelInstance := instance.Boot()
+
+    eventDispatcher := kernelInstance.EventDispatcher()
 
-    if true == instance.kernel.DebugMode() {
+    if true == kernelInstance.DebugMode() {
         http.RegisterKernelHttpProfilerListener(eventDispatcher)
     }
 
     http.RegisterKernelResponseNormalizerListener(eventDispatcher)
     http.RegisterKernelTerminateAccessLogListener(eventDispatcher)
-    http.RegisterKernelExceptionListener(eventDispatcher, instance.kernel.DebugMode())
+    exceptionListenerOptions := []http.ExceptionListenerOption{}
+    if true == instance.problemDetails {
+        exceptionListenerOptions = append(exceptionListenerOptions, http.WithProblemDetails())
+    }
 
-    configuration := instance.configuration
+    http.RegisterKernelExceptionListener(eventDispatcher, kernelInstance.DebugMode(), exceptionListenerOptions...)
+
+    middlewares := instance.httpMiddlewares.all(kernelInstance)
 
-    httpKernel := instance.kernel.HttpKernel()
+    profilerInstance := instance.profiler
+    if false == kernelInstance.DebugMode() {
+        profilerInstance = nil
+    }
+
+    if nil != profilerInstance {
+        profilerInstance.RegisterListeners(eventDispatcher)
+
+        observableEventDispatcher, isObservable := eventDispatcher.(eventcontract.ObservableEventDispatcher)
+        if true == isObservable {
+            observableEventDispatcher.AddListenerObserver(profilerInstance.ListenerObserver())
+        }
+
+        middlewares = append(
+            []httpcontract.Middleware{profilerInstance.Middleware()},
+            profilerInstance.ProfileMiddlewares(middlewares)...,
+        )
+    }
+
+    httpKernel := kernelInstance.HttpKernel()
     httpKernel.Use(
-        instance.httpMiddlewares.all(instance.kernel)...,
+        middlewares...,
     )
 
-    httpHandler := httpKernel.ServeHttp(instance.kernel.ServiceContainer())
+    instance.httpHandler = httpKernel.ServeHttp(kernelInstance.ServiceContainer())
+
+    if nil != profilerInstance {
+        instance.httpHandler = profilerInstance.Handler(instance.httpHandler)
+    }
+
+    return instance.httpHandler
+}
+
+func (instance *Application) runHttp(
+    ctx context.Context,
+) error {
+    configuration := instance.configuration
+
+    httpHandler := instance.HttpHandler()
 
     httpServer := &nethttp.Server{
         Addr:    configuration.Http().Address(),
@@ -90,6 +169,15 @@ func (instance *Application) runHttp(
         nil,
     )
 
+    /* @important the server is stopped by the drain http shutdown phase, after readiness was withdrawn, not as soon as ctx is done. */
+    instance.RegisterShutdownHook(
+        shutdown.PhaseDrainHttp,
+        "http.server",
+        func(shutdownContext context.Context) error {
+            return httpServer.Shutdown(shutdownContext)
+        },
+    )
+
     errorChannel := make(chan error, 1)
 
     go func() {
@@ -99,19 +187,6 @@ func (instance *Application) runHttp(
 
     select {
     case <-ctx.Done():
-        shutdownContext, cancel := context.WithTimeout(context.Background(), resolveHttpShutdownTimeout(configuration))
-        defer cancel()
-
-        shutdownErr := httpServer.Shutdown(shutdownContext)
-        if nil != shutdownErr {
-            logger.Error(
-                "http server shutdown error",
-                exception.LogContext(shutdownErr),
-            )
-
-            return shutdownErr
-        }
-
         return nil
 
     case err := <-errorChannel:
@@ -99,6 +99,10 @@ func (instance *Application) bootModulesPostConfigurationResolve() {
             httpModule.RegisterHttpRoutes(instance.kernel)
         }
 
+        if shutdownModule, ok := moduleInstance.(applicationcontract.ShutdownModule); true == ok {
+            shutdownModule.RegisterShutdownHooks(instance.kernel, instance)
+        }
+
         if cliModule, ok := moduleInstance.(applicationcontract.CliModule); true == ok {
             commands := cliModule.RegisterCliCommands(instance.kernel)
 
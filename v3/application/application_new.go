@@ -11,6 +11,7 @@
     clicontract "github.com/precision-soft/melody/v3/cli/contract"
     "github.com/precision-soft/melody/v3/clock"
     "github.com/precision-soft/melody/v3/config"
+    configcontract "github.com/precision-soft/melody/v3/config/contract"
     "github.com/precision-soft/melody/v3/container"
     "github.com/precision-soft/melody/v3/event"
     "github.com/precision-soft/melody/v3/exception"
@@ -18,6 +19,7 @@
     "github.com/precision-soft/melody/v3/http"
     "github.com/precision-soft/melody/v3/kernel"
     "github.com/precision-soft/melody/v3/logging"
+    "github.com/precision-soft/melody/v3/shutdown"
 )
 
 func NewApplication(
@@ -27,19 +29,67 @@ func NewApplication(
 ) *Application {
     defer logging.LogOnRecover(logging.EmergencyLogger(), true)
 
-    if nil == ctx {
-        ctx = context.Background()
-    }
+    projectDirectory := mustComputeProjectDirectory()
 
-    projectDirectory, projectDirectoryErr := computeProjectDirectory()
-    if nil != projectDirectoryErr {
-        exception.Panic(exception.NewError("failed to compute project directory", nil, projectDirectoryErr))
+    return newApplication(
+        ctx,
+        projectDirectory,
+        newEnvironmentSource(projectDirectory, embeddedEnvFiles),
+        embeddedPublicFiles,
+    )
+}
+
+/* NewApplicationWithEnvironmentSource replaces the default `.env` loading with the given source, typically a config.CompositeEnvironmentSource layering DefaultEnvironmentSource with YAML/JSON files, secret directories or the process environment. */
+func NewApplicationWithEnvironmentSource(
+    ctx context.Context,
+    environmentSource configcontract.EnvironmentSource,
+    embeddedPublicFiles fs.FS,
+) *Application {
+    defer logging.LogOnRecover(logging.EmergencyLogger(), true)
+
+    return newApplication(
+        ctx,
+        mustComputeProjectDirectory(),
+        environmentSource,
+        embeddedPublicFiles,
+    )
+}
+
+/* NewApplicationWithProjectDirectory skips the project directory detection, e.g. to keep the logs and cache directories of a test application in a temporary directory. */
+func NewApplicationWithProjectDirectory(
+    ctx context.Context,
+    projectDirectory string,
+    environmentSource configcontract.EnvironmentSource,
+    embeddedPublicFiles fs.FS,
+) *Application {
+    defer logging.LogOnRecover(logging.EmergencyLogger(), true)
+
+    if "" == projectDirectory {
+        exception.Panic(exception.NewError("project directory may not be empty", nil, nil))
     }
 
-    environmentSource := newEnvironmentSource(
+    return newApplication(
+        ctx,
         projectDirectory,
-        embeddedEnvFiles,
+        environmentSource,
+        embeddedPublicFiles,
     )
+}
+
+/* DefaultEnvironmentSource returns the `.env` source NewApplication uses, honoring the melody_env_embedded build tag. */
+func DefaultEnvironmentSource(embeddedEnvFiles fs.FS) configcontract.EnvironmentSource {
+    return newEnvironmentSource(mustComputeProjectDirectory(), embeddedEnvFiles)
+}
+
+func newApplication(
+    ctx context.Context,
+    projectDirectory string,
+    environmentSource configcontract.EnvironmentSource,
+    embeddedPublicFiles fs.FS,
+) *Application {
+    if nil == ctx {
+        ctx = context.Background()
+    }
 
     environment, newEnvironmentErr := config.NewEnvironment(environmentSource)
     if nil != newEnvironmentErr {
@@ -85,11 +135,22 @@ func NewApplication(
         httpMiddlewares:      httpMiddleware,
         routeRegistry:        routeRegistry,
         moduleConfigurations: make(map[string]any),
+        shutdownCoordinator:  newShutdownCoordinator(configuration),
+        readiness:            shutdown.NewReadiness(),
     }
 
     return application
 }
 
+func mustComputeProjectDirectory() string {
+    projectDirectory, projectDirectoryErr := computeProjectDirectory()
+    if nil != projectDirectoryErr {
+        exception.Panic(exception.NewError("failed to compute project directory", nil, projectDirectoryErr))
+    }
+
+    return projectDirectory
+}
+
 func computeProjectDirectory() (string, error) {
     executablePath, executableErr := os.Executable()
     if nil != executableErr {
@@ -0,0 +1,64 @@
+package application
+
+import (
+    nethttp "net/http"
+    "time"
+
+    "github.com/precision-soft/melody/v3/exception"
+    "github.com/precision-soft/melody/v3/shutdown"
+)
+
+/*
+ * RegisterShutdownHook adds a hook to a shutdown phase; hooks may be registered until Close runs. Phases run in order
+ * (stop accepting, drain http, stop consumers, flush, close connections) and the hooks of one phase run concurrently.
+ */
+func (instance *Application) RegisterShutdownHook(phase shutdown.Phase, name string, hook shutdown.Hook) {
+    instance.ShutdownCoordinator().AddHook(phase, name, hook)
+}
+
+/* ShutdownCoordinator exposes the coordinator Close runs, e.g. to change a phase timeout with SetPhaseTimeout. */
+func (instance *Application) ShutdownCoordinator() *shutdown.Coordinator {
+    if nil == instance.shutdownCoordinator {
+        instance.shutdownCoordinator = newShutdownCoordinator(instance.configuration)
+    }
+
+    return instance.shutdownCoordinator
+}
+
+func (instance *Application) Readiness() *shutdown.Readiness {
+    if nil == instance.readiness {
+        instance.readiness = shutdown.NewReadiness()
+    }
+
+    return instance.readiness
+}
+
+/*
+ * EnableReadinessProbe serves the readiness probe on path (200 while running, 503 once shutdown started) and withdraws it
+ * first on shutdown, then keeps serving for delay so the load balancer stops routing before the http server drains.
+ */
+func (instance *Application) EnableReadinessProbe(path string, delay time.Duration) {
+    if true == instance.booted {
+        exception.Panic(exception.NewError("may not enable the readiness probe after boot", nil, nil))
+    }
+
+    readiness := instance.Readiness()
+
+    instance.RegisterHttpRoute(nethttp.MethodGet, path, readiness.Handler())
+    instance.RegisterShutdownHook(shutdown.PhaseStopAccepting, "readiness", readiness.Hook(delay))
+
+    if delay >= instance.ShutdownCoordinator().PhaseTimeout(shutdown.PhaseStopAccepting) {
+        instance.ShutdownCoordinator().SetPhaseTimeout(shutdown.PhaseStopAccepting, delay+time.Second)
+    }
+}
+
+func newShutdownCoordinator(configuration any) *shutdown.Coordinator {
+    coordinator := shutdown.NewCoordinator()
+
+    /* @info the http shutdown timeout keeps bounding both the http drain and the async listener drain, as before phases existed. */
+    httpShutdownTimeout := resolveHttpShutdownTimeout(configuration)
+    coordinator.SetPhaseTimeout(shutdown.PhaseDrainHttp, httpShutdownTimeout)
+    coordinator.SetPhaseTimeout(shutdown.PhaseFlush, httpShutdownTimeout)
+
+    return coordinator
+}
@@ -0,0 +1,82 @@
+package application
+
+import (
+    "context"
+    "strings"
+    "testing"
+    "time"
+
+    applicationcontract "github.com/precision-soft/melody/v3/application/contract"
+    "github.com/precision-soft/melody/v3/internal/testhelper"
+    kernelcontract "github.com/precision-soft/melody/v3/kernel/contract"
+    "github.com/precision-soft/melody/v3/shutdown"
+)
+
+type fakeShutdownModule struct {
+    fakeModule
+    order *[]string
+}
+
+func (instance fakeShutdownModule) RegisterShutdownHooks(kernelInstance kernelcontract.Kernel, registrar applicationcontract.ShutdownRegistrar) {
+    registrar.RegisterShutdownHook(shutdown.PhaseStopConsumers, "consumer", func(ctx context.Context) error {
+        *instance.order = append(*instance.order, "consumer")
+
+        return nil
+    })
+}
+
+func TestApplicationClose_RunsModuleAndApplicationHooksInPhaseOrderOnce(t *testing.T) {
+    applicationInstance := NewApplication(
+        context.Background(),
+        testhelper.NewEmbeddedEnvFs(),
+        testhelper.NewEmbeddedStaticFs(),
+    )
+
+    order := make([]string, 0)
+    readyDuringFlush := true
+    applicationInstance.RegisterModule(fakeShutdownModule{fakeModule: fakeModule{name: "shutdown"}, order: &order})
+    applicationInstance.RegisterShutdownHook(shutdown.PhaseFlush, "audit", func(ctx context.Context) error {
+        order = append(order, "audit")
+        readyDuringFlush = applicationInstance.Readiness().IsReady()
+
+        return nil
+    })
+    applicationInstance.EnableReadinessProbe("/ready", 0)
+
+    applicationInstance.Boot()
+
+    applicationInstance.Close()
+    applicationInstance.Close()
+
+    if true == readyDuringFlush {
+        t.Fatalf("expected readiness to be withdrawn before the flush phase")
+    }
+
+    if expected, actual := "consumer,audit", strings.Join(order, ","); expected != actual {
+        t.Fatalf("expected %q, got %q", expected, actual)
+    }
+
+    if expected, actual := "container", strings.Join(applicationInstance.ShutdownCoordinator().HookNames(shutdown.PhaseCloseConnections), ","); expected != actual {
+        t.Fatalf("expected the container to be closed once in the last phase, got %q", actual)
+    }
+}
+
+func TestApplicationEnableReadinessProbe_ExtendsTheStopAcceptingTimeoutToTheDelay(t *testing.T) {
+    applicationInstance := NewApplication(
+        context.Background(),
+        testhelper.NewEmbeddedEnvFs(),
+        testhelper.NewEmbeddedStaticFs(),
+    )
+
+    applicationInstance.EnableReadinessProbe("/ready", 10*time.Second)
+
+    if timeout := applicationInstance.ShutdownCoordinator().PhaseTimeout(shutdown.PhaseStopAccepting); 10*time.Second >= timeout {
+        t.Fatalf("expected the phase timeout to exceed the delay, got %s", timeout)
+    }
+
+    applicationInstance.Boot()
+
+    testhelper.AssertPanics(t, func() {
+        applicationInstance.EnableReadinessProbe("/ready", 0)
+    })
+}
@@ -0,0 +1,15 @@
+package contract
+
+import (
+    kernelcontract "github.com/precision-soft/melody/v3/kernel/contract"
+    "github.com/precision-soft/melody/v3/shutdown"
+)
+
+type ShutdownModule interface {
+    Module
+    RegisterShutdownHooks(kernelInstance kernelcontract.Kernel, registrar ShutdownRegistrar)
+}
+
+type ShutdownRegistrar interface {
+    RegisterShutdownHook(phase shutdown.Phase, name string, hook shutdown.Hook)
+}
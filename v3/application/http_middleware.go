@@ -148,6 +148,13 @@ func (instance *HttpMiddleware) all(kernelInstance kernelcontract.Kernel) httpMi
     return middlewares
 }
 
+func (instance *HttpMiddleware) routeReports(kernelInstance kernelcontract.Kernel) ([]*middlewarepipeline.RouteMiddlewareReport, error) {
+    builder := middlewarepipeline.NewBuilder(instance.defaultDefinitions(kernelInstance)...)
+    builder.Add(instance.definitions...)
+
+    return builder.BuildRouteReports(kernelInstance, MiddlewareGroupHttp)
+}
+
 func (instance *HttpMiddleware) defaultDefinitions(kernelInstance kernelcontract.Kernel) []*middlewarepipeline.HttpMiddlewareDefinition {
     definitions := make([]*middlewarepipeline.HttpMiddlewareDefinition, 0, 5)
 
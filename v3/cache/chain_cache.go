@@ -0,0 +1,320 @@
+package cache
+
+import (
+    "sync"
+    "sync/atomic"
+    "time"
+
+    cachecontract "github.com/precision-soft/melody/v3/cache/contract"
+    "github.com/precision-soft/melody/v3/exception"
+    "github.com/precision-soft/melody/v3/internal"
+)
+
+/* @info carries the keys a ChainCache changed to the other replicas, which drop them from their local level. */
+type InvalidationBackplane interface {
+    PublishInvalidation(keys []string) error
+
+    PublishClear() error
+
+    Close() error
+}
+
+func NewChainCache(local cachecontract.Cache, remote cachecontract.Cache, localTtl time.Duration) *ChainCache {
+    if true == internal.IsNilInterface(local) {
+        exception.Panic(exception.NewError("chain cache local cache is nil", nil, nil))
+    }
+
+    if true == internal.IsNilInterface(remote) {
+        exception.Panic(exception.NewError("chain cache remote cache is nil", nil, nil))
+    }
+
+    if 0 >= localTtl {
+        exception.Panic(exception.NewError("chain cache local ttl must be positive", nil, nil))
+    }
+
+    return &ChainCache{
+        local:    local,
+        remote:   remote,
+        localTtl: localTtl,
+    }
+}
+
+/*
+ * ChainCache reads from a local level (typically the in-memory cache) and falls back to a shared remote level (redis),
+ * copying remote hits into the local level for at most localTtl. Writes go to the remote level first, then to the
+ * local one, and every write, delete and clear is published on the InvalidationBackplane so the other replicas drop
+ * their local copy. Without a backplane a replica may serve a value changed elsewhere for up to localTtl.
+ */
+type ChainCache struct {
+    local    cachecontract.Cache
+    remote   cachecontract.Cache
+    localTtl time.Duration
+
+    mutex             sync.RWMutex
+    backplane         InvalidationBackplane
+    backplaneFailures uint64
+}
+
+func (instance *ChainCache) Local() cachecontract.Cache {
+    return instance.local
+}
+
+func (instance *ChainCache) Remote() cachecontract.Cache {
+    return instance.remote
+}
+
+func (instance *ChainCache) LocalTtl() time.Duration {
+    return instance.localTtl
+}
+
+func (instance *ChainCache) SetBackplane(backplane InvalidationBackplane) {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.backplane = backplane
+}
+
+/* @info BackplaneFailures counts the invalidations that could not be published; the local write still happened. */
+func (instance *ChainCache) BackplaneFailures() uint64 {
+    return atomic.LoadUint64(&instance.backplaneFailures)
+}
+
+/* @info InvalidateLocal drops keys from the local level only; backplanes call it for invalidations from other replicas. */
+func (instance *ChainCache) InvalidateLocal(keys []string) error {
+    if 0 == len(keys) {
+        return nil
+    }
+
+    return instance.local.DeleteMultiple(keys)
+}
+
+func (instance *ChainCache) ClearLocal() error {
+    return instance.local.Clear()
+}
+
+func (instance *ChainCache) Get(key string) (any, bool, error) {
+    value, exists, localErr := instance.local.Get(key)
+    if nil == localErr && true == exists {
+        return value, true, nil
+    }
+
+    value, exists, remoteErr := instance.remote.Get(key)
+    if nil != remoteErr || false == exists {
+        return nil, false, remoteErr
+    }
+
+    /* @important the remaining remote ttl is unknown, so this copy may outlive the remote entry by up to localTtl */
+    _ = instance.local.Set(key, value, instance.localTtlFor(0))
+
+    return value, true, nil
+}
+
+func (instance *ChainCache) Set(key string, value any, ttl time.Duration) error {
+    setErr := instance.remote.Set(key, value, ttl)
+    if nil != setErr {
+        return setErr
+    }
+
+    _ = instance.local.Set(key, value, instance.localTtlFor(ttl))
+
+    instance.publishInvalidation([]string{key})
+
+    return nil
+}
+
+func (instance *ChainCache) Delete(key string) error {
+    return instance.DeleteMultiple([]string{key})
+}
+
+func (instance *ChainCache) Has(key string) (bool, error) {
+    exists, localErr := instance.local.Has(key)
+    if nil == localErr && true == exists {
+        return true, nil
+    }
+
+    return instance.remote.Has(key)
+}
+
+func (instance *ChainCache) Clear() error {
+    clearErr := instance.remote.Clear()
+    if nil != clearErr {
+        return clearErr
+    }
+
+    _ = instance.local.Clear()
+
+    instance.publishClear()
+
+    return nil
+}
+
+/* @info the local level is cleared entirely, which is cheaper than scanning it and always safe for a cache. */
+func (instance *ChainCache) ClearByPrefix(prefix string) error {
+    prefixClearer, isPrefixClearer := instance.remote.(cachecontract.PrefixClearer)
+    if false == isPrefixClearer {
+        return exception.NewError("chain cache remote cache does not support clearing by prefix", nil, nil)
+    }
+
+    clearErr := prefixClearer.ClearByPrefix(prefix)
+    if nil != clearErr {
+        return clearErr
+    }
+
+    _ = instance.local.Clear()
+
+    instance.publishClear()
+
+    return nil
+}
+
+func (instance *ChainCache) Many(keys []string) (map[string]any, error) {
+    result, localErr := instance.local.Many(keys)
+    if nil != localErr || nil == result {
+        result = make(map[string]any, len(keys))
+    }
+
+    missingKeys := make([]string, 0, len(keys))
+    for _, key := range keys {
+        if _, exists := result[key]; false == exists {
+            missingKeys = append(missingKeys, key)
+        }
+    }
+
+    if 0 == len(missingKeys) {
+        return result, nil
+    }
+
+    remoteValues, remoteErr := instance.remote.Many(missingKeys)
+    if nil != remoteErr {
+        return nil, remoteErr
+    }
+
+    if 0 < len(remoteValues) {
+        _ = instance.local.SetMultiple(remoteValues, instance.localTtlFor(0))
+    }
+
+    for key, value := range remoteValues {
+        result[key] = value
+    }
+
+    return result, nil
+}
+
+func (instance *ChainCache) SetMultiple(items map[string]any, ttl time.Duration) error {
+    setErr := instance.remote.SetMultiple(items, ttl)
+    if nil != setErr {
+        return setErr
+    }
+
+    _ = instance.local.SetMultiple(items, instance.localTtlFor(ttl))
+
+    keys := make([]string, 0, len(items))
+    for key := range items {
+        keys = append(keys, key)
+    }
+
+    instance.publishInvalidation(keys)
+
+    return nil
+}
+
+func (instance *ChainCache) DeleteMultiple(keys []string) error {
+    deleteErr := instance.remote.DeleteMultiple(keys)
+    if nil != deleteErr {
+        return deleteErr
+    }
+
+    _ = instance.local.DeleteMultiple(keys)
+
+    instance.publishInvalidation(keys)
+
+    return nil
+}
+
+/* @info counters live in the remote level only, so every replica sees the same value. */
+func (instance *ChainCache) Increment(key string, delta int64) (int64, error) {
+    value, incrementErr := instance.remote.Increment(key, delta)
+    if nil != incrementErr {
+        return 0, incrementErr
+    }
+
+    _ = instance.local.Delete(key)
+
+    instance.publishInvalidation([]string{key})
+
+    return value, nil
+}
+
+func (instance *ChainCache) Decrement(key string, delta int64) (int64, error) {
+    value, decrementErr := instance.remote.Decrement(key, delta)
+    if nil != decrementErr {
+        return 0, decrementErr
+    }
+
+    _ = instance.local.Delete(key)
+
+    instance.publishInvalidation([]string{key})
+
+    return value, nil
+}
+
+func (instance *ChainCache) Close() error {
+    instance.mutex.RLock()
+    backplane := instance.backplane
+    instance.mutex.RUnlock()
+
+    if false == internal.IsNilInterface(backplane) {
+        _ = backplane.Close()
+    }
+
+    localErr := instance.local.Close()
+    remoteErr := instance.remote.Close()
+    if nil != remoteErr {
+        return remoteErr
+    }
+
+    return localErr
+}
+
+/* @info the local copy never outlives localTtl, nor the ttl a write gave it; a remote hit is copied with ttl 0 and gets the full localTtl, because its remaining remote ttl is unknown. */
+func (instance *ChainCache) localTtlFor(ttl time.Duration) time.Duration {
+    if 0 < ttl && ttl < instance.localTtl {
+        return ttl
+    }
+
+    return instance.localTtl
+}
+
+func (instance *ChainCache) currentBackplane() InvalidationBackplane {
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    return instance.backplane
+}
+
+func (instance *ChainCache) publishInvalidation(keys []string) {
+    backplane := instance.currentBackplane()
+    if true == internal.IsNilInterface(backplane) || 0 == len(keys) {
+        return
+    }
+
+    if nil != backplane.PublishInvalidation(keys) {
+        atomic.AddUint64(&instance.backplaneFailures, 1)
+    }
+}
+
+func (instance *ChainCache) publishClear() {
+    backplane := instance.currentBackplane()
+    if true == internal.IsNilInterface(backplane) {
+        return
+    }
+
+    if nil != backplane.PublishClear() {
+        atomic.AddUint64(&instance.backplaneFailures, 1)
+    }
+}
+
+var (
+    _ cachecontract.Cache         = (*ChainCache)(nil)
+    _ cachecontract.PrefixClearer = (*ChainCache)(nil)
+)
@@ -0,0 +1,121 @@
+package cache
+
+import (
+    "testing"
+    "time"
+)
+
+type chainCacheTestBackplane struct {
+    caches []*ChainCache
+}
+
+func (instance *chainCacheTestBackplane) PublishInvalidation(keys []string) error {
+    for _, chainCache := range instance.caches {
+        _ = chainCache.InvalidateLocal(keys)
+    }
+
+    return nil
+}
+
+func (instance *chainCacheTestBackplane) PublishClear() error {
+    for _, chainCache := range instance.caches {
+        _ = chainCache.ClearLocal()
+    }
+
+    return nil
+}
+
+func (instance *chainCacheTestBackplane) Close() error {
+    return nil
+}
+
+func TestChainCache_ReadsThroughAndFillsLocal(t *testing.T) {
+    local := newTaggedCacheTestManager()
+    remote := newTaggedCacheTestManager()
+    chainCache := NewChainCache(local, remote, time.Minute)
+
+    if setErr := remote.Set("a", "1", 0); nil != setErr {
+        t.Fatalf("set error: %v", setErr)
+    }
+
+    value, exists, getErr := chainCache.Get("a")
+    if nil != getErr || false == exists || "1" != value {
+        t.Fatalf("expected the remote value, got: %v %v %v", value, exists, getErr)
+    }
+
+    if exists, _ := local.Has("a"); false == exists {
+        t.Fatalf("expected the remote hit to be copied locally")
+    }
+
+    if setErr := chainCache.Set("b", "2", 0); nil != setErr {
+        t.Fatalf("set error: %v", setErr)
+    }
+
+    if exists, _ := remote.Has("b"); false == exists {
+        t.Fatalf("expected writes to reach the remote level")
+    }
+
+    values, manyErr := chainCache.Many([]string{"a", "b", "c"})
+    if nil != manyErr || 2 != len(values) {
+        t.Fatalf("unexpected many result: %v %v", values, manyErr)
+    }
+}
+
+func TestChainCache_BackplaneInvalidatesOtherReplicas(t *testing.T) {
+    remote := newTaggedCacheTestManager()
+    first := NewChainCache(newTaggedCacheTestManager(), remote, time.Minute)
+    second := NewChainCache(newTaggedCacheTestManager(), remote, time.Minute)
+
+    backplane := &chainCacheTestBackplane{caches: []*ChainCache{first, second}}
+    first.SetBackplane(backplane)
+    second.SetBackplane(backplane)
+
+    _ = first.Set("a", "1", 0)
+
+    value, _, _ := second.Get("a")
+    if "1" != value {
+        t.Fatalf("expected the shared value, got: %v", value)
+    }
+
+    _ = first.Set("a", "2", 0)
+
+    value, _, _ = second.Get("a")
+    if "2" != value {
+        t.Fatalf("expected the stale local copy to be invalidated, got: %v", value)
+    }
+
+    _ = first.Clear()
+
+    if exists, _ := second.Local().Has("a"); true == exists {
+        t.Fatalf("expected clear to reach the other replica")
+    }
+}
+
+func TestChainCache_CountersLiveRemotely(t *testing.T) {
+    local := newTaggedCacheTestManager()
+    remote := newTaggedCacheTestManager()
+    chainCache := NewChainCache(local, remote, time.Minute)
+
+    for index := 0; index < 2; index++ {
+        if _, incrementErr := chainCache.Increment("hits", 1); nil != incrementErr {
+            t.Fatalf("increment error: %v", incrementErr)
+        }
+    }
+
+    value, incrementErr := remote.Increment("hits", 0)
+    if nil != incrementErr || 2 != value {
+        t.Fatalf("expected the remote counter to be 2, got: %d %v", value, incrementErr)
+    }
+
+    if exists, _ := local.Has("hits"); true == exists {
+        t.Fatalf("expected no local copy of a counter")
+    }
+}
+
+func TestChainCache_LocalTtlIsCappedByRemoteTtl(t *testing.T) {
+    chainCache := NewChainCache(newTaggedCacheTestManager(), newTaggedCacheTestManager(), time.Minute)
+
+    if time.Second != chainCache.localTtlFor(time.Second) || time.Minute != chainCache.localTtlFor(0) || time.Minute != chainCache.localTtlFor(time.Hour) {
+        t.Fatalf("unexpected local ttl")
+    }
+}
@@ -0,0 +1,6 @@
+package contract
+
+/* ItemCounter is implemented by backends able to report how many entries they hold. */
+type ItemCounter interface {
+    ItemCount() int
+}
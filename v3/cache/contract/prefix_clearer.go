@@ -0,0 +1,5 @@
+package contract
+
+type PrefixClearer interface {
+    ClearByPrefix(prefix string) error
+}
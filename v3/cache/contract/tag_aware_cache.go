@@ -0,0 +1,13 @@
+package contract
+
+import (
+    "time"
+)
+
+type TagAwareCache interface {
+    Cache
+
+    SetWithTags(key string, value any, ttl time.Duration, tags []string) error
+
+    InvalidateTags(tags ...string) error
+}
@@ -153,6 +153,14 @@ func (instance *InMemoryBackend) Delete(key string) error {
     return nil
 }
 
+/* ItemCount includes expired entries the cleanup loop has not removed yet. */
+func (instance *InMemoryBackend) ItemCount() int {
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    return len(instance.entries)
+}
+
 func (instance *InMemoryBackend) Clear() error {
     instance.mutex.Lock()
     defer instance.mutex.Unlock()
@@ -163,6 +171,19 @@ func (instance *InMemoryBackend) Clear() error {
     return nil
 }
 
+func (instance *InMemoryBackend) ClearByPrefix(prefix string) error {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    for key := range instance.entries {
+        if true == strings.HasPrefix(key, prefix) {
+            instance.deleteLocked(key)
+        }
+    }
+
+    return nil
+}
+
 func (instance *InMemoryBackend) Many(keys []string) (map[string][]byte, error) {
     now := instance.clock.Now()
 
@@ -533,4 +554,8 @@ func (instance *InMemoryBackend) addInt64WithOverflowCheck(left int64, right int
     return left + right, nil
 }
 
-var _ cachecontract.Backend = (*InMemoryBackend)(nil)
+var (
+    _ cachecontract.Backend       = (*InMemoryBackend)(nil)
+    _ cachecontract.PrefixClearer = (*InMemoryBackend)(nil)
+    _ cachecontract.ItemCounter   = (*InMemoryBackend)(nil)
+)
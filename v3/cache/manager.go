@@ -70,6 +70,16 @@ func (instance *Manager) Clear() error {
     return instance.backend.Clear()
 }
 
+/* @info ClearByPrefix needs a backend implementing cachecontract.PrefixClearer, like the in-memory backend. */
+func (instance *Manager) ClearByPrefix(prefix string) error {
+    prefixClearer, isPrefixClearer := instance.backend.(cachecontract.PrefixClearer)
+    if false == isPrefixClearer {
+        return exception.NewError("cache backend does not support clearing by prefix", nil, nil)
+    }
+
+    return prefixClearer.ClearByPrefix(prefix)
+}
+
 func (instance *Manager) Many(keys []string) (map[string]any, error) {
     payloadsByKey, manyErr := instance.backend.Many(keys)
     if nil != manyErr {
@@ -119,4 +129,21 @@ func (instance *Manager) Close() error {
     return instance.backend.Close()
 }
 
-var _ cachecontract.Cache = (*Manager)(nil)
+/* ItemCount reports the entry count of the backend, when the backend implements cachecontract.ItemCounter. */
+func ItemCount(cacheInstance any) (int, bool) {
+    switch typed := cacheInstance.(type) {
+    case cachecontract.ItemCounter:
+        return typed.ItemCount(), true
+    case *Manager:
+        if itemCounter, ok := typed.backend.(cachecontract.ItemCounter); true == ok {
+            return itemCounter.ItemCount(), true
+        }
+    }
+
+    return 0, false
+}
+
+var (
+    _ cachecontract.Cache         = (*Manager)(nil)
+    _ cachecontract.PrefixClearer = (*Manager)(nil)
+)
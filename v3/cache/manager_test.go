@@ -153,3 +153,22 @@ func TestManager_SetGetManySetMultipleDeleteMultipleClearDeleteHasClose(t *testi
         t.Fatalf("close error: %v", closeErr)
     }
 }
+
+func TestItemCount_ReportsBackendEntries(t *testing.T) {
+    clockInstance := &cacheTestClock{now: time.Unix(10, 0)}
+    backend := NewInMemoryBackend(10, time.Hour, clockInstance)
+    defer backend.Close()
+
+    cacheInstance := NewManager(backend, NewJsonSerializer())
+    _ = cacheInstance.Set("a", "1", 0)
+    _ = cacheInstance.Set("b", "2", 0)
+
+    count, supported := ItemCount(cacheInstance)
+    if false == supported || 2 != count {
+        t.Fatalf("expected 2 entries, got %d %v", count, supported)
+    }
+
+    if _, supported := ItemCount(NewTaggedCache(cacheInstance)); true == supported {
+        t.Fatalf("expected a decorated cache not to report an item count")
+    }
+}
@@ -0,0 +1,135 @@
+package cache
+
+import (
+    "strings"
+    "time"
+
+    cachecontract "github.com/precision-soft/melody/v3/cache/contract"
+    "github.com/precision-soft/melody/v3/exception"
+    exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+    "github.com/precision-soft/melody/v3/internal"
+)
+
+const namespaceSeparator = ":"
+
+func NewNamespacedCache(inner cachecontract.Cache, namespace string) *NamespacedCache {
+    if true == internal.IsNilInterface(inner) {
+        exception.Panic(exception.NewError("namespaced cache inner cache is nil", nil, nil))
+    }
+
+    if "" == namespace {
+        exception.Panic(exception.NewError("cache namespace is required", nil, nil))
+    }
+
+    return &NamespacedCache{
+        inner:     inner,
+        namespace: namespace,
+        prefix:    namespace + namespaceSeparator,
+    }
+}
+
+/*
+ * NamespacedCache prefixes every key with "<namespace>:" so modules can share one cache without colliding. Clear only
+ * removes the keys of the namespace, through the inner cache ClearByPrefix (cachecontract.PrefixClearer). Namespaces
+ * nest: a NamespacedCache over another one uses "<outer>:<inner>:".
+ */
+type NamespacedCache struct {
+    inner     cachecontract.Cache
+    namespace string
+    prefix    string
+}
+
+func (instance *NamespacedCache) Namespace() string {
+    return instance.namespace
+}
+
+func (instance *NamespacedCache) Inner() cachecontract.Cache {
+    return instance.inner
+}
+
+func (instance *NamespacedCache) Get(key string) (any, bool, error) {
+    return instance.inner.Get(instance.prefix + key)
+}
+
+func (instance *NamespacedCache) Set(key string, value any, ttl time.Duration) error {
+    return instance.inner.Set(instance.prefix+key, value, ttl)
+}
+
+func (instance *NamespacedCache) Delete(key string) error {
+    return instance.inner.Delete(instance.prefix + key)
+}
+
+func (instance *NamespacedCache) Has(key string) (bool, error) {
+    return instance.inner.Has(instance.prefix + key)
+}
+
+func (instance *NamespacedCache) Clear() error {
+    return instance.ClearByPrefix("")
+}
+
+func (instance *NamespacedCache) ClearByPrefix(prefix string) error {
+    prefixClearer, isPrefixClearer := instance.inner.(cachecontract.PrefixClearer)
+    if false == isPrefixClearer {
+        return exception.NewError(
+            "cache does not support clearing a namespace",
+            exceptioncontract.Context{"namespace": instance.namespace},
+            nil,
+        )
+    }
+
+    return prefixClearer.ClearByPrefix(instance.prefix + prefix)
+}
+
+func (instance *NamespacedCache) Many(keys []string) (map[string]any, error) {
+    values, manyErr := instance.inner.Many(instance.prefixKeys(keys))
+    if nil != manyErr {
+        return nil, manyErr
+    }
+
+    result := make(map[string]any, len(values))
+    for key, value := range values {
+        result[strings.TrimPrefix(key, instance.prefix)] = value
+    }
+
+    return result, nil
+}
+
+func (instance *NamespacedCache) SetMultiple(items map[string]any, ttl time.Duration) error {
+    prefixedItems := make(map[string]any, len(items))
+    for key, value := range items {
+        prefixedItems[instance.prefix+key] = value
+    }
+
+    return instance.inner.SetMultiple(prefixedItems, ttl)
+}
+
+func (instance *NamespacedCache) DeleteMultiple(keys []string) error {
+    return instance.inner.DeleteMultiple(instance.prefixKeys(keys))
+}
+
+func (instance *NamespacedCache) Increment(key string, delta int64) (int64, error) {
+    return instance.inner.Increment(instance.prefix+key, delta)
+}
+
+func (instance *NamespacedCache) Decrement(key string, delta int64) (int64, error) {
+    return instance.inner.Decrement(instance.prefix+key, delta)
+}
+
+/* @info the inner cache is shared with the other namespaces, so closing a namespace leaves it open. */
+func (instance *NamespacedCache) Close() error {
+    return nil
+}
+
+func (instance *NamespacedCache) prefixKeys(keys []string) []string {
+    prefixedKeys := make([]string, 0, len(keys))
+    for _, key := range keys {
+        prefixedKeys = append(prefixedKeys, instance.prefix+key)
+    }
+
+    return prefixedKeys
+}
+
+var (
+    _ cachecontract.Cache         = (*NamespacedCache)(nil)
+    _ cachecontract.PrefixClearer = (*NamespacedCache)(nil)
+)
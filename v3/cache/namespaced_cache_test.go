@@ -0,0 +1,63 @@
+package cache
+
+import (
+    "testing"
+)
+
+func TestNamespacedCache_PrefixesKeysAndClearsOnlyItsNamespace(t *testing.T) {
+    manager := newTaggedCacheTestManager()
+    orders := NewNamespacedCache(manager, "orders")
+    products := NewNamespacedCache(manager, "products")
+
+    if setErr := orders.SetMultiple(map[string]any{"1": "a", "2": "b"}, 0); nil != setErr {
+        t.Fatalf("set error: %v", setErr)
+    }
+
+    if setErr := products.Set("1", "c", 0); nil != setErr {
+        t.Fatalf("set error: %v", setErr)
+    }
+
+    value, exists, _ := manager.Get("orders:1")
+    if false == exists || "a" != value {
+        t.Fatalf("expected the key to be prefixed, got: %v", value)
+    }
+
+    values, manyErr := orders.Many([]string{"1", "2", "3"})
+    if nil != manyErr || 2 != len(values) || "b" != values["2"] {
+        t.Fatalf("unexpected many result: %v %v", values, manyErr)
+    }
+
+    if clearErr := orders.Clear(); nil != clearErr {
+        t.Fatalf("clear error: %v", clearErr)
+    }
+
+    if exists, _ := orders.Has("1"); true == exists {
+        t.Fatalf("expected the namespace to be cleared")
+    }
+
+    value, exists, _ = products.Get("1")
+    if false == exists || "c" != value {
+        t.Fatalf("expected the other namespace to survive, got: %v", value)
+    }
+}
+
+func TestNamespacedCache_NestedNamespaces(t *testing.T) {
+    manager := newTaggedCacheTestManager()
+    nested := NewNamespacedCache(NewNamespacedCache(manager, "module"), "orders")
+
+    if setErr := nested.Set("1", "a", 0); nil != setErr {
+        t.Fatalf("set error: %v", setErr)
+    }
+
+    if exists, _ := manager.Has("module:orders:1"); false == exists {
+        t.Fatalf("expected nested prefixes")
+    }
+
+    if clearErr := nested.Clear(); nil != clearErr {
+        t.Fatalf("clear error: %v", clearErr)
+    }
+
+    if exists, _ := manager.Has("module:orders:1"); true == exists {
+        t.Fatalf("expected the nested namespace to be cleared")
+    }
+}
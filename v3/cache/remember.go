@@ -8,6 +8,8 @@
     "time"
 
     cachecontract "github.com/precision-soft/melody/v3/cache/contract"
+    "github.com/precision-soft/melody/v3/clock"
+    clockcontract "github.com/precision-soft/melody/v3/clock/contract"
     "github.com/precision-soft/melody/v3/exception"
 )
 
@@ -16,6 +18,7 @@ func NewDefaultRememberOption() *RememberOption {
         enableStampedeProtection: true,
         waitTimeout:              -1,
         isCancelable:             false,
+        clock:                    clock.NewSystemClock(),
     }
 }
 
@@ -23,6 +26,11 @@ type RememberOption struct {
     enableStampedeProtection bool
     waitTimeout              time.Duration
     isCancelable             bool
+    staleTtl                 time.Duration
+    earlyExpirationBeta      float64
+    negativeTtl              time.Duration
+    metrics                  RememberMetrics
+    clock                    clockcontract.Clock
 }
 
 func (instance *RememberOption) EnableStampedeProtection() bool {
@@ -52,6 +60,59 @@ func (instance *RememberOption) WithCancelable(isCancelable bool) *RememberOptio
     return instance
 }
 
+/* @info StaleTtl keeps a value for that long past its ttl; it is served while one background call refreshes it. */
+func (instance *RememberOption) StaleTtl() time.Duration {
+    return instance.staleTtl
+}
+
+func (instance *RememberOption) WithStaleWhileRevalidate(staleTtl time.Duration) *RememberOption {
+    instance.staleTtl = staleTtl
+    return instance
+}
+
+/* @info EarlyExpirationBeta enables XFetch: 1 is the usual value, higher values refresh earlier, 0 disables it. */
+func (instance *RememberOption) EarlyExpirationBeta() float64 {
+    return instance.earlyExpirationBeta
+}
+
+func (instance *RememberOption) WithEarlyExpiration(beta float64) *RememberOption {
+    instance.earlyExpirationBeta = beta
+    return instance
+}
+
+/* @info NegativeTtl caches a callback returning ErrNotFound for that long, so a missing value is not looked up again. */
+func (instance *RememberOption) NegativeTtl() time.Duration {
+    return instance.negativeTtl
+}
+
+func (instance *RememberOption) WithNegativeTtl(negativeTtl time.Duration) *RememberOption {
+    instance.negativeTtl = negativeTtl
+    return instance
+}
+
+func (instance *RememberOption) Metrics() RememberMetrics {
+    return instance.metrics
+}
+
+func (instance *RememberOption) WithMetrics(metrics RememberMetrics) *RememberOption {
+    instance.metrics = metrics
+    return instance
+}
+
+func (instance *RememberOption) Clock() clockcontract.Clock {
+    return instance.clock
+}
+
+func (instance *RememberOption) WithClock(clockInstance clockcontract.Clock) *RememberOption {
+    instance.clock = clockInstance
+    return instance
+}
+
+/* @info stale, early expiration and negative entries need the expiry and compute time stored next to the value. */
+func (instance *RememberOption) storesEntryMetadata() bool {
+    return 0 < instance.staleTtl || 0 < instance.earlyExpirationBeta || 0 < instance.negativeTtl
+}
+
 func Remember(
     cacheInstance cachecontract.Cache,
     key string,
@@ -68,14 +129,21 @@ func Remember(
         effectiveOption = NewDefaultRememberOption()
     }
 
+    if true == effectiveOption.storesEntryMetadata() {
+        return rememberWithEntryMetadata(cacheInstance, key, ttl, callback, effectiveOption)
+    }
+
     value, exists, getErr := cacheInstance.Get(key)
     if nil != getErr {
         return nil, getErr
     }
     if true == exists {
+        recordRememberHit(effectiveOption, key)
         return value, nil
     }
 
+    recordRememberMiss(effectiveOption, key)
+
     if false == effectiveOption.EnableStampedeProtection() {
         return rememberWithoutStampedeProtection(
             cacheInstance,
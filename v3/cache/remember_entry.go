@@ -0,0 +1,271 @@
+package cache
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "math"
+    "math/rand/v2"
+    "sync"
+    "time"
+
+    cachecontract "github.com/precision-soft/melody/v3/cache/contract"
+    "github.com/precision-soft/melody/v3/clock"
+    clockcontract "github.com/precision-soft/melody/v3/clock/contract"
+    "github.com/precision-soft/melody/v3/exception"
+    "github.com/precision-soft/melody/v3/internal"
+)
+
+/* @info ErrNotFound is returned (or wrapped) by a Remember callback to report a missing value; see WithNegativeTtl. */
+var ErrNotFound = errors.New("cache remember value not found")
+
+const (
+    rememberEntryValueKey     = "melody.remember.value"
+    rememberEntryExpiresAtKey = "melody.remember.expiresAt"
+    rememberEntryDeltaKey     = "melody.remember.delta"
+    rememberEntryNotFoundKey  = "melody.remember.notFound"
+)
+
+/* @info refresh calls in flight, so a stale or early expiring key is refreshed by one goroutine at a time. */
+var rememberRefreshInFlight sync.Map
+
+/* @info expiresAt and delta are in microseconds, which json numbers hold exactly; a zero expiresAt never expires. */
+type rememberEntry struct {
+    value     any
+    expiresAt int64
+    delta     int64
+    notFound  bool
+}
+
+func (instance *rememberEntry) toMap() map[string]any {
+    return map[string]any{
+        rememberEntryValueKey:     instance.value,
+        rememberEntryExpiresAtKey: instance.expiresAt,
+        rememberEntryDeltaKey:     instance.delta,
+        rememberEntryNotFoundKey:  instance.notFound,
+    }
+}
+
+func (instance *rememberEntry) isStale(now time.Time) bool {
+    return 0 != instance.expiresAt && now.UnixMicro() >= instance.expiresAt
+}
+
+/* @info XFetch: refresh when now - delta * beta * ln(random) passes the expiry, more likely the closer it gets. */
+func (instance *rememberEntry) shouldExpireEarly(now time.Time, beta float64) bool {
+    if 0 >= beta || 0 == instance.expiresAt || 0 >= instance.delta {
+        return false
+    }
+
+    gap := float64(instance.delta) * beta * -math.Log(1-rand.Float64())
+
+    return float64(now.UnixMicro())+gap >= float64(instance.expiresAt)
+}
+
+func decodeRememberEntry(value any) (*rememberEntry, bool) {
+    entryMap, isMap := value.(map[string]any)
+    if false == isMap || 4 != len(entryMap) {
+        return nil, false
+    }
+
+    storedValue, hasValue := entryMap[rememberEntryValueKey]
+    expiresAt, expiresAtValid := rememberEntryInt64(entryMap[rememberEntryExpiresAtKey])
+    delta, deltaValid := rememberEntryInt64(entryMap[rememberEntryDeltaKey])
+    notFound, notFoundValid := entryMap[rememberEntryNotFoundKey].(bool)
+    if false == hasValue || false == expiresAtValid || false == deltaValid || false == notFoundValid {
+        return nil, false
+    }
+
+    return &rememberEntry{
+        value:     storedValue,
+        expiresAt: expiresAt,
+        delta:     delta,
+        notFound:  notFound,
+    }, true
+}
+
+func rememberEntryInt64(value any) (int64, bool) {
+    switch typedValue := value.(type) {
+    case int64:
+        return typedValue, true
+    case int:
+        return int64(typedValue), true
+    case float64:
+        return int64(typedValue), true
+    case json.Number:
+        parsed, parseErr := typedValue.Int64()
+        return parsed, nil == parseErr
+    default:
+        return 0, false
+    }
+}
+
+func rememberClock(option *RememberOption) clockcontract.Clock {
+    if true == internal.IsNilInterface(option.clock) {
+        return clock.NewSystemClock()
+    }
+
+    return option.clock
+}
+
+/* @info with a ttl the entry is kept staleTtl longer than its logical expiry, so it can still be served stale. */
+func rememberPhysicalTtl(ttl time.Duration, option *RememberOption) time.Duration {
+    if 0 >= ttl {
+        return ttl
+    }
+
+    return ttl + option.staleTtl
+}
+
+func rememberNotFoundError(key string) error {
+    return exception.NewError("cache remember value not found", map[string]any{"key": key}, ErrNotFound)
+}
+
+func rememberWithEntryMetadata(
+    cacheInstance cachecontract.Cache,
+    key string,
+    ttl time.Duration,
+    callback func(ctx context.Context) (any, error),
+    option *RememberOption,
+) (any, error) {
+    storedValue, exists, getErr := cacheInstance.Get(key)
+    if nil != getErr {
+        return nil, getErr
+    }
+
+    computeEntry := func(ctx context.Context) (any, error) {
+        return computeRememberEntry(ctx, cacheInstance, key, ttl, callback, option)
+    }
+
+    if true == exists {
+        entry, isEntry := decodeRememberEntry(storedValue)
+        if false == isEntry {
+            recordRememberHit(option, key)
+            return storedValue, nil
+        }
+
+        if true == entry.notFound {
+            recordRememberNegativeHit(option, key)
+            return nil, rememberNotFoundError(key)
+        }
+
+        now := rememberClock(option).Now()
+
+        if true == entry.isStale(now) {
+            recordRememberStaleServed(option, key)
+            refreshRememberEntryInBackground(cacheInstance, key, ttl, computeEntry, option)
+
+            return entry.value, nil
+        }
+
+        if true == entry.shouldExpireEarly(now, option.earlyExpirationBeta) {
+            recordRememberEarlyRefresh(option, key)
+            refreshRememberEntryInBackground(cacheInstance, key, ttl, computeEntry, option)
+        }
+
+        recordRememberHit(option, key)
+
+        return entry.value, nil
+    }
+
+    recordRememberMiss(option, key)
+
+    var computedValue any
+    var computeErr error
+    if false == option.EnableStampedeProtection() {
+        computedValue, computeErr = rememberWithoutStampedeProtection(cacheInstance, key, rememberPhysicalTtl(ttl, option), computeEntry)
+    } else {
+        computedValue, computeErr = rememberWithStampedeProtection(
+            cacheInstance,
+            buildRememberSingleFlightKey(cacheInstance, key, option.IsCancelable()),
+            key,
+            rememberPhysicalTtl(ttl, option),
+            option.WaitTimeout(),
+            option.IsCancelable(),
+            computeEntry,
+        )
+    }
+
+    if nil != computeErr {
+        return nil, computeErr
+    }
+
+    entry, isEntry := decodeRememberEntry(computedValue)
+    if false == isEntry {
+        return computedValue, nil
+    }
+
+    if true == entry.notFound {
+        return nil, rememberNotFoundError(key)
+    }
+
+    return entry.value, nil
+}
+
+/* @important a callback reporting ErrNotFound stores the negative entry itself, since failed callbacks are not stored by the callers. */
+func computeRememberEntry(
+    ctx context.Context,
+    cacheInstance cachecontract.Cache,
+    key string,
+    ttl time.Duration,
+    callback func(ctx context.Context) (any, error),
+    option *RememberOption,
+) (any, error) {
+    clockInstance := rememberClock(option)
+    startedAt := clockInstance.Now()
+
+    value, callbackErr := callback(ctx)
+    if nil != callbackErr {
+        if 0 < option.negativeTtl && true == errors.Is(callbackErr, ErrNotFound) {
+            negativeEntry := &rememberEntry{notFound: true}
+            _ = cacheInstance.Set(key, negativeEntry.toMap(), option.negativeTtl)
+        }
+
+        return nil, callbackErr
+    }
+
+    completedAt := clockInstance.Now()
+
+    entry := &rememberEntry{
+        value: value,
+        delta: completedAt.Sub(startedAt).Microseconds(),
+    }
+
+    if 0 < ttl {
+        entry.expiresAt = completedAt.Add(ttl).UnixMicro()
+    }
+
+    return entry.toMap(), nil
+}
+
+func refreshRememberEntryInBackground(
+    cacheInstance cachecontract.Cache,
+    key string,
+    ttl time.Duration,
+    computeEntry func(ctx context.Context) (any, error),
+    option *RememberOption,
+) {
+    refreshKey := buildRememberSingleFlightKey(cacheInstance, key, false) + ":refresh"
+
+    _, alreadyRefreshing := rememberRefreshInFlight.LoadOrStore(refreshKey, struct{}{})
+    if true == alreadyRefreshing {
+        return
+    }
+
+    go func() {
+        defer rememberRefreshInFlight.Delete(refreshKey)
+
+        entry, computeErr := executeRememberCallbackSafely(context.Background(), computeEntry, key)
+        if nil != computeErr {
+            if false == (0 < option.negativeTtl && true == errors.Is(computeErr, ErrNotFound)) {
+                recordRememberRefreshFailed(option, key, computeErr)
+            }
+
+            return
+        }
+
+        setErr := cacheInstance.Set(key, entry, rememberPhysicalTtl(ttl, option))
+        if nil != setErr {
+            recordRememberRefreshFailed(option, key, setErr)
+        }
+    }()
+}
@@ -0,0 +1,174 @@
+package cache
+
+import (
+    "context"
+    "errors"
+    "sync/atomic"
+    "testing"
+    "time"
+
+    "github.com/precision-soft/melody/v3/clock"
+)
+
+func newRememberEntryTestCache(frozenClock *clock.FrozenClock) *Manager {
+    return NewManager(NewInMemoryBackend(10, time.Hour, frozenClock), NewJsonSerializer())
+}
+
+func waitForRememberValue(t *testing.T, cacheInstance *Manager, key string, expected string) {
+    t.Helper()
+
+    deadline := time.Now().Add(2 * time.Second)
+    for time.Now().Before(deadline) {
+        value, exists, _ := cacheInstance.Get(key)
+        if entry, isEntry := decodeRememberEntry(value); true == exists && true == isEntry && expected == entry.value {
+            return
+        }
+
+        time.Sleep(5 * time.Millisecond)
+    }
+
+    t.Fatalf("expected %q to be refreshed to %q", key, expected)
+}
+
+func TestRemember_StaleWhileRevalidateServesStaleAndRefreshesInBackground(t *testing.T) {
+    frozenClock := clock.NewFrozenClock(time.Unix(1000, 0))
+    cacheInstance := newRememberEntryTestCache(frozenClock)
+    defer cacheInstance.Close()
+
+    counters := NewRememberCounters()
+    option := NewDefaultRememberOption().WithStaleWhileRevalidate(time.Minute).WithMetrics(counters).WithClock(frozenClock)
+
+    var calls int64
+    callback := func(ctx context.Context) (any, error) {
+        return []string{"v1", "v2"}[atomic.AddInt64(&calls, 1)-1], nil
+    }
+
+    value, rememberErr := Remember(cacheInstance, "k", 10*time.Second, callback, option)
+    if nil != rememberErr || "v1" != value {
+        t.Fatalf("expected the computed value, got: %v %v", value, rememberErr)
+    }
+
+    frozenClock.Advance(30 * time.Second)
+
+    value, rememberErr = Remember(cacheInstance, "k", 10*time.Second, callback, option)
+    if nil != rememberErr || "v1" != value {
+        t.Fatalf("expected the stale value, got: %v %v", value, rememberErr)
+    }
+
+    waitForRememberValue(t, cacheInstance, "k", "v2")
+
+    value, _ = Remember(cacheInstance, "k", 10*time.Second, callback, option)
+    if "v2" != value {
+        t.Fatalf("expected the refreshed value, got: %v", value)
+    }
+
+    if 1 != counters.Misses() || 1 != counters.StaleServes() || 1 != counters.Hits() {
+        t.Fatalf("unexpected counters: misses %d stale %d hits %d", counters.Misses(), counters.StaleServes(), counters.Hits())
+    }
+
+    frozenClock.Advance(2 * time.Minute)
+
+    if exists, _ := cacheInstance.Has("k"); true == exists {
+        t.Fatalf("expected the entry to expire after ttl plus stale ttl")
+    }
+}
+
+func TestRemember_EarlyExpirationRefreshesBeforeExpiry(t *testing.T) {
+    frozenClock := clock.NewFrozenClock(time.Unix(1000, 0))
+    cacheInstance := newRememberEntryTestCache(frozenClock)
+    defer cacheInstance.Close()
+
+    counters := NewRememberCounters()
+    option := NewDefaultRememberOption().WithEarlyExpiration(1e6).WithMetrics(counters).WithClock(frozenClock)
+
+    var calls int64
+    callback := func(ctx context.Context) (any, error) {
+        frozenClock.Advance(time.Second)
+
+        return []string{"v1", "v2"}[atomic.AddInt64(&calls, 1)-1], nil
+    }
+
+    _, _ = Remember(cacheInstance, "k", time.Hour, callback, option)
+
+    value, rememberErr := Remember(cacheInstance, "k", time.Hour, callback, option)
+    if nil != rememberErr || "v1" != value {
+        t.Fatalf("expected the current value while refreshing early, got: %v %v", value, rememberErr)
+    }
+
+    waitForRememberValue(t, cacheInstance, "k", "v2")
+
+    if 1 != counters.EarlyRefreshes() {
+        t.Fatalf("expected one early refresh, got: %d", counters.EarlyRefreshes())
+    }
+}
+
+func TestRememberEntry_ShouldExpireEarly(t *testing.T) {
+    now := time.Unix(1000, 0)
+    entry := &rememberEntry{expiresAt: now.Add(time.Hour).UnixMicro(), delta: time.Millisecond.Microseconds()}
+
+    if true == entry.shouldExpireEarly(now, 1) {
+        t.Fatalf("expected a fast callback far from expiry not to refresh early")
+    }
+
+    if true == entry.shouldExpireEarly(now, 0) {
+        t.Fatalf("expected beta 0 to disable early expiration")
+    }
+
+    if false == entry.shouldExpireEarly(now.Add(time.Hour), 1) {
+        t.Fatalf("expected an expired entry to refresh")
+    }
+}
+
+func TestRemember_NegativeTtlCachesNotFound(t *testing.T) {
+    frozenClock := clock.NewFrozenClock(time.Unix(1000, 0))
+    cacheInstance := newRememberEntryTestCache(frozenClock)
+    defer cacheInstance.Close()
+
+    counters := NewRememberCounters()
+    option := NewDefaultRememberOption().WithNegativeTtl(30 * time.Second).WithMetrics(counters).WithClock(frozenClock)
+
+    var calls int64
+    callback := func(ctx context.Context) (any, error) {
+        atomic.AddInt64(&calls, 1)
+
+        return nil, ErrNotFound
+    }
+
+    for index := 0; index < 3; index++ {
+        _, rememberErr := Remember(cacheInstance, "missing", time.Hour, callback, option)
+        if false == errors.Is(rememberErr, ErrNotFound) {
+            t.Fatalf("expected ErrNotFound, got: %v", rememberErr)
+        }
+    }
+
+    if 1 != atomic.LoadInt64(&calls) || 2 != counters.NegativeHits() {
+        t.Fatalf("expected the not found result to be cached, calls %d negative hits %d", calls, counters.NegativeHits())
+    }
+
+    frozenClock.Advance(time.Minute)
+
+    _, _ = Remember(cacheInstance, "missing", time.Hour, callback, option)
+    if 2 != atomic.LoadInt64(&calls) {
+        t.Fatalf("expected a lookup once the negative ttl expired, calls: %d", calls)
+    }
+}
+
+func TestRemember_MetricsWithoutEntryMetadata(t *testing.T) {
+    cacheInstance := newRememberEntryTestCache(clock.NewFrozenClock(time.Unix(1000, 0)))
+    defer cacheInstance.Close()
+
+    counters := NewRememberCounters()
+    option := NewDefaultRememberOption().WithMetrics(counters)
+
+    for index := 0; index < 2; index++ {
+        _, _ = Remember(cacheInstance, "k", time.Hour, func(ctx context.Context) (any, error) { return "v", nil }, option)
+    }
+
+    if 1 != counters.Misses() || 1 != counters.Hits() {
+        t.Fatalf("unexpected counters: misses %d hits %d", counters.Misses(), counters.Hits())
+    }
+
+    if value, _, _ := cacheInstance.Get("k"); "v" != value {
+        t.Fatalf("expected a plain value without metadata options, got: %v", value)
+    }
+}
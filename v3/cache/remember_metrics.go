@@ -0,0 +1,120 @@
+package cache
+
+import (
+    "sync/atomic"
+)
+
+/* @info RememberMetrics receives one call per Remember outcome; implementations must be safe for concurrent use. */
+type RememberMetrics interface {
+    RememberHit(key string)
+
+    RememberMiss(key string)
+
+    RememberStaleServed(key string)
+
+    RememberNegativeHit(key string)
+
+    RememberEarlyRefresh(key string)
+
+    RememberRefreshFailed(key string, err error)
+}
+
+func NewRememberCounters() *RememberCounters {
+    return &RememberCounters{}
+}
+
+/* @info RememberCounters is a RememberMetrics keeping totals, for tests and for exporting to a metrics system. */
+type RememberCounters struct {
+    hits            uint64
+    misses          uint64
+    staleServes     uint64
+    negativeHits    uint64
+    earlyRefreshes  uint64
+    refreshFailures uint64
+}
+
+func (instance *RememberCounters) RememberHit(key string) {
+    atomic.AddUint64(&instance.hits, 1)
+}
+
+func (instance *RememberCounters) RememberMiss(key string) {
+    atomic.AddUint64(&instance.misses, 1)
+}
+
+func (instance *RememberCounters) RememberStaleServed(key string) {
+    atomic.AddUint64(&instance.staleServes, 1)
+}
+
+func (instance *RememberCounters) RememberNegativeHit(key string) {
+    atomic.AddUint64(&instance.negativeHits, 1)
+}
+
+func (instance *RememberCounters) RememberEarlyRefresh(key string) {
+    atomic.AddUint64(&instance.earlyRefreshes, 1)
+}
+
+func (instance *RememberCounters) RememberRefreshFailed(key string, err error) {
+    atomic.AddUint64(&instance.refreshFailures, 1)
+}
+
+func (instance *RememberCounters) Hits() uint64 {
+    return atomic.LoadUint64(&instance.hits)
+}
+
+func (instance *RememberCounters) Misses() uint64 {
+    return atomic.LoadUint64(&instance.misses)
+}
+
+func (instance *RememberCounters) StaleServes() uint64 {
+    return atomic.LoadUint64(&instance.staleServes)
+}
+
+func (instance *RememberCounters) NegativeHits() uint64 {
+    return atomic.LoadUint64(&instance.negativeHits)
+}
+
+func (instance *RememberCounters) EarlyRefreshes() uint64 {
+    return atomic.LoadUint64(&instance.earlyRefreshes)
+}
+
+func (instance *RememberCounters) RefreshFailures() uint64 {
+    return atomic.LoadUint64(&instance.refreshFailures)
+}
+
+var _ RememberMetrics = (*RememberCounters)(nil)
+
+func recordRememberHit(option *RememberOption, key string) {
+    if nil != option.metrics {
+        option.metrics.RememberHit(key)
+    }
+}
+
+func recordRememberMiss(option *RememberOption, key string) {
+    if nil != option.metrics {
+        option.metrics.RememberMiss(key)
+    }
+}
+
+func recordRememberStaleServed(option *RememberOption, key string) {
+    if nil != option.metrics {
+        option.metrics.RememberStaleServed(key)
+    }
+}
+
+func recordRememberNegativeHit(option *RememberOption, key string) {
+    if nil != option.metrics {
+        option.metrics.RememberNegativeHit(key)
+    }
+}
+
+func recordRememberEarlyRefresh(option *RememberOption, key string) {
+    if nil != option.metrics {
+        option.metrics.RememberEarlyRefresh(key)
+    }
+}
+
+func recordRememberRefreshFailed(option *RememberOption, key string, err error) {
+    if nil != option.metrics {
+        option.metrics.RememberRefreshFailed(key, err)
+    }
+}
@@ -0,0 +1,306 @@
+package cache
+
+import (
+    "crypto/rand"
+    "encoding/hex"
+    "time"
+
+    cachecontract "github.com/precision-soft/melody/v3/cache/contract"
+    "github.com/precision-soft/melody/v3/exception"
+    exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+    "github.com/precision-soft/melody/v3/internal"
+)
+
+const (
+    TagVersionKeyPrefix  = "melody.cache.tag:"
+    DefaultTagVersionTtl = 24 * time.Hour
+
+    taggedEnvelopeValueKey    = "melody.cache.value"
+    taggedEnvelopeVersionsKey = "melody.cache.tagVersions"
+)
+
+func NewTaggedCache(inner cachecontract.Cache) *TaggedCache {
+    if true == internal.IsNilInterface(inner) {
+        exception.Panic(exception.NewError("tagged cache inner cache is nil", nil, nil))
+    }
+
+    return &TaggedCache{
+        inner:         inner,
+        tagVersionTtl: DefaultTagVersionTtl,
+    }
+}
+
+/*
+ * TaggedCache adds tags to any cache. Every tag has a version stored in the inner cache under TagVersionKeyPrefix;
+ * SetWithTags stores the value together with the versions of its tags and InvalidateTags deletes those versions, so
+ * a read that finds an outdated or missing version treats the entry as missing and deletes it. Invalidation is a
+ * single delete per tag whatever the number of entries, and every TaggedCache over the same inner cache (or the same
+ * shared backend on another replica) sees it. Values stored with Set, SetMultiple or Increment are not tagged.
+ */
+type TaggedCache struct {
+    inner         cachecontract.Cache
+    tagVersionTtl time.Duration
+}
+
+func (instance *TaggedCache) Inner() cachecontract.Cache {
+    return instance.inner
+}
+
+/*
+ * SetTagVersionTtl sets the minimum lifetime of a tag version key; a version key lives for the longer of it and the
+ * TTL of the entry that created it, and never expires only when that entry does not. Set it to at least the longest
+ * entry TTL: an entry that outlives its version key is not revived, it only misses early.
+ */
+func (instance *TaggedCache) SetTagVersionTtl(tagVersionTtl time.Duration) *TaggedCache {
+    if 0 >= tagVersionTtl {
+        exception.Panic(exception.NewError("tag version ttl must be positive", nil, nil))
+    }
+
+    instance.tagVersionTtl = tagVersionTtl
+
+    return instance
+}
+
+func (instance *TaggedCache) TagVersionTtl() time.Duration {
+    return instance.tagVersionTtl
+}
+
+func (instance *TaggedCache) Get(key string) (any, bool, error) {
+    value, exists, getErr := instance.inner.Get(key)
+    if nil != getErr || false == exists {
+        return nil, false, getErr
+    }
+
+    return instance.resolve(key, value)
+}
+
+func (instance *TaggedCache) Set(key string, value any, ttl time.Duration) error {
+    return instance.inner.Set(key, value, ttl)
+}
+
+func (instance *TaggedCache) SetWithTags(key string, value any, ttl time.Duration, tags []string) error {
+    if 0 == len(tags) {
+        return instance.inner.Set(key, value, ttl)
+    }
+
+    versions, versionsErr := instance.currentVersions(tags, true, instance.versionTtlFor(ttl))
+    if nil != versionsErr {
+        return versionsErr
+    }
+
+    return instance.inner.Set(
+        key,
+        map[string]any{
+            taggedEnvelopeValueKey:    value,
+            taggedEnvelopeVersionsKey: versions,
+        },
+        ttl,
+    )
+}
+
+func (instance *TaggedCache) InvalidateTags(tags ...string) error {
+    if 0 == len(tags) {
+        return nil
+    }
+
+    /* @info deleting the versions invalidates every entry that recorded them, and a tag nothing references any more leaves no key behind */
+    keys := make([]string, 0, len(tags))
+    for _, tag := range tags {
+        keys = append(keys, TagVersionKeyPrefix+tag)
+    }
+
+    deleteErr := instance.inner.DeleteMultiple(keys)
+    if nil != deleteErr {
+        return exception.NewError("failed to invalidate cache tags", exceptioncontract.Context{"tags": tags}, deleteErr)
+    }
+
+    return nil
+}
+
+func (instance *TaggedCache) Delete(key string) error {
+    return instance.inner.Delete(key)
+}
+
+func (instance *TaggedCache) Has(key string) (bool, error) {
+    _, exists, getErr := instance.Get(key)
+
+    return exists, getErr
+}
+
+func (instance *TaggedCache) Clear() error {
+    return instance.inner.Clear()
+}
+
+func (instance *TaggedCache) Many(keys []string) (map[string]any, error) {
+    values, manyErr := instance.inner.Many(keys)
+    if nil != manyErr {
+        return nil, manyErr
+    }
+
+    result := make(map[string]any, len(values))
+    for key, value := range values {
+        resolvedValue, exists, resolveErr := instance.resolve(key, value)
+        if nil != resolveErr {
+            return nil, resolveErr
+        }
+
+        if true == exists {
+            result[key] = resolvedValue
+        }
+    }
+
+    return result, nil
+}
+
+func (instance *TaggedCache) SetMultiple(items map[string]any, ttl time.Duration) error {
+    return instance.inner.SetMultiple(items, ttl)
+}
+
+func (instance *TaggedCache) DeleteMultiple(keys []string) error {
+    return instance.inner.DeleteMultiple(keys)
+}
+
+func (instance *TaggedCache) Increment(key string, delta int64) (int64, error) {
+    return instance.inner.Increment(key, delta)
+}
+
+func (instance *TaggedCache) Decrement(key string, delta int64) (int64, error) {
+    return instance.inner.Decrement(key, delta)
+}
+
+func (instance *TaggedCache) Close() error {
+    return instance.inner.Close()
+}
+
+var _ cachecontract.TagAwareCache = (*TaggedCache)(nil)
+
+/* @info InvalidateTags uses the cache's own tag support when it has one, the tag versions of a TaggedCache otherwise. */
+func InvalidateTags(cacheInstance cachecontract.Cache, tags ...string) error {
+    if true == internal.IsNilInterface(cacheInstance) {
+        return exception.NewError("cache instance is nil", nil, nil)
+    }
+
+    tagAwareCache, isTagAware := cacheInstance.(cachecontract.TagAwareCache)
+    if false == isTagAware {
+        tagAwareCache = NewTaggedCache(cacheInstance)
+    }
+
+    return tagAwareCache.InvalidateTags(tags...)
+}
+
+/* @info returns the stored value, or a miss (deleting the entry) when one of its tags was invalidated since. */
+func (instance *TaggedCache) resolve(key string, value any) (any, bool, error) {
+    envelope, isEnvelope := value.(map[string]any)
+    if false == isEnvelope {
+        return value, true, nil
+    }
+
+    storedValue, hasValue := envelope[taggedEnvelopeValueKey]
+    storedVersions, hasVersions := envelope[taggedEnvelopeVersionsKey]
+    if false == hasValue || false == hasVersions || 2 != len(envelope) {
+        return value, true, nil
+    }
+
+    expectedVersions := taggedVersionStrings(storedVersions)
+
+    tags := make([]string, 0, len(expectedVersions))
+    for tag := range expectedVersions {
+        tags = append(tags, tag)
+    }
+
+    currentVersions, versionsErr := instance.currentVersions(tags, false, 0)
+    if nil != versionsErr {
+        return nil, false, versionsErr
+    }
+
+    for tag, expectedVersion := range expectedVersions {
+        if currentVersions[tag] != expectedVersion {
+            _ = instance.inner.Delete(key)
+
+            return nil, false, nil
+        }
+    }
+
+    return storedValue, true, nil
+}
+
+/*
+ * @important with create set, missing versions are written before the entry is stored: an entry never records an
+ * empty version, so losing a version key (eviction, flush) invalidates its entries instead of reviving them.
+ */
+func (instance *TaggedCache) currentVersions(tags []string, create bool, versionTtl time.Duration) (map[string]string, error) {
+    keys := make([]string, 0, len(tags))
+    for _, tag := range tags {
+        keys = append(keys, TagVersionKeyPrefix+tag)
+    }
+
+    values, manyErr := instance.inner.Many(keys)
+    if nil != manyErr {
+        return nil, exception.NewError("failed to read cache tag versions", exceptioncontract.Context{"tags": tags}, manyErr)
+    }
+
+    versions := make(map[string]string, len(tags))
+    missing := make(map[string]any)
+    for _, tag := range tags {
+        version, _ := values[TagVersionKeyPrefix+tag].(string)
+        if "" == version && true == create {
+            newVersion, versionErr := newTagVersion()
+            if nil != versionErr {
+                return nil, versionErr
+            }
+
+            version = newVersion
+            missing[TagVersionKeyPrefix+tag] = version
+        }
+
+        versions[tag] = version
+    }
+
+    if 0 < len(missing) {
+        setErr := instance.inner.SetMultiple(missing, versionTtl)
+        if nil != setErr {
+            return nil, exception.NewError("failed to store cache tag versions", exceptioncontract.Context{"tags": tags}, setErr)
+        }
+    }
+
+    return versions, nil
+}
+
+func (instance *TaggedCache) versionTtlFor(entryTtl time.Duration) time.Duration {
+    if 0 >= entryTtl {
+        return 0
+    }
+
+    if entryTtl < instance.tagVersionTtl {
+        return instance.tagVersionTtl
+    }
+
+    return entryTtl
+}
+
+func taggedVersionStrings(value any) map[string]string {
+    switch typedValue := value.(type) {
+    case map[string]string:
+        return typedValue
+    case map[string]any:
+        versions := make(map[string]string, len(typedValue))
+        for tag, version := range typedValue {
+            versions[tag], _ = version.(string)
+        }
+
+        return versions
+    default:
+        return map[string]string{}
+    }
+}
+
+func newTagVersion() (string, error) {
+    randomBytes := make([]byte, 12)
+
+    _, readErr := rand.Read(randomBytes)
+    if nil != readErr {
+        return "", exception.NewError("failed to generate cache tag version", nil, readErr)
+    }
+
+    return hex.EncodeToString(randomBytes), nil
+}
@@ -0,0 +1,121 @@
+package cache
+
+import (
+    "testing"
+    "time"
+)
+
+func newTaggedCacheTestManager() *Manager {
+    clockInstance := &cacheTestClock{now: time.Unix(10, 0)}
+
+    return NewManager(NewInMemoryBackend(10, time.Hour, clockInstance), NewJsonSerializer())
+}
+
+func TestTaggedCache_InvalidateTagsMissesTaggedEntries(t *testing.T) {
+    cacheInstance := NewTaggedCache(newTaggedCacheTestManager())
+
+    if setErr := cacheInstance.SetWithTags("product", "42", 0, []string{"product:42", "catalog"}); nil != setErr {
+        t.Fatalf("set error: %v", setErr)
+    }
+
+    if setErr := cacheInstance.SetWithTags("other", "7", 0, []string{"product:7"}); nil != setErr {
+        t.Fatalf("set error: %v", setErr)
+    }
+
+    if setErr := cacheInstance.Set("plain", "1", 0); nil != setErr {
+        t.Fatalf("set error: %v", setErr)
+    }
+
+    value, exists, getErr := cacheInstance.Get("product")
+    if nil != getErr || false == exists || "42" != value {
+        t.Fatalf("expected tagged value, got: %v %v %v", value, exists, getErr)
+    }
+
+    if invalidateErr := InvalidateTags(cacheInstance.Inner(), "product:42"); nil != invalidateErr {
+        t.Fatalf("invalidate error: %v", invalidateErr)
+    }
+
+    _, exists, _ = cacheInstance.Get("product")
+    if true == exists {
+        t.Fatalf("expected the invalidated entry to miss")
+    }
+
+    values, manyErr := cacheInstance.Many([]string{"product", "other", "plain"})
+    if nil != manyErr {
+        t.Fatalf("many error: %v", manyErr)
+    }
+
+    if 2 != len(values) || "7" != values["other"] || "1" != values["plain"] {
+        t.Fatalf("unexpected values: %v", values)
+    }
+
+    if hasInner, _ := cacheInstance.Inner().Has("product"); true == hasInner {
+        t.Fatalf("expected the invalidated entry to be deleted on read")
+    }
+}
+
+func TestTaggedCache_LostTagVersionInvalidatesEntries(t *testing.T) {
+    manager := newTaggedCacheTestManager()
+    cacheInstance := NewTaggedCache(manager)
+
+    if setErr := cacheInstance.SetWithTags("product", "42", 0, []string{"product:42"}); nil != setErr {
+        t.Fatalf("set error: %v", setErr)
+    }
+
+    if deleteErr := manager.Delete(TagVersionKeyPrefix + "product:42"); nil != deleteErr {
+        t.Fatalf("delete error: %v", deleteErr)
+    }
+
+    if exists, _ := cacheInstance.Has("product"); true == exists {
+        t.Fatalf("expected the entry to miss once its tag version is gone")
+    }
+}
+
+func TestTaggedCache_SetWithoutTagsStoresPlainValue(t *testing.T) {
+    manager := newTaggedCacheTestManager()
+    cacheInstance := NewTaggedCache(manager)
+
+    if setErr := cacheInstance.SetWithTags("plain", "1", 0, nil); nil != setErr {
+        t.Fatalf("set error: %v", setErr)
+    }
+
+    value, exists, _ := manager.Get("plain")
+    if false == exists || "1" != value {
+        t.Fatalf("expected a plain value in the inner cache, got: %v", value)
+    }
+}
+
+func TestTaggedCache_TagVersionKeysExpireAndInvalidationLeavesNoKey(t *testing.T) {
+    clockInstance := &cacheTestClock{now: time.Unix(10, 0)}
+    manager := NewManager(NewInMemoryBackend(10, time.Hour, clockInstance), NewJsonSerializer())
+    cacheInstance := NewTaggedCache(manager).SetTagVersionTtl(time.Hour)
+
+    if setErr := cacheInstance.SetWithTags("product", "42", time.Minute, []string{"product:42"}); nil != setErr {
+        t.Fatalf("set error: %v", setErr)
+    }
+
+    clockInstance.now = clockInstance.now.Add(time.Hour)
+
+    if exists, _ := manager.Has(TagVersionKeyPrefix + "product:42"); true == exists {
+        t.Fatalf("expected the tag version key to expire")
+    }
+
+    if invalidateErr := cacheInstance.InvalidateTags("product:7"); nil != invalidateErr {
+        t.Fatalf("invalidate error: %v", invalidateErr)
+    }
+
+    if exists, _ := manager.Has(TagVersionKeyPrefix + "product:7"); true == exists {
+        t.Fatalf("expected invalidating an unused tag to leave no version key")
+    }
+
+    if setErr := cacheInstance.SetWithTags("product", "42", 2*time.Hour, []string{"product:42"}); nil != setErr {
+        t.Fatalf("set error: %v", setErr)
+    }
+
+    clockInstance.now = clockInstance.now.Add(90 * time.Minute)
+
+    value, exists, getErr := cacheInstance.Get("product")
+    if nil != getErr || false == exists || "42" != value {
+        t.Fatalf("expected the version key to live as long as its entry, got: %v %v %v", value, exists, getErr)
+    }
+}
@@ -9,6 +9,8 @@
     "time"
 
     clicontract "github.com/precision-soft/melody/v3/cli/contract"
+    "github.com/precision-soft/melody/v3/cli/interaction"
+    "github.com/precision-soft/melody/v3/cli/output"
     "github.com/precision-soft/melody/v3/exception"
     runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
 )
@@ -17,6 +19,9 @@ func NewCommandContext(applicationName string, applicationDescription string) *c
     commandContext := &clicontract.CommandContext{
         Name:  applicationName,
         Usage: applicationDescription,
+        Flags: []clicontract.Flag{
+            interaction.NoInteractionFlag(),
+        },
     }
 
     return commandContext
@@ -88,6 +93,10 @@ func Register(commandContext *clicontract.CommandContext, command clicontract.Co
                     writer = io.Discard
                 }
 
+                if true == isRawOutput(copied, commandContext) {
+                    writer = io.Discard
+                }
+
                 startedAt := time.Now()
                 const logFiller = "======================================"
                 printGreenFullLine := func(writer io.Writer) {
@@ -197,6 +206,15 @@ func Register(commandContext *clicontract.CommandContext, command clicontract.Co
     )
 }
 
+/* isRawOutput reports whether the standard output of the command is meant for another program: a raw output command, or a machine-readable --format. */
+func isRawOutput(command clicontract.Command, commandContext *clicontract.CommandContext) bool {
+    if rawOutputCommand, isRawOutputCommand := command.(clicontract.RawOutputCommand); true == isRawOutputCommand && true == rawOutputCommand.RawOutput() {
+        return true
+    }
+
+    return output.FormatTable != output.NormalizeOption(output.ParseOptionFromCommand(commandContext)).Format
+}
+
 func aggregateCliErrors(runErr error, closeErrorByName map[string]error) error {
     if 0 == len(closeErrorByName) {
         return runErr
@@ -3,10 +3,13 @@
 import (
     "context"
     "errors"
+    "io"
     "strings"
     "testing"
 
     clicontract "github.com/precision-soft/melody/v3/cli/contract"
+    "github.com/precision-soft/melody/v3/cli/interaction"
+    "github.com/precision-soft/melody/v3/cli/output"
     "github.com/precision-soft/melody/v3/container"
     containercontract "github.com/precision-soft/melody/v3/container/contract"
     "github.com/precision-soft/melody/v3/internal/testhelper"
@@ -266,3 +269,98 @@ func TestRegister_ActionCallsRunWithRuntimeInstance(t *testing.T) {
         t.Fatalf("expected cli command to be passed to Run")
     }
 }
+
+func TestNewCommandContext_NoInteractionFlagReachesEveryCommand(t *testing.T) {
+    rootCommand := NewCommandContext("app", "desc")
+    rootCommand.Writer = io.Discard
+    rootCommand.ErrWriter = io.Discard
+
+    noInteraction := false
+    Register(
+        rootCommand,
+        &testCommand{
+            nameValue:        "hello",
+            descriptionValue: "hello command",
+            runCallback: func(runtimeInstance runtimecontract.Runtime, commandContext *clicontract.CommandContext) error {
+                noInteraction = commandContext.Bool(interaction.FlagNameNoInteraction)
+
+                return nil
+            },
+        },
+        newTestRuntime(),
+    )
+
+    runErr := rootCommand.Run(context.Background(), []string{"app", "hello", "--no-interaction"})
+    if nil != runErr {
+        t.Fatalf("unexpected error: %v", runErr)
+    }
+
+    if false == noInteraction {
+        t.Fatalf("expected --no-interaction to be accepted after the command name")
+    }
+}
+
+type testRawOutputCommand struct {
+    testCommand
+}
+
+func (instance *testRawOutputCommand) RawOutput() bool {
+    return true
+}
+
+func TestRegister_RawOutputPrintsNoBanner(t *testing.T) {
+    cases := map[string]struct {
+        command        clicontract.Command
+        arguments      []string
+        expectedBanner bool
+    }{
+        "regular command": {
+            command:        &testCommand{nameValue: "hello", runCallback: printHello},
+            expectedBanner: true,
+        },
+        "raw output command": {
+            command:        &testRawOutputCommand{testCommand: testCommand{nameValue: "hello", runCallback: printHello}},
+            expectedBanner: false,
+        },
+        "machine-readable format": {
+            command:        &testCommand{nameValue: "hello", flagsValue: output.StandardFlags(), runCallback: printHello},
+            arguments:      []string{"--format=csv"},
+            expectedBanner: false,
+        },
+        "table format": {
+            command:        &testCommand{nameValue: "hello", flagsValue: output.StandardFlags(), runCallback: printHello},
+            arguments:      []string{"--format=table"},
+            expectedBanner: true,
+        },
+    }
+
+    for name, testCase := range cases {
+        t.Run(name, func(t *testing.T) {
+            buffer := &strings.Builder{}
+
+            rootCommand := NewCommandContext("app", "desc")
+            rootCommand.ErrWriter = io.Discard
+
+            Register(rootCommand, testCase.command, newTestRuntime())
+            rootCommand.Commands[0].Writer = buffer
+
+            if runErr := rootCommand.Run(context.Background(), append([]string{"app", "hello"}, testCase.arguments...)); nil != runErr {
+                t.Fatalf("unexpected error: %v", runErr)
+            }
+
+            if testCase.expectedBanner != strings.Contains(buffer.String(), "[started]") {
+                t.Fatalf("unexpected banner in output: %q", buffer.String())
+            }
+
+            if false == strings.Contains(buffer.String(), "hello\n") {
+                t.Fatalf("expected the command output, got %q", buffer.String())
+            }
+        })
+    }
+}
+
+func printHello(runtimeInstance runtimecontract.Runtime, commandContext *clicontract.CommandContext) error {
+    _, writeErr := io.WriteString(commandContext.Writer, "hello\n")
+
+    return writeErr
+}
@@ -0,0 +1,8 @@
+package contract
+
+/* RawOutputCommand is implemented by commands whose standard output is consumed by another program (a shell completion script, a generated document); Register prints no started/finished banner for them when RawOutput returns true. */
+type RawOutputCommand interface {
+    Command
+
+    RawOutput() bool
+}
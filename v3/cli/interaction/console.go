@@ -0,0 +1,143 @@
+package interaction
+
+import (
+    "bufio"
+    "errors"
+    "fmt"
+    "io"
+    "os"
+    "strings"
+    "sync"
+
+    clicontract "github.com/precision-soft/melody/v3/cli/contract"
+    "github.com/precision-soft/melody/v3/cli/output"
+    "github.com/precision-soft/melody/v3/exception"
+)
+
+var (
+    /* ErrInteractionRequired is the cause of every prompt error raised because the command may not prompt. */
+    ErrInteractionRequired = errors.New("interactive input required")
+    /* ErrAborted is the cause of the error ConfirmDestructive returns when the user declines. */
+    ErrAborted = errors.New("aborted by the user")
+)
+
+const maxPromptAttempts = 3
+
+/*
+ * NewConsole prompts on writer and reads answers from input. interactive allows prompting at all; decorated allows
+ * redrawing progress bars and spinners in place instead of printing log lines.
+ */
+func NewConsole(input io.Reader, writer io.Writer, interactive bool, decorated bool) *Console {
+    if nil == input {
+        input = os.Stdin
+    }
+
+    if nil == writer {
+        writer = os.Stderr
+    }
+
+    return &Console{
+        input:       input,
+        reader:      bufio.NewReader(input),
+        writer:      writer,
+        interactive: interactive,
+        decorated:   decorated,
+    }
+}
+
+/*
+ * FromCommand builds the console of a running command: prompts and progress go to the error writer so the standard
+ * output stays parseable, prompting requires a terminal input and no --no-interaction, and decorations require a
+ * terminal error writer and the table --format.
+ */
+func FromCommand(commandContext *clicontract.CommandContext) *Console {
+    if nil == commandContext {
+        return NewConsole(os.Stdin, os.Stderr, isTerminal(os.Stdin), isTerminal(os.Stderr))
+    }
+
+    var input io.Reader = os.Stdin
+    if nil != commandContext.Reader {
+        input = commandContext.Reader
+    } else if root := commandContext.Root(); nil != root && nil != root.Reader {
+        input = root.Reader
+    }
+
+    var writer io.Writer = os.Stderr
+    if nil != commandContext.ErrWriter {
+        writer = commandContext.ErrWriter
+    }
+
+    format := output.NormalizeOption(output.ParseOptionFromCommand(commandContext)).Format
+
+    console := NewConsole(
+        input,
+        writer,
+        false == commandContext.Bool(FlagNameNoInteraction) && true == isTerminal(input),
+        true == isTerminal(writer) && output.FormatTable == format,
+    )
+    console.SetForce(commandContext.Bool(FlagNameForce))
+
+    return console
+}
+
+type Console struct {
+    mutex       sync.Mutex
+    input       io.Reader
+    reader      *bufio.Reader
+    writer      io.Writer
+    interactive bool
+    decorated   bool
+    force       bool
+}
+
+func (instance *Console) IsInteractive() bool {
+    return instance.interactive
+}
+
+func (instance *Console) IsDecorated() bool {
+    return instance.decorated
+}
+
+func (instance *Console) IsForced() bool {
+    return instance.force
+}
+
+func (instance *Console) SetForce(force bool) {
+    instance.force = force
+}
+
+func (instance *Console) Writer() io.Writer {
+    return instance.writer
+}
+
+func (instance *Console) write(format string, arguments ...any) {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    _, _ = fmt.Fprintf(instance.writer, format, arguments...)
+}
+
+func (instance *Console) readLine() (string, error) {
+    line, readErr := instance.reader.ReadString('\n')
+    if nil != readErr {
+        if true == errors.Is(readErr, io.EOF) && "" != line {
+            return strings.TrimRight(line, "\r\n"), nil
+        }
+
+        return "", exception.NewError("could not read the answer", nil, readErr)
+    }
+
+    return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (instance *Console) requireInteraction(question string) error {
+    if true == instance.interactive {
+        return nil
+    }
+
+    return exception.NewError(
+        "interactive input required; pass the value as a flag",
+        map[string]any{"question": question},
+        ErrInteractionRequired,
+    )
+}
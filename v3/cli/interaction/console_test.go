@@ -0,0 +1,187 @@
+package interaction
+
+import (
+    "bytes"
+    "context"
+    "errors"
+    "io"
+    "strings"
+    "testing"
+
+    clicontract "github.com/precision-soft/melody/v3/cli/contract"
+    "github.com/precision-soft/melody/v3/cli/output"
+)
+
+func newTestConsole(input string, interactive bool) (*Console, *bytes.Buffer) {
+    writer := &bytes.Buffer{}
+
+    return NewConsole(strings.NewReader(input), writer, interactive, false), writer
+}
+
+func TestConsole_PromptsFailWithoutInteraction(t *testing.T) {
+    console, writer := newTestConsole("yes\n", false)
+
+    for name, prompt := range map[string]func() error{
+        "confirm": func() error { _, err := console.Confirm("continue?", true); return err },
+        "ask":     func() error { _, err := console.Ask("name", "default"); return err },
+        "choice":  func() error { _, err := console.Choice("pick", []string{"a"}, "a"); return err },
+        "secret":  func() error { _, err := console.Secret("password"); return err },
+    } {
+        t.Run(name, func(t *testing.T) {
+            promptErr := prompt()
+            if false == errors.Is(promptErr, ErrInteractionRequired) {
+                t.Fatalf("expected ErrInteractionRequired, got %v", promptErr)
+            }
+        })
+    }
+
+    if 0 != writer.Len() {
+        t.Fatalf("expected nothing to be prompted, got %q", writer.String())
+    }
+}
+
+func TestConsole_Confirm(t *testing.T) {
+    for name, testCase := range map[string]struct {
+        input         string
+        defaultAnswer bool
+        expected      bool
+    }{
+        "yes":                   {input: "y\n", expected: true},
+        "no":                    {input: "no\n", defaultAnswer: true, expected: false},
+        "empty selects default": {input: "\n", defaultAnswer: true, expected: true},
+        "asks again on garbage": {input: "maybe\nYES\n", expected: true},
+    } {
+        t.Run(name, func(t *testing.T) {
+            console, _ := newTestConsole(testCase.input, true)
+
+            confirmed, confirmErr := console.Confirm("continue?", testCase.defaultAnswer)
+            if nil != confirmErr {
+                t.Fatalf("unexpected error: %v", confirmErr)
+            }
+
+            if testCase.expected != confirmed {
+                t.Fatalf("expected %v, got %v", testCase.expected, confirmed)
+            }
+        })
+    }
+}
+
+func TestConsole_ConfirmDestructive(t *testing.T) {
+    forced, _ := newTestConsole("", false)
+    forced.SetForce(true)
+    if forceErr := forced.ConfirmDestructive("drop?"); nil != forceErr {
+        t.Fatalf("expected --force to skip the confirmation, got %v", forceErr)
+    }
+
+    nonInteractive, _ := newTestConsole("", false)
+    if nonInteractiveErr := nonInteractive.ConfirmDestructive("drop?"); false == errors.Is(nonInteractiveErr, ErrInteractionRequired) || false == strings.Contains(nonInteractiveErr.Error(), "--force") {
+        t.Fatalf("expected an error pointing at --force, got %v", nonInteractiveErr)
+    }
+
+    declined, _ := newTestConsole("\n", true)
+    if declinedErr := declined.ConfirmDestructive("drop?"); false == errors.Is(declinedErr, ErrAborted) {
+        t.Fatalf("expected an empty answer to abort, got %v", declinedErr)
+    }
+
+    accepted, _ := newTestConsole("yes\n", true)
+    if acceptedErr := accepted.ConfirmDestructive("drop?"); nil != acceptedErr {
+        t.Fatalf("unexpected error: %v", acceptedErr)
+    }
+}
+
+func TestConsole_AskUsesTheDefaultAndRequiresAValueWithoutOne(t *testing.T) {
+    console, writer := newTestConsole("\n\n\nvalue\n", true)
+
+    answer, askErr := console.Ask("table", "users")
+    if nil != askErr || "users" != answer {
+        t.Fatalf("expected the default, got %q (%v)", answer, askErr)
+    }
+
+    answer, askErr = console.Ask("column", "")
+    if nil != askErr || "value" != answer {
+        t.Fatalf("expected the third answer, got %q (%v)", answer, askErr)
+    }
+
+    if 2 != strings.Count(writer.String(), "a value is required") {
+        t.Fatalf("expected two retries, got %q", writer.String())
+    }
+}
+
+func TestConsole_ChoiceAcceptsANumberOrAValue(t *testing.T) {
+    for name, testCase := range map[string]struct {
+        input    string
+        expected string
+    }{
+        "number":  {input: "2\n", expected: "reencrypt"},
+        "value":   {input: "decrypt\n", expected: "decrypt"},
+        "default": {input: "\n", expected: "encrypt"},
+        "retry":   {input: "9\n1\n", expected: "encrypt"},
+    } {
+        t.Run(name, func(t *testing.T) {
+            console, _ := newTestConsole(testCase.input, true)
+
+            choice, choiceErr := console.Choice("mode", []string{"encrypt", "reencrypt", "decrypt"}, "encrypt")
+            if nil != choiceErr || testCase.expected != choice {
+                t.Fatalf("expected %q, got %q (%v)", testCase.expected, choice, choiceErr)
+            }
+        })
+    }
+
+    console, _ := newTestConsole("x\ny\nz\n", true)
+    if _, choiceErr := console.Choice("mode", []string{"a"}, ""); nil == choiceErr {
+        t.Fatalf("expected an error after three invalid answers")
+    }
+}
+
+func TestConsole_SecretReadsPipedInput(t *testing.T) {
+    console, writer := newTestConsole("s3cret\n", true)
+
+    secret, secretErr := console.Secret("password")
+    if nil != secretErr || "s3cret" != secret {
+        t.Fatalf("expected the piped secret, got %q (%v)", secret, secretErr)
+    }
+
+    if true == strings.Contains(writer.String(), "s3cret") {
+        t.Fatalf("expected the secret not to be written, got %q", writer.String())
+    }
+}
+
+func TestConsole_PromptFailsOnClosedInput(t *testing.T) {
+    console, _ := newTestConsole("", true)
+
+    if _, askErr := console.Ask("table", ""); nil == askErr {
+        t.Fatalf("expected an error on closed input")
+    }
+}
+
+func TestFromCommand_ReadsTheFlagsAndUsesTheErrorWriter(t *testing.T) {
+    errWriter := &bytes.Buffer{}
+
+    var console *Console
+    commandContext := &clicontract.CommandContext{
+        Name:      "app",
+        Flags:     output.MergeFlags(output.StandardFlags(), []clicontract.Flag{NoInteractionFlag(), ForceFlag()}),
+        Reader:    strings.NewReader(""),
+        Writer:    io.Discard,
+        ErrWriter: errWriter,
+        Action: func(ctx context.Context, commandContext *clicontract.CommandContext) error {
+            console = FromCommand(commandContext)
+
+            return nil
+        },
+    }
+
+    runErr := commandContext.Run(context.Background(), []string{"app", "--force", "--format=json"})
+    if nil != runErr {
+        t.Fatalf("unexpected error: %v", runErr)
+    }
+
+    if false == console.IsForced() || true == console.IsInteractive() || true == console.IsDecorated() {
+        t.Fatalf("expected a forced, non-interactive, undecorated console")
+    }
+
+    console.Spinner("working").Start()
+    if "working...\n" != errWriter.String() {
+        t.Fatalf("expected the console to write to the error writer, got %q", errWriter.String())
+    }
+}
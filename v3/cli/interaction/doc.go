@@ -0,0 +1,4 @@
+/*
+Package interaction gives cli commands confirm, ask, choice and secret prompts that fail instead of blocking when the input is not a terminal or --no-interaction is set, progress bars and spinners that degrade to log lines when the output format is not table or not a terminal, and the --force convention for destructive commands.
+*/
+package interaction
@@ -0,0 +1,28 @@
+package interaction
+
+import (
+    clicontract "github.com/precision-soft/melody/v3/cli/contract"
+)
+
+const (
+    FlagNameNoInteraction = "no-interaction"
+    FlagNameForce         = "force"
+)
+
+/* NoInteractionFlag is registered on the root command, so every command accepts it. */
+func NoInteractionFlag() clicontract.Flag {
+    return &clicontract.BoolFlag{
+        Name:  FlagNameNoInteraction,
+        Usage: "never prompt; commands needing input fail instead",
+        Value: false,
+    }
+}
+
+/* ForceFlag is the flag destructive commands add to run without confirmation. */
+func ForceFlag() clicontract.Flag {
+    return &clicontract.BoolFlag{
+        Name:  FlagNameForce,
+        Usage: "run without asking for confirmation",
+        Value: false,
+    }
+}
@@ -0,0 +1,175 @@
+package interaction
+
+import (
+    "fmt"
+    "strings"
+    "sync"
+    "time"
+)
+
+const (
+    progressBarWidth          = 28
+    progressRedrawInterval    = 100 * time.Millisecond
+    progressLogPercentStep    = 10
+    progressLogUnknownTotalAt = 5 * time.Second
+)
+
+/* ProgressBar redraws a bar in place on a decorated console and prints a progress line every 10% (every 5 seconds for an unknown total, 0) otherwise. */
+func (instance *Console) ProgressBar(total int) *ProgressBar {
+    if 0 > total {
+        total = 0
+    }
+
+    now := time.Now()
+
+    return &ProgressBar{
+        console:     instance,
+        total:       total,
+        startedAt:   now,
+        lastLogAt:   now,
+        lastPercent: 0,
+    }
+}
+
+type ProgressBar struct {
+    mutex        sync.Mutex
+    console      *Console
+    total        int
+    current      int
+    message      string
+    startedAt    time.Time
+    lastRedrawAt time.Time
+    lastLogAt    time.Time
+    lastPercent  int
+    finished     bool
+}
+
+func (instance *ProgressBar) Advance(step int) {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    if true == instance.finished {
+        return
+    }
+
+    instance.current += step
+    if 0 < instance.total && instance.current > instance.total {
+        instance.current = instance.total
+    }
+
+    instance.render(false)
+}
+
+func (instance *ProgressBar) SetMessage(message string) {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.message = message
+}
+
+func (instance *ProgressBar) Current() int {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    return instance.current
+}
+
+/* Finish draws the final state (or prints the final line) once. */
+func (instance *ProgressBar) Finish() {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    if true == instance.finished {
+        return
+    }
+
+    instance.finished = true
+    instance.render(true)
+}
+
+func (instance *ProgressBar) render(final bool) {
+    now := time.Now()
+
+    if true == instance.console.decorated {
+        if false == final && now.Sub(instance.lastRedrawAt) < progressRedrawInterval {
+            return
+        }
+
+        instance.lastRedrawAt = now
+
+        line := "\r\033[K" + instance.decoratedLine(now)
+        if true == final {
+            line += "\n"
+        }
+
+        instance.console.write("%s", line)
+
+        return
+    }
+
+    if false == final && false == instance.shouldLog(now) {
+        return
+    }
+
+    instance.lastLogAt = now
+    instance.console.write("%s\n", instance.logLine(now))
+}
+
+func (instance *ProgressBar) shouldLog(now time.Time) bool {
+    if 0 == instance.total {
+        return now.Sub(instance.lastLogAt) >= progressLogUnknownTotalAt
+    }
+
+    /* @info 100% is left to Finish so the last line is printed once */
+    step := instance.percent() / progressLogPercentStep * progressLogPercentStep
+    if step <= instance.lastPercent || 100 <= step {
+        return false
+    }
+
+    instance.lastPercent = step
+
+    return true
+}
+
+func (instance *ProgressBar) percent() int {
+    if 0 == instance.total {
+        return 0
+    }
+
+    return instance.current * 100 / instance.total
+}
+
+func (instance *ProgressBar) decoratedLine(now time.Time) string {
+    elapsed := now.Sub(instance.startedAt).Round(time.Second)
+
+    if 0 == instance.total {
+        return strings.TrimRight(fmt.Sprintf("%d [%s] %s", instance.current, elapsed, instance.message), " ")
+    }
+
+    filled := instance.current * progressBarWidth / instance.total
+    bar := strings.Repeat("=", filled)
+    if filled < progressBarWidth {
+        bar += ">" + strings.Repeat("-", progressBarWidth-filled-1)
+    }
+
+    return strings.TrimRight(
+        fmt.Sprintf("%d/%d [%s] %3d%% %s %s", instance.current, instance.total, bar, instance.percent(), elapsed, instance.message),
+        " ",
+    )
+}
+
+func (instance *ProgressBar) logLine(now time.Time) string {
+    elapsed := now.Sub(instance.startedAt).Round(time.Millisecond)
+
+    line := fmt.Sprintf("progress: %d", instance.current)
+    if 0 < instance.total {
+        line = fmt.Sprintf("progress: %d/%d (%d%%)", instance.current, instance.total, instance.percent())
+    }
+
+    line += " elapsed=" + elapsed.String()
+    if "" != instance.message {
+        line += " " + instance.message
+    }
+
+    return line
+}
@@ -0,0 +1,78 @@
+package interaction
+
+import (
+    "bytes"
+    "strings"
+    "testing"
+)
+
+func TestProgressBar_PrintsLinesEveryTenPercentWhenNotDecorated(t *testing.T) {
+    writer := &bytes.Buffer{}
+    console := NewConsole(strings.NewReader(""), writer, false, false)
+
+    progressBar := console.ProgressBar(100)
+    progressBar.SetMessage("users")
+    for index := 0; index < 100; index++ {
+        progressBar.Advance(1)
+    }
+    progressBar.Finish()
+    progressBar.Finish()
+
+    lines := strings.Split(strings.TrimSpace(writer.String()), "\n")
+    if 10 != len(lines) {
+        t.Fatalf("expected 10 lines (10%% to 90%% and the final one), got %d: %q", len(lines), writer.String())
+    }
+
+    if false == strings.HasPrefix(lines[0], "progress: 10/100 (10%)") || false == strings.HasSuffix(lines[0], " users") {
+        t.Fatalf("unexpected first line %q", lines[0])
+    }
+
+    if false == strings.HasPrefix(lines[9], "progress: 100/100 (100%)") {
+        t.Fatalf("unexpected last line %q", lines[9])
+    }
+}
+
+func TestProgressBar_RedrawsInPlaceWhenDecorated(t *testing.T) {
+    writer := &bytes.Buffer{}
+    console := NewConsole(strings.NewReader(""), writer, false, true)
+
+    progressBar := console.ProgressBar(4)
+    progressBar.Advance(10)
+    progressBar.Finish()
+
+    if false == strings.HasPrefix(writer.String(), "\r\033[K") || false == strings.Contains(writer.String(), "4/4 [============================] 100%") {
+        t.Fatalf("unexpected decorated output %q", writer.String())
+    }
+
+    if 4 != progressBar.Current() {
+        t.Fatalf("expected the progress to be capped at the total, got %d", progressBar.Current())
+    }
+}
+
+func TestSpinner_PrintsStartAndStopLinesWhenNotDecorated(t *testing.T) {
+    writer := &bytes.Buffer{}
+    console := NewConsole(strings.NewReader(""), writer, false, false)
+
+    spinner := console.Spinner("warming up")
+    spinner.Start()
+    spinner.Stop("warmed up")
+    spinner.Stop("ignored")
+
+    lines := strings.Split(strings.TrimSpace(writer.String()), "\n")
+    if 2 != len(lines) || "warming up..." != lines[0] || false == strings.HasPrefix(lines[1], "warmed up (") {
+        t.Fatalf("unexpected output %q", writer.String())
+    }
+}
+
+func TestSpinner_AnimatesUntilStoppedWhenDecorated(t *testing.T) {
+    writer := &bytes.Buffer{}
+    console := NewConsole(strings.NewReader(""), writer, false, true)
+
+    spinner := console.Spinner("warming up")
+    spinner.Start()
+    spinner.Stop("")
+
+    if false == strings.Contains(writer.String(), "| warming up") || false == strings.Contains(writer.String(), "\r\033[Kwarming up (") {
+        t.Fatalf("unexpected output %q", writer.String())
+    }
+}
@@ -0,0 +1,182 @@
+package interaction
+
+import (
+    "strconv"
+    "strings"
+
+    "github.com/precision-soft/melody/v3/exception"
+)
+
+/* Confirm asks a yes/no question; an empty answer selects defaultAnswer. */
+func (instance *Console) Confirm(question string, defaultAnswer bool) (bool, error) {
+    if requireErr := instance.requireInteraction(question); nil != requireErr {
+        return false, requireErr
+    }
+
+    hint := "y/N"
+    if true == defaultAnswer {
+        hint = "Y/n"
+    }
+
+    for attempt := 0; attempt < maxPromptAttempts; attempt++ {
+        instance.write("%s [%s]: ", question, hint)
+
+        answer, readErr := instance.readLine()
+        if nil != readErr {
+            return false, readErr
+        }
+
+        switch strings.ToLower(strings.TrimSpace(answer)) {
+        case "":
+            return defaultAnswer, nil
+        case "y", "yes":
+            return true, nil
+        case "n", "no":
+            return false, nil
+        }
+
+        instance.write("please answer yes or no\n")
+    }
+
+    return false, exception.NewError("no valid answer given", map[string]any{"question": question}, nil)
+}
+
+/*
+ * ConfirmDestructive is the --force convention: it returns nil right away when --force is set, asks (defaulting to no)
+ * when the command may prompt, and fails otherwise. A declined confirmation returns an error caused by ErrAborted.
+ */
+func (instance *Console) ConfirmDestructive(question string) error {
+    if true == instance.force {
+        return nil
+    }
+
+    if false == instance.interactive {
+        return exception.NewError(
+            "confirmation required; rerun with --force to proceed without prompting",
+            map[string]any{"question": question},
+            ErrInteractionRequired,
+        )
+    }
+
+    confirmed, confirmErr := instance.Confirm(question, false)
+    if nil != confirmErr {
+        return confirmErr
+    }
+
+    if false == confirmed {
+        return exception.NewError("aborted", map[string]any{"question": question}, ErrAborted)
+    }
+
+    return nil
+}
+
+/* Ask reads a free-form answer; an empty answer selects defaultValue, and is asked again when there is no default. */
+func (instance *Console) Ask(question string, defaultValue string) (string, error) {
+    if requireErr := instance.requireInteraction(question); nil != requireErr {
+        return "", requireErr
+    }
+
+    prompt := question + ": "
+    if "" != defaultValue {
+        prompt = question + " [" + defaultValue + "]: "
+    }
+
+    for attempt := 0; attempt < maxPromptAttempts; attempt++ {
+        instance.write("%s", prompt)
+
+        answer, readErr := instance.readLine()
+        if nil != readErr {
+            return "", readErr
+        }
+
+        answer = strings.TrimSpace(answer)
+        if "" != answer {
+            return answer, nil
+        }
+
+        if "" != defaultValue {
+            return defaultValue, nil
+        }
+
+        instance.write("a value is required\n")
+    }
+
+    return "", exception.NewError("no valid answer given", map[string]any{"question": question}, nil)
+}
+
+/* Choice lists choices and accepts either the number or the value of one; an empty answer selects defaultChoice when set. */
+func (instance *Console) Choice(question string, choices []string, defaultChoice string) (string, error) {
+    if 0 == len(choices) {
+        return "", exception.NewError("choice prompt needs at least one choice", map[string]any{"question": question}, nil)
+    }
+
+    if requireErr := instance.requireInteraction(question); nil != requireErr {
+        return "", requireErr
+    }
+
+    prompt := "> "
+    if "" != defaultChoice {
+        prompt = "> [" + defaultChoice + "] "
+    }
+
+    for attempt := 0; attempt < maxPromptAttempts; attempt++ {
+        instance.write("%s\n", question)
+        for index, choice := range choices {
+            instance.write("  [%d] %s\n", index+1, choice)
+        }
+        instance.write("%s", prompt)
+
+        answer, readErr := instance.readLine()
+        if nil != readErr {
+            return "", readErr
+        }
+
+        answer = strings.TrimSpace(answer)
+        if "" == answer && "" != defaultChoice {
+            return defaultChoice, nil
+        }
+
+        if number, parseErr := strconv.Atoi(answer); nil == parseErr && 1 <= number && number <= len(choices) {
+            return choices[number-1], nil
+        }
+
+        for _, choice := range choices {
+            if choice == answer {
+                return choice, nil
+            }
+        }
+
+        instance.write("invalid choice %q\n", answer)
+    }
+
+    return "", exception.NewError("no valid choice given", map[string]any{"question": question}, nil)
+}
+
+/* Secret reads an answer without echoing it when the input is a terminal; an empty answer is asked again. */
+func (instance *Console) Secret(question string) (string, error) {
+    if requireErr := instance.requireInteraction(question); nil != requireErr {
+        return "", requireErr
+    }
+
+    for attempt := 0; attempt < maxPromptAttempts; attempt++ {
+        instance.write("%s: ", question)
+
+        answer, readErr := readSecret(instance.input, instance.readLine)
+        if true == isTerminal(instance.input) {
+            /* @info the enter key is not echoed either */
+            instance.write("\n")
+        }
+
+        if nil != readErr {
+            return "", readErr
+        }
+
+        if "" != answer {
+            return answer, nil
+        }
+
+        instance.write("a value is required\n")
+    }
+
+    return "", exception.NewError("no valid answer given", map[string]any{"question": question}, nil)
+}
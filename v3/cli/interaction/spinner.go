@@ -0,0 +1,118 @@
+package interaction
+
+import (
+    "sync"
+    "time"
+)
+
+var spinnerFrames = []string{"|", "/", "-", "\\"}
+
+const spinnerInterval = 100 * time.Millisecond
+
+/* Spinner animates message on a decorated console until Stop; otherwise it prints one line at Start and one at Stop. */
+func (instance *Console) Spinner(message string) *Spinner {
+    return &Spinner{
+        console: instance,
+        message: message,
+    }
+}
+
+type Spinner struct {
+    mutex     sync.Mutex
+    console   *Console
+    message   string
+    startedAt time.Time
+    running   bool
+    done      chan struct{}
+    stopped   chan struct{}
+}
+
+func (instance *Spinner) Start() {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    if true == instance.running {
+        return
+    }
+
+    instance.running = true
+    instance.startedAt = time.Now()
+
+    if false == instance.console.decorated {
+        instance.console.write("%s...\n", instance.message)
+
+        return
+    }
+
+    instance.done = make(chan struct{})
+    instance.stopped = make(chan struct{})
+
+    go instance.animate(instance.done, instance.stopped)
+}
+
+func (instance *Spinner) SetMessage(message string) {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.message = message
+}
+
+/* Stop ends the animation and prints finalMessage (the current message when empty) with the elapsed time. */
+func (instance *Spinner) Stop(finalMessage string) {
+    instance.mutex.Lock()
+    if false == instance.running {
+        instance.mutex.Unlock()
+
+        return
+    }
+
+    instance.running = false
+    done := instance.done
+    stopped := instance.stopped
+    instance.mutex.Unlock()
+
+    if nil != done {
+        close(done)
+        <-stopped
+    }
+
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    if "" == finalMessage {
+        finalMessage = instance.message
+    }
+
+    elapsed := time.Since(instance.startedAt).Round(time.Millisecond)
+
+    if true == instance.console.decorated {
+        instance.console.write("\r\033[K%s (%s)\n", finalMessage, elapsed)
+
+        return
+    }
+
+    instance.console.write("%s (%s)\n", finalMessage, elapsed)
+}
+
+func (instance *Spinner) animate(done <-chan struct{}, stopped chan<- struct{}) {
+    defer close(stopped)
+
+    ticker := time.NewTicker(spinnerInterval)
+    defer ticker.Stop()
+
+    frame := 0
+    for {
+        instance.mutex.Lock()
+        message := instance.message
+        instance.mutex.Unlock()
+
+        instance.console.write("\r\033[K%s %s", spinnerFrames[frame%len(spinnerFrames)], message)
+        frame++
+
+        select {
+        case <-done:
+            return
+        case <-ticker.C:
+        }
+    }
+}
@@ -0,0 +1,36 @@
+package interaction
+
+import (
+    "io"
+    "os"
+)
+
+func isTerminal(stream any) bool {
+    file, isFile := stream.(*os.File)
+    if false == isFile || nil == file {
+        return false
+    }
+
+    fileInfo, statErr := file.Stat()
+    if nil != statErr {
+        return false
+    }
+
+    return 0 != (fileInfo.Mode() & os.ModeCharDevice)
+}
+
+/* readSecret reads a line from input with the terminal echo disabled; input that is not a terminal is read as is. */
+func readSecret(input io.Reader, readLine func() (string, error)) (string, error) {
+    file, isFile := input.(*os.File)
+    if false == isFile || false == isTerminal(file) {
+        return readLine()
+    }
+
+    restore, disableEchoErr := disableEcho(int(file.Fd()))
+    if nil != disableEchoErr {
+        return "", disableEchoErr
+    }
+    defer restore()
+
+    return readLine()
+}
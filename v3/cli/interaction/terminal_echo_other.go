@@ -0,0 +1,12 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package interaction
+
+import (
+    "github.com/precision-soft/melody/v3/exception"
+)
+
+/* @important failing is safer than echoing a secret: on these platforms pipe the secret in or pass it as a flag. */
+func disableEcho(fileDescriptor int) (func(), error) {
+    return nil, exception.NewError("hidden input is not supported on this platform", nil, nil)
+}
@@ -0,0 +1,29 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package interaction
+
+import (
+    "syscall"
+    "unsafe"
+
+    "github.com/precision-soft/melody/v3/exception"
+)
+
+func disableEcho(fileDescriptor int) (func(), error) {
+    var original syscall.Termios
+    if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fileDescriptor), ioctlReadTermios, uintptr(unsafe.Pointer(&original))); 0 != errno {
+        return nil, exception.NewError("could not read the terminal state", nil, errno)
+    }
+
+    silent := original
+    silent.Lflag &^= syscall.ECHO
+    silent.Lflag |= syscall.ICANON | syscall.ISIG
+
+    if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fileDescriptor), ioctlWriteTermios, uintptr(unsafe.Pointer(&silent))); 0 != errno {
+        return nil, exception.NewError("could not disable the terminal echo", nil, errno)
+    }
+
+    return func() {
+        _, _, _ = syscall.Syscall(syscall.SYS_IOCTL, uintptr(fileDescriptor), ioctlWriteTermios, uintptr(unsafe.Pointer(&original)))
+    }, nil
+}
@@ -0,0 +1,12 @@
+//go:build darwin || freebsd || netbsd || openbsd
+
+package interaction
+
+import (
+    "syscall"
+)
+
+const (
+    ioctlReadTermios  = syscall.TIOCGETA
+    ioctlWriteTermios = syscall.TIOCSETA
+)
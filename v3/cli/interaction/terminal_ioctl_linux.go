@@ -0,0 +1,12 @@
+//go:build linux
+
+package interaction
+
+import (
+    "syscall"
+)
+
+const (
+    ioctlReadTermios  = syscall.TCGETS
+    ioctlWriteTermios = syscall.TCSETS
+)
@@ -0,0 +1,122 @@
+package output
+
+import (
+    "encoding/csv"
+    "encoding/json"
+    "io"
+    "strconv"
+)
+
+const csvValueColumn = "value"
+
+/*
+ * CsvPrinter prints the data as csv with a header row: one row per item of a list payload (or element of a list), or a
+ * single row for an object. The columns are the --fields, or the fields of the records in order of appearance; nested
+ * values are written as compact json. An envelope error is returned instead of printed.
+ */
+type CsvPrinter struct {
+}
+
+func (instance *CsvPrinter) Print(
+    writer io.Writer,
+    envelope Envelope,
+    option Option,
+) error {
+    if envelopeErr := envelopeError(envelope); nil != envelopeErr {
+        return envelopeErr
+    }
+
+    if nil == envelope.Data {
+        return nil
+    }
+
+    data, convertErr := toPayloadValue(envelope.Data)
+    if nil != convertErr {
+        return convertErr
+    }
+
+    records := make([]payloadObject, 0)
+    for _, record := range payloadRecords(data) {
+        object, isObject := record.(payloadObject)
+        if false == isObject {
+            object = payloadObject{{key: csvValueColumn, value: record}}
+        }
+
+        records = append(records, object)
+    }
+
+    columns := option.Fields
+    if 0 == len(columns) {
+        columns = csvColumns(records)
+    }
+
+    csvWriter := csv.NewWriter(writer)
+
+    if writeErr := csvWriter.Write(columns); nil != writeErr {
+        return writeErr
+    }
+
+    for _, record := range records {
+        row := make([]string, 0, len(columns))
+        for _, column := range columns {
+            row = append(row, csvCell(record, column))
+        }
+
+        if writeErr := csvWriter.Write(row); nil != writeErr {
+            return writeErr
+        }
+    }
+
+    csvWriter.Flush()
+
+    return csvWriter.Error()
+}
+
+func csvColumns(records []payloadObject) []string {
+    columns := make([]string, 0)
+    seen := map[string]bool{}
+
+    for _, record := range records {
+        for _, key := range record.keys() {
+            if true == seen[key] {
+                continue
+            }
+
+            seen[key] = true
+            columns = append(columns, key)
+        }
+    }
+
+    return columns
+}
+
+func csvCell(record payloadObject, column string) string {
+    for _, field := range record {
+        if true == matchesField(field.key, column) {
+            return toCellString(field.value)
+        }
+    }
+
+    return ""
+}
+
+/* toCellString writes a payload value as a single text value: nested objects and lists become compact json. */
+func toCellString(value any) string {
+    switch typed := value.(type) {
+    case nil:
+        return ""
+    case string:
+        return typed
+    case bool:
+        return strconv.FormatBool(typed)
+    case json.Number:
+        return typed.String()
+    default:
+        encoded, marshalErr := json.Marshal(typed)
+        if nil != marshalErr {
+            return ""
+        }
+
+        return string(encoded)
+    }
+}
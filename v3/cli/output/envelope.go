@@ -20,15 +20,16 @@ type Meta struct {
 }
 
 type Flags struct {
-    Format  Format    `json:"format"`
-    NoColor bool      `json:"noColor"`
-    Verbose bool      `json:"verbose"`
-    Quiet   bool      `json:"quiet"`
-    Fields  []string  `json:"fields"`
-    SortKey string    `json:"sortKey"`
-    Order   SortOrder `json:"order"`
-    Limit   int       `json:"limit"`
-    Offset  int       `json:"offset"`
+    Format   Format    `json:"format"`
+    NoColor  bool      `json:"noColor"`
+    Verbose  bool      `json:"verbose"`
+    Quiet    bool      `json:"quiet"`
+    Fields   []string  `json:"fields"`
+    SortKey  string    `json:"sortKey"`
+    Order    SortOrder `json:"order"`
+    Limit    int       `json:"limit"`
+    Offset   int       `json:"offset"`
+    Template string    `json:"template"`
 }
 
 type Version struct {
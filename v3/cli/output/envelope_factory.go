@@ -29,15 +29,16 @@ func NewMeta(
         Command:   command,
         Arguments: copyStringSlice(arguments),
         Flags: Flags{
-            Format:  normalized.Format,
-            NoColor: normalized.NoColor,
-            Verbose: normalized.Verbose,
-            Quiet:   normalized.Quiet,
-            Fields:  copyStringSlice(normalized.Fields),
-            SortKey: normalized.SortKey,
-            Order:   normalized.Order,
-            Limit:   normalized.Limit,
-            Offset:  normalized.Offset,
+            Format:   normalized.Format,
+            NoColor:  normalized.NoColor,
+            Verbose:  normalized.Verbose,
+            Quiet:    normalized.Quiet,
+            Fields:   copyStringSlice(normalized.Fields),
+            SortKey:  normalized.SortKey,
+            Order:    normalized.Order,
+            Limit:    normalized.Limit,
+            Offset:   normalized.Offset,
+            Template: normalized.Template,
         },
         StartedAt:            startedAt,
         DurationMilliseconds: duration.Milliseconds(),
@@ -0,0 +1,152 @@
+package output
+
+/*
+ * ApplyFields keeps only the given fields (matched case-insensitively, in the given order) in every format: in the items of a
+ * list payload, in the elements of a list or in an object payload, and in the columns of the table blocks, matched by
+ * column name or by the TableBlock.Fields the block declares. Table blocks without any of the fields are left out.
+ */
+func ApplyFields(envelope Envelope, fields []string) (Envelope, error) {
+    if 0 == len(fields) {
+        return envelope, nil
+    }
+
+    filtered := envelope
+
+    if nil != envelope.Data {
+        data, convertErr := toPayloadValue(envelope.Data)
+        if nil != convertErr {
+            return envelope, convertErr
+        }
+
+        filtered.Data = selectPayloadFields(data, fields)
+    }
+
+    if nil != envelope.Table {
+        filtered.Table = selectTableFields(envelope.Table, fields)
+    }
+
+    return filtered, nil
+}
+
+func selectPayloadFields(value any, fields []string) any {
+    switch typed := value.(type) {
+    case payloadObject:
+        if items, exists := typed.get(listPayloadItemsKey); true == exists {
+            if list, isList := items.([]any); true == isList {
+                selected := make(payloadObject, 0, len(typed))
+                for _, field := range typed {
+                    if listPayloadItemsKey == field.key {
+                        field.value = selectPayloadFields(list, fields)
+                    }
+
+                    selected = append(selected, field)
+                }
+
+                return selected
+            }
+        }
+
+        return selectObjectFields(typed, fields)
+    case []any:
+        selected := make([]any, 0, len(typed))
+        for _, element := range typed {
+            if object, isObject := element.(payloadObject); true == isObject {
+                element = selectObjectFields(object, fields)
+            }
+
+            selected = append(selected, element)
+        }
+
+        return selected
+    default:
+        return typed
+    }
+}
+
+func selectObjectFields(object payloadObject, fields []string) payloadObject {
+    selected := make(payloadObject, 0, len(fields))
+
+    for _, field := range fields {
+        for _, objectField := range object {
+            if true == matchesField(objectField.key, field) {
+                selected = append(selected, objectField)
+                break
+            }
+        }
+    }
+
+    return selected
+}
+
+func selectTableFields(table *TableData, fields []string) *TableData {
+    selected := &TableData{
+        SummaryLines: table.SummaryLines,
+        Blocks:       make([]TableBlock, 0, len(table.Blocks)),
+    }
+
+    for _, block := range table.Blocks {
+        keys := block.Fields
+        if len(keys) != len(block.Columns) {
+            keys = block.Columns
+        }
+
+        indexes := make([]int, 0, len(keys))
+        for _, field := range fields {
+            for index, key := range keys {
+                isMatch := true == matchesField(key, field) || true == matchesField(block.Columns[index], field)
+                if true == isMatch && false == containsIndex(indexes, index) {
+                    indexes = append(indexes, index)
+                }
+            }
+        }
+
+        if 0 == len(indexes) {
+            continue
+        }
+
+        selectedBlock := TableBlock{
+            Title:   block.Title,
+            Columns: make([]string, 0, len(indexes)),
+            Fields:  make([]string, 0, len(indexes)),
+            Rows:    make([][]string, 0, len(block.Rows)),
+        }
+
+        for _, index := range indexes {
+            selectedBlock.Columns = append(selectedBlock.Columns, block.Columns[index])
+            selectedBlock.Fields = append(selectedBlock.Fields, keys[index])
+        }
+
+        for _, row := range block.Rows {
+            if 1 == len(row) && TableRowSeparatorToken == row[0] {
+                selectedBlock.Rows = append(selectedBlock.Rows, row)
+                continue
+            }
+
+            selectedRow := make([]string, 0, len(indexes))
+            for _, index := range indexes {
+                cell := ""
+                if index < len(row) {
+                    cell = row[index]
+                }
+
+                selectedRow = append(selectedRow, cell)
+            }
+
+            selectedBlock.Rows = append(selectedBlock.Rows, selectedRow)
+        }
+
+        selected.Blocks = append(selected.Blocks, selectedBlock)
+    }
+
+    return selected
+}
+
+func containsIndex(indexes []int, index int) bool {
+    for _, existing := range indexes {
+        if existing == index {
+            return true
+        }
+    }
+
+    return false
+}
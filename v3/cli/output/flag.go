@@ -16,6 +16,7 @@
     FlagNameLimit         = "limit"
     FlagNameOffset        = "offset"
     FlagNameTableMaxWidth = "table-width"
+    FlagNameTemplate      = "template"
 )
 
 func MergeFlags(
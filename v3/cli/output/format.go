@@ -3,8 +3,11 @@
 type Format string
 
 const (
-    FormatTable Format = "table"
-    FormatJson  Format = "json"
+    FormatTable    Format = "table"
+    FormatJson     Format = "json"
+    FormatYaml     Format = "yaml"
+    FormatCsv      Format = "csv"
+    FormatTemplate Format = "template"
 )
 
 type SortOrder string
@@ -12,6 +12,7 @@ type Option struct {
     Limit          int
     Offset         int
     TableMaxWidth  int
+    Template       string
 }
 
 func DefaultOption() Option {
@@ -27,5 +28,6 @@ func DefaultOption() Option {
         Limit:          0,
         Offset:         0,
         TableMaxWidth:  0,
+        Template:       "",
     }
 }
@@ -44,6 +44,8 @@ func ParseOptionFromCommand(commandContext *clicontract.CommandContext) Option {
 
     option.TableMaxWidth = commandContext.Int(FlagNameTableMaxWidth)
 
+    option.Template = commandContext.String(FlagNameTemplate)
+
     return option
 }
 
@@ -54,7 +56,7 @@ func NormalizeOption(option Option) Option {
         normalized.Format = FormatTable
     }
 
-    if FormatTable != normalized.Format && FormatJson != normalized.Format {
+    if false == IsRegisteredFormat(normalized.Format) {
         normalized.Format = FormatTable
     }
 
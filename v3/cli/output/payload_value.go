@@ -0,0 +1,175 @@
+package output
+
+import (
+    "bytes"
+    "encoding/json"
+    "io"
+    "strings"
+)
+
+const listPayloadItemsKey = "items"
+
+/* payloadField and payloadObject keep the field order of the json encoding, so yaml keys and csv columns follow the struct declaration. */
+type payloadField struct {
+    key   string
+    value any
+}
+
+type payloadObject []payloadField
+
+func (instance payloadObject) get(key string) (any, bool) {
+    for _, field := range instance {
+        if key == field.key {
+            return field.value, true
+        }
+    }
+
+    return nil, false
+}
+
+func (instance payloadObject) keys() []string {
+    keys := make([]string, 0, len(instance))
+    for _, field := range instance {
+        keys = append(keys, field.key)
+    }
+
+    return keys
+}
+
+func (instance payloadObject) MarshalJSON() ([]byte, error) {
+    buffer := &bytes.Buffer{}
+    buffer.WriteByte('{')
+
+    for index, field := range instance {
+        if 0 < index {
+            buffer.WriteByte(',')
+        }
+
+        key, keyErr := json.Marshal(field.key)
+        if nil != keyErr {
+            return nil, keyErr
+        }
+
+        value, valueErr := json.Marshal(field.value)
+        if nil != valueErr {
+            return nil, valueErr
+        }
+
+        buffer.Write(key)
+        buffer.WriteByte(':')
+        buffer.Write(value)
+    }
+
+    buffer.WriteByte('}')
+
+    return buffer.Bytes(), nil
+}
+
+/* toPayloadValue converts value through its json encoding into payloadObject, []any, string, json.Number, bool or nil. */
+func toPayloadValue(value any) (any, error) {
+    encoded, marshalErr := json.Marshal(value)
+    if nil != marshalErr {
+        return nil, marshalErr
+    }
+
+    decoder := json.NewDecoder(bytes.NewReader(encoded))
+    decoder.UseNumber()
+
+    return decodePayloadValue(decoder)
+}
+
+func decodePayloadValue(decoder *json.Decoder) (any, error) {
+    token, tokenErr := decoder.Token()
+    if nil != tokenErr {
+        return nil, tokenErr
+    }
+
+    delimiter, isDelimiter := token.(json.Delim)
+    if false == isDelimiter {
+        return token, nil
+    }
+
+    switch delimiter {
+    case '{':
+        object := payloadObject{}
+        for true == decoder.More() {
+            keyToken, keyErr := decoder.Token()
+            if nil != keyErr {
+                return nil, keyErr
+            }
+
+            value, valueErr := decodePayloadValue(decoder)
+            if nil != valueErr {
+                return nil, valueErr
+            }
+
+            object = append(object, payloadField{key: keyToken.(string), value: value})
+        }
+
+        _, closeErr := decoder.Token()
+
+        return object, closeErr
+    case '[':
+        list := []any{}
+        for true == decoder.More() {
+            value, valueErr := decodePayloadValue(decoder)
+            if nil != valueErr {
+                return nil, valueErr
+            }
+
+            list = append(list, value)
+        }
+
+        _, closeErr := decoder.Token()
+
+        return list, closeErr
+    default:
+        return nil, io.ErrUnexpectedEOF
+    }
+}
+
+/* payloadRecords returns the items of a list payload (see NewListPayload), the elements of a list, or the value itself as the only record. */
+func payloadRecords(value any) []any {
+    switch typed := value.(type) {
+    case nil:
+        return []any{}
+    case payloadObject:
+        if items, exists := typed.get(listPayloadItemsKey); true == exists {
+            if list, isList := items.([]any); true == isList {
+                return list
+            }
+        }
+
+        return []any{typed}
+    case []any:
+        return typed
+    default:
+        return []any{typed}
+    }
+}
+
+/* toTemplateValue turns payload objects into maps so templates can use {{.field}}. */
+func toTemplateValue(value any) any {
+    switch typed := value.(type) {
+    case payloadObject:
+        converted := make(map[string]any, len(typed))
+        for _, field := range typed {
+            converted[field.key] = toTemplateValue(field.value)
+        }
+
+        return converted
+    case []any:
+        converted := make([]any, 0, len(typed))
+        for _, element := range typed {
+            converted = append(converted, toTemplateValue(element))
+        }
+
+        return converted
+    default:
+        return typed
+    }
+}
+
+func matchesField(key string, field string) bool {
+    return true == strings.EqualFold(key, field)
+}
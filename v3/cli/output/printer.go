@@ -1,6 +1,10 @@
 package output
 
-import "io"
+import (
+    "io"
+
+    "github.com/precision-soft/melody/v3/exception"
+)
 
 type Printer interface {
     Print(
@@ -9,3 +13,16 @@ type Printer interface {
         option Option,
     ) error
 }
+
+/* envelopeError turns the error of an envelope into the command error for the formats that print the data only. */
+func envelopeError(envelope Envelope) error {
+    if nil == envelope.Error {
+        return nil
+    }
+
+    return exception.NewError(
+        envelope.Error.Message,
+        map[string]any{"code": envelope.Error.Code, "details": envelope.Error.Details},
+        nil,
+    )
+}
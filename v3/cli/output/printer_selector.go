@@ -1,15 +1,88 @@
 package output
 
-func SelectPrinter(option Option) Printer {
-    normalized := NormalizeOption(option)
+import (
+    "sync"
+
+    "github.com/precision-soft/melody/v3/exception"
+)
+
+/* PrinterFactory builds the printer of a format for the normalized option. */
+type PrinterFactory func(option Option) Printer
+
+var printerRegistry = &printerFactoryRegistry{
+    formats: []Format{FormatTable, FormatJson, FormatYaml, FormatCsv, FormatTemplate},
+    factoryByFormat: map[Format]PrinterFactory{
+        FormatTable: func(option Option) Printer {
+            if 0 < option.TableMaxWidth {
+                return NewTablePrinter(option.TableMaxWidth)
+            }
+
+            return NewDefaultTablePrinter()
+        },
+        FormatJson: func(option Option) Printer {
+            return &JsonPrinter{}
+        },
+        FormatYaml: func(option Option) Printer {
+            return &YamlPrinter{}
+        },
+        FormatCsv: func(option Option) Printer {
+            return &CsvPrinter{}
+        },
+        FormatTemplate: func(option Option) Printer {
+            return &TemplatePrinter{}
+        },
+    },
+}
 
-    if FormatJson == normalized.Format {
-        return &JsonPrinter{}
+type printerFactoryRegistry struct {
+    mutex           sync.RWMutex
+    formats         []Format
+    factoryByFormat map[Format]PrinterFactory
+}
+
+/* RegisterPrinter makes format selectable with --format; registering a built-in format replaces its printer. Register formats before the cli runs. */
+func RegisterPrinter(format Format, factory PrinterFactory) {
+    if "" == format {
+        exception.Panic(exception.NewError("output format may not be empty", nil, nil))
+    }
+
+    if nil == factory {
+        exception.Panic(exception.NewError("output printer factory may not be nil", map[string]any{"format": format}, nil))
     }
 
-    if 0 < normalized.TableMaxWidth {
-        return NewTablePrinter(normalized.TableMaxWidth)
+    printerRegistry.mutex.Lock()
+    defer printerRegistry.mutex.Unlock()
+
+    if _, exists := printerRegistry.factoryByFormat[format]; false == exists {
+        printerRegistry.formats = append(printerRegistry.formats, format)
     }
 
-    return NewDefaultTablePrinter()
+    printerRegistry.factoryByFormat[format] = factory
+}
+
+/* Formats returns the registered formats, the built-in ones first. */
+func Formats() []Format {
+    printerRegistry.mutex.RLock()
+    defer printerRegistry.mutex.RUnlock()
+
+    return append([]Format{}, printerRegistry.formats...)
+}
+
+func IsRegisteredFormat(format Format) bool {
+    printerRegistry.mutex.RLock()
+    defer printerRegistry.mutex.RUnlock()
+
+    _, exists := printerRegistry.factoryByFormat[format]
+
+    return exists
+}
+
+func SelectPrinter(option Option) Printer {
+    normalized := NormalizeOption(option)
+
+    printerRegistry.mutex.RLock()
+    factory := printerRegistry.factoryByFormat[normalized.Format]
+    printerRegistry.mutex.RUnlock()
+
+    return factory(normalized)
 }
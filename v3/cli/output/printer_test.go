@@ -0,0 +1,225 @@
+package output
+
+import (
+    "io"
+    "strings"
+    "testing"
+    "time"
+)
+
+type testRouteItem struct {
+    Methods string         `json:"methods"`
+    Pattern string         `json:"pattern"`
+    Name    string         `json:"name"`
+    Tags    []string       `json:"tags"`
+    Meta    map[string]int `json:"meta"`
+}
+
+func newTestEnvelope(option Option) Envelope {
+    envelope := NewEnvelope(
+        NewMeta("debug:test", []string{}, option, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), 0, Version{Application: "1.0.0", Melody: "v3"}),
+    )
+
+    envelope.Data = NewListPayload(
+        []testRouteItem{
+            {Methods: "GET", Pattern: "/", Name: "home", Tags: []string{}, Meta: map[string]int{}},
+            {Methods: "GET,POST", Pattern: "/users/{id}", Name: "user: show", Tags: []string{"a", "b"}, Meta: map[string]int{"weight": 2}},
+        },
+        2,
+        0,
+        0,
+    )
+
+    builder := NewTableBuilder()
+    builder.AddBlock("ROUTES", []string{"methods", "pattern", "route"}).
+        SetFields("methods", "pattern", "name").
+        AddRow("GET", "/", "home").
+        AddRow(TableRowSeparatorToken).
+        AddRow("GET,POST", "/users/{id}", "user: show")
+    builder.AddBlock("DETAILS", []string{"key", "value"}).AddRow("total", "2")
+    envelope.Table = builder.Build()
+
+    return envelope
+}
+
+func renderToString(t *testing.T, option Option) string {
+    t.Helper()
+
+    normalized := NormalizeOption(option)
+
+    builder := &strings.Builder{}
+    if renderErr := Render(builder, newTestEnvelope(normalized), normalized); nil != renderErr {
+        t.Fatalf("unexpected error: %v", renderErr)
+    }
+
+    return builder.String()
+}
+
+func TestRender_Formats(t *testing.T) {
+    cases := map[string]struct {
+        option   Option
+        expected string
+    }{
+        "csv": {
+            option:   Option{Format: FormatCsv},
+            expected: "methods,pattern,name,tags,meta\nGET,/,home,[],{}\n\"GET,POST\",/users/{id},user: show,\"[\"\"a\"\",\"\"b\"\"]\",\"{\"\"weight\"\":2}\"\n",
+        },
+        "csv with fields": {
+            option:   Option{Format: FormatCsv, Fields: []string{"Name", "methods", "missing"}},
+            expected: "Name,methods,missing\nhome,GET,\nuser: show,\"GET,POST\",\n",
+        },
+        "template": {
+            option:   Option{Format: FormatTemplate, Template: "{{.name}} {{.methods}} {{json .tags}}"},
+            expected: "home GET []\nuser: show GET,POST [\"a\",\"b\"]\n",
+        },
+        "json with fields": {
+            option:   Option{Format: FormatJson, Fields: []string{"name"}},
+            expected: "\"items\": [\n      {\n        \"name\": \"home\"\n      },\n      {\n        \"name\": \"user: show\"\n      }\n    ],\n    \"total\": 2,",
+        },
+        "table with fields": {
+            option:   Option{Format: FormatTable, Quiet: true, Fields: []string{"route"}},
+            expected: "ROUTES",
+        },
+    }
+
+    for name, testCase := range cases {
+        t.Run(name, func(t *testing.T) {
+            rendered := renderToString(t, testCase.option)
+
+            if false == strings.Contains(rendered, testCase.expected) {
+                t.Fatalf("expected %q in:\n%s", testCase.expected, rendered)
+            }
+        })
+    }
+}
+
+func TestRender_YamlKeepsFieldOrderAndQuotesAmbiguousStrings(t *testing.T) {
+    rendered := renderToString(t, Option{Format: FormatYaml, Fields: []string{"name", "pattern", "tags", "meta"}})
+
+    expected := `data:
+  items:
+    - name: home
+      pattern: /
+      tags: []
+      meta: {}
+    - name: "user: show"
+      pattern: "/users/{id}"
+      tags:
+        - a
+        - b
+      meta:
+        weight: 2
+  total: 2
+  limit: 0
+  offset: 0
+warnings: []
+error: null
+`
+    if false == strings.HasSuffix(rendered, expected) {
+        t.Fatalf("expected the yaml to end with:\n%s\ngot:\n%s", expected, rendered)
+    }
+
+    if false == strings.HasPrefix(rendered, "meta:\n  command: \"debug:test\"\n") {
+        t.Fatalf("expected the meta first, got:\n%s", rendered)
+    }
+}
+
+func TestApplyFields_SelectsTableColumnsThroughBlockFields(t *testing.T) {
+    envelope, applyErr := ApplyFields(newTestEnvelope(DefaultOption()), []string{"name", "methods"})
+    if nil != applyErr {
+        t.Fatalf("unexpected error: %v", applyErr)
+    }
+
+    if 1 != len(envelope.Table.Blocks) {
+        t.Fatalf("expected the block without any field to be left out, got %d blocks", len(envelope.Table.Blocks))
+    }
+
+    block := envelope.Table.Blocks[0]
+    if "route,methods" != strings.Join(block.Columns, ",") {
+        t.Fatalf("unexpected columns: %v", block.Columns)
+    }
+
+    if 3 != len(block.Rows) || TableRowSeparatorToken != block.Rows[1][0] || "home,GET" != strings.Join(block.Rows[0], ",") {
+        t.Fatalf("unexpected rows: %v", block.Rows)
+    }
+}
+
+func TestRender_DataOnlyFormatsReturnTheEnvelopeError(t *testing.T) {
+    for _, format := range []Format{FormatCsv, FormatTemplate} {
+        envelope := newTestEnvelope(DefaultOption())
+        envelope.SetError("cli.failed", "lookup failed", nil, nil)
+
+        renderErr := Render(io.Discard, envelope, Option{Format: format, Template: "{{.name}}"})
+        if nil == renderErr || "lookup failed" != renderErr.Error() {
+            t.Fatalf("%s: expected the envelope error, got %v", format, renderErr)
+        }
+    }
+}
+
+func TestRender_TemplateErrors(t *testing.T) {
+    cases := map[string]string{
+        "missing template": "",
+        "invalid template": "{{.name",
+    }
+
+    for name, templateText := range cases {
+        t.Run(name, func(t *testing.T) {
+            renderErr := Render(io.Discard, newTestEnvelope(DefaultOption()), Option{Format: FormatTemplate, Template: templateText})
+            if nil == renderErr {
+                t.Fatalf("expected an error")
+            }
+        })
+    }
+}
+
+type testPrinter struct{}
+
+func (instance *testPrinter) Print(writer io.Writer, envelope Envelope, option Option) error {
+    _, writeErr := io.WriteString(writer, "custom")
+
+    return writeErr
+}
+
+func TestRegisterPrinter_AddsSelectableFormat(t *testing.T) {
+    const formatCustom Format = "test-custom"
+
+    if Format("test-custom") == NormalizeOption(Option{Format: formatCustom}).Format {
+        t.Fatalf("expected an unregistered format to fall back to the table format")
+    }
+
+    RegisterPrinter(formatCustom, func(option Option) Printer { return &testPrinter{} })
+
+    if false == IsRegisteredFormat(formatCustom) || formatCustom != Formats()[len(Formats())-1] {
+        t.Fatalf("expected the format to be registered last, got %v", Formats())
+    }
+
+    builder := &strings.Builder{}
+    if renderErr := Render(builder, newTestEnvelope(DefaultOption()), Option{Format: formatCustom}); nil != renderErr || "custom" != builder.String() {
+        t.Fatalf("expected the custom printer, got %q (%v)", builder.String(), renderErr)
+    }
+}
+
+func TestIsPlainYamlString(t *testing.T) {
+    cases := map[string]bool{
+        "home":          true,
+        "App.Service_1": true,
+        "a b":           true,
+        "":              false,
+        " padded":       false,
+        "true":          false,
+        "No":            false,
+        "null":          false,
+        "12":            false,
+        "1.5e3":         false,
+        "-dash":         false,
+        "key: value":    false,
+        "#comment":      false,
+        "multi\nline":   false,
+    }
+
+    for value, expected := range cases {
+        if expected != isPlainYamlString(value) {
+            t.Fatalf("%q: expected plain=%v", value, expected)
+        }
+    }
+}
@@ -2,14 +2,20 @@
 
 import "io"
 
+/* Render applies --fields to the envelope (see ApplyFields) and prints it with the printer of the selected format. */
 func Render(
     writer io.Writer,
     envelope Envelope,
     option Option,
 ) error {
+    filtered, applyFieldsErr := ApplyFields(envelope, option.Fields)
+    if nil != applyFieldsErr {
+        return applyFieldsErr
+    }
+
     printer := SelectPrinter(option)
 
-    printErr := printer.Print(writer, envelope, option)
+    printErr := printer.Print(writer, filtered, option)
     if nil != printErr {
         return printErr
     }
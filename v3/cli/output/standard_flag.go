@@ -11,7 +11,7 @@ func StandardFlags() []clicontract.Flag {
     return []clicontract.Flag{
         &clicontract.StringFlag{
             Name:  FlagNameFormat,
-            Usage: "output format: table|json",
+            Usage: "output format: " + formatList(),
             Value: string(FormatTable),
         },
         &clicontract.BoolFlag{
@@ -36,7 +36,7 @@ func StandardFlags() []clicontract.Flag {
         },
         &clicontract.StringFlag{
             Name:  FlagNameFields,
-            Usage: "comma-separated list of fields to include (json only)",
+            Usage: "comma-separated list of fields to include, in every format",
             Value: "",
         },
         &clicontract.StringFlag{
@@ -64,7 +64,23 @@ func StandardFlags() []clicontract.Flag {
             Usage: fmt.Sprintf("max table width in characters (0 = default %d)", defaultTableMaxWidth),
             Value: 0,
         },
+        &clicontract.StringFlag{
+            Name:  FlagNameTemplate,
+            Usage: "go template applied to each item (or to the data) with --format=template, e.g. '{{.name}}'",
+            Value: "",
+        },
+    }
+}
+
+func formatList() string {
+    formats := Formats()
+
+    names := make([]string, 0, len(formats))
+    for _, format := range formats {
+        names = append(names, string(format))
     }
+
+    return strings.Join(names, "|")
 }
 
 func SplitFields(fieldsString string) []string {
@@ -43,6 +43,12 @@ type TableBlockBuilder struct {
     block *TableBlock
 }
 
+/* SetFields names the payload field of each column, in column order, when it differs from the column name; several columns may share a field. */
+func (instance *TableBlockBuilder) SetFields(fields ...string) *TableBlockBuilder {
+    instance.block.Fields = fields
+    return instance
+}
+
 func (instance *TableBlockBuilder) AddRow(cells ...string) *TableBlockBuilder {
     instance.block.Rows = append(instance.block.Rows, cells)
     return instance
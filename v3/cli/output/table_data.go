@@ -1,8 +1,10 @@
 package output
 
+/* TableBlock is a titled table; Fields, when set, holds the payload field of each column so --fields selects the same columns as in the other formats. */
 type TableBlock struct {
     Title   string
     Columns []string
+    Fields  []string
     Rows    [][]string
 }
 
@@ -0,0 +1,83 @@
+package output
+
+import (
+    "encoding/json"
+    "io"
+    "strings"
+    "text/template"
+
+    "github.com/precision-soft/melody/v3/exception"
+)
+
+/*
+ * TemplatePrinter executes the --template go template once per item of a list payload (or element of a list), or once
+ * for any other data, each followed by a new line. Fields use the json names ({{.name}}); the json function encodes a
+ * value ({{json .chain}}). An envelope error is returned instead of printed.
+ */
+type TemplatePrinter struct {
+}
+
+func (instance *TemplatePrinter) Print(
+    writer io.Writer,
+    envelope Envelope,
+    option Option,
+) error {
+    templateText := option.Template
+    if "" == strings.TrimSpace(templateText) {
+        return exception.NewError(
+            "the template format needs a template",
+            map[string]any{"flag": "--" + FlagNameTemplate},
+            nil,
+        )
+    }
+
+    parsedTemplate, parseErr := template.New("output").
+        Funcs(template.FuncMap{"json": templateJson}).
+        Parse(templateText)
+    if nil != parseErr {
+        return exception.NewError(
+            "invalid output template",
+            map[string]any{"template": templateText},
+            parseErr,
+        )
+    }
+
+    if envelopeErr := envelopeError(envelope); nil != envelopeErr {
+        return envelopeErr
+    }
+
+    if nil == envelope.Data {
+        return nil
+    }
+
+    data, convertErr := toPayloadValue(envelope.Data)
+    if nil != convertErr {
+        return convertErr
+    }
+
+    for _, record := range payloadRecords(data) {
+        executeErr := parsedTemplate.Execute(writer, toTemplateValue(record))
+        if nil != executeErr {
+            return exception.NewError(
+                "could not execute the output template",
+                map[string]any{"template": templateText},
+                executeErr,
+            )
+        }
+
+        if _, writeErr := io.WriteString(writer, "\n"); nil != writeErr {
+            return writeErr
+        }
+    }
+
+    return nil
+}
+
+func templateJson(value any) (string, error) {
+    encoded, marshalErr := json.Marshal(value)
+    if nil != marshalErr {
+        return "", marshalErr
+    }
+
+    return string(encoded), nil
+}
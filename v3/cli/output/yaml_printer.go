@@ -0,0 +1,146 @@
+package output
+
+import (
+    "encoding/json"
+    "io"
+    "strconv"
+    "strings"
+)
+
+/* YamlPrinter prints the whole envelope, like JsonPrinter, as a yaml document with the json field names and order. */
+type YamlPrinter struct {
+}
+
+func (instance *YamlPrinter) Print(
+    writer io.Writer,
+    envelope Envelope,
+    option Option,
+) error {
+    value, convertErr := toPayloadValue(envelope)
+    if nil != convertErr {
+        return convertErr
+    }
+
+    _, writeErr := io.WriteString(writer, encodeYaml(value))
+
+    return writeErr
+}
+
+func encodeYaml(value any) string {
+    if true == isYamlScalar(value) {
+        return yamlScalar(value) + "\n"
+    }
+
+    return strings.Join(yamlLines(value), "\n") + "\n"
+}
+
+/* yamlLines renders a non-empty object or list as block lines without base indentation. */
+func yamlLines(value any) []string {
+    lines := make([]string, 0)
+
+    switch typed := value.(type) {
+    case payloadObject:
+        for _, field := range typed {
+            key := yamlString(field.key)
+
+            if true == isYamlScalar(field.value) {
+                lines = append(lines, key+": "+yamlScalar(field.value))
+                continue
+            }
+
+            lines = append(lines, key+":")
+            for _, line := range yamlLines(field.value) {
+                lines = append(lines, "  "+line)
+            }
+        }
+    case []any:
+        for _, element := range typed {
+            if true == isYamlScalar(element) {
+                lines = append(lines, "- "+yamlScalar(element))
+                continue
+            }
+
+            for index, line := range yamlLines(element) {
+                prefix := "  "
+                if 0 == index {
+                    prefix = "- "
+                }
+
+                lines = append(lines, prefix+line)
+            }
+        }
+    }
+
+    return lines
+}
+
+/* isYamlScalar reports whether value is written inline: scalars and empty collections. */
+func isYamlScalar(value any) bool {
+    switch typed := value.(type) {
+    case payloadObject:
+        return 0 == len(typed)
+    case []any:
+        return 0 == len(typed)
+    default:
+        return true
+    }
+}
+
+func yamlScalar(value any) string {
+    switch typed := value.(type) {
+    case nil:
+        return "null"
+    case bool:
+        return strconv.FormatBool(typed)
+    case string:
+        return yamlString(typed)
+    case json.Number:
+        return typed.String()
+    case payloadObject:
+        return "{}"
+    case []any:
+        return "[]"
+    default:
+        return yamlString(toCellString(typed))
+    }
+}
+
+/* yamlString leaves plain only the strings yaml cannot read as another type or as syntax; the others are double quoted. */
+func yamlString(value string) string {
+    if true == isPlainYamlString(value) {
+        return value
+    }
+
+    return strconv.Quote(value)
+}
+
+func isPlainYamlString(value string) bool {
+    if "" == value || strings.TrimSpace(value) != value {
+        return false
+    }
+
+    switch strings.ToLower(value) {
+    case "true", "false", "yes", "no", "on", "off", "y", "n", "null", "~":
+        return false
+    }
+
+    if _, parseErr := strconv.ParseFloat(value, 64); nil == parseErr {
+        return false
+    }
+
+    first := value[0]
+    if ('0' <= first && '9' >= first) || true == strings.ContainsRune("-?:,[]{}#&*!|>'\"%@`.+", rune(first)) {
+        return false
+    }
+
+    for _, character := range value {
+        isLetter := ('a' <= character && 'z' >= character) || ('A' <= character && 'Z' >= character)
+        isDigit := '0' <= character && '9' >= character
+
+        if false == isLetter && false == isDigit && false == strings.ContainsRune(" _-./()+=,@", character) {
+            return false
+        }
+    }
+
+    return true
+}
@@ -0,0 +1,73 @@
+package reference
+
+import (
+    "io"
+    "os"
+    "strings"
+
+    clicontract "github.com/precision-soft/melody/v3/cli/contract"
+    "github.com/precision-soft/melody/v3/exception"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+const FlagNameProgram = "program"
+
+func NewCompletionCommand() *CompletionCommand {
+    return &CompletionCommand{}
+}
+
+type CompletionCommand struct{}
+
+func (instance *CompletionCommand) Name() string {
+    return "melody:completion"
+}
+
+func (instance *CompletionCommand) Description() string {
+    return "print the shell completion script (bash, zsh or fish) of the registered commands and their flags"
+}
+
+func (instance *CompletionCommand) Flags() []clicontract.Flag {
+    return []clicontract.Flag{
+        &clicontract.StringFlag{
+            Name:  FlagNameProgram,
+            Usage: "executable name the script completes; defaults to the cli name",
+        },
+    }
+}
+
+func (instance *CompletionCommand) RawOutput() bool {
+    return true
+}
+
+func (instance *CompletionCommand) Run(
+    _ runtimecontract.Runtime,
+    commandContext *clicontract.CommandContext,
+) error {
+    shell := strings.TrimSpace(commandContext.Args().First())
+    if "" == shell {
+        return exception.NewError(
+            "missing shell argument",
+            map[string]any{"supported": Shells()},
+            nil,
+        )
+    }
+
+    root := commandContext.Root()
+
+    program := strings.TrimSpace(commandContext.String(FlagNameProgram))
+    if "" == program {
+        program = root.Name
+    }
+
+    return RenderCompletion(commandWriter(commandContext), shell, FromCommandContext(root), program)
+}
+
+func commandWriter(commandContext *clicontract.CommandContext) io.Writer {
+    if nil == commandContext.Writer {
+        return os.Stdout
+    }
+
+    return commandContext.Writer
+}
+
+var _ clicontract.RawOutputCommand = (*CompletionCommand)(nil)
@@ -0,0 +1,81 @@
+package reference
+
+import (
+    "fmt"
+    "os"
+    "strings"
+
+    clicontract "github.com/precision-soft/melody/v3/cli/contract"
+    "github.com/precision-soft/melody/v3/exception"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+const (
+    FlagNameFormat = "format"
+    FlagNameOut    = "out"
+)
+
+func NewDocumentationCommand() *DocumentationCommand {
+    return &DocumentationCommand{}
+}
+
+type DocumentationCommand struct{}
+
+func (instance *DocumentationCommand) Name() string {
+    return "melody:docs:cli"
+}
+
+func (instance *DocumentationCommand) Description() string {
+    return "generate the Markdown or man page reference of every command and its flags, grouped by namespace"
+}
+
+func (instance *DocumentationCommand) Flags() []clicontract.Flag {
+    return []clicontract.Flag{
+        &clicontract.StringFlag{
+            Name:  FlagNameFormat,
+            Value: DocumentationFormatMarkdown,
+            Usage: "documentation format: markdown or man",
+        },
+        &clicontract.StringFlag{
+            Name:  FlagNameOut,
+            Usage: "path to write the documentation to; prints to stdout when empty",
+        },
+    }
+}
+
+func (instance *DocumentationCommand) RawOutput() bool {
+    return true
+}
+
+func (instance *DocumentationCommand) Run(
+    _ runtimecontract.Runtime,
+    commandContext *clicontract.CommandContext,
+) error {
+    format := strings.TrimSpace(commandContext.String(FlagNameFormat))
+    application := FromCommandContext(commandContext.Root())
+
+    out := strings.TrimSpace(commandContext.String(FlagNameOut))
+    if "" == out {
+        return RenderDocumentation(commandWriter(commandContext), format, application)
+    }
+
+    builder := &strings.Builder{}
+    if renderErr := RenderDocumentation(builder, format, application); nil != renderErr {
+        return renderErr
+    }
+
+    writeErr := os.WriteFile(out, []byte(builder.String()), 0o644)
+    if nil != writeErr {
+        return exception.NewError(
+            "could not write the cli documentation",
+            map[string]any{"out": out},
+            writeErr,
+        )
+    }
+
+    _, _ = fmt.Fprintln(commandWriter(commandContext), "wrote cli documentation to", out)
+
+    return nil
+}
+
+var _ clicontract.RawOutputCommand = (*DocumentationCommand)(nil)
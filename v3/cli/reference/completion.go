@@ -0,0 +1,90 @@
+package reference
+
+import (
+    "io"
+    "regexp"
+    "strings"
+
+    "github.com/precision-soft/melody/v3/exception"
+)
+
+const (
+    ShellBash = "bash"
+    ShellZsh  = "zsh"
+    ShellFish = "fish"
+)
+
+var programNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+func Shells() []string {
+    return []string{ShellBash, ShellZsh, ShellFish}
+}
+
+/* RenderCompletion writes the completion script of shell for program (the executable name the shell completes), listing the commands and flags of application. */
+func RenderCompletion(writer io.Writer, shell string, application *Application, program string) error {
+    if nil == application {
+        return exception.NewError("application reference may not be nil", nil, nil)
+    }
+
+    if false == programNamePattern.MatchString(program) {
+        return exception.NewError(
+            "invalid program name for the completion script",
+            map[string]any{"program": program},
+            nil,
+        )
+    }
+
+    var script string
+    switch shell {
+    case ShellBash:
+        script = renderBashCompletion(application, program)
+    case ShellZsh:
+        script = renderZshCompletion(application, program)
+    case ShellFish:
+        script = renderFishCompletion(application, program)
+    default:
+        return exception.NewError(
+            "unsupported shell",
+            map[string]any{"shell": shell, "supported": Shells()},
+            nil,
+        )
+    }
+
+    _, writeErr := io.WriteString(writer, script)
+
+    return writeErr
+}
+
+func completionFunctionName(program string) string {
+    return "_" + strings.Map(
+        func(character rune) rune {
+            if ('a' <= character && 'z' >= character) || ('A' <= character && 'Z' >= character) || ('0' <= character && '9' >= character) {
+                return character
+            }
+
+            return '_'
+        },
+        program,
+    ) + "_completion"
+}
+
+func flagSpellings(flags []Flag) []string {
+    spellings := make([]string, 0, len(flags))
+    for _, flag := range flags {
+        spellings = append(spellings, flag.Spellings()...)
+    }
+
+    return spellings
+}
+
+/* singleQuote quotes value for bash and zsh. */
+func singleQuote(value string) string {
+    return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+/* firstLine keeps descriptions on one line, as the completion entries require. */
+func firstLine(value string) string {
+    line, _, _ := strings.Cut(strings.TrimSpace(value), "\n")
+
+    return strings.TrimSpace(line)
+}
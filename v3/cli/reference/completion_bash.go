@@ -0,0 +1,66 @@
+package reference
+
+import (
+    "fmt"
+    "strings"
+)
+
+func renderBashCompletion(application *Application, program string) string {
+    functionName := completionFunctionName(program)
+    builder := &strings.Builder{}
+
+    fmt.Fprintf(builder, "# bash completion for %s, generated by melody:completion\n", program)
+    fmt.Fprintf(builder, "# load it with: source <(%s melody:completion bash)\n\n", program)
+
+    fmt.Fprintf(builder, "%s() {\n", functionName)
+    builder.WriteString(`    local line="${COMP_LINE:0:COMP_POINT}"
+    local current="${line##* }"
+    local -a words
+    read -r -a words <<< "${line%"${current}"}"
+
+    local command="" word
+    for word in "${words[@]:1}"; do
+        if [[ "${word}" != -* ]]; then
+            command="${word}"
+            break
+        fi
+    done
+
+`)
+    fmt.Fprintf(builder, "    local candidates=%s\n", singleQuote(strings.Join(flagSpellings(application.GlobalFlags), " ")))
+    builder.WriteString(`    case "${command}" in
+        "")
+            if [[ "${current}" != -* ]]; then
+`)
+    fmt.Fprintf(builder, "                candidates=%s\n", singleQuote(strings.Join(application.CommandNames(), " ")))
+    builder.WriteString("            fi\n            ;;\n")
+
+    for _, command := range application.Commands {
+        if 0 == len(command.Flags) {
+            continue
+        }
+
+        fmt.Fprintf(builder, "        %s)\n", singleQuote(command.Name))
+        fmt.Fprintf(builder, "            candidates+=%s\n", singleQuote(" "+strings.Join(flagSpellings(command.Flags), " ")))
+        builder.WriteString("            ;;\n")
+    }
+
+    builder.WriteString(`    esac
+
+    COMPREPLY=($(compgen -W "${candidates}" -- "${current}"))
+
+    # bash splits words on ":", so the part of a command name before the last ":" is already on the line
+    if [[ "${current}" == *:* && "${COMP_WORDBREAKS}" == *:* ]]; then
+        local prefix="${current%"${current##*:}"}"
+        local index
+        for index in "${!COMPREPLY[@]}"; do
+            COMPREPLY[index]="${COMPREPLY[index]#"${prefix}"}"
+        done
+    fi
+}
+
+`)
+    fmt.Fprintf(builder, "complete -o default -F %s %s\n", functionName, program)
+
+    return builder.String()
+}
@@ -0,0 +1,65 @@
+package reference
+
+import (
+    "fmt"
+    "strings"
+)
+
+func renderFishCompletion(application *Application, program string) string {
+    builder := &strings.Builder{}
+
+    fmt.Fprintf(builder, "# fish completion for %s, generated by melody:completion\n", program)
+    fmt.Fprintf(builder, "# load it with: %s melody:completion fish | source\n\n", program)
+
+    for _, command := range application.Commands {
+        fmt.Fprintf(builder, "complete -c %s -n '__fish_use_subcommand' -f -a %s", program, fishQuote(command.Name))
+        writeFishDescription(builder, command.Description)
+        builder.WriteString("\n")
+    }
+
+    for _, flag := range application.GlobalFlags {
+        fmt.Fprintf(builder, "complete -c %s", program)
+        writeFishFlag(builder, flag)
+    }
+
+    for _, command := range application.Commands {
+        for _, flag := range command.Flags {
+            fmt.Fprintf(builder, "complete -c %s -n %s", program, fishQuote("__fish_seen_subcommand_from "+command.Name))
+            writeFishFlag(builder, flag)
+        }
+    }
+
+    return builder.String()
+}
+
+func writeFishFlag(builder *strings.Builder, flag Flag) {
+    for _, name := range append([]string{flag.Name}, flag.Aliases...) {
+        if 1 == len(name) {
+            fmt.Fprintf(builder, " -s %s", fishQuote(name))
+            continue
+        }
+
+        fmt.Fprintf(builder, " -l %s", fishQuote(name))
+    }
+
+    if true == flag.TakesValue {
+        builder.WriteString(" -r")
+    }
+
+    writeFishDescription(builder, flag.Usage)
+    builder.WriteString("\n")
+}
+
+func writeFishDescription(builder *strings.Builder, description string) {
+    description = firstLine(description)
+    if "" == description {
+        return
+    }
+
+    fmt.Fprintf(builder, " -d %s", fishQuote(description))
+}
+
+/* fishQuote quotes value for fish, where only "\" and "'" are escaped inside single quotes. */
+func fishQuote(value string) string {
+    return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
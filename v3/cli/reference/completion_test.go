@@ -0,0 +1,110 @@
+package reference
+
+import (
+    "os/exec"
+    "strings"
+    "testing"
+)
+
+func TestRenderCompletion_ListsCommandsAndFlags(t *testing.T) {
+    cases := map[string][]string{
+        ShellBash: {
+            "_my_app_completion() {",
+            "candidates='--no-interaction'",
+            "candidates='app:import cache:clear:all debug:router serve'",
+            "'debug:router')\n            candidates+=' --format --verbose -V'",
+            "complete -o default -F _my_app_completion my-app",
+        },
+        ShellZsh: {
+            `'debug\:router:List all registered HTTP routes'`,
+            `'--format:output format: table|json'`,
+            `'-V:include advanced details'`,
+            "compdef _my_app_completion my-app",
+        },
+        ShellFish: {
+            "complete -c my-app -n '__fish_use_subcommand' -f -a 'debug:router' -d 'List all registered HTTP routes'",
+            "complete -c my-app -l 'no-interaction' -d 'never prompt'",
+            "complete -c my-app -n '__fish_seen_subcommand_from debug:router' -l 'format' -r -d 'output format: table|json'",
+            "-l 'verbose' -s 'V' -d",
+        },
+    }
+
+    application := FromCommandContext(newTestRoot())
+
+    for shell, expectedFragments := range cases {
+        t.Run(shell, func(t *testing.T) {
+            builder := &strings.Builder{}
+            if renderErr := RenderCompletion(builder, shell, application, "my-app"); nil != renderErr {
+                t.Fatalf("unexpected error: %v", renderErr)
+            }
+
+            for _, expectedFragment := range expectedFragments {
+                if false == strings.Contains(builder.String(), expectedFragment) {
+                    t.Fatalf("expected %q in:\n%s", expectedFragment, builder.String())
+                }
+            }
+
+            if true == strings.Contains(builder.String(), "internal:task") || true == strings.Contains(builder.String(), "secret") {
+                t.Fatalf("expected hidden commands and flags to be left out:\n%s", builder.String())
+            }
+        })
+    }
+}
+
+func TestRenderCompletion_Errors(t *testing.T) {
+    cases := map[string]struct {
+        shell   string
+        program string
+    }{
+        "unsupported shell": {shell: "powershell", program: "app"},
+        "invalid program":   {shell: ShellBash, program: "app; rm -rf /"},
+    }
+
+    for name, testCase := range cases {
+        t.Run(name, func(t *testing.T) {
+            renderErr := RenderCompletion(&strings.Builder{}, testCase.shell, FromCommandContext(newTestRoot()), testCase.program)
+            if nil == renderErr {
+                t.Fatalf("expected an error")
+            }
+        })
+    }
+}
+
+func TestRenderCompletion_BashCompletesNamespacedCommands(t *testing.T) {
+    bashPath, lookErr := exec.LookPath("bash")
+    if nil != lookErr {
+        t.Skip("bash is not available")
+    }
+
+    builder := &strings.Builder{}
+    if renderErr := RenderCompletion(builder, ShellBash, FromCommandContext(newTestRoot()), "app"); nil != renderErr {
+        t.Fatalf("unexpected error: %v", renderErr)
+    }
+
+    cases := map[string]string{
+        "app ":                 "app:import cache:clear:all debug:router serve",
+        "app debug:r":          "router",
+        "app cache:clear:":     "all",
+        "app debug:router --f": "--format",
+        "app --no":             "--no-interaction",
+    }
+
+    for line, expected := range cases {
+        t.Run(line, func(t *testing.T) {
+            script := builder.String() + `
+COMP_LINE="$1"
+COMP_POINT=${#1}
+_app_completion
+echo -n "${COMPREPLY[*]}"
+`
+            completed, runErr := exec.Command(bashPath, "-c", script, "bash", line).Output()
+            if nil != runErr {
+                t.Fatalf("unexpected error: %v", runErr)
+            }
+
+            if expected != string(completed) {
+                t.Fatalf("expected %q, got %q", expected, string(completed))
+            }
+        })
+    }
+}
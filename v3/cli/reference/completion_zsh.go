@@ -0,0 +1,79 @@
+package reference
+
+import (
+    "fmt"
+    "strings"
+)
+
+func renderZshCompletion(application *Application, program string) string {
+    functionName := completionFunctionName(program)
+    builder := &strings.Builder{}
+
+    fmt.Fprintf(builder, "# zsh completion for %s, generated by melody:completion\n", program)
+    fmt.Fprintf(builder, "# load it after compinit with: source <(%s melody:completion zsh)\n\n", program)
+
+    fmt.Fprintf(builder, "%s() {\n", functionName)
+    builder.WriteString(`    local -a commands flags
+    local command="" word
+    for word in "${(@)words[2,CURRENT-1]}"; do
+        if [[ "${word}" != -* ]]; then
+            command="${word}"
+            break
+        fi
+    done
+
+    if [[ -z "${command}" && "${PREFIX}" != -* ]]; then
+        commands=(
+`)
+    for _, command := range application.Commands {
+        fmt.Fprintf(builder, "            %s\n", singleQuote(zshDescribeEntry(command.Name, command.Description)))
+    }
+    builder.WriteString(`        )
+        _describe -t commands 'command' commands
+        return
+    fi
+
+    flags=(
+`)
+    writeZshFlags(builder, application.GlobalFlags, "        ")
+    builder.WriteString("    )\n    case \"${command}\" in\n")
+
+    for _, command := range application.Commands {
+        if 0 == len(command.Flags) {
+            continue
+        }
+
+        fmt.Fprintf(builder, "        %s)\n            flags+=(\n", singleQuote(command.Name))
+        writeZshFlags(builder, command.Flags, "                ")
+        builder.WriteString("            )\n            ;;\n")
+    }
+
+    builder.WriteString(`    esac
+    _describe -t flags 'flag' flags
+}
+
+`)
+    fmt.Fprintf(builder, "compdef %s %s\n", functionName, program)
+
+    return builder.String()
+}
+
+func writeZshFlags(builder *strings.Builder, flags []Flag, indentation string) {
+    for _, flag := range flags {
+        for _, spelling := range flag.Spellings() {
+            fmt.Fprintf(builder, "%s%s\n", indentation, singleQuote(zshDescribeEntry(spelling, flag.Usage)))
+        }
+    }
+}
+
+/* zshDescribeEntry builds a "value:description" entry for _describe, where a ":" in the value must be escaped. */
+func zshDescribeEntry(value string, description string) string {
+    entry := strings.ReplaceAll(value, ":", `\:`)
+
+    description = firstLine(description)
+    if "" == description {
+        return entry
+    }
+
+    return entry + ":" + description
+}
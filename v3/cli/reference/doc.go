@@ -0,0 +1,4 @@
+/*
+Package reference describes the commands registered on the root cli command, with their flags and namespaces, and renders bash, zsh and fish completion scripts and Markdown or man page documentation from that description.
+*/
+package reference
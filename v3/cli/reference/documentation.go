@@ -0,0 +1,238 @@
+package reference
+
+import (
+    "fmt"
+    "io"
+    "strings"
+
+    "github.com/precision-soft/melody/v3/exception"
+)
+
+const (
+    DocumentationFormatMarkdown = "markdown"
+    DocumentationFormatMan      = "man"
+
+    defaultNamespaceTitle = "general"
+)
+
+func DocumentationFormats() []string {
+    return []string{DocumentationFormatMarkdown, DocumentationFormatMan}
+}
+
+/* RenderDocumentation writes the reference of every command of application, grouped by namespace, as one Markdown document or one man page (section 1). */
+func RenderDocumentation(writer io.Writer, format string, application *Application) error {
+    if nil == application {
+        return exception.NewError("application reference may not be nil", nil, nil)
+    }
+
+    var document string
+    switch format {
+    case DocumentationFormatMarkdown:
+        document = renderMarkdown(application)
+    case DocumentationFormatMan:
+        document = renderMan(application)
+    default:
+        return exception.NewError(
+            "unsupported documentation format",
+            map[string]any{"format": format, "supported": DocumentationFormats()},
+            nil,
+        )
+    }
+
+    _, writeErr := io.WriteString(writer, document)
+
+    return writeErr
+}
+
+func namespaceTitle(namespace string) string {
+    if "" == namespace {
+        return defaultNamespaceTitle
+    }
+
+    return namespace
+}
+
+func renderMarkdown(application *Application) string {
+    builder := &strings.Builder{}
+
+    fmt.Fprintf(builder, "# %s\n\n", application.Name)
+    if description := strings.TrimSpace(application.Description); "" != description {
+        fmt.Fprintf(builder, "%s\n\n", description)
+    }
+
+    fmt.Fprintf(builder, "## Usage\n\n```\n%s [global flags] <command> [flags] [arguments]\n```\n\n", application.Name)
+
+    if 0 < len(application.GlobalFlags) {
+        builder.WriteString("## Global flags\n\n")
+        writeMarkdownFlags(builder, application.GlobalFlags)
+    }
+
+    builder.WriteString("## Commands\n\n")
+
+    namespaces := application.Namespaces()
+    for _, namespace := range namespaces {
+        fmt.Fprintf(builder, "- %s:", namespaceTitle(namespace.Name))
+        for index, command := range namespace.Commands {
+            separator := ","
+            if 0 == index {
+                separator = ""
+            }
+
+            fmt.Fprintf(builder, "%s [`%s`](#%s)", separator, command.Name, markdownAnchor(command.Name))
+        }
+        builder.WriteString("\n")
+    }
+    builder.WriteString("\n")
+
+    for _, namespace := range namespaces {
+        fmt.Fprintf(builder, "### %s\n\n", namespaceTitle(namespace.Name))
+
+        for _, command := range namespace.Commands {
+            fmt.Fprintf(builder, "#### %s\n\n", command.Name)
+
+            if description := strings.TrimSpace(command.Description); "" != description {
+                fmt.Fprintf(builder, "%s\n\n", description)
+            }
+
+            fmt.Fprintf(builder, "```\n%s %s [flags] [arguments]\n```\n\n", application.Name, command.Name)
+
+            if 0 < len(command.Flags) {
+                writeMarkdownFlags(builder, command.Flags)
+            }
+        }
+    }
+
+    return builder.String()
+}
+
+func writeMarkdownFlags(builder *strings.Builder, flags []Flag) {
+    builder.WriteString("| Flag | Default | Description |\n|------|---------|-------------|\n")
+
+    for _, flag := range flags {
+        spellings := flag.Spellings()
+        if true == flag.TakesValue {
+            spellings[0] += " <value>"
+        }
+
+        defaultText := "-"
+        if "" != flag.DefaultText {
+            defaultText = "`" + flag.DefaultText + "`"
+        }
+
+        usage := markdownCell(flag.Usage)
+        if true == flag.Required {
+            usage = strings.TrimSpace("**required** " + usage)
+        }
+
+        fmt.Fprintf(builder, "| `%s` | %s | %s |\n", strings.Join(spellings, "`, `"), defaultText, usage)
+    }
+
+    builder.WriteString("\n")
+}
+
+/* markdownAnchor follows the heading anchors GitHub generates: lowercase, with punctuation other than "-" and "_" dropped. */
+func markdownAnchor(heading string) string {
+    return strings.Map(
+        func(character rune) rune {
+            switch {
+            case 'A' <= character && 'Z' >= character:
+                return character + ('a' - 'A')
+            case ('a' <= character && 'z' >= character) || ('0' <= character && '9' >= character) || '-' == character || '_' == character:
+                return character
+            case ' ' == character:
+                return '-'
+            default:
+                return -1
+            }
+        },
+        heading,
+    )
+}
+
+func markdownCell(value string) string {
+    return strings.ReplaceAll(strings.Join(strings.Fields(value), " "), "|", `\|`)
+}
+
+func renderMan(application *Application) string {
+    builder := &strings.Builder{}
+    name := roffEscape(application.Name)
+
+    fmt.Fprintf(builder, ".TH \"%s\" \"1\" \"\" \"%s\" \"%s manual\"\n", strings.ToUpper(name), name, name)
+
+    builder.WriteString(".SH NAME\n")
+    if description := firstLine(application.Description); "" != description {
+        fmt.Fprintf(builder, "%s \\- %s\n", name, roffEscape(description))
+    } else {
+        fmt.Fprintf(builder, "%s\n", name)
+    }
+
+    fmt.Fprintf(builder, ".SH SYNOPSIS\n.B %s\n[global flags] \\fIcommand\\fR [flags] [arguments]\n", name)
+
+    if 0 < len(application.GlobalFlags) {
+        builder.WriteString(".SH \"GLOBAL FLAGS\"\n")
+        writeManFlags(builder, application.GlobalFlags)
+    }
+
+    for _, namespace := range application.Namespaces() {
+        fmt.Fprintf(builder, ".SH \"%s COMMANDS\"\n", strings.ToUpper(roffEscape(namespaceTitle(namespace.Name))))
+
+        for _, command := range namespace.Commands {
+            fmt.Fprintf(builder, ".SS \"%s\"\n", roffEscape(command.Name))
+
+            if description := strings.TrimSpace(command.Description); "" != description {
+                fmt.Fprintf(builder, "%s\n", roffText(description))
+            }
+
+            writeManFlags(builder, command.Flags)
+        }
+    }
+
+    return builder.String()
+}
+
+func writeManFlags(builder *strings.Builder, flags []Flag) {
+    for _, flag := range flags {
+        spellings := flag.Spellings()
+        for index := range spellings {
+            spellings[index] = roffEscape(spellings[index])
+        }
+
+        builder.WriteString(".TP\n")
+        fmt.Fprintf(builder, "\\fB%s\\fR", strings.Join(spellings, "\\fR, \\fB"))
+        if true == flag.TakesValue {
+            builder.WriteString(" \\fIvalue\\fR")
+        }
+        builder.WriteString("\n")
+
+        usage := strings.Join(strings.Fields(flag.Usage), " ")
+        if true == flag.Required {
+            usage = strings.TrimSpace("(required) " + usage)
+        }
+        if "" != flag.DefaultText {
+            usage = strings.TrimSpace(usage + " (default: " + flag.DefaultText + ")")
+        }
+        if "" != usage {
+            fmt.Fprintf(builder, "%s\n", roffText(usage))
+        }
+    }
+}
+
+/* roffEscape escapes the backslashes and hyphens of value so roff prints them as typed. */
+func roffEscape(value string) string {
+    return strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(value)
+}
+
+/* roffText escapes value and keeps lines starting with "." or "'" from being read as requests. */
+func roffText(value string) string {
+    lines := strings.Split(roffEscape(value), "\n")
+    for index, line := range lines {
+        line = strings.TrimSpace(line)
+        if true == strings.HasPrefix(line, ".") || true == strings.HasPrefix(line, "'") {
+            line = `\&` + line
+        }
+
+        lines[index] = line
+    }
+
+    return strings.Join(lines, "\n")
+}
@@ -0,0 +1,56 @@
+package reference
+
+import (
+    "strings"
+    "testing"
+)
+
+func TestRenderDocumentation(t *testing.T) {
+    cases := map[string][]string{
+        DocumentationFormatMarkdown: {
+            "# app\n\ntest application\n",
+            "- debug: [`debug:router`](#debugrouter)\n",
+            "### general\n\n#### serve\n",
+            "### debug\n\n#### debug:router\n\nList all registered HTTP routes\n",
+            "| `--format <value>` | `\"table\"` | output format: table\\|json |\n",
+            "| `--verbose`, `-V` | - | include advanced details |\n",
+            "| `--file <value>` | - | **required** catalog file |\n",
+        },
+        DocumentationFormatMan: {
+            `.TH "APP" "1" "" "app" "app manual"`,
+            "app \\- test application\n",
+            ".SH \"GLOBAL FLAGS\"\n.TP\n\\fB\\-\\-no\\-interaction\\fR\nnever prompt\n",
+            ".SH \"GENERAL COMMANDS\"\n.SS \"serve\"\n",
+            ".SS \"cache:clear:all\"\n",
+            "\\fB\\-\\-format\\fR \\fIvalue\\fR\noutput format: table|json (default: \"table\")\n",
+        },
+    }
+
+    application := FromCommandContext(newTestRoot())
+
+    for format, expectedFragments := range cases {
+        t.Run(format, func(t *testing.T) {
+            builder := &strings.Builder{}
+            if renderErr := RenderDocumentation(builder, format, application); nil != renderErr {
+                t.Fatalf("unexpected error: %v", renderErr)
+            }
+
+            for _, expectedFragment := range expectedFragments {
+                if false == strings.Contains(builder.String(), expectedFragment) {
+                    t.Fatalf("expected %q in:\n%s", expectedFragment, builder.String())
+                }
+            }
+        })
+    }
+
+    if renderErr := RenderDocumentation(&strings.Builder{}, "html", application); nil == renderErr {
+        t.Fatalf("expected an error for an unsupported format")
+    }
+}
+
+func TestRoffText_EscapesRequests(t *testing.T) {
+    expected := "first\n\\&.SH not a request\n\\&'quoted\nback\\eslash \\- dash"
+    if actual := roffText("first\n.SH not a request\n'quoted\nback\\slash - dash"); expected != actual {
+        t.Fatalf("expected %q, got %q", expected, actual)
+    }
+}
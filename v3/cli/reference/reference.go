@@ -0,0 +1,191 @@
+package reference
+
+import (
+    "sort"
+    "strings"
+
+    clicontract "github.com/precision-soft/melody/v3/cli/contract"
+    "github.com/precision-soft/melody/v3/exception"
+)
+
+const (
+    helpName = "help"
+
+    namespaceSeparator = ":"
+)
+
+/* Application describes a root cli command: its global flags and every visible command, sorted by name. */
+type Application struct {
+    Name        string
+    Description string
+    GlobalFlags []Flag
+    Commands    []Command
+}
+
+type Command struct {
+    Name        string
+    Description string
+    Namespace   string
+    Flags       []Flag
+}
+
+/* Flag describes a flag; DefaultText is the explicit default text, or the default value of a flag taking a value. */
+type Flag struct {
+    Name        string
+    Aliases     []string
+    Usage       string
+    TakesValue  bool
+    DefaultText string
+    Required    bool
+}
+
+/* Namespace groups the commands sharing the name prefix before the first ":"; commands without one are in the "" namespace. */
+type Namespace struct {
+    Name     string
+    Commands []Command
+}
+
+/* FromCommandContext describes root; the help command and hidden commands and flags are left out, and the help flag is only listed among the global flags. */
+func FromCommandContext(root *clicontract.CommandContext) *Application {
+    if nil == root {
+        exception.Panic(exception.NewError("root cli command may not be nil", nil, nil))
+    }
+
+    application := &Application{
+        Name:        root.Name,
+        Description: root.Usage,
+        GlobalFlags: describeFlags(root.VisibleFlags(), true),
+        Commands:    make([]Command, 0, len(root.Commands)),
+    }
+
+    for _, command := range root.VisibleCommands() {
+        if helpName == command.Name {
+            continue
+        }
+
+        application.Commands = append(
+            application.Commands,
+            Command{
+                Name:        command.Name,
+                Description: command.Usage,
+                Namespace:   namespaceOf(command.Name),
+                Flags:       describeFlags(command.VisibleFlags(), false),
+            },
+        )
+    }
+
+    sort.Slice(application.Commands, func(leftIndex int, rightIndex int) bool {
+        return application.Commands[leftIndex].Name < application.Commands[rightIndex].Name
+    })
+
+    return application
+}
+
+func (instance *Application) CommandNames() []string {
+    names := make([]string, 0, len(instance.Commands))
+    for _, command := range instance.Commands {
+        names = append(names, command.Name)
+    }
+
+    return names
+}
+
+/* Namespaces returns the commands grouped by namespace, the "" namespace first and the others sorted by name. */
+func (instance *Application) Namespaces() []Namespace {
+    namespaces := make([]Namespace, 0)
+    indexByName := map[string]int{}
+
+    for _, command := range instance.Commands {
+        index, exists := indexByName[command.Namespace]
+        if false == exists {
+            index = len(namespaces)
+            indexByName[command.Namespace] = index
+            namespaces = append(namespaces, Namespace{Name: command.Namespace})
+        }
+
+        namespaces[index].Commands = append(namespaces[index].Commands, command)
+    }
+
+    sort.SliceStable(namespaces, func(leftIndex int, rightIndex int) bool {
+        return namespaces[leftIndex].Name < namespaces[rightIndex].Name
+    })
+
+    return namespaces
+}
+
+/* Spellings returns every way to write the flag on the command line: "--name" for long names and "-n" for single letter ones. */
+func (instance Flag) Spellings() []string {
+    spellings := make([]string, 0, 1+len(instance.Aliases))
+    for _, name := range append([]string{instance.Name}, instance.Aliases...) {
+        spellings = append(spellings, flagSpelling(name))
+    }
+
+    return spellings
+}
+
+func describeFlags(flags []clicontract.Flag, includeHelp bool) []Flag {
+    described := make([]Flag, 0, len(flags))
+
+    for _, flag := range flags {
+        names := flag.Names()
+        if 0 == len(names) {
+            continue
+        }
+
+        if helpName == names[0] && false == includeHelp {
+            continue
+        }
+
+        describedFlag := Flag{
+            Name:    names[0],
+            Aliases: append([]string{}, names[1:]...),
+        }
+
+        if documentedFlag, isDocumented := flag.(interface {
+            TakesValue() bool
+            GetUsage() string
+            GetValue() string
+            GetDefaultText() string
+            IsDefaultVisible() bool
+        }); true == isDocumented {
+            describedFlag.TakesValue = documentedFlag.TakesValue()
+            describedFlag.Usage = documentedFlag.GetUsage()
+
+            if true == documentedFlag.IsDefaultVisible() {
+                describedFlag.DefaultText = documentedFlag.GetDefaultText()
+                if "" == describedFlag.DefaultText && true == describedFlag.TakesValue {
+                    describedFlag.DefaultText = documentedFlag.GetValue()
+                }
+            }
+        }
+
+        if requiredFlag, isRequired := flag.(interface{ IsRequired() bool }); true == isRequired {
+            describedFlag.Required = requiredFlag.IsRequired()
+        }
+
+        described = append(described, describedFlag)
+    }
+
+    sort.Slice(described, func(leftIndex int, rightIndex int) bool {
+        return described[leftIndex].Name < described[rightIndex].Name
+    })
+
+    return described
+}
+
+func namespaceOf(commandName string) string {
+    namespace, _, found := strings.Cut(commandName, namespaceSeparator)
+    if false == found {
+        return ""
+    }
+
+    return namespace
+}
+
+func flagSpelling(name string) string {
+    if 1 == len(name) {
+        return "-" + name
+    }
+
+    return "--" + name
+}
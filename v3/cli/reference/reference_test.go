@@ -0,0 +1,101 @@
+package reference
+
+import (
+    "reflect"
+    "testing"
+
+    clicontract "github.com/precision-soft/melody/v3/cli/contract"
+)
+
+func newTestRoot() *clicontract.CommandContext {
+    return &clicontract.CommandContext{
+        Name:  "app",
+        Usage: "test application",
+        Flags: []clicontract.Flag{
+            &clicontract.BoolFlag{Name: "no-interaction", Usage: "never prompt"},
+        },
+        Commands: []*clicontract.CommandContext{
+            {
+                Name:  "debug:router",
+                Usage: "List all registered HTTP routes",
+                Flags: []clicontract.Flag{
+                    &clicontract.StringFlag{Name: "format", Value: "table", Usage: "output format: table|json"},
+                    &clicontract.BoolFlag{Name: "verbose", Aliases: []string{"V"}, Usage: "include advanced details"},
+                    &clicontract.StringFlag{Name: "secret", Hidden: true},
+                },
+            },
+            {
+                Name:  "app:import",
+                Usage: "import the catalog",
+                Flags: []clicontract.Flag{
+                    &clicontract.StringFlag{Name: "file", Required: true, Usage: "catalog file"},
+                },
+            },
+            {Name: "cache:clear:all", Usage: "clear every cache"},
+            {Name: "serve", Usage: "serve http"},
+            {Name: "internal:task", Hidden: true},
+            {Name: "help", Usage: "show help"},
+        },
+    }
+}
+
+func TestFromCommandContext_DescribesVisibleCommandsAndFlags(t *testing.T) {
+    application := FromCommandContext(newTestRoot())
+
+    expectedNames := []string{"app:import", "cache:clear:all", "debug:router", "serve"}
+    if false == reflect.DeepEqual(expectedNames, application.CommandNames()) {
+        t.Fatalf("expected commands %v, got %v", expectedNames, application.CommandNames())
+    }
+
+    router := application.Commands[2]
+    expectedFlags := []Flag{
+        {Name: "format", Aliases: []string{}, Usage: "output format: table|json", TakesValue: true, DefaultText: `"table"`},
+        {Name: "verbose", Aliases: []string{"V"}, Usage: "include advanced details"},
+    }
+    if false == reflect.DeepEqual(expectedFlags, router.Flags) {
+        t.Fatalf("expected flags %#v, got %#v", expectedFlags, router.Flags)
+    }
+
+    if false == application.Commands[0].Flags[0].Required {
+        t.Fatalf("expected the required flag to be described as required")
+    }
+
+    if 1 != len(application.GlobalFlags) || "no-interaction" != application.GlobalFlags[0].Name {
+        t.Fatalf("unexpected global flags: %#v", application.GlobalFlags)
+    }
+}
+
+func TestApplication_NamespacesGroupByFirstSegment(t *testing.T) {
+    namespaces := FromCommandContext(newTestRoot()).Namespaces()
+
+    expected := map[string][]string{
+        "":      {"serve"},
+        "app":   {"app:import"},
+        "cache": {"cache:clear:all"},
+        "debug": {"debug:router"},
+    }
+
+    if len(expected) != len(namespaces) || "" != namespaces[0].Name {
+        t.Fatalf("unexpected namespaces: %#v", namespaces)
+    }
+
+    for _, namespace := range namespaces {
+        names := make([]string, 0, len(namespace.Commands))
+        for _, command := range namespace.Commands {
+            names = append(names, command.Name)
+        }
+
+        if false == reflect.DeepEqual(expected[namespace.Name], names) {
+            t.Fatalf("namespace %q: expected %v, got %v", namespace.Name, expected[namespace.Name], names)
+        }
+    }
+}
+
+func TestFlag_Spellings(t *testing.T) {
+    flag := Flag{Name: "verbose", Aliases: []string{"V", "loud"}}
+
+    expected := []string{"--verbose", "-V", "--loud"}
+    if false == reflect.DeepEqual(expected, flag.Spellings()) {
+        t.Fatalf("expected %v, got %v", expected, flag.Spellings())
+    }
+}
@@ -0,0 +1,38 @@
+package worker
+
+import (
+    "strconv"
+    "strings"
+
+    "github.com/precision-soft/melody/v3/exception"
+)
+
+/* ParseByteSize parses "512", "64K", "256M", "1G" (optionally "KB", "MiB", ...) as binary multiples. */
+func ParseByteSize(value string) (uint64, error) {
+    normalized := strings.ToUpper(strings.TrimSpace(value))
+    normalized = strings.TrimSuffix(normalized, "IB")
+    normalized = strings.TrimSuffix(normalized, "B")
+
+    multiplier := uint64(1)
+    if "" != normalized {
+        switch normalized[len(normalized)-1] {
+        case 'K':
+            multiplier = 1 << 10
+        case 'M':
+            multiplier = 1 << 20
+        case 'G':
+            multiplier = 1 << 30
+        }
+
+        if 1 != multiplier {
+            normalized = normalized[:len(normalized)-1]
+        }
+    }
+
+    number, parseErr := strconv.ParseUint(strings.TrimSpace(normalized), 10, 64)
+    if nil != parseErr {
+        return 0, exception.NewError("invalid byte size", map[string]any{"value": value}, parseErr)
+    }
+
+    return number * multiplier, nil
+}
@@ -0,0 +1,312 @@
+package worker
+
+import (
+    "context"
+    "errors"
+    "os"
+    "os/signal"
+    goruntime "runtime"
+    "strings"
+    "time"
+
+    clicontract "github.com/precision-soft/melody/v3/cli/contract"
+    "github.com/precision-soft/melody/v3/exception"
+    "github.com/precision-soft/melody/v3/lock"
+    lockcontract "github.com/precision-soft/melody/v3/lock/contract"
+    "github.com/precision-soft/melody/v3/logging"
+    "github.com/precision-soft/melody/v3/runtime"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+const (
+    FlagNameTimeLimit   = "time-limit"
+    FlagNameMemoryLimit = "memory-limit"
+    FlagNameUnitLimit   = "unit-limit"
+)
+
+/* NewCommand wraps command; config (NewConfig() when nil) holds the defaults the --time-limit, --memory-limit and --unit-limit flags override. */
+func NewCommand(command clicontract.Command, config *Config) *Command {
+    if nil == command {
+        exception.Panic(exception.NewError("worker command may not be nil", nil, nil))
+    }
+
+    if nil == config {
+        config = NewConfig()
+    }
+
+    return &Command{
+        command: command,
+        config:  config,
+    }
+}
+
+type Command struct {
+    command clicontract.Command
+    config  *Config
+}
+
+func (instance *Command) Name() string {
+    return instance.command.Name()
+}
+
+func (instance *Command) Description() string {
+    return instance.command.Description()
+}
+
+func (instance *Command) Flags() []clicontract.Flag {
+    flags := instance.command.Flags()
+
+    existing := make(map[string]bool, len(flags))
+    for _, flag := range flags {
+        for _, name := range flag.Names() {
+            existing[name] = true
+        }
+    }
+
+    supervisionFlags := []clicontract.Flag{
+        &clicontract.StringFlag{
+            Name:  FlagNameTimeLimit,
+            Usage: "stop cleanly after this duration (e.g. 1h); 0 means no limit",
+        },
+        &clicontract.StringFlag{
+            Name:  FlagNameMemoryLimit,
+            Usage: "stop cleanly once the heap exceeds this size (e.g. 256M); 0 means no limit",
+        },
+        &clicontract.IntFlag{
+            Name:  FlagNameUnitLimit,
+            Usage: "stop cleanly after this many units of work (e.g. messages); 0 means no limit",
+        },
+    }
+
+    merged := make([]clicontract.Flag, 0, len(flags)+len(supervisionFlags))
+    merged = append(merged, flags...)
+    for _, flag := range supervisionFlags {
+        if true == existing[flag.Names()[0]] {
+            continue
+        }
+
+        merged = append(merged, flag)
+    }
+
+    return merged
+}
+
+func (instance *Command) Run(
+    runtimeInstance runtimecontract.Runtime,
+    commandContext *clicontract.CommandContext,
+) error {
+    timeLimit, memoryLimit, unitLimit, limitsErr := instance.resolveLimits(commandContext)
+    if nil != limitsErr {
+        return limitsErr
+    }
+
+    ctx, cancel := context.WithCancelCause(runtimeInstance.Context())
+    defer cancel(nil)
+
+    if true == instance.config.Lock() {
+        lockInstance, acquired, acquireErr := instance.acquireLock(runtimeInstance)
+        if nil != acquireErr {
+            return acquireErr
+        }
+
+        if false == acquired {
+            if true == instance.config.SkipWhenLocked() {
+                instance.log(runtimeInstance, "command is already running; skipped", map[string]any{"command": instance.Name()})
+
+                return nil
+            }
+
+            return exception.NewError(
+                "command is already running",
+                map[string]any{"command": instance.Name(), "lock": instance.lockName()},
+                nil,
+            )
+        }
+
+        defer func() {
+            if releaseErr := lockInstance.Release(runtimeInstance); nil != releaseErr {
+                instance.log(runtimeInstance, "could not release the command lock", exception.LogContext(releaseErr))
+            }
+        }()
+
+        go instance.refreshLock(ctx, runtimeInstance, lockInstance, cancel)
+    }
+
+    if 0 < len(instance.config.StopSignals()) {
+        signalChannel := make(chan os.Signal, 1)
+        signal.Notify(signalChannel, instance.config.StopSignals()...)
+        defer signal.Stop(signalChannel)
+
+        go func() {
+            select {
+            case receivedSignal := <-signalChannel:
+                /* @important the handler is removed after the first signal; a second one only terminates the process the default way when no other handler (e.g. a signal context) is registered for it. */
+                signal.Stop(signalChannel)
+                instance.log(runtimeInstance, "stop signal received; finishing the current unit of work", map[string]any{"command": instance.Name(), "signal": receivedSignal.String()})
+                cancel(ErrStopSignal)
+            case <-ctx.Done():
+            }
+        }()
+    }
+
+    if 0 < timeLimit {
+        timer := time.AfterFunc(timeLimit, func() {
+            cancel(ErrTimeLimitReached)
+        })
+        defer timer.Stop()
+    }
+
+    if 0 < memoryLimit {
+        go watchMemory(ctx, memoryLimit, cancel)
+    }
+
+    supervisor := newSupervisor(cancel, unitLimit)
+    supervisedRuntime := runtime.New(
+        context.WithValue(ctx, supervisorContextKey{}, supervisor),
+        runtimeInstance.Scope(),
+        runtimeInstance.Container(),
+    )
+
+    runErr := instance.command.Run(supervisedRuntime, commandContext)
+
+    stopReason := StopReason(ctx)
+    if nil != stopReason {
+        instance.log(
+            runtimeInstance,
+            "worker stopped",
+            map[string]any{"command": instance.Name(), "reason": stopReason.Error(), "units": supervisor.Units()},
+        )
+
+        /* @info a command returning the context error because it was asked to stop exits cleanly, so the supervisor restarts it. */
+        if nil != runErr && (true == errors.Is(runErr, context.Canceled) || true == errors.Is(runErr, context.DeadlineExceeded)) {
+            return nil
+        }
+    }
+
+    return runErr
+}
+
+func (instance *Command) resolveLimits(commandContext *clicontract.CommandContext) (time.Duration, uint64, int64, error) {
+    timeLimit := instance.config.TimeLimit()
+    memoryLimit := instance.config.MemoryLimit()
+    unitLimit := instance.config.UnitLimit()
+
+    if nil == commandContext {
+        return timeLimit, memoryLimit, unitLimit, nil
+    }
+
+    if timeLimitString := strings.TrimSpace(commandContext.String(FlagNameTimeLimit)); "" != timeLimitString {
+        parsed, parseErr := time.ParseDuration(timeLimitString)
+        if nil != parseErr || 0 > parsed {
+            return 0, 0, 0, exception.NewError("invalid --time-limit", map[string]any{"value": timeLimitString}, parseErr)
+        }
+
+        timeLimit = parsed
+    }
+
+    if memoryLimitString := strings.TrimSpace(commandContext.String(FlagNameMemoryLimit)); "" != memoryLimitString {
+        parsed, parseErr := ParseByteSize(memoryLimitString)
+        if nil != parseErr {
+            return 0, 0, 0, exception.NewError("invalid --memory-limit", map[string]any{"value": memoryLimitString}, parseErr)
+        }
+
+        memoryLimit = parsed
+    }
+
+    if flagUnitLimit := commandContext.Int(FlagNameUnitLimit); 0 < flagUnitLimit {
+        unitLimit = int64(flagUnitLimit)
+    }
+
+    return timeLimit, memoryLimit, unitLimit, nil
+}
+
+func (instance *Command) lockName() string {
+    if "" != instance.config.LockName() {
+        return instance.config.LockName()
+    }
+
+    return "cli.command." + instance.Name()
+}
+
+func (instance *Command) acquireLock(runtimeInstance runtimecontract.Runtime) (lockcontract.Lock, bool, error) {
+    locker := instance.config.Locker()
+    if nil == locker {
+        locker = lock.LockerMustFromResolver(runtimeInstance.Container())
+    }
+
+    lockInstance := locker.CreateLock(instance.lockName(), instance.config.LockTtl())
+
+    acquired, acquireErr := lockInstance.Acquire(runtimeInstance)
+    if nil != acquireErr {
+        return nil, false, exception.NewError(
+            "could not acquire the command lock",
+            map[string]any{"command": instance.Name(), "lock": instance.lockName()},
+            acquireErr,
+        )
+    }
+
+    return lockInstance, acquired, nil
+}
+
+func (instance *Command) refreshLock(
+    ctx context.Context,
+    runtimeInstance runtimecontract.Runtime,
+    lockInstance lockcontract.Lock,
+    cancel context.CancelCauseFunc,
+) {
+    lockTtl := instance.config.LockTtl()
+    if 0 >= lockTtl {
+        return
+    }
+
+    ticker := time.NewTicker(lockTtl / 3)
+    defer ticker.Stop()
+
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+            if refreshErr := lockInstance.Refresh(runtimeInstance, lockTtl); nil != refreshErr {
+                /* @important another instance may already hold the lock, so this one stops instead of running concurrently. */
+                instance.log(runtimeInstance, "could not refresh the command lock; stopping", exception.LogContext(refreshErr))
+                cancel(ErrLockLost)
+
+                return
+            }
+        }
+    }
+}
+
+func (instance *Command) log(runtimeInstance runtimecontract.Runtime, message string, context map[string]any) {
+    logger := logging.LoggerFromRuntime(runtimeInstance)
+    if nil == logger {
+        return
+    }
+
+    logger.Info(message, context)
+}
+
+func watchMemory(ctx context.Context, memoryLimit uint64, cancel context.CancelCauseFunc) {
+    ticker := time.NewTicker(memoryCheckInterval)
+    defer ticker.Stop()
+
+    for {
+        var memoryStats goruntime.MemStats
+        goruntime.ReadMemStats(&memoryStats)
+
+        if memoryStats.HeapAlloc >= memoryLimit {
+            cancel(ErrMemoryLimitReached)
+
+            return
+        }
+
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+        }
+    }
+}
+
+var _ clicontract.Command = (*Command)(nil)
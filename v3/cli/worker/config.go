@@ -0,0 +1,96 @@
+package worker
+
+import (
+    "os"
+    "syscall"
+    "time"
+
+    lockcontract "github.com/precision-soft/melody/v3/lock/contract"
+)
+
+const (
+    DefaultLockTtl = 60 * time.Second
+
+    memoryCheckInterval = time.Second
+)
+
+func NewConfig() *Config {
+    return &Config{
+        lockTtl:     DefaultLockTtl,
+        stopSignals: []os.Signal{syscall.SIGINT, syscall.SIGTERM},
+    }
+}
+
+type Config struct {
+    lock           bool
+    locker         lockcontract.Locker
+    lockName       string
+    lockTtl        time.Duration
+    skipWhenLocked bool
+    timeLimit      time.Duration
+    memoryLimit    uint64
+    unitLimit      int64
+    stopSignals    []os.Signal
+}
+
+func (instance *Config) Lock() bool { return instance.lock }
+
+/* SetLock makes the command single-instance through the `service.lock.locker` service (see SetLocker for another locker). */
+func (instance *Config) SetLock(lock bool) {
+    instance.lock = lock
+}
+
+func (instance *Config) Locker() lockcontract.Locker { return instance.locker }
+
+/* SetLocker makes the command single-instance through locker. */
+func (instance *Config) SetLocker(locker lockcontract.Locker) {
+    instance.locker = locker
+    instance.lock = nil != locker
+}
+
+/* LockName defaults to "cli.command.<command name>". */
+func (instance *Config) LockName() string { return instance.lockName }
+
+func (instance *Config) SetLockName(lockName string) {
+    instance.lockName = lockName
+}
+
+func (instance *Config) LockTtl() time.Duration { return instance.lockTtl }
+
+/* SetLockTtl sets how long the lock outlives a crashed process; a running command refreshes it every third of the ttl. */
+func (instance *Config) SetLockTtl(lockTtl time.Duration) {
+    instance.lockTtl = lockTtl
+}
+
+func (instance *Config) SkipWhenLocked() bool { return instance.skipWhenLocked }
+
+/* SetSkipWhenLocked exits successfully instead of failing when another instance holds the lock (e.g. overlapping cron runs). */
+func (instance *Config) SetSkipWhenLocked(skipWhenLocked bool) {
+    instance.skipWhenLocked = skipWhenLocked
+}
+
+func (instance *Config) TimeLimit() time.Duration { return instance.timeLimit }
+
+func (instance *Config) SetTimeLimit(timeLimit time.Duration) {
+    instance.timeLimit = timeLimit
+}
+
+func (instance *Config) MemoryLimit() uint64 { return instance.memoryLimit }
+
+/* SetMemoryLimit stops the command once the live heap exceeds memoryLimit bytes; it is checked every second. */
+func (instance *Config) SetMemoryLimit(memoryLimit uint64) {
+    instance.memoryLimit = memoryLimit
+}
+
+func (instance *Config) UnitLimit() int64 { return instance.unitLimit }
+
+/* SetUnitLimit stops the command after unitLimit units of work reported through CompleteUnit. */
+func (instance *Config) SetUnitLimit(unitLimit int64) {
+    instance.unitLimit = unitLimit
+}
+
+func (instance *Config) StopSignals() []os.Signal { return instance.stopSignals }
+
+func (instance *Config) SetStopSignals(stopSignals ...os.Signal) {
+    instance.stopSignals = stopSignals
+}
@@ -0,0 +1,4 @@
+/*
+Package worker wraps a cli command with optional single-instance locking, time, memory and unit-of-work limits after which it exits cleanly for a supervisor restart, and stop signal handling that cancels the runtime context so the command finishes its current unit of work.
+*/
+package worker
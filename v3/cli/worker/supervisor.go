@@ -0,0 +1,110 @@
+package worker
+
+import (
+    "context"
+    "errors"
+    "sync/atomic"
+
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+/* the stop reasons are the cause of the cancelled runtime context; a command stopped by one of them exits successfully. */
+var (
+    ErrStopSignal         = errors.New("stop signal received")
+    ErrTimeLimitReached   = errors.New("time limit reached")
+    ErrMemoryLimitReached = errors.New("memory limit reached")
+    ErrUnitLimitReached   = errors.New("unit limit reached")
+    ErrLockLost           = errors.New("command lock lost")
+    /* @info the runtime context of the worker was cancelled from outside, e.g. by an application signal context */
+    ErrContextCanceled = errors.New("runtime context canceled")
+)
+
+var stopReasons = []error{ErrStopSignal, ErrTimeLimitReached, ErrMemoryLimitReached, ErrUnitLimitReached, ErrLockLost, ErrContextCanceled}
+
+type supervisorContextKey struct{}
+
+type Supervisor struct {
+    cancel    context.CancelCauseFunc
+    unitLimit int64
+    units     atomic.Int64
+}
+
+func newSupervisor(cancel context.CancelCauseFunc, unitLimit int64) *Supervisor {
+    return &Supervisor{
+        cancel:    cancel,
+        unitLimit: unitLimit,
+    }
+}
+
+func (instance *Supervisor) Units() int64 {
+    return instance.units.Load()
+}
+
+/* Stop cancels the runtime context of the supervised command with reason. */
+func (instance *Supervisor) Stop(reason error) {
+    instance.cancel(reason)
+}
+
+func (instance *Supervisor) completeUnit() {
+    units := instance.units.Add(1)
+    if 0 < instance.unitLimit && units >= instance.unitLimit {
+        instance.cancel(ErrUnitLimitReached)
+    }
+}
+
+func FromContext(ctx context.Context) (*Supervisor, bool) {
+    if nil == ctx {
+        return nil, false
+    }
+
+    supervisor, exists := ctx.Value(supervisorContextKey{}).(*Supervisor)
+
+    return supervisor, exists
+}
+
+/* CompleteUnit reports a finished unit of work (a message, a job) to the supervisor; it is a no-op for a command that is not supervised. */
+func CompleteUnit(runtimeInstance runtimecontract.Runtime) {
+    if nil == runtimeInstance {
+        return
+    }
+
+    supervisor, exists := FromContext(runtimeInstance.Context())
+    if false == exists {
+        return
+    }
+
+    supervisor.completeUnit()
+}
+
+/* StopReason returns why the supervised command was asked to stop, or nil while it may keep running. */
+func StopReason(ctx context.Context) error {
+    if nil == ctx {
+        return nil
+    }
+
+    cause := context.Cause(ctx)
+    if true == IsStopReason(cause) {
+        return cause
+    }
+
+    /* @important a signal handler registered elsewhere (application.NewSignalContext, the message bus consumer) may cancel the parent first */
+    if nil != ctx.Err() {
+        return ErrContextCanceled
+    }
+
+    return nil
+}
+
+func IsStopReason(err error) bool {
+    if nil == err {
+        return false
+    }
+
+    for _, stopReason := range stopReasons {
+        if true == errors.Is(err, stopReason) {
+            return true
+        }
+    }
+
+    return false
+}
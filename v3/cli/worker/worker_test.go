@@ -0,0 +1,336 @@
+package worker
+
+import (
+    "context"
+    "errors"
+    "syscall"
+    "testing"
+    "time"
+
+    clicontract "github.com/precision-soft/melody/v3/cli/contract"
+    "github.com/precision-soft/melody/v3/clock"
+    "github.com/precision-soft/melody/v3/container"
+    "github.com/precision-soft/melody/v3/lock"
+    "github.com/precision-soft/melody/v3/logging"
+    "github.com/precision-soft/melody/v3/runtime"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+func newTestRuntime() runtimecontract.Runtime {
+    serviceContainer := container.NewContainer()
+    scope := serviceContainer.NewScope()
+    scope.MustOverrideProtectedInstance(logging.ServiceLogger, logging.NewNopLogger())
+
+    return runtime.New(context.Background(), scope, serviceContainer)
+}
+
+type testCommand struct {
+    flags       []clicontract.Flag
+    runCallback func(runtimeInstance runtimecontract.Runtime) error
+}
+
+func (instance *testCommand) Name() string {
+    return "test:worker"
+}
+
+func (instance *testCommand) Description() string {
+    return "test worker"
+}
+
+func (instance *testCommand) Flags() []clicontract.Flag {
+    return instance.flags
+}
+
+func (instance *testCommand) Run(runtimeInstance runtimecontract.Runtime, commandContext *clicontract.CommandContext) error {
+    return instance.runCallback(runtimeInstance)
+}
+
+/* loopUntilStopped processes a unit every millisecond until the runtime context is cancelled. */
+func loopUntilStopped(runtimeInstance runtimecontract.Runtime) error {
+    for {
+        select {
+        case <-runtimeInstance.Context().Done():
+            return runtimeInstance.Context().Err()
+        case <-time.After(time.Millisecond):
+            CompleteUnit(runtimeInstance)
+        }
+    }
+}
+
+func TestCommand_StopsOnLimits(t *testing.T) {
+    cases := map[string]struct {
+        configure      func(config *Config)
+        expectedReason error
+    }{
+        "unit limit": {
+            configure:      func(config *Config) { config.SetUnitLimit(3) },
+            expectedReason: ErrUnitLimitReached,
+        },
+        "time limit": {
+            configure:      func(config *Config) { config.SetTimeLimit(20 * time.Millisecond) },
+            expectedReason: ErrTimeLimitReached,
+        },
+        "memory limit": {
+            configure:      func(config *Config) { config.SetMemoryLimit(1) },
+            expectedReason: ErrMemoryLimitReached,
+        },
+    }
+
+    for name, testCase := range cases {
+        t.Run(name, func(t *testing.T) {
+            config := NewConfig()
+            testCase.configure(config)
+
+            var reason error
+            var units int64
+            command := NewCommand(
+                &testCommand{
+                    runCallback: func(runtimeInstance runtimecontract.Runtime) error {
+                        runErr := loopUntilStopped(runtimeInstance)
+                        reason = StopReason(runtimeInstance.Context())
+                        supervisor, _ := FromContext(runtimeInstance.Context())
+                        units = supervisor.Units()
+
+                        return runErr
+                    },
+                },
+                config,
+            )
+
+            if runErr := command.Run(newTestRuntime(), nil); nil != runErr {
+                t.Fatalf("expected a clean exit, got %v", runErr)
+            }
+
+            if false == errors.Is(reason, testCase.expectedReason) {
+                t.Fatalf("expected stop reason %v, got %v", testCase.expectedReason, reason)
+            }
+
+            if ErrUnitLimitReached == testCase.expectedReason && 3 != units {
+                t.Fatalf("expected 3 units, got %d", units)
+            }
+        })
+    }
+}
+
+func TestCommand_StopSignalLetsTheCurrentUnitFinish(t *testing.T) {
+    config := NewConfig()
+    config.SetStopSignals(syscall.SIGUSR1)
+
+    started := make(chan struct{})
+    finishedUnit := false
+    command := NewCommand(
+        &testCommand{
+            runCallback: func(runtimeInstance runtimecontract.Runtime) error {
+                close(started)
+                <-runtimeInstance.Context().Done()
+
+                /* @info the unit in progress still completes after the signal. */
+                finishedUnit = true
+
+                return nil
+            },
+        },
+        config,
+    )
+
+    go func() {
+        <-started
+        _ = syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+    }()
+
+    if runErr := command.Run(newTestRuntime(), nil); nil != runErr {
+        t.Fatalf("expected a clean exit, got %v", runErr)
+    }
+
+    if false == finishedUnit {
+        t.Fatalf("expected the command to finish its unit of work")
+    }
+}
+
+func TestCommand_CancelledParentContextIsACleanStop(t *testing.T) {
+    parentContext, cancelParent := context.WithCancel(context.Background())
+    serviceContainer := container.NewContainer()
+    scope := serviceContainer.NewScope()
+    scope.MustOverrideProtectedInstance(logging.ServiceLogger, logging.NewNopLogger())
+
+    var reason error
+    command := NewCommand(
+        &testCommand{
+            runCallback: func(runtimeInstance runtimecontract.Runtime) error {
+                cancelParent()
+
+                runErr := loopUntilStopped(runtimeInstance)
+                reason = StopReason(runtimeInstance.Context())
+
+                return runErr
+            },
+        },
+        NewConfig(),
+    )
+
+    if runErr := command.Run(runtime.New(parentContext, scope, serviceContainer), nil); nil != runErr {
+        t.Fatalf("expected a clean exit, got %v", runErr)
+    }
+
+    if false == errors.Is(reason, ErrContextCanceled) {
+        t.Fatalf("expected stop reason %v, got %v", ErrContextCanceled, reason)
+    }
+}
+
+func TestCommand_Lock(t *testing.T) {
+    cases := map[string]struct {
+        skipWhenLocked bool
+        expectError    bool
+    }{
+        "fails when locked": {skipWhenLocked: false, expectError: true},
+        "skips when locked": {skipWhenLocked: true, expectError: false},
+    }
+
+    for name, testCase := range cases {
+        t.Run(name, func(t *testing.T) {
+            runtimeInstance := newTestRuntime()
+            locker := lock.NewInMemoryLocker(clock.NewSystemClock())
+
+            held := locker.CreateLock("cli.command.test:worker", time.Minute)
+            if acquired, acquireErr := held.Acquire(runtimeInstance); nil != acquireErr || false == acquired {
+                t.Fatalf("expected to acquire the lock: %v %v", acquired, acquireErr)
+            }
+
+            config := NewConfig()
+            config.SetLocker(locker)
+            config.SetSkipWhenLocked(testCase.skipWhenLocked)
+
+            ran := false
+            command := NewCommand(
+                &testCommand{
+                    runCallback: func(runtimeInstance runtimecontract.Runtime) error {
+                        ran = true
+
+                        return nil
+                    },
+                },
+                config,
+            )
+
+            runErr := command.Run(runtimeInstance, nil)
+            if testCase.expectError != (nil != runErr) {
+                t.Fatalf("unexpected error: %v", runErr)
+            }
+
+            if true == ran {
+                t.Fatalf("expected the command not to run while locked")
+            }
+        })
+    }
+}
+
+func TestCommand_ReleasesTheLock(t *testing.T) {
+    runtimeInstance := newTestRuntime()
+    locker := lock.NewInMemoryLocker(clock.NewSystemClock())
+
+    config := NewConfig()
+    config.SetLocker(locker)
+    config.SetLockName("worker.lock")
+
+    command := NewCommand(
+        &testCommand{
+            runCallback: func(runtimeInstance runtimecontract.Runtime) error {
+                acquired, _ := locker.CreateLock("worker.lock", time.Minute).Acquire(runtimeInstance)
+                if true == acquired {
+                    t.Fatalf("expected the lock to be held while the command runs")
+                }
+
+                return nil
+            },
+        },
+        config,
+    )
+
+    if runErr := command.Run(runtimeInstance, nil); nil != runErr {
+        t.Fatalf("unexpected error: %v", runErr)
+    }
+
+    acquired, acquireErr := locker.CreateLock("worker.lock", time.Minute).Acquire(runtimeInstance)
+    if nil != acquireErr || false == acquired {
+        t.Fatalf("expected the lock to be released: %v %v", acquired, acquireErr)
+    }
+}
+
+func TestCommand_FlagsOverrideConfig(t *testing.T) {
+    var reason error
+    command := NewCommand(
+        &testCommand{
+            flags: []clicontract.Flag{
+                &clicontract.StringFlag{Name: FlagNameTimeLimit},
+            },
+            runCallback: func(runtimeInstance runtimecontract.Runtime) error {
+                runErr := loopUntilStopped(runtimeInstance)
+                reason = StopReason(runtimeInstance.Context())
+
+                return runErr
+            },
+        },
+        nil,
+    )
+
+    flagCount := 0
+    for _, flag := range command.Flags() {
+        if FlagNameTimeLimit == flag.Names()[0] {
+            flagCount++
+        }
+    }
+    if 1 != flagCount {
+        t.Fatalf("expected the wrapped --time-limit flag to be kept once, got %d", flagCount)
+    }
+
+    commandContext := &clicontract.CommandContext{
+        Name:  command.Name(),
+        Flags: command.Flags(),
+        Action: func(ctx context.Context, commandContext *clicontract.CommandContext) error {
+            return command.Run(newTestRuntime(), commandContext)
+        },
+    }
+
+    if runErr := commandContext.Run(context.Background(), []string{command.Name(), "--unit-limit", "2"}); nil != runErr {
+        t.Fatalf("unexpected error: %v", runErr)
+    }
+
+    if false == errors.Is(reason, ErrUnitLimitReached) {
+        t.Fatalf("expected the unit limit flag to stop the command, got %v", reason)
+    }
+}
+
+func TestParseByteSize(t *testing.T) {
+    cases := map[string]struct {
+        value       string
+        expected    uint64
+        expectError bool
+    }{
+        "bytes":     {value: "512", expected: 512},
+        "kilobytes": {value: "64K", expected: 64 << 10},
+        "megabytes": {value: "256MB", expected: 256 << 20},
+        "gigabytes": {value: "1GiB", expected: 1 << 30},
+        "lowercase": {value: "2m", expected: 2 << 20},
+        "empty":     {value: "", expectError: true},
+        "unit only": {value: "M", expectError: true},
+        "unknown":   {value: "3X", expectError: true},
+        "negative":  {value: "-1", expectError: true},
+    }
+
+    for name, testCase := range cases {
+        t.Run(name, func(t *testing.T) {
+            parsed, parseErr := ParseByteSize(testCase.value)
+            if true == testCase.expectError {
+                if nil == parseErr {
+                    t.Fatalf("expected an error for %q", testCase.value)
+                }
+
+                return
+            }
+
+            if nil != parseErr || testCase.expected != parsed {
+                t.Fatalf("expected %d, got %d (%v)", testCase.expected, parsed, parseErr)
+            }
+        })
+    }
+}
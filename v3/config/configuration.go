@@ -87,6 +87,7 @@ type Configuration struct {
     cli         *cliConfiguration
     kernel      *kernelConfiguration
     http        *httpConfiguration
+    bindings    []configcontract.Binding
 }
 
 func (instance *Configuration) Cli() configcontract.CliConfiguration {
@@ -177,7 +178,10 @@ func (instance *Configuration) RegisterRuntime(name string, value any) {
         )
     }
 
-    instance.parameters[name] = NewParameter("", value, value, false)
+    parameter := NewParameter("", value, value, false)
+    parameter.source = ParameterSourceRuntime
+
+    instance.parameters[name] = parameter
 }
 
 func (instance *Configuration) Names() []string {
@@ -70,6 +70,9 @@ func (instance *Configuration) addAliasedParameterFromEnvironment(
         environmentValue,
         false,
     )
+    if nil != instance.environment {
+        parameterInstance.source = instance.environment.Origin(environmentKey)
+    }
 
     for _, name := range parameterNames {
         if "" == name {
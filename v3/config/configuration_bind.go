@@ -0,0 +1,799 @@
+package config
+
+import (
+    "fmt"
+    "reflect"
+    "sort"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+    "unicode"
+
+    configcontract "github.com/precision-soft/melody/v3/config/contract"
+    "github.com/precision-soft/melody/v3/exception"
+    exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+    "github.com/precision-soft/melody/v3/internal"
+    "github.com/precision-soft/melody/v3/validation"
+)
+
+const (
+    BindingTagKey     = "config"
+    BindingTagDefault = "default"
+    BindingOptional   = "optional"
+)
+
+/* Bind registers a typed binding of the parameters under prefix into T (a struct); the configuration re-binds it on every Resolve and reports every missing or invalid key at once. */
+func Bind[T any](configuration configcontract.Configuration, prefix string) *Binding[T] {
+    if true == internal.IsNilInterface(configuration) {
+        exception.Panic(
+            exception.NewError("configuration may not be nil for a binding", nil, nil),
+        )
+    }
+
+    bindingType := reflect.TypeOf((*T)(nil)).Elem()
+    if reflect.Struct != bindingType.Kind() {
+        exception.Panic(
+            exception.NewError(
+                "configuration binding target must be a struct",
+                exceptioncontract.Context{
+                    "type":   bindingType.String(),
+                    "prefix": prefix,
+                },
+                nil,
+            ),
+        )
+    }
+
+    binding := &Binding[T]{
+        prefix:        prefix,
+        configuration: configuration,
+        validator:     validation.NewValidator(),
+    }
+
+    bindingRegistry, isBindingRegistry := configuration.(configcontract.BindingRegistry)
+    if true == isBindingRegistry {
+        bindingRegistry.RegisterBinding(binding)
+    }
+
+    return binding
+}
+
+/* Decode maps the parameters under prefix into T once, without registering a binding. */
+func Decode[T any](configuration configcontract.Configuration, prefix string) (T, error) {
+    return decodeBinding[T](configuration, prefix, validation.NewValidator())
+}
+
+type Binding[T any] struct {
+    mutex         sync.RWMutex
+    prefix        string
+    configuration configcontract.Configuration
+    validator     *validation.Validator
+    value         T
+    isBound       bool
+}
+
+/* WithValidator replaces the default validator, for example with one that has custom constraints registered. */
+func (instance *Binding[T]) WithValidator(validator *validation.Validator) *Binding[T] {
+    if nil == validator {
+        exception.Panic(
+            exception.NewError("validator may not be nil for a configuration binding", nil, nil),
+        )
+    }
+
+    instance.mutex.Lock()
+    instance.validator = validator
+    instance.mutex.Unlock()
+
+    return instance
+}
+
+func (instance *Binding[T]) Prefix() string {
+    return instance.prefix
+}
+
+func (instance *Binding[T]) Bind(configuration configcontract.Configuration) error {
+    instance.mutex.RLock()
+    validator := instance.validator
+    instance.mutex.RUnlock()
+
+    value, decodeErr := decodeBinding[T](configuration, instance.prefix, validator)
+    if nil != decodeErr {
+        return decodeErr
+    }
+
+    instance.mutex.Lock()
+    instance.value = value
+    instance.isBound = true
+    instance.mutex.Unlock()
+
+    return nil
+}
+
+func (instance *Binding[T]) IsBound() bool {
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    return instance.isBound
+}
+
+/* Value returns the bound value; a binding registered after the last Resolve is bound on first use and panics if it is invalid. */
+func (instance *Binding[T]) Value() T {
+    if false == instance.IsBound() {
+        bindErr := instance.Bind(instance.configuration)
+        if nil != bindErr {
+            exception.Panic(
+                exception.NewError(
+                    "configuration binding failed",
+                    exceptioncontract.Context{
+                        "prefix": instance.prefix,
+                    },
+                    bindErr,
+                ),
+            )
+        }
+    }
+
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    return instance.value
+}
+
+func NewBindingError(key string, message string, causeErr error) *BindingError {
+    return &BindingError{
+        key:      key,
+        message:  message,
+        causeErr: causeErr,
+    }
+}
+
+type BindingError struct {
+    key      string
+    message  string
+    causeErr error
+}
+
+func (instance *BindingError) Key() string {
+    return instance.key
+}
+
+func (instance *BindingError) Message() string {
+    return instance.message
+}
+
+func (instance *BindingError) Error() string {
+    return instance.key + ": " + instance.message
+}
+
+func (instance *BindingError) Unwrap() error {
+    return instance.causeErr
+}
+
+type BindingErrors []*BindingError
+
+func (instance BindingErrors) Error() string {
+    return strings.Join(instance.Strings(), "; ")
+}
+
+func (instance BindingErrors) Strings() []string {
+    messages := make([]string, 0, len(instance))
+    for _, bindingError := range instance {
+        messages = append(messages, bindingError.Error())
+    }
+
+    sort.Strings(messages)
+
+    return messages
+}
+
+func (instance BindingErrors) Keys() []string {
+    keys := make([]string, 0, len(instance))
+    for _, bindingError := range instance {
+        keys = append(keys, bindingError.key)
+    }
+
+    sort.Strings(keys)
+
+    return keys
+}
+
+func (instance *Configuration) RegisterBinding(binding configcontract.Binding) {
+    if true == internal.IsNilInterface(binding) {
+        exception.Panic(
+            exception.NewError("configuration binding may not be nil", nil, nil),
+        )
+    }
+
+    instance.mutex.Lock()
+    instance.bindings = append(instance.bindings, binding)
+    instance.mutex.Unlock()
+}
+
+func (instance *Configuration) bindAll() error {
+    instance.mutex.RLock()
+    bindings := append([]configcontract.Binding{}, instance.bindings...)
+    instance.mutex.RUnlock()
+
+    var bindingErrors BindingErrors
+
+    for _, binding := range bindings {
+        bindErr := binding.Bind(instance)
+        if nil == bindErr {
+            continue
+        }
+
+        typedBindingErrors, isBindingErrors := bindErr.(BindingErrors)
+        if true == isBindingErrors {
+            bindingErrors = append(bindingErrors, typedBindingErrors...)
+
+            continue
+        }
+
+        bindingErrors = append(bindingErrors, NewBindingError(binding.Prefix(), bindErr.Error(), bindErr))
+    }
+
+    if 0 == len(bindingErrors) {
+        return nil
+    }
+
+    return exception.NewError(
+        "configuration binding failed",
+        exceptioncontract.Context{
+            "issues": bindingErrors.Strings(),
+        },
+        bindingErrors,
+    )
+}
+
+type bindingDecoder struct {
+    configuration configcontract.Configuration
+    validator     *validation.Validator
+    names         []string
+    errors        BindingErrors
+}
+
+func decodeBinding[T any](
+    configuration configcontract.Configuration,
+    prefix string,
+    validator *validation.Validator,
+) (T, error) {
+    var value T
+
+    decoder := &bindingDecoder{
+        configuration: configuration,
+        validator:     validator,
+        names:         configuration.Names(),
+    }
+
+    targetValue := reflect.ValueOf(&value).Elem()
+    if reflect.Struct != targetValue.Kind() {
+        return value, exception.NewError(
+            "configuration binding target must be a struct",
+            exceptioncontract.Context{
+                "type":   targetValue.Type().String(),
+                "prefix": prefix,
+            },
+            nil,
+        )
+    }
+
+    decoder.decodeStruct(prefix, targetValue)
+
+    if 0 < len(decoder.errors) {
+        return value, decoder.errors
+    }
+
+    return value, nil
+}
+
+func (instance *bindingDecoder) decodeStruct(prefix string, structValue reflect.Value) {
+    structType := structValue.Type()
+    fieldKeys := make(map[string]string)
+    failedKeys := make(map[string]bool)
+
+    for index := 0; index < structType.NumField(); index++ {
+        field := structType.Field(index)
+        if false == field.IsExported() {
+            continue
+        }
+
+        name, isOptional, isSkipped := parseBindingTag(field)
+        if true == isSkipped {
+            continue
+        }
+
+        key := joinBindingKey(prefix, name)
+
+        fieldKeys[field.Name] = key
+        jsonName := strings.Split(field.Tag.Get("json"), ",")[0]
+        if "" != jsonName && "-" != jsonName {
+            fieldKeys[jsonName] = key
+        }
+
+        defaultValue, hasDefault := field.Tag.Lookup(BindingTagDefault)
+
+        fieldErrorCount := len(instance.errors)
+        instance.decodeValue(key, structValue.Field(index), defaultValue, hasDefault, isOptional)
+        if fieldErrorCount != len(instance.errors) {
+            failedKeys[key] = true
+        }
+    }
+
+    if nil == instance.validator {
+        return
+    }
+
+    validateErr := instance.validator.Validate(structValue.Addr().Interface())
+    validationErrors, isValidationErrors := validateErr.(validation.ValidationErrors)
+    if false == isValidationErrors {
+        return
+    }
+
+    for _, validationError := range validationErrors {
+        key, exists := fieldKeys[validationError.Field()]
+        if false == exists {
+            key = joinBindingKey(prefix, validationError.Field())
+        }
+
+        if true == failedKeys[key] {
+            continue
+        }
+
+        instance.errors = append(instance.errors, NewBindingError(key, validationError.Message(), validationError))
+    }
+}
+
+func (instance *bindingDecoder) decodeValue(
+    key string,
+    fieldValue reflect.Value,
+    defaultValue string,
+    hasDefault bool,
+    isOptional bool,
+) {
+    fieldType := fieldValue.Type()
+
+    if reflect.Pointer == fieldType.Kind() && reflect.Struct == fieldType.Elem().Kind() {
+        if false == instance.hasChildren(key) && true == isOptional {
+            return
+        }
+
+        fieldValue.Set(reflect.New(fieldType.Elem()))
+        instance.decodeStruct(key, fieldValue.Elem())
+
+        return
+    }
+
+    if reflect.Struct == fieldType.Kind() && false == isBindingScalarType(fieldType) {
+        instance.decodeStruct(key, fieldValue)
+
+        return
+    }
+
+    switch fieldType.Kind() {
+    case reflect.Slice:
+        instance.decodeSlice(key, fieldValue, defaultValue, hasDefault, isOptional)
+
+        return
+    case reflect.Map:
+        instance.decodeMap(key, fieldValue, defaultValue, hasDefault, isOptional)
+
+        return
+    }
+
+    rawValue, exists := instance.lookup(key)
+    if false == exists {
+        if false == hasDefault {
+            if false == isOptional {
+                instance.errors = append(instance.errors, NewBindingError(key, "missing required configuration key", nil))
+            }
+
+            return
+        }
+
+        rawValue = defaultValue
+    }
+
+    setErr := setBindingScalar(fieldValue, rawValue)
+    if nil != setErr {
+        instance.errors = append(instance.errors, NewBindingError(key, setErr.Error(), setErr))
+    }
+}
+
+/* @info slices are read from indexed keys (`servers.0`, `servers.1`, as produced by structured file sources) or, when only the key itself is set, from a comma separated value */
+func (instance *bindingDecoder) decodeSlice(
+    key string,
+    fieldValue reflect.Value,
+    defaultValue string,
+    hasDefault bool,
+    isOptional bool,
+) {
+    elementType := fieldValue.Type().Elem()
+    indexes := instance.childIndexes(key)
+
+    if 0 < len(indexes) {
+        sliceValue := reflect.MakeSlice(fieldValue.Type(), len(indexes), len(indexes))
+        for position, index := range indexes {
+            instance.decodeValue(joinBindingKey(key, strconv.Itoa(index)), sliceValue.Index(position), "", false, false)
+        }
+
+        fieldValue.Set(sliceValue)
+
+        return
+    }
+
+    rawValue, exists := instance.lookup(key)
+    if false == exists {
+        if false == hasDefault {
+            if false == isOptional {
+                instance.errors = append(instance.errors, NewBindingError(key, "missing required configuration key", nil))
+            }
+
+            return
+        }
+
+        rawValue = defaultValue
+    }
+
+    if true == isBindingStructType(elementType) {
+        instance.errors = append(instance.errors, NewBindingError(key, "a list of structs requires indexed keys", nil))
+
+        return
+    }
+
+    parts := splitBindingList(rawValue)
+    sliceValue := reflect.MakeSlice(fieldValue.Type(), len(parts), len(parts))
+    for position, part := range parts {
+        setErr := setBindingScalar(sliceValue.Index(position), part)
+        if nil != setErr {
+            instance.errors = append(
+                instance.errors,
+                NewBindingError(joinBindingKey(key, strconv.Itoa(position)), setErr.Error(), setErr),
+            )
+        }
+    }
+
+    fieldValue.Set(sliceValue)
+}
+
+/* @info maps are read from the child keys (`labels.team`); struct values group by the first segment, scalar values use the whole remainder as map key, and a `k=v,k2=v2` value on the key itself is accepted too */
+func (instance *bindingDecoder) decodeMap(
+    key string,
+    fieldValue reflect.Value,
+    defaultValue string,
+    hasDefault bool,
+    isOptional bool,
+) {
+    fieldType := fieldValue.Type()
+    if reflect.String != fieldType.Key().Kind() {
+        instance.errors = append(instance.errors, NewBindingError(key, "configuration maps must have string keys", nil))
+
+        return
+    }
+
+    elementType := fieldType.Elem()
+    mapValue := reflect.MakeMap(fieldType)
+
+    childPrefix := key + "."
+    if "" == key {
+        childPrefix = ""
+    }
+
+    seen := make(map[string]bool)
+    for _, name := range instance.names {
+        if false == strings.HasPrefix(name, childPrefix) || name == key {
+            continue
+        }
+
+        mapKey := strings.TrimPrefix(name, childPrefix)
+        if true == isBindingStructType(elementType) {
+            mapKey, _, _ = strings.Cut(mapKey, ".")
+        }
+
+        if "" == mapKey || true == seen[mapKey] {
+            continue
+        }
+
+        seen[mapKey] = true
+
+        elementValue := reflect.New(elementType).Elem()
+        instance.decodeValue(childPrefix+mapKey, elementValue, "", false, false)
+        mapValue.SetMapIndex(reflect.ValueOf(mapKey).Convert(fieldType.Key()), elementValue)
+    }
+
+    if 0 < len(seen) {
+        fieldValue.Set(mapValue)
+
+        return
+    }
+
+    rawValue, exists := instance.lookup(key)
+    if false == exists {
+        if false == hasDefault {
+            if false == isOptional {
+                instance.errors = append(instance.errors, NewBindingError(key, "missing required configuration key", nil))
+            }
+
+            return
+        }
+
+        rawValue = defaultValue
+    }
+
+    if true == isBindingStructType(elementType) {
+        instance.errors = append(instance.errors, NewBindingError(key, "a map of structs requires nested keys", nil))
+
+        return
+    }
+
+    for _, part := range splitBindingList(rawValue) {
+        mapKey, mapItemValue, hasSeparator := strings.Cut(part, "=")
+        if false == hasSeparator || "" == strings.TrimSpace(mapKey) {
+            instance.errors = append(instance.errors, NewBindingError(key, "expected `key=value` map entries", nil))
+
+            return
+        }
+
+        mapKey = strings.TrimSpace(mapKey)
+
+        elementValue := reflect.New(elementType).Elem()
+        setErr := setBindingScalar(elementValue, strings.TrimSpace(mapItemValue))
+        if nil != setErr {
+            instance.errors = append(instance.errors, NewBindingError(joinBindingKey(key, mapKey), setErr.Error(), setErr))
+
+            continue
+        }
+
+        mapValue.SetMapIndex(reflect.ValueOf(mapKey).Convert(fieldType.Key()), elementValue)
+    }
+
+    fieldValue.Set(mapValue)
+}
+
+func (instance *bindingDecoder) lookup(key string) (any, bool) {
+    parameter := instance.configuration.Get(key)
+    if true == internal.IsNilInterface(parameter) {
+        return nil, false
+    }
+
+    return parameter.Value(), true
+}
+
+func (instance *bindingDecoder) hasChildren(key string) bool {
+    childPrefix := key + "."
+    for _, name := range instance.names {
+        if true == strings.HasPrefix(name, childPrefix) {
+            return true
+        }
+    }
+
+    return false
+}
+
+func (instance *bindingDecoder) childIndexes(key string) []int {
+    childPrefix := key + "."
+    indexSet := make(map[int]bool)
+
+    for _, name := range instance.names {
+        if false == strings.HasPrefix(name, childPrefix) {
+            continue
+        }
+
+        segment, _, _ := strings.Cut(strings.TrimPrefix(name, childPrefix), ".")
+        index, atoiErr := strconv.Atoi(segment)
+        if nil != atoiErr || 0 > index {
+            continue
+        }
+
+        indexSet[index] = true
+    }
+
+    indexes := make([]int, 0, len(indexSet))
+    for index := range indexSet {
+        indexes = append(indexes, index)
+    }
+
+    sort.Ints(indexes)
+
+    return indexes
+}
+
+func setBindingScalar(fieldValue reflect.Value, rawValue any) error {
+    fieldType := fieldValue.Type()
+
+    if nil != rawValue {
+        rawType := reflect.TypeOf(rawValue)
+        if reflect.String != rawType.Kind() && true == rawType.AssignableTo(fieldType) {
+            fieldValue.Set(reflect.ValueOf(rawValue))
+
+            return nil
+        }
+    }
+
+    if reflect.TypeOf(time.Time{}) == fieldType {
+        timeValue, parseErr := time.Parse(time.RFC3339, strings.TrimSpace(fmt.Sprintf("%v", rawValue)))
+        if nil != parseErr {
+            return exception.NewError("invalid RFC 3339 time value", nil, parseErr)
+        }
+
+        fieldValue.Set(reflect.ValueOf(timeValue))
+
+        return nil
+    }
+
+    if reflect.TypeOf(time.Duration(0)) == fieldType {
+        duration, _, durationErr := internal.Duration(rawValue, "")
+        if nil != durationErr {
+            return exception.NewError("invalid duration value", nil, durationErr)
+        }
+
+        fieldValue.SetInt(int64(duration))
+
+        return nil
+    }
+
+    switch fieldType.Kind() {
+    case reflect.String:
+        stringValue, isString := rawValue.(string)
+        if false == isString {
+            stringValue = fmt.Sprintf("%v", rawValue)
+        }
+
+        fieldValue.SetString(stringValue)
+
+        return nil
+    case reflect.Bool:
+        boolValue, _, boolErr := internal.Bool(rawValue, "")
+        if nil != boolErr {
+            return exception.NewError("invalid bool value", nil, boolErr)
+        }
+
+        fieldValue.SetBool(boolValue)
+
+        return nil
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+        intValue, _, intErr := internal.Int(rawValue, "")
+        if nil != intErr {
+            return exception.NewError("invalid int value", nil, intErr)
+        }
+
+        if true == fieldValue.OverflowInt(intValue) {
+            return exception.NewError(
+                "int value overflows the field type",
+                exceptioncontract.Context{
+                    "type": fieldType.String(),
+                },
+                nil,
+            )
+        }
+
+        fieldValue.SetInt(intValue)
+
+        return nil
+    case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+        uintValue, parseErr := strconv.ParseUint(strings.TrimSpace(fmt.Sprintf("%v", rawValue)), 10, 64)
+        if nil != parseErr {
+            return exception.NewError("invalid unsigned int value", nil, parseErr)
+        }
+
+        if true == fieldValue.OverflowUint(uintValue) {
+            return exception.NewError(
+                "unsigned int value overflows the field type",
+                exceptioncontract.Context{
+                    "type": fieldType.String(),
+                },
+                nil,
+            )
+        }
+
+        fieldValue.SetUint(uintValue)
+
+        return nil
+    case reflect.Float32, reflect.Float64:
+        floatValue, _, floatErr := internal.Float64(rawValue, "")
+        if nil != floatErr {
+            return exception.NewError("invalid float value", nil, floatErr)
+        }
+
+        fieldValue.SetFloat(floatValue)
+
+        return nil
+    }
+
+    return exception.NewError(
+        "unsupported configuration binding field type",
+        exceptioncontract.Context{
+            "type": fieldType.String(),
+        },
+        nil,
+    )
+}
+
+func parseBindingTag(field reflect.StructField) (string, bool, bool) {
+    tag, hasTag := field.Tag.Lookup(BindingTagKey)
+    if true == hasTag && "-" == tag {
+        return "", false, true
+    }
+
+    parts := strings.Split(tag, ",")
+
+    name := strings.TrimSpace(parts[0])
+    if "" == name {
+        name = bindingSnakeCase(field.Name)
+    }
+
+    isOptional := false
+    for _, option := range parts[1:] {
+        if BindingOptional == strings.TrimSpace(option) {
+            isOptional = true
+        }
+    }
+
+    return name, isOptional, false
+}
+
+func joinBindingKey(prefix string, name string) string {
+    if "" == prefix {
+        return name
+    }
+
+    return prefix + "." + name
+}
+
+func splitBindingList(rawValue any) []string {
+    stringValue, isString := rawValue.(string)
+    if false == isString {
+        stringValue = fmt.Sprintf("%v", rawValue)
+    }
+
+    if "" == strings.TrimSpace(stringValue) {
+        return []string{}
+    }
+
+    parts := strings.Split(stringValue, ",")
+    for index, part := range parts {
+        parts[index] = strings.TrimSpace(part)
+    }
+
+    return parts
+}
+
+func isBindingScalarType(fieldType reflect.Type) bool {
+    return reflect.TypeOf(time.Time{}) == fieldType
+}
+
+func isBindingStructType(fieldType reflect.Type) bool {
+    if reflect.Pointer == fieldType.Kind() {
+        fieldType = fieldType.Elem()
+    }
+
+    return reflect.Struct == fieldType.Kind() && false == isBindingScalarType(fieldType)
+}
+
+/* @info MaxConnections -> max_connections, HTTPAddress -> http_address */
+func bindingSnakeCase(name string) string {
+    runes := []rune(name)
+    builder := strings.Builder{}
+
+    for index, character := range runes {
+        if true == unicode.IsUpper(character) {
+            isWordStart := 0 < index &&
+                (true == unicode.IsLower(runes[index-1]) ||
+                    true == unicode.IsDigit(runes[index-1]) ||
+                    (index+1 < len(runes) && true == unicode.IsLower(runes[index+1]) && true == unicode.IsUpper(runes[index-1])))
+            if true == isWordStart {
+                builder.WriteRune('_')
+            }
+
+            builder.WriteRune(unicode.ToLower(character))
+
+            continue
+        }
+
+        builder.WriteRune(character)
+    }
+
+    return builder.String()
+}
+
+var _ configcontract.BindingRegistry = (*Configuration)(nil)
@@ -0,0 +1,183 @@
+package config
+
+import (
+    "errors"
+    "strings"
+    "testing"
+    "time"
+)
+
+type bindTestPool struct {
+    Size    int           `config:"size" default:"4"`
+    Timeout time.Duration `config:"timeout" default:"5s"`
+}
+
+type bindTestReplica struct {
+    Host string `config:"host" validate:"notBlank"`
+    Port int    `config:"port" default:"5432"`
+}
+
+type bindTestDatabase struct {
+    Host     string                     `config:"host" validate:"notBlank"`
+    Port     int                        `validate:"greaterThan(value=0)"`
+    Debug    bool                       `config:"debug,optional"`
+    Tags     []string                   `config:"tags" default:"primary,eu"`
+    Labels   map[string]string          `config:"labels,optional"`
+    Pool     bindTestPool               `config:"pool"`
+    Replicas []bindTestReplica          `config:"replicas,optional"`
+    Shards   map[string]bindTestReplica `config:"shards,optional"`
+    Ratio    float64                    `config:"max_ratio" default:"0.75"`
+    internal string
+}
+
+func newBindTestConfiguration(t *testing.T, values map[string]string) *Configuration {
+    t.Helper()
+
+    environment, newEnvironmentErr := NewEnvironment(&testEnvironmentSource{values: values})
+    if nil != newEnvironmentErr {
+        t.Fatalf("new environment error: %v", newEnvironmentErr)
+    }
+
+    configuration, newConfigurationErr := NewConfiguration(environment, t.TempDir())
+    if nil != newConfigurationErr {
+        t.Fatalf("new configuration error: %v", newConfigurationErr)
+    }
+
+    return configuration
+}
+
+func TestBind_MapsNestedStructsSlicesMapsAndDefaults(t *testing.T) {
+    configuration := newBindTestConfiguration(
+        t,
+        map[string]string{
+            "database.host":            "db.internal",
+            "database.port":            "%env(DB_PORT)%",
+            "DB_PORT":                  "6432",
+            "database.debug":           "yes",
+            "database.labels.team":     "core",
+            "database.labels.tier":     "gold",
+            "database.pool.size":       "16",
+            "database.replicas.0.host": "r0",
+            "database.replicas.1.host": "r1",
+            "database.replicas.1.port": "7000",
+            "database.shards.eu.host":  "eu.internal",
+            "database.shards.us.host":  "us.internal",
+            "database.shards.us.port":  "5433",
+        },
+    )
+
+    binding := Bind[bindTestDatabase](configuration, "database")
+    if true == binding.IsBound() {
+        t.Fatalf("expected the binding to wait for resolve")
+    }
+
+    if resolveErr := configuration.Resolve(); nil != resolveErr {
+        t.Fatalf("unexpected resolve error: %v", resolveErr)
+    }
+
+    database := binding.Value()
+    if "db.internal" != database.Host || 6432 != database.Port || true != database.Debug {
+        t.Fatalf("unexpected scalars: %#v", database)
+    }
+
+    if 2 != len(database.Tags) || "eu" != database.Tags[1] {
+        t.Fatalf("unexpected default slice: %#v", database.Tags)
+    }
+
+    if "core" != database.Labels["team"] || "gold" != database.Labels["tier"] {
+        t.Fatalf("unexpected labels: %#v", database.Labels)
+    }
+
+    if 16 != database.Pool.Size || 5*time.Second != database.Pool.Timeout {
+        t.Fatalf("unexpected pool: %#v", database.Pool)
+    }
+
+    if 2 != len(database.Replicas) || "r1" != database.Replicas[1].Host || 7000 != database.Replicas[1].Port || 5432 != database.Replicas[0].Port {
+        t.Fatalf("unexpected replicas: %#v", database.Replicas)
+    }
+
+    if "eu.internal" != database.Shards["eu"].Host || 5433 != database.Shards["us"].Port {
+        t.Fatalf("unexpected shards: %#v", database.Shards)
+    }
+
+    if 0.75 != database.Ratio {
+        t.Fatalf("unexpected ratio: %v", database.Ratio)
+    }
+}
+
+func TestBind_ResolveReportsEveryMissingAndInvalidKey(t *testing.T) {
+    configuration := newBindTestConfiguration(
+        t,
+        map[string]string{
+            "database.host":            "",
+            "database.pool.size":       "many",
+            "database.replicas.0.port": "1",
+        },
+    )
+
+    binding := Bind[bindTestDatabase](configuration, "database")
+
+    resolveErr := configuration.Resolve()
+    if nil == resolveErr {
+        t.Fatalf("expected resolve error")
+    }
+
+    var bindingErrors BindingErrors
+    if false == errors.As(resolveErr, &bindingErrors) {
+        t.Fatalf("expected binding errors, got: %v", resolveErr)
+    }
+
+    keys := strings.Join(bindingErrors.Keys(), ",")
+    expected := "database.host,database.pool.size,database.port,database.replicas.0.host"
+    if expected != keys {
+        t.Fatalf("unexpected failing keys: %s (%v)", keys, bindingErrors)
+    }
+
+    if true == binding.IsBound() {
+        t.Fatalf("expected the binding to stay unbound")
+    }
+}
+
+func TestBind_ValueBindsLazilyAfterResolve(t *testing.T) {
+    configuration := newBindTestConfiguration(t, map[string]string{"app.pool.size": "2"})
+
+    type bindTestApp struct {
+        Pool bindTestPool `config:"pool"`
+        Name string       `default:"melody"`
+    }
+
+    binding := Bind[bindTestApp](configuration, "app")
+
+    app := binding.Value()
+    if 2 != app.Pool.Size || "melody" != app.Name {
+        t.Fatalf("unexpected value: %#v", app)
+    }
+}
+
+func TestDecode_UsesRuntimeParameterValues(t *testing.T) {
+    configuration := newBindTestConfiguration(t, map[string]string{})
+    configuration.RegisterRuntime("worker.timeout", 3*time.Second)
+    configuration.RegisterRuntime("worker.size", 8)
+
+    pool, decodeErr := Decode[bindTestPool](configuration, "worker")
+    if nil != decodeErr {
+        t.Fatalf("unexpected decode error: %v", decodeErr)
+    }
+
+    if 8 != pool.Size || 3*time.Second != pool.Timeout {
+        t.Fatalf("unexpected pool: %#v", pool)
+    }
+}
+
+func TestBindingSnakeCase(t *testing.T) {
+    for input, expected := range map[string]string{
+        "MaxConnections": "max_connections",
+        "HTTPAddress":    "http_address",
+        "Port":           "port",
+        "Ipv4Only":       "ipv4_only",
+    } {
+        if expected != bindingSnakeCase(input) {
+            t.Fatalf("unexpected snake case for %s: %s", input, bindingSnakeCase(input))
+        }
+    }
+}
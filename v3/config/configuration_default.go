@@ -51,6 +51,7 @@ func (instance *Configuration) setDefaultParameter(
         defaultValue,
         true,
     )
+    parameter.source = ParameterSourceDefault
 
     for _, name := range parameterNames {
         if "" == name {
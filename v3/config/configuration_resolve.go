@@ -47,7 +47,7 @@ func (instance *Configuration) Resolve() error {
         parameter.value = value
     }
 
-    return nil
+    return instance.bindAll()
 }
 
 func (instance *Configuration) resolveWithTemplates(
@@ -0,0 +1,12 @@
+package contract
+
+/* Binding maps resolved parameters under a prefix into a typed value; a BindingRegistry re-runs every registered binding at the end of Resolve. */
+type Binding interface {
+    Prefix() string
+
+    Bind(configuration Configuration) error
+}
+
+type BindingRegistry interface {
+    RegisterBinding(binding Binding)
+}
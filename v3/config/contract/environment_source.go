@@ -3,3 +3,10 @@
 type EnvironmentSource interface {
     Load() (map[string]string, error)
 }
+
+/* TracedEnvironmentSource reports, next to every loaded value, the origin it was read from (for example `yaml:config/app.yaml`), so configuration introspection can show where a parameter came from. */
+type TracedEnvironmentSource interface {
+    EnvironmentSource
+
+    LoadTraced() (map[string]string, map[string]string, error)
+}
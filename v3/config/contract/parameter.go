@@ -17,3 +17,7 @@ type Parameter interface {
 
     Int() (int, error)
 }
+
+type SourcedParameter interface {
+    Source() string
+}
@@ -46,8 +46,20 @@
     KernelCacheDir   = "kernel.cache_dir"
 )
 
+const (
+    EnvironmentOriginDotEnv  = "dotenv"
+    EnvironmentOriginYaml    = "yaml"
+    EnvironmentOriginJson    = "json"
+    EnvironmentOriginSecret  = "secret"
+    EnvironmentOriginProcess = "process"
+
+    ParameterSourceDefault = "default"
+    ParameterSourceRuntime = "runtime"
+)
+
 type Environment struct {
-    values map[string]string
+    values  map[string]string
+    origins map[string]string
 }
 
 func NewEnvironment(source configcontract.EnvironmentSource) (*Environment, error) {
@@ -55,13 +67,27 @@ func NewEnvironment(source configcontract.EnvironmentSource) (*Environment, erro
         return nil, exception.NewError("environment source is required", nil, nil)
     }
 
+    tracedSource, isTracedSource := source.(configcontract.TracedEnvironmentSource)
+    if true == isTracedSource {
+        values, origins, loadTracedErr := tracedSource.LoadTraced()
+        if nil != loadTracedErr {
+            return nil, loadTracedErr
+        }
+
+        return &Environment{
+            values:  values,
+            origins: origins,
+        }, nil
+    }
+
     values, loadErr := source.Load()
     if nil != loadErr {
         return nil, loadErr
     }
 
     return &Environment{
-        values: values,
+        values:  values,
+        origins: make(map[string]string),
     }, nil
 }
 
@@ -83,3 +109,8 @@ func (instance *Environment) Get(key string) (string, bool) {
 
     return value, true
 }
+
+/* Origin reports where the value of the key was read from; it is empty when the source does not trace origins. */
+func (instance *Environment) Origin(key string) string {
+    return instance.origins[key]
+}
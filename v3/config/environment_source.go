@@ -30,30 +30,37 @@ type EnvironmentSource struct {
 }
 
 func (instance *EnvironmentSource) Load() (map[string]string, error) {
+    values, _, loadErr := instance.LoadTraced()
+
+    return values, loadErr
+}
+
+func (instance *EnvironmentSource) LoadTraced() (map[string]string, map[string]string, error) {
     values := make(map[string]string)
+    origins := make(map[string]string)
 
-    environmentName, loadDotEnvFilesErr := instance.loadDotEnvFiles(values)
+    environmentName, loadDotEnvFilesErr := instance.loadDotEnvFiles(values, origins)
     if nil != loadDotEnvFilesErr {
-        return nil, loadDotEnvFilesErr
+        return nil, nil, loadDotEnvFilesErr
     }
 
-    loadDotEnvEnvironmentFilesErr := instance.loadDotEnvEnvironmentFiles(values, environmentName)
+    loadDotEnvEnvironmentFilesErr := instance.loadDotEnvEnvironmentFiles(values, origins, environmentName)
     if nil != loadDotEnvEnvironmentFilesErr {
-        return nil, loadDotEnvEnvironmentFilesErr
+        return nil, nil, loadDotEnvEnvironmentFilesErr
     }
 
-    return values, nil
+    return values, origins, nil
 }
 
-func (instance *EnvironmentSource) loadDotEnvFiles(values map[string]string) (string, error) {
+func (instance *EnvironmentSource) loadDotEnvFiles(values map[string]string, origins map[string]string) (string, error) {
     dotEnvPath := filepath.Join(instance.baseDir, ".env")
-    loadOptionalDotEnvFileErr := instance.loadOptionalDotEnvFile(values, dotEnvPath)
+    loadOptionalDotEnvFileErr := instance.loadOptionalDotEnvFile(values, origins, dotEnvPath)
     if nil != loadOptionalDotEnvFileErr {
         return "", loadOptionalDotEnvFileErr
     }
 
     dotEnvLocalPath := filepath.Join(instance.baseDir, ".env.local")
-    loadOptionalDotEnvFileErr = instance.loadOptionalDotEnvFile(values, dotEnvLocalPath)
+    loadOptionalDotEnvFileErr = instance.loadOptionalDotEnvFile(values, origins, dotEnvLocalPath)
     if nil != loadOptionalDotEnvFileErr {
         return "", loadOptionalDotEnvFileErr
     }
@@ -73,18 +80,19 @@ func (instance *EnvironmentSource) loadDotEnvFiles(values map[string]string) (st
 
 func (instance *EnvironmentSource) loadDotEnvEnvironmentFiles(
     values map[string]string,
+    origins map[string]string,
     environmentName string,
 ) error {
     baseName := ".env." + environmentName
 
     environmentPath := filepath.Join(instance.baseDir, baseName)
-    loadOptionalDotEnvFileErr := instance.loadOptionalDotEnvFile(values, environmentPath)
+    loadOptionalDotEnvFileErr := instance.loadOptionalDotEnvFile(values, origins, environmentPath)
     if nil != loadOptionalDotEnvFileErr {
         return loadOptionalDotEnvFileErr
     }
 
     environmentLocalPath := filepath.Join(instance.baseDir, baseName+".local")
-    loadOptionalDotEnvFileErr = instance.loadOptionalDotEnvFile(values, environmentLocalPath)
+    loadOptionalDotEnvFileErr = instance.loadOptionalDotEnvFile(values, origins, environmentLocalPath)
     if nil != loadOptionalDotEnvFileErr {
         return loadOptionalDotEnvFileErr
     }
@@ -92,7 +100,7 @@ func (instance *EnvironmentSource) loadDotEnvEnvironmentFiles(
     return nil
 }
 
-func (instance *EnvironmentSource) loadRequiredDotEnvFile(values map[string]string, pathValue string) error {
+func (instance *EnvironmentSource) loadRequiredDotEnvFile(values map[string]string, origins map[string]string, pathValue string) error {
     _, err := fs.Stat(instance.fileSystem, pathValue)
     if nil != err {
         if true == errors.Is(err, fs.ErrNotExist) {
@@ -114,10 +122,10 @@ func (instance *EnvironmentSource) loadRequiredDotEnvFile(values map[string]stri
         )
     }
 
-    return instance.loadExistingDotEnvFile(values, pathValue)
+    return instance.loadExistingDotEnvFile(values, origins, pathValue)
 }
 
-func (instance *EnvironmentSource) loadOptionalDotEnvFile(values map[string]string, pathValue string) error {
+func (instance *EnvironmentSource) loadOptionalDotEnvFile(values map[string]string, origins map[string]string, pathValue string) error {
     _, err := fs.Stat(instance.fileSystem, pathValue)
     if nil != err {
         if true == errors.Is(err, fs.ErrNotExist) {
@@ -133,10 +141,10 @@ func (instance *EnvironmentSource) loadOptionalDotEnvFile(values map[string]stri
         )
     }
 
-    return instance.loadExistingDotEnvFile(values, pathValue)
+    return instance.loadExistingDotEnvFile(values, origins, pathValue)
 }
 
-func (instance *EnvironmentSource) loadExistingDotEnvFile(values map[string]string, pathValue string) error {
+func (instance *EnvironmentSource) loadExistingDotEnvFile(values map[string]string, origins map[string]string, pathValue string) error {
     data, readFileErr := fs.ReadFile(instance.fileSystem, pathValue)
     if nil != readFileErr {
         return exception.NewError(
@@ -177,6 +185,7 @@ func (instance *EnvironmentSource) loadExistingDotEnvFile(values map[string]stri
         }
 
         values[trimmedKey] = value
+        origins[trimmedKey] = EnvironmentOriginDotEnv + ":" + pathValue
     }
 
     return nil
@@ -244,4 +253,4 @@ func preprocessDotEnvContent(content string) (string, error) {
     return strings.Join(lines, "\n"), nil
 }
 
-var _ configcontract.EnvironmentSource = (*EnvironmentSource)(nil)
+var _ configcontract.TracedEnvironmentSource = (*EnvironmentSource)(nil)
@@ -0,0 +1,90 @@
+package config
+
+import (
+    "fmt"
+
+    configcontract "github.com/precision-soft/melody/v3/config/contract"
+    "github.com/precision-soft/melody/v3/exception"
+    exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+    "github.com/precision-soft/melody/v3/internal"
+)
+
+/* NewCompositeEnvironmentSource merges the given sources in order: a key loaded by a later source overrides the same key from an earlier one. */
+func NewCompositeEnvironmentSource(sources ...configcontract.EnvironmentSource) *CompositeEnvironmentSource {
+    for index, source := range sources {
+        if true == internal.IsNilInterface(source) {
+            exception.Panic(
+                exception.NewError(
+                    "environment source may not be nil in a composite source",
+                    exceptioncontract.Context{
+                        "index": index,
+                    },
+                    nil,
+                ),
+            )
+        }
+    }
+
+    return &CompositeEnvironmentSource{
+        sources: append([]configcontract.EnvironmentSource{}, sources...),
+    }
+}
+
+type CompositeEnvironmentSource struct {
+    sources []configcontract.EnvironmentSource
+}
+
+func (instance *CompositeEnvironmentSource) Sources() []configcontract.EnvironmentSource {
+    return append([]configcontract.EnvironmentSource{}, instance.sources...)
+}
+
+func (instance *CompositeEnvironmentSource) Load() (map[string]string, error) {
+    values, _, loadErr := instance.LoadTraced()
+
+    return values, loadErr
+}
+
+func (instance *CompositeEnvironmentSource) LoadTraced() (map[string]string, map[string]string, error) {
+    values := make(map[string]string)
+    origins := make(map[string]string)
+
+    for index, source := range instance.sources {
+        sourceValues, sourceOrigins, loadErr := loadTracedEnvironmentSource(source)
+        if nil != loadErr {
+            return nil, nil, exception.NewError(
+                "failed to load environment source",
+                exceptioncontract.Context{
+                    "index":  index,
+                    "source": fmt.Sprintf("%T", source),
+                },
+                loadErr,
+            )
+        }
+
+        for key, value := range sourceValues {
+            values[key] = value
+
+            origin, hasOrigin := sourceOrigins[key]
+            if false == hasOrigin || "" == origin {
+                origin = fmt.Sprintf("%T", source)
+            }
+
+            origins[key] = origin
+        }
+    }
+
+    return values, origins, nil
+}
+
+func loadTracedEnvironmentSource(source configcontract.EnvironmentSource) (map[string]string, map[string]string, error) {
+    tracedSource, isTracedSource := source.(configcontract.TracedEnvironmentSource)
+    if true == isTracedSource {
+        return tracedSource.LoadTraced()
+    }
+
+    values, loadErr := source.Load()
+
+    return values, map[string]string{}, loadErr
+}
+
+var _ configcontract.TracedEnvironmentSource = (*CompositeEnvironmentSource)(nil)
@@ -0,0 +1,230 @@
+package config
+
+import (
+    "bytes"
+    "encoding/json"
+    "errors"
+    "io/fs"
+    "sort"
+    "strconv"
+
+    configcontract "github.com/precision-soft/melody/v3/config/contract"
+    "github.com/precision-soft/melody/v3/exception"
+    exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+)
+
+func NewYamlFileSource(fileSystem fs.FS, path string) *FileSource {
+    return newFileSource(fileSystem, path, EnvironmentOriginYaml, decodeYaml)
+}
+
+func NewJsonFileSource(fileSystem fs.FS, path string) *FileSource {
+    return newFileSource(fileSystem, path, EnvironmentOriginJson, decodeJson)
+}
+
+/* FileSource loads a structured configuration file and flattens nested keys into dotted names (`database.primary.host`); sequence items use their index (`servers.0`). Like the `.env` files, a missing file is not an error unless the source is marked required. */
+type FileSource struct {
+    fileSystem fs.FS
+    path       string
+    format     string
+    decode     func(content []byte) (map[string]any, error)
+    isRequired bool
+}
+
+func (instance *FileSource) Required() *FileSource {
+    instance.isRequired = true
+
+    return instance
+}
+
+func (instance *FileSource) Load() (map[string]string, error) {
+    values, _, loadErr := instance.LoadTraced()
+
+    return values, loadErr
+}
+
+func (instance *FileSource) LoadTraced() (map[string]string, map[string]string, error) {
+    values := make(map[string]string)
+    origins := make(map[string]string)
+
+    content, readFileErr := fs.ReadFile(instance.fileSystem, instance.path)
+    if nil != readFileErr {
+        if true == errors.Is(readFileErr, fs.ErrNotExist) && false == instance.isRequired {
+            return values, origins, nil
+        }
+
+        return nil, nil, exception.NewError(
+            "failed to read configuration file",
+            exceptioncontract.Context{
+                "path":   instance.path,
+                "format": instance.format,
+            },
+            readFileErr,
+        )
+    }
+
+    document, decodeErr := instance.decode(content)
+    if nil != decodeErr {
+        return nil, nil, exception.NewError(
+            "failed to decode configuration file",
+            exceptioncontract.Context{
+                "path":   instance.path,
+                "format": instance.format,
+            },
+            decodeErr,
+        )
+    }
+
+    flattenErr := flattenStructuredValue("", document, values)
+    if nil != flattenErr {
+        return nil, nil, exception.NewError(
+            "failed to flatten configuration file",
+            exceptioncontract.Context{
+                "path":   instance.path,
+                "format": instance.format,
+            },
+            flattenErr,
+        )
+    }
+
+    origin := instance.format + ":" + instance.path
+    for key := range values {
+        origins[key] = origin
+    }
+
+    return values, origins, nil
+}
+
+func newFileSource(
+    fileSystem fs.FS,
+    path string,
+    format string,
+    decode func(content []byte) (map[string]any, error),
+) *FileSource {
+    if nil == fileSystem {
+        exception.Panic(
+            exception.NewError("file system may not be nil for a configuration file source", nil, nil),
+        )
+    }
+
+    if "" == path {
+        exception.Panic(
+            exception.NewError(
+                "path may not be empty for a configuration file source",
+                exceptioncontract.Context{
+                    "format": format,
+                },
+                nil,
+            ),
+        )
+    }
+
+    return &FileSource{
+        fileSystem: fileSystem,
+        path:       path,
+        format:     format,
+        decode:     decode,
+        isRequired: false,
+    }
+}
+
+func decodeJson(content []byte) (map[string]any, error) {
+    decoder := json.NewDecoder(bytes.NewReader(content))
+    decoder.UseNumber()
+
+    var document any
+    decodeErr := decoder.Decode(&document)
+    if nil != decodeErr {
+        return nil, decodeErr
+    }
+
+    if nil == document {
+        return map[string]any{}, nil
+    }
+
+    documentMap, isMap := document.(map[string]any)
+    if false == isMap {
+        return nil, exception.NewError("json configuration document must be an object", nil, nil)
+    }
+
+    return documentMap, nil
+}
+
+func flattenStructuredValue(name string, value any, values map[string]string) error {
+    switch typedValue := value.(type) {
+    case map[string]any:
+        keys := make([]string, 0, len(typedValue))
+        for key := range typedValue {
+            keys = append(keys, key)
+        }
+
+        sort.Strings(keys)
+
+        for _, key := range keys {
+            if "" == key {
+                return exception.NewError(
+                    "configuration key may not be empty",
+                    exceptioncontract.Context{
+                        "parent": name,
+                    },
+                    nil,
+                )
+            }
+
+            childName := key
+            if "" != name {
+                childName = name + "." + key
+            }
+
+            flattenErr := flattenStructuredValue(childName, typedValue[key], values)
+            if nil != flattenErr {
+                return flattenErr
+            }
+        }
+
+        return nil
+    case []any:
+        for index, item := range typedValue {
+            flattenErr := flattenStructuredValue(name+"."+strconv.Itoa(index), item, values)
+            if nil != flattenErr {
+                return flattenErr
+            }
+        }
+
+        return nil
+    }
+
+    if _, exists := values[name]; true == exists {
+        return exception.NewError(
+            "duplicate configuration key",
+            exceptioncontract.Context{
+                "key": name,
+            },
+            nil,
+        )
+    }
+
+    switch typedValue := value.(type) {
+    case nil:
+        values[name] = ""
+    case string:
+        values[name] = typedValue
+    case bool:
+        values[name] = strconv.FormatBool(typedValue)
+    case json.Number:
+        values[name] = typedValue.String()
+    case float64:
+        values[name] = strconv.FormatFloat(typedValue, 'f', -1, 64)
+    default:
+        return exception.NewError(
+            "unsupported configuration value",
+            exceptioncontract.Context{
+                "key": name,
+            },
+            nil,
+        )
+    }
+
+    return nil
+}
+
+var _ configcontract.TracedEnvironmentSource = (*FileSource)(nil)
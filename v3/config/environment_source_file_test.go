@@ -0,0 +1,157 @@
+package config
+
+import (
+    configcontract "github.com/precision-soft/melody/v3/config/contract"
+    "testing"
+    "testing/fstest"
+)
+
+func TestFileSource_LoadsJsonWithOrigins(t *testing.T) {
+    fileSystem := fstest.MapFS{
+        "config/app.json": &fstest.MapFile{Data: []byte(`{"database": {"host": "db", "port": 5432, "ratio": 0.5}, "tags": ["a", "b"], "debug": false}`)},
+    }
+
+    values, origins, loadErr := NewJsonFileSource(fileSystem, "config/app.json").LoadTraced()
+    if nil != loadErr {
+        t.Fatalf("unexpected load error: %v", loadErr)
+    }
+
+    if "db" != values["database.host"] || "5432" != values["database.port"] || "0.5" != values["database.ratio"] {
+        t.Fatalf("unexpected values: %#v", values)
+    }
+
+    if "b" != values["tags.1"] || "false" != values["debug"] {
+        t.Fatalf("unexpected values: %#v", values)
+    }
+
+    if "json:config/app.json" != origins["database.host"] {
+        t.Fatalf("unexpected origin: %s", origins["database.host"])
+    }
+}
+
+func TestFileSource_MissingFileIsOnlyAnErrorWhenRequired(t *testing.T) {
+    fileSystem := fstest.MapFS{}
+
+    values, loadErr := NewYamlFileSource(fileSystem, "config/app.yaml").Load()
+    if nil != loadErr || 0 != len(values) {
+        t.Fatalf("expected empty values for a missing optional file, got: %#v, %v", values, loadErr)
+    }
+
+    _, loadErr = NewYamlFileSource(fileSystem, "config/app.yaml").Required().Load()
+    if nil == loadErr {
+        t.Fatalf("expected error for a missing required file")
+    }
+}
+
+func TestSecretDirectorySource_ReadsOneValuePerFile(t *testing.T) {
+    fileSystem := fstest.MapFS{
+        "run/secrets/DB_PASSWORD":    &fstest.MapFile{Data: []byte("s3cret\n")},
+        "run/secrets/API_TOKEN":      &fstest.MapFile{Data: []byte("token\n\n")},
+        "run/secrets/..data/ignored": &fstest.MapFile{Data: []byte("x")},
+        "run/secrets/.hidden":        &fstest.MapFile{Data: []byte("x")},
+        "run/secrets/nested/IGNORED": &fstest.MapFile{Data: []byte("x")},
+    }
+
+    values, origins, loadErr := NewSecretDirectorySource(fileSystem, "run/secrets").LoadTraced()
+    if nil != loadErr {
+        t.Fatalf("unexpected load error: %v", loadErr)
+    }
+
+    if 2 != len(values) || "s3cret" != values["DB_PASSWORD"] || "token\n" != values["API_TOKEN"] {
+        t.Fatalf("unexpected values: %#v", values)
+    }
+
+    if "secret:run/secrets/DB_PASSWORD" != origins["DB_PASSWORD"] {
+        t.Fatalf("unexpected origin: %s", origins["DB_PASSWORD"])
+    }
+}
+
+func TestProcessEnvironmentSource_FiltersByPrefix(t *testing.T) {
+    source := NewProcessEnvironmentSource("APP_")
+    source.environ = func() []string {
+        return []string{"APP_NAME=melody", "HOME=/root", "APP_EMPTY=", "APP_URL=http://x?a=b"}
+    }
+
+    values, origins, loadErr := source.LoadTraced()
+    if nil != loadErr {
+        t.Fatalf("unexpected load error: %v", loadErr)
+    }
+
+    if 3 != len(values) || "melody" != values["APP_NAME"] || "http://x?a=b" != values["APP_URL"] {
+        t.Fatalf("unexpected values: %#v", values)
+    }
+
+    if EnvironmentOriginProcess != origins["APP_NAME"] {
+        t.Fatalf("unexpected origin: %s", origins["APP_NAME"])
+    }
+}
+
+func TestCompositeEnvironmentSource_LaterSourcesTakePrecedence(t *testing.T) {
+    fileSystem := fstest.MapFS{
+        ".env":            &fstest.MapFile{Data: []byte("APP_NAME=dotenv\nAPP_DEBUG=1\n")},
+        "config/app.yaml": &fstest.MapFile{Data: []byte("APP_NAME: yaml\nAPP_REGION: eu\n")},
+    }
+
+    composite := NewCompositeEnvironmentSource(
+        NewEnvironmentSource(fileSystem, "."),
+        NewYamlFileSource(fileSystem, "config/app.yaml"),
+        &testEnvironmentSource{values: map[string]string{"APP_REGION": "us"}},
+    )
+
+    environment, newEnvironmentErr := NewEnvironment(composite)
+    if nil != newEnvironmentErr {
+        t.Fatalf("unexpected environment error: %v", newEnvironmentErr)
+    }
+
+    name, _ := environment.Get("APP_NAME")
+    region, _ := environment.Get("APP_REGION")
+    debug, _ := environment.Get("APP_DEBUG")
+    if "yaml" != name || "us" != region || "1" != debug {
+        t.Fatalf("unexpected values: %#v", environment.All())
+    }
+
+    if "yaml:config/app.yaml" != environment.Origin("APP_NAME") {
+        t.Fatalf("unexpected origin for APP_NAME: %s", environment.Origin("APP_NAME"))
+    }
+
+    if "dotenv:.env" != environment.Origin("APP_DEBUG") {
+        t.Fatalf("unexpected origin for APP_DEBUG: %s", environment.Origin("APP_DEBUG"))
+    }
+
+    if "*config.testEnvironmentSource" != environment.Origin("APP_REGION") {
+        t.Fatalf("unexpected origin for APP_REGION: %s", environment.Origin("APP_REGION"))
+    }
+}
+
+func TestConfiguration_ParametersReportTheirSource(t *testing.T) {
+    fileSystem := fstest.MapFS{
+        "config/app.yaml": &fstest.MapFile{Data: []byte("APP_NAME: melody\n")},
+    }
+
+    environment, newEnvironmentErr := NewEnvironment(NewYamlFileSource(fileSystem, "config/app.yaml"))
+    if nil != newEnvironmentErr {
+        t.Fatalf("unexpected environment error: %v", newEnvironmentErr)
+    }
+
+    configuration, newConfigurationErr := NewConfiguration(environment, t.TempDir())
+    if nil != newConfigurationErr {
+        t.Fatalf("unexpected configuration error: %v", newConfigurationErr)
+    }
+
+    if "yaml:config/app.yaml" != testParameterSource(configuration, "APP_NAME") {
+        t.Fatalf("unexpected source: %s", testParameterSource(configuration, "APP_NAME"))
+    }
+
+    if ParameterSourceDefault != testParameterSource(configuration, KernelHttpAddress) {
+        t.Fatalf("unexpected default source: %s", testParameterSource(configuration, KernelHttpAddress))
+    }
+
+    configuration.RegisterRuntime("app.started", true)
+    if ParameterSourceRuntime != testParameterSource(configuration, "app.started") {
+        t.Fatalf("unexpected runtime source: %s", testParameterSource(configuration, "app.started"))
+    }
+}
+
+func testParameterSource(configuration *Configuration, name string) string {
+    return configuration.MustGet(name).(configcontract.SourcedParameter).Source()
+}
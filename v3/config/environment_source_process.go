@@ -0,0 +1,64 @@
+package config
+
+import (
+    "os"
+    "strings"
+
+    configcontract "github.com/precision-soft/melody/v3/config/contract"
+)
+
+/* NewProcessEnvironmentSource exposes the process environment; with prefixes, only the variables starting with one of them are loaded. */
+func NewProcessEnvironmentSource(prefixes ...string) *ProcessEnvironmentSource {
+    return &ProcessEnvironmentSource{
+        prefixes: append([]string{}, prefixes...),
+        environ:  os.Environ,
+    }
+}
+
+type ProcessEnvironmentSource struct {
+    prefixes []string
+    environ  func() []string
+}
+
+func (instance *ProcessEnvironmentSource) Load() (map[string]string, error) {
+    values, _, loadErr := instance.LoadTraced()
+
+    return values, loadErr
+}
+
+func (instance *ProcessEnvironmentSource) LoadTraced() (map[string]string, map[string]string, error) {
+    values := make(map[string]string)
+    origins := make(map[string]string)
+
+    for _, entry := range instance.environ() {
+        key, value, hasSeparator := strings.Cut(entry, "=")
+        if false == hasSeparator || "" == key {
+            continue
+        }
+
+        if false == instance.matchesPrefix(key) {
+            continue
+        }
+
+        values[key] = value
+        origins[key] = EnvironmentOriginProcess
+    }
+
+    return values, origins, nil
+}
+
+func (instance *ProcessEnvironmentSource) matchesPrefix(key string) bool {
+    if 0 == len(instance.prefixes) {
+        return true
+    }
+
+    for _, prefix := range instance.prefixes {
+        if true == strings.HasPrefix(key, prefix) {
+            return true
+        }
+    }
+
+    return false
+}
+
+var _ configcontract.TracedEnvironmentSource = (*ProcessEnvironmentSource)(nil)
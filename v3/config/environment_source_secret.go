@@ -0,0 +1,101 @@
+package config
+
+import (
+    "errors"
+    "io/fs"
+    "path"
+    "strings"
+
+    configcontract "github.com/precision-soft/melody/v3/config/contract"
+    "github.com/precision-soft/melody/v3/exception"
+    exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+)
+
+/* NewSecretDirectorySource reads one value per file (Docker/Kubernetes secret mounts): the file name is the key and the content, without a single trailing newline, is the value. */
+func NewSecretDirectorySource(fileSystem fs.FS, dir string) *SecretDirectorySource {
+    if nil == fileSystem {
+        exception.Panic(
+            exception.NewError("file system may not be nil for a secret directory source", nil, nil),
+        )
+    }
+
+    if "" == dir {
+        exception.Panic(
+            exception.NewError("directory may not be empty for a secret directory source", nil, nil),
+        )
+    }
+
+    return &SecretDirectorySource{
+        fileSystem: fileSystem,
+        dir:        dir,
+        isRequired: false,
+    }
+}
+
+type SecretDirectorySource struct {
+    fileSystem fs.FS
+    dir        string
+    isRequired bool
+}
+
+func (instance *SecretDirectorySource) Required() *SecretDirectorySource {
+    instance.isRequired = true
+
+    return instance
+}
+
+func (instance *SecretDirectorySource) Load() (map[string]string, error) {
+    values, _, loadErr := instance.LoadTraced()
+
+    return values, loadErr
+}
+
+func (instance *SecretDirectorySource) LoadTraced() (map[string]string, map[string]string, error) {
+    values := make(map[string]string)
+    origins := make(map[string]string)
+
+    entries, readDirErr := fs.ReadDir(instance.fileSystem, instance.dir)
+    if nil != readDirErr {
+        if true == errors.Is(readDirErr, fs.ErrNotExist) && false == instance.isRequired {
+            return values, origins, nil
+        }
+
+        return nil, nil, exception.NewError(
+            "failed to read secret directory",
+            exceptioncontract.Context{
+                "dir": instance.dir,
+            },
+            readDirErr,
+        )
+    }
+
+    for _, entry := range entries {
+        /* @info hidden entries cover the `..data` symlinks kubernetes creates next to the projected files */
+        if true == strings.HasPrefix(entry.Name(), ".") || true == entry.IsDir() {
+            continue
+        }
+
+        filePath := path.Join(instance.dir, entry.Name())
+
+        content, readFileErr := fs.ReadFile(instance.fileSystem, filePath)
+        if nil != readFileErr {
+            return nil, nil, exception.NewError(
+                "failed to read secret file",
+                exceptioncontract.Context{
+                    "path": filePath,
+                },
+                readFileErr,
+            )
+        }
+
+        value := strings.TrimSuffix(string(content), "\n")
+        value = strings.TrimSuffix(value, "\r")
+
+        values[entry.Name()] = value
+        origins[entry.Name()] = EnvironmentOriginSecret + ":" + filePath
+    }
+
+    return values, origins, nil
+}
+
+var _ configcontract.TracedEnvironmentSource = (*SecretDirectorySource)(nil)
@@ -47,7 +47,7 @@ func TestLoadExistingDotEnvFile_PreservesQuotedWhitespace(t *testing.T) {
     source := NewEnvironmentSource(os.DirFS(directory), "")
     values := make(map[string]string)
 
-    if loadErr := source.loadExistingDotEnvFile(values, ".env"); nil != loadErr {
+    if loadErr := source.loadExistingDotEnvFile(values, make(map[string]string), ".env"); nil != loadErr {
         t.Fatalf("load env file: %s", loadErr.Error())
     }
 
@@ -18,6 +18,7 @@ func NewParameter(environmentKey string, environmentValue any, value any, isDefa
         environmentValue: environmentValue,
         value:            value,
         isDefault:        isDefault,
+        source:           "",
     }
 }
 
@@ -26,6 +27,7 @@ type Parameter struct {
     environmentValue any
     value            any
     isDefault        bool
+    source           string
 }
 
 func (instance *Parameter) EnvironmentKey() string {
@@ -44,6 +46,11 @@ func (instance *Parameter) IsDefault() bool {
     return instance.isDefault
 }
 
+/* Source is where the parameter value came from: the environment origin (for example `dotenv:.env.local`), `default` or `runtime`. */
+func (instance *Parameter) Source() string {
+    return instance.source
+}
+
 func (instance *Parameter) String() string {
     stringValue, ok := instance.value.(string)
     if true == ok {
@@ -136,3 +143,4 @@ func (instance *Parameter) Int() (int, error) {
 }
 
 var _ configcontract.Parameter = (*Parameter)(nil)
+var _ configcontract.SourcedParameter = (*Parameter)(nil)
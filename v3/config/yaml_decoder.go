@@ -0,0 +1,712 @@
+package config
+
+import (
+    "strconv"
+    "strings"
+
+    "github.com/precision-soft/melody/v3/exception"
+    exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+)
+
+/* @info decodeYaml supports a strict subset of YAML 1.2: block mappings with plain or quoted keys, block sequences, single-line flow sequences and mappings of scalars, single-line plain and quoted scalars and literal/folded block scalars. Everything outside it (anchors, aliases, tags, explicit `?` keys, multiple documents, nested or multiline flow collections, multiline plain or quoted scalars and plain scalars containing `: `) is rejected with an error instead of being read differently from the specification. */
+func decodeYaml(content []byte) (map[string]any, error) {
+    rawLines := strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+
+    parser := &yamlParser{
+        rawLines: rawLines,
+        lines:    make([]yamlLine, 0, len(rawLines)),
+    }
+
+    collectErr := parser.collectLines()
+    if nil != collectErr {
+        return nil, collectErr
+    }
+
+    if 0 == len(parser.lines) {
+        return map[string]any{}, nil
+    }
+
+    document, nextIndex, parseErr := parser.parseBlock(0, parser.lines[0].indent)
+    if nil != parseErr {
+        return nil, parseErr
+    }
+
+    if nextIndex < len(parser.lines) {
+        return nil, parser.lineError("unexpected indentation", parser.lines[nextIndex])
+    }
+
+    documentMap, isMap := document.(map[string]any)
+    if false == isMap {
+        return nil, exception.NewError("yaml configuration document must be a mapping", nil, nil)
+    }
+
+    return documentMap, nil
+}
+
+type yamlLine struct {
+    rawIndex int
+    indent   int
+    content  string
+}
+
+type yamlParser struct {
+    rawLines []string
+    lines    []yamlLine
+}
+
+func (instance *yamlParser) collectLines() error {
+    for rawIndex, rawLine := range instance.rawLines {
+        withoutIndent := strings.TrimLeft(rawLine, " ")
+        indent := len(rawLine) - len(withoutIndent)
+
+        content := strings.TrimRight(stripYamlComment(withoutIndent), " \t")
+        if "" == content {
+            continue
+        }
+
+        if true == strings.HasPrefix(withoutIndent, "\t") {
+            return exception.NewError(
+                "yaml indentation may not contain tabs",
+                exceptioncontract.Context{
+                    "line": rawIndex + 1,
+                },
+                nil,
+            )
+        }
+
+        if 0 == indent && "---" == content {
+            if 0 < len(instance.lines) {
+                return exception.NewError(
+                    "multiple yaml documents are not supported",
+                    exceptioncontract.Context{
+                        "line": rawIndex + 1,
+                    },
+                    nil,
+                )
+            }
+
+            continue
+        }
+
+        if 0 == indent && "..." == content {
+            break
+        }
+
+        instance.lines = append(
+            instance.lines,
+            yamlLine{
+                rawIndex: rawIndex,
+                indent:   indent,
+                content:  content,
+            },
+        )
+    }
+
+    return nil
+}
+
+func (instance *yamlParser) parseBlock(index int, indent int) (any, int, error) {
+    if true == isYamlSequenceItem(instance.lines[index].content) {
+        return instance.parseSequence(index, indent)
+    }
+
+    return instance.parseMapping(index, indent)
+}
+
+func (instance *yamlParser) parseMapping(index int, indent int) (any, int, error) {
+    result := make(map[string]any)
+
+    for index < len(instance.lines) {
+        line := instance.lines[index]
+        if line.indent < indent {
+            break
+        }
+
+        if line.indent > indent {
+            return nil, index, instance.lineError("unexpected indentation", line)
+        }
+
+        if true == isYamlSequenceItem(line.content) {
+            break
+        }
+
+        key, rest, isMappingEntry := splitYamlMappingEntry(line.content)
+        if false == isMappingEntry {
+            return nil, index, instance.lineError("expected a `key: value` mapping entry", line)
+        }
+
+        if _, exists := result[key]; true == exists {
+            return nil, index, instance.lineError("duplicate yaml key", line)
+        }
+
+        index++
+
+        value, nextIndex, valueErr := instance.parseMappingValue(index, indent, rest, line)
+        if nil != valueErr {
+            return nil, nextIndex, valueErr
+        }
+
+        result[key] = value
+        index = nextIndex
+    }
+
+    return result, index, nil
+}
+
+func (instance *yamlParser) parseMappingValue(index int, indent int, rest string, line yamlLine) (any, int, error) {
+    if "" == rest {
+        if index < len(instance.lines) {
+            nextLine := instance.lines[index]
+
+            if nextLine.indent > indent || (nextLine.indent == indent && true == isYamlSequenceItem(nextLine.content)) {
+                return instance.parseBlock(index, nextLine.indent)
+            }
+        }
+
+        return nil, index, nil
+    }
+
+    if true == isYamlBlockScalarIndicator(rest) {
+        return instance.parseBlockScalar(index, indent, rest, line)
+    }
+
+    value, scalarErr := parseYamlScalar(rest)
+    if nil != scalarErr {
+        return nil, index, instance.scalarError(line, scalarErr)
+    }
+
+    if index < len(instance.lines) && instance.lines[index].indent > indent {
+        return nil, index, instance.lineError("multiline yaml scalars are not supported", instance.lines[index])
+    }
+
+    return value, index, nil
+}
+
+func (instance *yamlParser) parseSequence(index int, indent int) (any, int, error) {
+    result := make([]any, 0)
+
+    for index < len(instance.lines) {
+        line := instance.lines[index]
+        if line.indent < indent {
+            break
+        }
+
+        if line.indent > indent {
+            return nil, index, instance.lineError("unexpected indentation", line)
+        }
+
+        if false == isYamlSequenceItem(line.content) {
+            break
+        }
+
+        afterDash := line.content[1:]
+        rest := strings.TrimLeft(afterDash, " ")
+
+        if "" == rest {
+            index++
+
+            if index < len(instance.lines) && instance.lines[index].indent > indent {
+                value, nextIndex, blockErr := instance.parseBlock(index, instance.lines[index].indent)
+                if nil != blockErr {
+                    return nil, nextIndex, blockErr
+                }
+
+                result = append(result, value)
+                index = nextIndex
+
+                continue
+            }
+
+            result = append(result, nil)
+
+            continue
+        }
+
+        if true == isYamlSequenceItem(rest) || true == isYamlBlockScalarIndicator(rest) {
+            return nil, index, instance.lineError("unsupported yaml sequence item", line)
+        }
+
+        if _, _, isMappingEntry := splitYamlMappingEntry(rest); true == isMappingEntry {
+            /* @info `- key: value` opens a mapping whose keys are aligned with the first key, so the item line is re-read as a mapping line at that column */
+            itemIndent := line.indent + 1 + (len(afterDash) - len(rest))
+            instance.lines[index] = yamlLine{
+                rawIndex: line.rawIndex,
+                indent:   itemIndent,
+                content:  rest,
+            }
+
+            value, nextIndex, mappingErr := instance.parseMapping(index, itemIndent)
+            if nil != mappingErr {
+                return nil, nextIndex, mappingErr
+            }
+
+            result = append(result, value)
+            index = nextIndex
+
+            continue
+        }
+
+        value, scalarErr := parseYamlScalar(rest)
+        if nil != scalarErr {
+            return nil, index, instance.scalarError(line, scalarErr)
+        }
+
+        index++
+
+        if index < len(instance.lines) && instance.lines[index].indent > indent {
+            return nil, index, instance.lineError("multiline yaml scalars are not supported", instance.lines[index])
+        }
+
+        result = append(result, value)
+    }
+
+    return result, index, nil
+}
+
+func (instance *yamlParser) parseBlockScalar(index int, parentIndent int, indicator string, line yamlLine) (any, int, error) {
+    isFolded := '>' == indicator[0]
+    chomping := indicator[1:]
+
+    blockLines := make([]string, 0)
+    blockIndent := -1
+    endRawIndex := line.rawIndex + 1
+
+    for rawIndex := line.rawIndex + 1; rawIndex < len(instance.rawLines); rawIndex++ {
+        rawLine := instance.rawLines[rawIndex]
+        if "" == strings.TrimSpace(rawLine) {
+            blockLines = append(blockLines, "")
+            endRawIndex = rawIndex + 1
+
+            continue
+        }
+
+        lineIndent := len(rawLine) - len(strings.TrimLeft(rawLine, " "))
+        if lineIndent <= parentIndent {
+            break
+        }
+
+        if -1 == blockIndent {
+            blockIndent = lineIndent
+        }
+
+        if lineIndent < blockIndent {
+            return nil, index, instance.lineError("inconsistent block scalar indentation", yamlLine{rawIndex: rawIndex})
+        }
+
+        blockLines = append(blockLines, strings.TrimRight(rawLine[blockIndent:], " \t"))
+        endRawIndex = rawIndex + 1
+    }
+
+    for index < len(instance.lines) && instance.lines[index].rawIndex < endRawIndex {
+        index++
+    }
+
+    trailingBlankCount := 0
+    for 0 < len(blockLines) && "" == blockLines[len(blockLines)-1] {
+        blockLines = blockLines[:len(blockLines)-1]
+        trailingBlankCount++
+    }
+
+    if 0 == len(blockLines) {
+        return "", index, nil
+    }
+
+    text := ""
+    if true == isFolded {
+        builder := strings.Builder{}
+        for lineIndex, blockLine := range blockLines {
+            if 0 < lineIndex {
+                if "" == blockLine || "" == blockLines[lineIndex-1] {
+                    builder.WriteString("\n")
+                } else {
+                    builder.WriteString(" ")
+                }
+            }
+
+            builder.WriteString(blockLine)
+        }
+
+        text = builder.String()
+    } else {
+        text = strings.Join(blockLines, "\n")
+    }
+
+    switch chomping {
+    case "-":
+        return text, index, nil
+    case "+":
+        return text + "\n" + strings.Repeat("\n", trailingBlankCount), index, nil
+    }
+
+    return text + "\n", index, nil
+}
+
+func (instance *yamlParser) lineError(message string, line yamlLine) error {
+    return exception.NewError(
+        message,
+        exceptioncontract.Context{
+            "line": line.rawIndex + 1,
+        },
+        nil,
+    )
+}
+
+func (instance *yamlParser) scalarError(line yamlLine, scalarErr error) error {
+    return exception.NewError(
+        scalarErr.Error(),
+        exceptioncontract.Context{
+            "line": line.rawIndex + 1,
+        },
+        scalarErr,
+    )
+}
+
+func isYamlSequenceItem(content string) bool {
+    return "-" == content || true == strings.HasPrefix(content, "- ")
+}
+
+func isYamlBlockScalarIndicator(value string) bool {
+    switch value {
+    case "|", "|-", "|+", ">", ">-", ">+":
+        return true
+    }
+
+    return false
+}
+
+func splitYamlMappingEntry(content string) (string, string, bool) {
+    if '"' == content[0] || '\'' == content[0] {
+        closingIndex := findYamlClosingQuote(content)
+        if -1 == closingIndex {
+            return "", "", false
+        }
+
+        afterKey := content[closingIndex+1:]
+        if ":" != afterKey && false == strings.HasPrefix(afterKey, ": ") {
+            return "", "", false
+        }
+
+        key, keyErr := parseYamlScalar(content[:closingIndex+1])
+        if nil != keyErr {
+            return "", "", false
+        }
+
+        keyString, _ := key.(string)
+
+        return keyString, strings.TrimSpace(afterKey[1:]), true
+    }
+
+    if true == isYamlReservedIndicator(content[0]) {
+        return "", "", false
+    }
+
+    separatorIndex := strings.Index(content, ": ")
+    if -1 == separatorIndex && true == strings.HasSuffix(content, ":") {
+        separatorIndex = len(content) - 1
+    }
+
+    if -1 == separatorIndex {
+        return "", "", false
+    }
+
+    key := strings.TrimSpace(content[:separatorIndex])
+    if "" == key {
+        return "", "", false
+    }
+
+    return key, strings.TrimSpace(content[separatorIndex+1:]), true
+}
+
+func parseYamlScalar(text string) (any, error) {
+    text = strings.TrimSpace(text)
+    if "" == text {
+        return nil, nil
+    }
+
+    switch text[0] {
+    case '"', '\'':
+        closingIndex := findYamlClosingQuote(text)
+        if -1 == closingIndex {
+            return nil, exception.NewError("multiline quoted yaml scalars are not supported", nil, nil)
+        }
+
+        if len(text)-1 != closingIndex {
+            return nil, exception.NewError("unexpected content after a quoted yaml scalar", nil, nil)
+        }
+
+        if '"' == text[0] {
+            return parseYamlDoubleQuoted(text)
+        }
+
+        return strings.ReplaceAll(text[1:len(text)-1], "''", "'"), nil
+    case '[':
+        return parseYamlFlowSequence(text)
+    case '{':
+        return parseYamlFlowMapping(text)
+    case '&', '*', '!':
+        return nil, exception.NewError("yaml anchors, aliases and tags are not supported", nil, nil)
+    case '|', '>':
+        return nil, exception.NewError("unsupported yaml block scalar position", nil, nil)
+    case '%', '@', '`', ']', '}':
+        return nil, exception.NewError("plain yaml scalars may not start with a reserved indicator", nil, nil)
+    }
+
+    if "-" == text || true == strings.HasPrefix(text, "- ") || "?" == text || true == strings.HasPrefix(text, "? ") {
+        return nil, exception.NewError("yaml collection indicators are not supported inside a scalar position", nil, nil)
+    }
+
+    if true == strings.Contains(text, ": ") || true == strings.HasSuffix(text, ":") {
+        return nil, exception.NewError("plain yaml scalars may not contain `: `; quote the value", nil, nil)
+    }
+
+    switch text {
+    case "~", "null", "Null", "NULL":
+        return nil, nil
+    }
+
+    return text, nil
+}
+
+func parseYamlDoubleQuoted(text string) (any, error) {
+    inner := text[1 : len(text)-1]
+    builder := strings.Builder{}
+
+    for index := 0; index < len(inner); index++ {
+        character := inner[index]
+        if '\\' != character {
+            builder.WriteByte(character)
+            continue
+        }
+
+        index++
+        if index >= len(inner) {
+            return nil, exception.NewError("invalid yaml escape sequence", nil, nil)
+        }
+
+        switch inner[index] {
+        case '\\', '"', '/':
+            builder.WriteByte(inner[index])
+        case 'n':
+            builder.WriteByte('\n')
+        case 't':
+            builder.WriteByte('\t')
+        case 'r':
+            builder.WriteByte('\r')
+        case '0':
+            builder.WriteByte(0)
+        case 'u':
+            if index+5 > len(inner) {
+                return nil, exception.NewError("invalid yaml unicode escape", nil, nil)
+            }
+
+            codePoint, parseErr := strconv.ParseUint(inner[index+1:index+5], 16, 32)
+            if nil != parseErr {
+                return nil, exception.NewError("invalid yaml unicode escape", nil, parseErr)
+            }
+
+            builder.WriteRune(rune(codePoint))
+            index = index + 4
+        default:
+            return nil, exception.NewError(
+                "unsupported yaml escape sequence",
+                exceptioncontract.Context{
+                    "escape": "\\" + string(inner[index]),
+                },
+                nil,
+            )
+        }
+    }
+
+    return builder.String(), nil
+}
+
+func parseYamlFlowSequence(text string) (any, error) {
+    if ']' != text[len(text)-1] {
+        return nil, exception.NewError("yaml flow sequences must close on the line they open", nil, nil)
+    }
+
+    items, splitErr := splitYamlFlowItems(text[1 : len(text)-1])
+    if nil != splitErr {
+        return nil, splitErr
+    }
+
+    result := make([]any, 0, len(items))
+    for _, item := range items {
+        value, scalarErr := parseYamlScalar(item)
+        if nil != scalarErr {
+            return nil, scalarErr
+        }
+
+        result = append(result, value)
+    }
+
+    return result, nil
+}
+
+func parseYamlFlowMapping(text string) (any, error) {
+    if '}' != text[len(text)-1] {
+        return nil, exception.NewError("yaml flow mappings must close on the line they open", nil, nil)
+    }
+
+    items, splitErr := splitYamlFlowItems(text[1 : len(text)-1])
+    if nil != splitErr {
+        return nil, splitErr
+    }
+
+    result := make(map[string]any, len(items))
+    for _, item := range items {
+        key, rest, isMappingEntry := splitYamlMappingEntry(item)
+        if false == isMappingEntry {
+            return nil, exception.NewError("expected a `key: value` entry in yaml flow mapping", nil, nil)
+        }
+
+        if _, exists := result[key]; true == exists {
+            return nil, exception.NewError("duplicate yaml key", exceptioncontract.Context{"key": key}, nil)
+        }
+
+        value, scalarErr := parseYamlScalar(rest)
+        if nil != scalarErr {
+            return nil, scalarErr
+        }
+
+        result[key] = value
+    }
+
+    return result, nil
+}
+
+func splitYamlFlowItems(inner string) ([]string, error) {
+    items := make([]string, 0)
+    if "" == strings.TrimSpace(inner) {
+        return items, nil
+    }
+
+    var quoteCharacter byte = 0
+    start := 0
+
+    for index := 0; index < len(inner); index++ {
+        character := inner[index]
+
+        if 0 != quoteCharacter {
+            if '\\' == character && '"' == quoteCharacter {
+                index++
+                continue
+            }
+
+            if character == quoteCharacter {
+                quoteCharacter = 0
+            }
+
+            continue
+        }
+
+        switch character {
+        case '"', '\'':
+            quoteCharacter = character
+        case '[', ']', '{', '}':
+            return nil, exception.NewError("nested yaml flow collections are not supported", nil, nil)
+        case ',':
+            items = append(items, strings.TrimSpace(inner[start:index]))
+            start = index + 1
+        }
+    }
+
+    if 0 != quoteCharacter {
+        return nil, exception.NewError("unterminated quoted scalar in yaml flow collection", nil, nil)
+    }
+
+    lastItem := strings.TrimSpace(inner[start:])
+    if "" != lastItem {
+        items = append(items, lastItem)
+    }
+
+    return items, nil
+}
+
+func isYamlReservedIndicator(character byte) bool {
+    switch character {
+    case '[', ']', '{', '}', '&', '*', '!', '|', '>', '%', '@', '`', ',':
+        return true
+    }
+
+    return false
+}
+
+func findYamlClosingQuote(text string) int {
+    quoteCharacter := text[0]
+
+    for index := 1; index < len(text); index++ {
+        character := text[index]
+
+        if '"' == quoteCharacter && '\\' == character {
+            index++
+            continue
+        }
+
+        if character != quoteCharacter {
+            continue
+        }
+
+        if '\'' == quoteCharacter && index+1 < len(text) && '\'' == text[index+1] {
+            index++
+            continue
+        }
+
+        return index
+    }
+
+    return -1
+}
+
+/* @info stripYamlComment drops a `#` comment that starts the line or follows whitespace, ignoring `#` inside a quoted scalar; quotes only count where a scalar may start, so an apostrophe inside a plain scalar does not open a quote. */
+func stripYamlComment(content string) string {
+    var quoteCharacter byte = 0
+    var previousSignificant byte = 0
+
+    for index := 0; index < len(content); index++ {
+        character := content[index]
+
+        if 0 != quoteCharacter {
+            if '"' == quoteCharacter && '\\' == character {
+                index++
+                continue
+            }
+
+            if character == quoteCharacter {
+                if '\'' == quoteCharacter && index+1 < len(content) && '\'' == content[index+1] {
+                    index++
+                    continue
+                }
+
+                quoteCharacter = 0
+                previousSignificant = character
+            }
+
+            continue
+        }
+
+        if ('"' == character || '\'' == character) && true == isYamlScalarStart(previousSignificant) {
+            quoteCharacter = character
+            continue
+        }
+
+        if '#' == character && (0 == index || ' ' == content[index-1] || '\t' == content[index-1]) {
+            return content[:index]
+        }
+
+        if ' ' != character && '\t' != character {
+            previousSignificant = character
+        }
+    }
+
+    return content
+}
+
+func isYamlScalarStart(previousSignificant byte) bool {
+    switch previousSignificant {
+    case 0, ':', '-', '[', '{', ',', '?':
+        return true
+    }
+
+    return false
+}
@@ -0,0 +1,184 @@
+package config
+
+import (
+    "testing"
+)
+
+func TestDecodeYaml_FlattensNestedMappingsAndSequences(t *testing.T) {
+    content := `
+# database settings
+database:
+  primary:
+    host: db.internal   # trailing comment
+    port: 5432
+  replicas: [r1, "r2"]
+servers:
+  - name: a
+    weight: 1
+  - name: b
+    weight: 2
+motd: |
+  hello
+  world
+enabled: true
+empty:
+quoted: 'it''s'
+`
+
+    document, decodeErr := decodeYaml([]byte(content))
+    if nil != decodeErr {
+        t.Fatalf("unexpected decode error: %v", decodeErr)
+    }
+
+    values := make(map[string]string)
+    if flattenErr := flattenStructuredValue("", document, values); nil != flattenErr {
+        t.Fatalf("unexpected flatten error: %v", flattenErr)
+    }
+
+    expected := map[string]string{
+        "database.primary.host": "db.internal",
+        "database.primary.port": "5432",
+        "database.replicas.0":   "r1",
+        "database.replicas.1":   "r2",
+        "servers.0.name":        "a",
+        "servers.0.weight":      "1",
+        "servers.1.name":        "b",
+        "servers.1.weight":      "2",
+        "motd":                  "hello\nworld\n",
+        "enabled":               "true",
+        "empty":                 "",
+        "quoted":                "it's",
+    }
+
+    if len(expected) != len(values) {
+        t.Fatalf("unexpected values: %#v", values)
+    }
+
+    for key, expectedValue := range expected {
+        if expectedValue != values[key] {
+            t.Fatalf("unexpected value for %s: %q", key, values[key])
+        }
+    }
+}
+
+func TestDecodeYaml_RejectsUnsupportedFeatures(t *testing.T) {
+    for _, content := range []string{
+        "a: 1\n---\nb: 2\n",
+        "base: &base\n  a: 1\n",
+        "copy: *base\n",
+        "a:\n\tb: 1\n",
+        "a: 1\na: 2\n",
+    } {
+        _, decodeErr := decodeYaml([]byte(content))
+        if nil == decodeErr {
+            t.Fatalf("expected decode error for %q", content)
+        }
+    }
+}
+
+func TestDecodeYaml_RejectsConstructsOutsideTheSubset(t *testing.T) {
+    testCases := []struct {
+        name     string
+        content  string
+        expected string
+    }{
+        {
+            name:     "nested mapping on one line",
+            content:  "a: b: c\n",
+            expected: "plain yaml scalars may not contain `: `; quote the value",
+        },
+        {
+            name:     "plain scalar ending with a colon",
+            content:  "servers:\n  - host:\n  - b: c:\n",
+            expected: "plain yaml scalars may not contain `: `; quote the value",
+        },
+        {
+            name:     "flow mapping inside a flow sequence",
+            content:  "servers: [{name: a}, {name: b}]\n",
+            expected: "nested yaml flow collections are not supported",
+        },
+        {
+            name:     "flow sequence spanning lines",
+            content:  "servers: [a,\n  b]\n",
+            expected: "yaml flow sequences must close on the line they open",
+        },
+        {
+            name:     "multiline double-quoted scalar",
+            content:  "motd: \"hello\n  world\"\n",
+            expected: "multiline quoted yaml scalars are not supported",
+        },
+        {
+            name:     "multiline single-quoted scalar",
+            content:  "motd: 'hello\n  world'\n",
+            expected: "multiline quoted yaml scalars are not supported",
+        },
+        {
+            name:     "multiline plain scalar",
+            content:  "motd: hello\n  world\n",
+            expected: "multiline yaml scalars are not supported",
+        },
+        {
+            name:     "multiline plain sequence item",
+            content:  "items:\n  - hello\n    world\n",
+            expected: "multiline yaml scalars are not supported",
+        },
+        {
+            name:     "content after a quoted scalar",
+            content:  "name: \"a\" b\n",
+            expected: "unexpected content after a quoted yaml scalar",
+        },
+        {
+            name:     "sequence on the same line as its key",
+            content:  "items: - a\n",
+            expected: "yaml collection indicators are not supported inside a scalar position",
+        },
+        {
+            name:     "explicit key",
+            content:  "? a\n: b\n",
+            expected: "expected a `key: value` mapping entry",
+        },
+        {
+            name:     "reserved indicator",
+            content:  "name: @value\n",
+            expected: "plain yaml scalars may not start with a reserved indicator",
+        },
+    }
+
+    for _, testCase := range testCases {
+        t.Run(testCase.name, func(t *testing.T) {
+            _, decodeErr := decodeYaml([]byte(testCase.content))
+            if nil == decodeErr {
+                t.Fatalf("expected decode error for %q", testCase.content)
+            }
+
+            if testCase.expected != decodeErr.Error() {
+                t.Fatalf("unexpected error for %q: %v", testCase.content, decodeErr)
+            }
+        })
+    }
+}
+
+func TestDecodeYaml_AcceptsScalarsThatOnlyLookLikeMappings(t *testing.T) {
+    document, decodeErr := decodeYaml([]byte("url: http://example.com:8080/path\ntime: 12:30\nquoted: \"a: b\"\nflow: {name: 'x: y'}\n"))
+    if nil != decodeErr {
+        t.Fatalf("unexpected decode error: %v", decodeErr)
+    }
+
+    values := make(map[string]string)
+    if flattenErr := flattenStructuredValue("", document, values); nil != flattenErr {
+        t.Fatalf("unexpected flatten error: %v", flattenErr)
+    }
+
+    expected := map[string]string{
+        "url":       "http://example.com:8080/path",
+        "time":      "12:30",
+        "quoted":    "a: b",
+        "flow.name": "x: y",
+    }
+
+    for key, expectedValue := range expected {
+        if expectedValue != values[key] {
+            t.Fatalf("unexpected value for %s: %q", key, values[key])
+        }
+    }
+}
@@ -23,6 +23,8 @@ func NewContainer() containercontract.Container {
         resolverWaitGraph:           make(map[uint64]map[uint64]struct{}),
         typeRegistrationNamesByType: make(map[reflect.Type][]string),
         dependencyGraph:             make(map[string]map[string]struct{}),
+        declaredDependencyGraph:     make(map[string]map[string]struct{}),
+        scopedServiceNames:          make(map[string]struct{}),
     }
 }
 
@@ -38,6 +40,8 @@ type container struct {
     resolverWaitGraph           map[uint64]map[uint64]struct{}
     typeRegistrationNamesByType map[reflect.Type][]string
     dependencyGraph             map[string]map[string]struct{}
+    declaredDependencyGraph     map[string]map[string]struct{}
+    scopedServiceNames          map[string]struct{}
     isClosed                    bool
     closeErr                    error
 }
@@ -333,6 +337,21 @@ func (instance *container) register(
         }
     }
 
+    nodeKey := serviceNodeKey(serviceName)
+    for _, dependencyKey := range registerOption.Dependencies {
+        dependencies, exists := instance.declaredDependencyGraph[nodeKey]
+        if false == exists {
+            dependencies = make(map[string]struct{})
+            instance.declaredDependencyGraph[nodeKey] = dependencies
+        }
+
+        dependencies[dependencyKey] = struct{}{}
+    }
+
+    if containercontract.LifetimeScoped == registerOption.Lifetime {
+        instance.scopedServiceNames[serviceName] = struct{}{}
+    }
+
     return nil
 }
 
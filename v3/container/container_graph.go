@@ -0,0 +1,420 @@
+package container
+
+import (
+    "sort"
+    "strings"
+
+    containercontract "github.com/precision-soft/melody/v3/container/contract"
+    "github.com/precision-soft/melody/v3/exception"
+    exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+)
+
+/* @important WarmUp instantiates every non-scoped service, running constructor side effects and opening connections; it is an explicit opt-in, never part of Validate */
+func WarmUp(serviceContainer containercontract.Container) []containercontract.GraphIssue {
+    scopedServiceNames := make(map[string]struct{})
+
+    inspector, isInspector := serviceContainer.(containercontract.GraphInspector)
+    if true == isInspector {
+        for _, node := range inspector.DependencyGraph().Nodes {
+            if containercontract.DependencyNodeKindService == node.Kind && containercontract.LifetimeScoped == node.Lifetime {
+                scopedServiceNames[node.Name] = struct{}{}
+            }
+        }
+    }
+
+    issues := make([]containercontract.GraphIssue, 0)
+
+    for _, serviceName := range serviceContainer.Names() {
+        if _, isScoped := scopedServiceNames[serviceName]; true == isScoped {
+            continue
+        }
+
+        _, getErr := serviceContainer.Get(serviceName)
+        if nil == getErr {
+            continue
+        }
+
+        issues = append(
+            issues,
+            containercontract.GraphIssue{
+                Kind:    containercontract.GraphIssueKindResolution,
+                Node:    serviceNodeKey(serviceName),
+                Path:    []string{serviceNodeKey(serviceName)},
+                Message: getErr.Error(),
+            },
+        )
+    }
+
+    return issues
+}
+
+/* @info Validate inspects the dependency graph without constructing any service */
+func Validate(serviceContainer containercontract.Container) error {
+    return validateGraph(serviceContainer, false)
+}
+
+func ValidateWithWarmUp(serviceContainer containercontract.Container) error {
+    return validateGraph(serviceContainer, true)
+}
+
+func validateGraph(serviceContainer containercontract.Container, warmUp bool) error {
+    inspector, isInspector := serviceContainer.(containercontract.GraphInspector)
+    if false == isInspector {
+        return exception.NewError(
+            "container does not support graph inspection",
+            nil,
+            nil,
+        )
+    }
+
+    issues := make([]containercontract.GraphIssue, 0)
+    if true == warmUp {
+        issues = append(issues, WarmUp(serviceContainer)...)
+    }
+
+    issues = append(issues, inspector.ValidateGraph()...)
+    if 0 == len(issues) {
+        return nil
+    }
+
+    issueLines := make([]string, 0, len(issues))
+    for _, issue := range issues {
+        issueLines = append(issueLines, FormatGraphIssue(issue))
+    }
+
+    return exception.NewError(
+        "container graph validation failed",
+        exceptioncontract.Context{
+            "issueCount": len(issues),
+            "issues":     issueLines,
+        },
+        nil,
+    )
+}
+
+func FormatGraphIssue(issue containercontract.GraphIssue) string {
+    return issue.Kind + ": " + strings.Join(issue.Path, " -> ") + " (" + issue.Message + ")"
+}
+
+func (instance *container) MarkScoped(serviceNames ...string) {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    for _, serviceName := range serviceNames {
+        if "" == serviceName {
+            continue
+        }
+
+        instance.scopedServiceNames[serviceName] = struct{}{}
+    }
+}
+
+func (instance *container) DependencyGraph() containercontract.DependencyGraph {
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    return instance.dependencyGraphLocked()
+}
+
+func (instance *container) ValidateGraph() []containercontract.GraphIssue {
+    graph := instance.DependencyGraph()
+
+    nodeByKey := make(map[string]containercontract.DependencyNode, len(graph.Nodes))
+    for _, node := range graph.Nodes {
+        nodeByKey[node.Key] = node
+    }
+
+    issues := make([]containercontract.GraphIssue, 0)
+
+    for _, edge := range graph.Edges {
+        if containercontract.DependencyEdgeSourceAlias == edge.Source {
+            continue
+        }
+
+        dependentNode := nodeByKey[edge.From]
+        dependencyNode := nodeByKey[edge.To]
+
+        if false == dependencyNode.Registered && containercontract.LifetimeScoped != dependencyNode.Lifetime {
+            issues = append(
+                issues,
+                containercontract.GraphIssue{
+                    Kind:    containercontract.GraphIssueKindMissing,
+                    Node:    edge.From,
+                    Path:    []string{edge.From, edge.To},
+                    Message: "dependency is not registered",
+                },
+            )
+
+            continue
+        }
+
+        if containercontract.LifetimeSingleton == dependentNode.Lifetime && containercontract.LifetimeScoped == dependencyNode.Lifetime {
+            issues = append(
+                issues,
+                containercontract.GraphIssue{
+                    Kind:    containercontract.GraphIssueKindLifetime,
+                    Node:    edge.From,
+                    Path:    []string{edge.From, edge.To},
+                    Message: "singleton service depends on a scoped service",
+                },
+            )
+        }
+    }
+
+    issues = append(issues, findDependencyCycles(graph)...)
+
+    sort.SliceStable(
+        issues,
+        func(leftIndex int, rightIndex int) bool {
+            if issues[leftIndex].Kind != issues[rightIndex].Kind {
+                return issues[leftIndex].Kind < issues[rightIndex].Kind
+            }
+
+            return strings.Join(issues[leftIndex].Path, " ") < strings.Join(issues[rightIndex].Path, " ")
+        },
+    )
+
+    return issues
+}
+
+func (instance *container) dependencyGraphLocked() containercontract.DependencyGraph {
+    nodeByKey := make(map[string]containercontract.DependencyNode)
+
+    for serviceName := range instance.providers {
+        _, isResolved := instance.instances[serviceName]
+
+        nodeByKey[serviceNodeKey(serviceName)] = containercontract.DependencyNode{
+            Key:        serviceNodeKey(serviceName),
+            Kind:       containercontract.DependencyNodeKindService,
+            Name:       serviceName,
+            Lifetime:   instance.serviceLifetimeLocked(serviceName),
+            Registered: true,
+            Resolved:   isResolved,
+        }
+    }
+
+    for serviceName := range instance.scopedServiceNames {
+        if _, exists := nodeByKey[serviceNodeKey(serviceName)]; true == exists {
+            continue
+        }
+
+        nodeByKey[serviceNodeKey(serviceName)] = containercontract.DependencyNode{
+            Key:        serviceNodeKey(serviceName),
+            Kind:       containercontract.DependencyNodeKindService,
+            Name:       serviceName,
+            Lifetime:   containercontract.LifetimeScoped,
+            Registered: false,
+            Resolved:   false,
+        }
+    }
+
+    edgeSourceByPair := make(map[[2]string]string)
+
+    for registeredType, serviceNames := range instance.typeRegistrationNamesByType {
+        if 0 == len(serviceNames) {
+            continue
+        }
+
+        typeKey := typeNodeKey(registeredType.String())
+        _, isResolved := instance.typeInstances[registeredType]
+
+        lifetime := containercontract.LifetimeSingleton
+        for _, serviceName := range serviceNames {
+            if containercontract.LifetimeScoped == instance.serviceLifetimeLocked(serviceName) {
+                lifetime = containercontract.LifetimeScoped
+            }
+
+            edgeSourceByPair[[2]string{typeKey, serviceNodeKey(serviceName)}] = containercontract.DependencyEdgeSourceAlias
+        }
+
+        nodeByKey[typeKey] = containercontract.DependencyNode{
+            Key:        typeKey,
+            Kind:       containercontract.DependencyNodeKindType,
+            Name:       registeredType.String(),
+            Lifetime:   lifetime,
+            Registered: true,
+            Resolved:   isResolved,
+        }
+    }
+
+    for dependentKey, dependencySet := range instance.dependencyGraph {
+        for dependencyKey := range dependencySet {
+            pair := [2]string{dependentKey, dependencyKey}
+            if _, exists := edgeSourceByPair[pair]; false == exists {
+                edgeSourceByPair[pair] = containercontract.DependencyEdgeSourceRecorded
+            }
+        }
+    }
+
+    for dependentKey, dependencySet := range instance.declaredDependencyGraph {
+        for dependencyKey := range dependencySet {
+            edgeSourceByPair[[2]string{dependentKey, dependencyKey}] = containercontract.DependencyEdgeSourceDeclared
+        }
+    }
+
+    edges := make([]containercontract.DependencyEdge, 0, len(edgeSourceByPair))
+    for pair, source := range edgeSourceByPair {
+        for _, nodeKey := range pair {
+            if _, exists := nodeByKey[nodeKey]; true == exists {
+                continue
+            }
+
+            nodeByKey[nodeKey] = unregisteredDependencyNode(nodeKey)
+        }
+
+        edges = append(
+            edges,
+            containercontract.DependencyEdge{
+                From:   pair[0],
+                To:     pair[1],
+                Source: source,
+            },
+        )
+    }
+
+    sort.Slice(
+        edges,
+        func(leftIndex int, rightIndex int) bool {
+            if edges[leftIndex].From != edges[rightIndex].From {
+                return edges[leftIndex].From < edges[rightIndex].From
+            }
+
+            return edges[leftIndex].To < edges[rightIndex].To
+        },
+    )
+
+    nodes := make([]containercontract.DependencyNode, 0, len(nodeByKey))
+    for _, node := range nodeByKey {
+        nodes = append(nodes, node)
+    }
+
+    sort.Slice(
+        nodes,
+        func(leftIndex int, rightIndex int) bool {
+            return nodes[leftIndex].Key < nodes[rightIndex].Key
+        },
+    )
+
+    return containercontract.DependencyGraph{
+        Nodes: nodes,
+        Edges: edges,
+    }
+}
+
+func (instance *container) serviceLifetimeLocked(serviceName string) string {
+    if _, isScoped := instance.scopedServiceNames[serviceName]; true == isScoped {
+        return containercontract.LifetimeScoped
+    }
+
+    return containercontract.LifetimeSingleton
+}
+
+func unregisteredDependencyNode(nodeKey string) containercontract.DependencyNode {
+    kind := containercontract.DependencyNodeKindService
+    name := strings.TrimPrefix(nodeKey, "service:")
+
+    if true == strings.HasPrefix(nodeKey, "type:") {
+        kind = containercontract.DependencyNodeKindType
+        name = strings.TrimPrefix(nodeKey, "type:")
+    }
+
+    return containercontract.DependencyNode{
+        Key:        nodeKey,
+        Kind:       kind,
+        Name:       name,
+        Lifetime:   containercontract.LifetimeSingleton,
+        Registered: false,
+        Resolved:   false,
+    }
+}
+
+func findDependencyCycles(graph containercontract.DependencyGraph) []containercontract.GraphIssue {
+    adjacency := make(map[string][]string, len(graph.Nodes))
+    for _, edge := range graph.Edges {
+        adjacency[edge.From] = append(adjacency[edge.From], edge.To)
+    }
+
+    const (
+        stateUnvisited = 0
+        stateVisiting  = 1
+        stateVisited   = 2
+    )
+
+    stateByKey := make(map[string]int, len(graph.Nodes))
+    stack := make([]string, 0, 8)
+    seenCycles := make(map[string]struct{})
+    issues := make([]containercontract.GraphIssue, 0)
+
+    var visit func(nodeKey string)
+    visit = func(nodeKey string) {
+        stateByKey[nodeKey] = stateVisiting
+        stack = append(stack, nodeKey)
+
+        for _, dependencyKey := range adjacency[nodeKey] {
+            if stateVisiting == stateByKey[dependencyKey] {
+                cycle := extractCycle(stack, dependencyKey)
+
+                cycleKey := strings.Join(cycle, " -> ")
+                if _, seen := seenCycles[cycleKey]; false == seen {
+                    seenCycles[cycleKey] = struct{}{}
+
+                    issues = append(
+                        issues,
+                        containercontract.GraphIssue{
+                            Kind:    containercontract.GraphIssueKindCycle,
+                            Node:    cycle[0],
+                            Path:    cycle,
+                            Message: "circular service dependency detected",
+                        },
+                    )
+                }
+
+                continue
+            }
+
+            if stateUnvisited == stateByKey[dependencyKey] {
+                visit(dependencyKey)
+            }
+        }
+
+        stack = stack[:len(stack)-1]
+        stateByKey[nodeKey] = stateVisited
+    }
+
+    for _, node := range graph.Nodes {
+        if stateUnvisited == stateByKey[node.Key] {
+            visit(node.Key)
+        }
+    }
+
+    return issues
+}
+
+/* @info extractCycle returns the cycle closed by repeatedKey, rotated so it starts at its smallest key, so the same cycle reached from different entry points is reported once. */
+func extractCycle(stack []string, repeatedKey string) []string {
+    startIndex := 0
+    for index, key := range stack {
+        if key == repeatedKey {
+            startIndex = index
+            break
+        }
+    }
+
+    members := stack[startIndex:]
+
+    smallestIndex := 0
+    for index, key := range members {
+        if key < members[smallestIndex] {
+            smallestIndex = index
+        }
+    }
+
+    cycle := make([]string, 0, len(members)+1)
+    cycle = append(cycle, members[smallestIndex:]...)
+    cycle = append(cycle, members[:smallestIndex]...)
+    cycle = append(cycle, cycle[0])
+
+    return cycle
+}
+
+var _ containercontract.GraphInspector = (*container)(nil)
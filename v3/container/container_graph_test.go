@@ -0,0 +1,197 @@
+package container
+
+import (
+    "reflect"
+    "strings"
+    "testing"
+
+    containercontract "github.com/precision-soft/melody/v3/container/contract"
+)
+
+func TestContainer_ValidateGraph_ReportsMissingDeclaredDependency(t *testing.T) {
+    serviceContainer := NewContainer()
+
+    serviceContainer.MustRegister(
+        "app.consumer",
+        func(resolver containercontract.Resolver) (*testService, error) {
+            return &testService{Value: "consumer"}, nil
+        },
+        WithDependencies("app.missing"),
+    )
+
+    issues := serviceContainer.(containercontract.GraphInspector).ValidateGraph()
+    if 1 != len(issues) {
+        t.Fatalf("expected one issue, got %d", len(issues))
+    }
+
+    if containercontract.GraphIssueKindMissing != issues[0].Kind {
+        t.Fatalf("unexpected issue kind: %s", issues[0].Kind)
+    }
+
+    if "service:app.consumer" != issues[0].Node {
+        t.Fatalf("unexpected issue node: %s", issues[0].Node)
+    }
+}
+
+func TestContainer_ValidateGraph_ReportsDeclaredCycleOnce(t *testing.T) {
+    serviceContainer := NewContainer()
+
+    serviceContainer.MustRegister(
+        "app.first",
+        func(resolver containercontract.Resolver) (*testService, error) {
+            return &testService{Value: "first"}, nil
+        },
+        WithoutTypeRegistration(),
+        WithDependencies("app.second"),
+    )
+
+    serviceContainer.MustRegister(
+        "app.second",
+        func(resolver containercontract.Resolver) (*testService, error) {
+            return &testService{Value: "second"}, nil
+        },
+        WithoutTypeRegistration(),
+        WithDependencies("app.first"),
+    )
+
+    issues := serviceContainer.(containercontract.GraphInspector).ValidateGraph()
+    if 1 != len(issues) {
+        t.Fatalf("expected one issue, got %d", len(issues))
+    }
+
+    if containercontract.GraphIssueKindCycle != issues[0].Kind {
+        t.Fatalf("unexpected issue kind: %s", issues[0].Kind)
+    }
+
+    expectedPath := []string{"service:app.first", "service:app.second", "service:app.first"}
+    if false == reflect.DeepEqual(expectedPath, issues[0].Path) {
+        t.Fatalf("unexpected cycle path: %v", issues[0].Path)
+    }
+}
+
+func TestContainer_ValidateGraph_ReportsSingletonDependingOnScoped(t *testing.T) {
+    serviceContainer := NewContainer()
+
+    serviceContainer.(containercontract.GraphInspector).MarkScoped("service.request.context")
+
+    serviceContainer.MustRegister(
+        "app.singleton",
+        func(resolver containercontract.Resolver) (*testService, error) {
+            return &testService{Value: "singleton"}, nil
+        },
+        WithDependencies("service.request.context"),
+    )
+
+    serviceContainer.MustRegister(
+        "app.scoped",
+        func(resolver containercontract.Resolver) (*testImplementation, error) {
+            return &testImplementation{name: "scoped"}, nil
+        },
+        WithGraphScopeHint(),
+        WithDependencies("service.request.context"),
+    )
+
+    issues := serviceContainer.(containercontract.GraphInspector).ValidateGraph()
+    if 1 != len(issues) {
+        t.Fatalf("expected one issue, got %d", len(issues))
+    }
+
+    if containercontract.GraphIssueKindLifetime != issues[0].Kind {
+        t.Fatalf("unexpected issue kind: %s", issues[0].Kind)
+    }
+
+    if "service:app.singleton" != issues[0].Node {
+        t.Fatalf("unexpected issue node: %s", issues[0].Node)
+    }
+}
+
+func TestContainer_DependencyGraph_RecordsResolvedAndAliasEdges(t *testing.T) {
+    serviceContainer := NewContainer()
+
+    MustRegisterType[*testImplementation](
+        serviceContainer,
+        func(resolver containercontract.Resolver) (*testImplementation, error) {
+            return &testImplementation{name: "dependency"}, nil
+        },
+    )
+
+    serviceContainer.MustRegister(
+        "app.consumer",
+        func(resolver containercontract.Resolver) (*testService, error) {
+            dependency := MustFromResolverByType[*testImplementation](resolver)
+
+            return &testService{Value: dependency.Name()}, nil
+        },
+        WithTypeDependencies(reflect.TypeOf(&testImplementation{})),
+    )
+
+    _ = serviceContainer.MustGet("app.consumer")
+
+    graph := serviceContainer.(containercontract.GraphInspector).DependencyGraph()
+
+    sourceByEdge := make(map[string]string, len(graph.Edges))
+    for _, edge := range graph.Edges {
+        sourceByEdge[edge.From+" -> "+edge.To] = edge.Source
+    }
+
+    typeKey := "type:*container.testImplementation"
+
+    if containercontract.DependencyEdgeSourceDeclared != sourceByEdge["service:app.consumer -> "+typeKey] {
+        t.Fatalf("expected declared edge, got %v", sourceByEdge)
+    }
+
+    if containercontract.DependencyEdgeSourceAlias != sourceByEdge[typeKey+" -> service:*container.testImplementation"] {
+        t.Fatalf("expected alias edge, got %v", sourceByEdge)
+    }
+
+    for _, node := range graph.Nodes {
+        if "service:app.consumer" == node.Key && false == node.Resolved {
+            t.Fatalf("expected consumer node to be resolved")
+        }
+    }
+
+    issues := serviceContainer.(containercontract.GraphInspector).ValidateGraph()
+    if 0 != len(issues) {
+        t.Fatalf("expected no issues, got %v", issues)
+    }
+}
+
+func TestValidate_WarmUpReportsRecordedMissingDependency(t *testing.T) {
+    serviceContainer := NewContainer()
+
+    serviceContainer.MustRegister(
+        "app.consumer",
+        func(resolver containercontract.Resolver) (*testService, error) {
+            _, getErr := resolver.Get("app.missing")
+            if nil != getErr {
+                return nil, getErr
+            }
+
+            return &testService{Value: "consumer"}, nil
+        },
+    )
+
+    validateErr := Validate(serviceContainer)
+    if nil != validateErr {
+        t.Fatalf("expected no issue without warm-up, got %v", validateErr)
+    }
+
+    validateErr = ValidateWithWarmUp(serviceContainer)
+    if nil == validateErr {
+        t.Fatalf("expected validation error after warm-up")
+    }
+
+    issues := WarmUp(serviceContainer)
+    if 1 != len(issues) || containercontract.GraphIssueKindResolution != issues[0].Kind {
+        t.Fatalf("expected one resolution issue, got %v", issues)
+    }
+
+    graphIssues := serviceContainer.(containercontract.GraphInspector).ValidateGraph()
+    if 1 != len(graphIssues) || containercontract.GraphIssueKindMissing != graphIssues[0].Kind {
+        t.Fatalf("expected recorded missing dependency, got %v", graphIssues)
+    }
+
+    if false == strings.Contains(FormatGraphIssue(graphIssues[0]), "service:app.consumer -> service:app.missing") {
+        t.Fatalf("unexpected formatted issue: %s", FormatGraphIssue(graphIssues[0]))
+    }
+}
@@ -0,0 +1,59 @@
+package contract
+
+const (
+    LifetimeSingleton = "singleton"
+    LifetimeScoped    = "scoped"
+)
+
+const (
+    DependencyNodeKindService = "service"
+    DependencyNodeKindType    = "type"
+)
+
+const (
+    DependencyEdgeSourceDeclared = "declared"
+    DependencyEdgeSourceRecorded = "recorded"
+    DependencyEdgeSourceAlias    = "alias"
+)
+
+const (
+    GraphIssueKindMissing    = "missing"
+    GraphIssueKindCycle      = "cycle"
+    GraphIssueKindLifetime   = "lifetime"
+    GraphIssueKindResolution = "resolution"
+)
+
+type GraphInspector interface {
+    DependencyGraph() DependencyGraph
+
+    ValidateGraph() []GraphIssue
+
+    MarkScoped(serviceNames ...string)
+}
+
+type DependencyGraph struct {
+    Nodes []DependencyNode `json:"nodes"`
+    Edges []DependencyEdge `json:"edges"`
+}
+
+type DependencyNode struct {
+    Key        string `json:"key"`
+    Kind       string `json:"kind"`
+    Name       string `json:"name"`
+    Lifetime   string `json:"lifetime"`
+    Registered bool   `json:"registered"`
+    Resolved   bool   `json:"resolved"`
+}
+
+type DependencyEdge struct {
+    From   string `json:"from"`
+    To     string `json:"to"`
+    Source string `json:"source"`
+}
+
+type GraphIssue struct {
+    Kind    string   `json:"kind"`
+    Node    string   `json:"node"`
+    Path    []string `json:"path"`
+    Message string   `json:"message"`
+}
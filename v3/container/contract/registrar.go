@@ -3,6 +3,8 @@
 type RegisterOptions struct {
     AlsoRegisterType         bool
     TypeRegistrationIsStrict bool
+    Dependencies             []string
+    Lifetime                 string
 }
 
 type RegisterOption func(option *RegisterOptions)
@@ -11,3 +11,8 @@ type Scope interface {
 
     Close() error
 }
+
+/* ResolutionTracker is implemented by scopes that record the node keys ("service:<name>", "type:<T>") resolved through them, in first-resolution order. */
+type ResolutionTracker interface {
+    ResolvedServices() []string
+}
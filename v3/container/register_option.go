@@ -1,6 +1,8 @@
 package container
 
 import (
+    "reflect"
+
     containercontract "github.com/precision-soft/melody/v3/container/contract"
 )
 
@@ -17,10 +19,48 @@ func WithTypeRegistration(isStrict bool) containercontract.RegisterOption {
     }
 }
 
+func WithDependencies(serviceNames ...string) containercontract.RegisterOption {
+    return func(option *containercontract.RegisterOptions) {
+        for _, serviceName := range serviceNames {
+            if "" == serviceName {
+                continue
+            }
+
+            option.Dependencies = append(option.Dependencies, serviceNodeKey(serviceName))
+        }
+    }
+}
+
+func WithTypeDependencies(targetTypes ...reflect.Type) containercontract.RegisterOption {
+    return func(option *containercontract.RegisterOptions) {
+        for _, targetType := range targetTypes {
+            canonicalType := canonicalServiceType(targetType)
+            if nil == canonicalType {
+                continue
+            }
+
+            option.Dependencies = append(option.Dependencies, typeNodeKey(canonicalType.String()))
+        }
+    }
+}
+
+/*
+ * @important WithGraphScopeHint only marks the service as scope-lifetime in DependencyGraph and ValidateGraph; it has no
+ * runtime effect. The provider is still resolved once and cached container-wide, so per-scope instances must come from
+ * a scope override (OverrideInstance on the scope).
+ */
+func WithGraphScopeHint() containercontract.RegisterOption {
+    return func(option *containercontract.RegisterOptions) {
+        option.Lifetime = containercontract.LifetimeScoped
+    }
+}
+
 func buildRegisterServiceOption() *containercontract.RegisterOptions {
     return &containercontract.RegisterOptions{
         AlsoRegisterType:         true,
         TypeRegistrationIsStrict: true,
+        Dependencies:             nil,
+        Lifetime:                 containercontract.LifetimeSingleton,
     }
 }
 
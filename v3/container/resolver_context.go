@@ -68,6 +68,8 @@ func (instance *resolverContext) Get(serviceName string) (any, error) {
     defer instance.popKey()
 
     if nil != instance.scopeInstance {
+        instance.scopeInstance.recordResolved(nodeKey)
+
         value, exists, lookupInstanceByNameErr := instance.scopeInstance.lookupInstanceByName(serviceName)
         if nil != lookupInstanceByNameErr {
             return nil, lookupInstanceByNameErr
@@ -197,6 +199,8 @@ func (instance *resolverContext) GetByType(targetType reflect.Type) (any, error)
     defer instance.popKey()
 
     if nil != instance.scopeInstance {
+        instance.scopeInstance.recordResolved(nodeKey)
+
         value, exists, lookupInstanceByTypeErr := instance.scopeInstance.lookupInstanceByType(canonicalTargetType)
         if nil != lookupInstanceByTypeErr {
             return nil, lookupInstanceByTypeErr
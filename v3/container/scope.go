@@ -26,6 +26,10 @@ type scope struct {
     container     atomic.Pointer[container]
     instances     map[string]any
     typeInstances map[reflect.Type]any
+
+    resolvedMutex  sync.Mutex
+    resolvedKeys   []string
+    resolvedKeySet map[string]struct{}
 }
 
 func (instance *scope) Get(serviceName string) (any, error) {
@@ -295,6 +299,29 @@ func (instance *scope) Close() error {
     return nil
 }
 
+func (instance *scope) ResolvedServices() []string {
+    instance.resolvedMutex.Lock()
+    defer instance.resolvedMutex.Unlock()
+
+    return append([]string{}, instance.resolvedKeys...)
+}
+
+func (instance *scope) recordResolved(nodeKey string) {
+    instance.resolvedMutex.Lock()
+    defer instance.resolvedMutex.Unlock()
+
+    if nil == instance.resolvedKeySet {
+        instance.resolvedKeySet = make(map[string]struct{})
+    }
+
+    if _, exists := instance.resolvedKeySet[nodeKey]; true == exists {
+        return
+    }
+
+    instance.resolvedKeySet[nodeKey] = struct{}{}
+    instance.resolvedKeys = append(instance.resolvedKeys, nodeKey)
+}
+
 func (instance *scope) lookupInstanceByName(serviceName string) (any, bool, error) {
     if "" == serviceName {
         return nil, false, exception.NewError(
@@ -346,3 +373,4 @@ func (instance *scope) lookupInstanceByType(canonicalType reflect.Type) (any, bo
 }
 
 var _ containercontract.Scope = (*scope)(nil)
+var _ containercontract.ResolutionTracker = (*scope)(nil)
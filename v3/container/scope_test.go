@@ -331,3 +331,31 @@ func TestScope_MustGetByTypeNilTypePanicsDescriptively(t *testing.T) {
 
     _ = scope.MustGetByType(nil)
 }
+
+func TestScope_ResolvedServicesListsFirstResolutionOrder(t *testing.T) {
+    serviceContainer := NewContainer()
+
+    serviceContainer.MustRegister(
+        "service.dependency",
+        func(resolver containercontract.Resolver) (string, error) {
+            return "dependency", nil
+        },
+    )
+    serviceContainer.MustRegister(
+        "service.test",
+        func(resolver containercontract.Resolver) (*scopeTestService, error) {
+            resolver.MustGet("service.dependency")
+
+            return &scopeTestService{value: "ok"}, nil
+        },
+    )
+
+    scope := serviceContainer.NewScope()
+    scope.MustGet("service.test")
+    scope.MustGet("service.test")
+
+    resolvedServices := scope.(containercontract.ResolutionTracker).ResolvedServices()
+    if false == reflect.DeepEqual([]string{"service:service.test", "service:service.dependency"}, resolvedServices) {
+        t.Fatalf("unexpected resolved services: %v", resolvedServices)
+    }
+}
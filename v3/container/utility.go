@@ -41,3 +41,11 @@ func isAnyType(targetType reflect.Type) bool {
 
     return 0 == targetType.NumMethod()
 }
+
+func serviceNodeKey(serviceName string) string {
+    return "service:" + serviceName
+}
+
+func typeNodeKey(typeString string) string {
+    return "type:" + typeString
+}
@@ -12,6 +12,7 @@
     "github.com/precision-soft/melody/v3/cli/output"
     containercontract "github.com/precision-soft/melody/v3/container/contract"
     "github.com/precision-soft/melody/v3/exception"
+    "github.com/precision-soft/melody/v3/internal"
     runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
 )
 
@@ -27,7 +28,21 @@ func (instance *ContainerCommand) Description() string {
 }
 
 func (instance *ContainerCommand) Flags() []clicontract.Flag {
-    return output.DebugFlags()
+    return output.MergeFlags(
+        output.DebugFlags(),
+        []clicontract.Flag{
+            &clicontract.StringFlag{
+                Name:  containerGraphFlagName,
+                Usage: "emit the service dependency graph: dot|json",
+                Value: "",
+            },
+            &clicontract.BoolFlag{
+                Name:  containerWarmUpFlagName,
+                Usage: "resolve every non-scoped service before emitting the graph, so runtime dependencies are recorded",
+                Value: false,
+            },
+        },
+    )
 }
 
 func (instance *ContainerCommand) Run(
@@ -53,6 +68,19 @@ func (instance *ContainerCommand) Run(
 
     serviceContainer := runtimeInstance.Container()
 
+    graphFormat := strings.TrimSpace(commandContext.String(containerGraphFlagName))
+    if "" != graphFormat {
+        return instance.renderGraph(
+            serviceContainer,
+            graphFormat,
+            commandContext.Bool(containerWarmUpFlagName),
+            commandContext,
+            option,
+            envelope,
+            startedAt,
+        )
+    }
+
     serviceName := ""
     if 0 < commandContext.Args().Len() {
         serviceName = commandContext.Args().First()
@@ -222,7 +250,7 @@ func (instance *ContainerCommand) populateServiceList(
         okBlock := builder.AddBlock(
             "SERVICES (OK)",
             []string{"name", "type"},
-        )
+        ).SetFields("name", "typeName")
 
         for _, item := range okItems {
             okBlock.AddRow(item.Name, item.TypeName)
@@ -241,7 +269,7 @@ func (instance *ContainerCommand) populateServiceList(
                 errorBlock := builder.AddBlock(
                     "SERVICES (ERROR)",
                     []string{"name", "type", "error"},
-                )
+                ).SetFields("name", "typeName", "error")
 
                 for _, item := range errorItems {
                     errorBlock.AddRow(output.TableRowSeparatorToken)
@@ -296,119 +324,14 @@ func (instance *ContainerCommand) populateServiceList(
     )
 }
 
-func sanitizeErrorContextValue(value any) any {
-    if nil == value {
-        return nil
-    }
-
-    mapValue, isMap := value.(map[string]any)
-    if true == isMap {
-        return sanitizeErrorContextMap(mapValue)
-    }
-
-    sliceValue, isSlice := value.([]any)
-    if true == isSlice {
-        return sanitizeErrorContextSlice(sliceValue)
-    }
-
-    return value
-}
-
-func sanitizeErrorContextMap(value map[string]any) map[string]any {
-    result := map[string]any{}
-
-    for key, itemValue := range value {
-        if true == shouldDropErrorContextKey(key) {
-            continue
-        }
-
-        result[key] = sanitizeErrorContextValue(itemValue)
-    }
-
-    return result
-}
-
-func sanitizeErrorContextSlice(value []any) []any {
-    result := make([]any, 0, len(value))
-
-    for _, itemValue := range value {
-        result = append(result, sanitizeErrorContextValue(itemValue))
-    }
-
-    return result
-}
-
-func toLowerAscii(value string) string {
-    if "" == value {
-        return ""
-    }
-
-    bytesValue := []byte(value)
-
-    for index := 0; index < len(bytesValue); index++ {
-        character := bytesValue[index]
-
-        if character >= 'A' && character <= 'Z' {
-            bytesValue[index] = character + ('a' - 'A')
-        }
-    }
-
-    return string(bytesValue)
-}
-
-func shouldDropErrorContextKey(key string) bool {
-    if "trace" == key {
-        return true
-    }
-    if "stack" == key {
-        return true
-    }
-    if "stackTrace" == key {
-        return true
-    }
-    if "stacktrace" == key {
-        return true
-    }
-    if "traceString" == key {
-        return true
-    }
-    if "trace_string" == key {
-        return true
-    }
-    if "panicStack" == key {
-        return true
-    }
-
-    lowerKey := toLowerAscii(key)
-
-    if true == containsSubstring(lowerKey, "trace") {
-        return true
-    }
-    if true == containsSubstring(lowerKey, "stack") {
-        return true
-    }
-
-    return false
-}
-
-func containsSubstring(value string, needle string) bool {
-    if "" == needle {
-        return true
-    }
-    if "" == value {
-        return false
-    }
-    if len(value) < len(needle) {
-        return false
-    }
-
-    for index := 0; index <= len(value)-len(needle); index++ {
-        if value[index:index+len(needle)] == needle {
-            return true
-        }
-    }
+/* @info trace and stack payloads are dropped from the error context, whatever the casing or prefix of their key */
+var errorContextKeyMatcher = internal.NewContextKeyMatcher(
+    []string{"trace", "stack", "stackTrace", "stacktrace", "traceString", "trace_string", "panicStack"},
+    []string{"trace", "stack"},
+)
 
-    return false
+func sanitizeErrorContextValue(value any) any {
+    return internal.SanitizeContextValue(value, errorContextKeyMatcher, nil)
 }
 
 func truncateTableCellValue(value string) string {
@@ -0,0 +1,152 @@
+package debug
+
+import (
+    "fmt"
+    "strings"
+    "time"
+
+    clicontract "github.com/precision-soft/melody/v3/cli/contract"
+    "github.com/precision-soft/melody/v3/cli/output"
+    "github.com/precision-soft/melody/v3/container"
+    containercontract "github.com/precision-soft/melody/v3/container/contract"
+    "github.com/precision-soft/melody/v3/exception"
+)
+
+const (
+    containerGraphFlagName   = "graph"
+    containerWarmUpFlagName  = "warm-up"
+    containerGraphFormatDot  = "dot"
+    containerGraphFormatJson = "json"
+)
+
+type containerGraphPayload struct {
+    Graph  containercontract.DependencyGraph `json:"graph"`
+    Issues []containercontract.GraphIssue    `json:"issues"`
+}
+
+func (instance *ContainerCommand) renderGraph(
+    serviceContainer containercontract.Container,
+    graphFormat string,
+    warmUp bool,
+    commandContext *clicontract.CommandContext,
+    option output.Option,
+    envelope output.Envelope,
+    startedAt time.Time,
+) error {
+    inspector, isInspector := serviceContainer.(containercontract.GraphInspector)
+    if false == isInspector {
+        return exception.NewError(
+            "container does not support graph inspection",
+            nil,
+            nil,
+        )
+    }
+
+    if containerGraphFormatDot != graphFormat && containerGraphFormatJson != graphFormat {
+        return exception.NewError(
+            "unsupported container graph format",
+            map[string]any{
+                "format":    graphFormat,
+                "supported": []string{containerGraphFormatDot, containerGraphFormatJson},
+            },
+            nil,
+        )
+    }
+
+    issues := make([]containercontract.GraphIssue, 0)
+    if true == warmUp {
+        /* @important resolving every service records the dependencies providers pull at runtime, so the graph is not limited to declared edges */
+        issues = append(issues, container.WarmUp(serviceContainer)...)
+    }
+
+    issues = append(issues, inspector.ValidateGraph()...)
+
+    graph := inspector.DependencyGraph()
+
+    if containerGraphFormatDot == graphFormat {
+        _, writeErr := fmt.Fprint(commandContext.Writer, renderContainerGraphDot(graph, issues))
+
+        return writeErr
+    }
+
+    envelope.Data = containerGraphPayload{
+        Graph:  graph,
+        Issues: issues,
+    }
+    envelope.Meta.DurationMilliseconds = time.Since(startedAt).Milliseconds()
+
+    option.Format = output.FormatJson
+
+    return output.Render(commandContext.Writer, envelope, option)
+}
+
+func renderContainerGraphDot(
+    graph containercontract.DependencyGraph,
+    issues []containercontract.GraphIssue,
+) string {
+    nodesWithIssue := make(map[string]struct{}, len(issues))
+    for _, issue := range issues {
+        for _, nodeKey := range issue.Path {
+            nodesWithIssue[nodeKey] = struct{}{}
+        }
+    }
+
+    builder := strings.Builder{}
+    builder.WriteString("digraph container {\n")
+    builder.WriteString("    rankdir=LR;\n")
+    builder.WriteString("    node [shape=box, fontname=\"monospace\"];\n")
+
+    for _, node := range graph.Nodes {
+        attributes := []string{
+            "label=" + quoteDotString(node.Name+"\\n"+node.Kind+", "+node.Lifetime),
+        }
+
+        if containercontract.DependencyNodeKindType == node.Kind {
+            attributes = append(attributes, "shape=ellipse")
+        }
+
+        if containercontract.LifetimeScoped == node.Lifetime {
+            attributes = append(attributes, "style=dashed")
+        }
+
+        if _, hasIssue := nodesWithIssue[node.Key]; true == hasIssue {
+            attributes = append(attributes, "color=red")
+        }
+
+        builder.WriteString(
+            fmt.Sprintf(
+                "    %s [%s];\n",
+                quoteDotString(node.Key),
+                strings.Join(attributes, ", "),
+            ),
+        )
+    }
+
+    for _, edge := range graph.Edges {
+        style := "solid"
+        if containercontract.DependencyEdgeSourceRecorded == edge.Source {
+            style = "dashed"
+        }
+        if containercontract.DependencyEdgeSourceAlias == edge.Source {
+            style = "dotted"
+        }
+
+        builder.WriteString(
+            fmt.Sprintf(
+                "    %s -> %s [style=%s, label=%s];\n",
+                quoteDotString(edge.From),
+                quoteDotString(edge.To),
+                style,
+                quoteDotString(edge.Source),
+            ),
+        )
+    }
+
+    builder.WriteString("}\n")
+
+    return builder.String()
+}
+
+func quoteDotString(value string) string {
+    return "\"" + strings.ReplaceAll(value, "\"", "\\\"") + "\""
+}
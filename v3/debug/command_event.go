@@ -116,6 +116,7 @@ func (instance *EventCommand) Run(
             items,
             eventListItem{
                 EventName:            registeredEvent.EventName,
+                PayloadType:          registeredEvent.PayloadType,
                 ListenerCount:        len(registeredEvent.Listeners),
                 FromSubscriberCount:  fromSubscriberCount,
                 SubscriberOwnerCount: subscriberOwnerCount,
@@ -146,12 +147,18 @@ func(leftIndex int, rightIndex int) bool {
 
         block := builder.AddBlock(
             "EVENTS",
-            []string{"event", "listeners", "from subscribers", "subscribers", "priorities"},
-        )
+            []string{"event", "payload", "listeners", "from subscribers", "subscribers", "priorities"},
+        ).SetFields("eventName", "payloadType", "listenerCount", "fromSubscriberCount", "subscriberOwnerCount", "priorities")
 
         for _, item := range items {
+            payloadType := item.PayloadType
+            if "" == payloadType {
+                payloadType = "-"
+            }
+
             block.AddRow(
                 item.EventName,
+                payloadType,
                 fmt.Sprintf("%d", item.ListenerCount),
                 fmt.Sprintf("%d", item.FromSubscriberCount),
                 fmt.Sprintf("%d", item.SubscriberOwnerCount),
@@ -162,8 +169,8 @@ func(leftIndex int, rightIndex int) bool {
         if true == option.Verbose {
             verboseBlock := builder.AddBlock(
                 "LISTENERS",
-                []string{"event", "priority", "source", "owner", "listener"},
-            )
+                []string{"event", "priority", "mode", "source", "owner", "listener"},
+            ).SetFields("eventName", "priority", "mode", "source", "owner", "listener")
 
             sortedRegisteredEvents := make([]eventcontract.RegisteredEvent, 0, len(registeredEvents))
             for _, registeredEvent := range registeredEvents {
@@ -220,6 +227,7 @@ func(leftIndex int, rightIndex int) bool {
                     verboseBlock.AddRow(
                         eventCell,
                         fmt.Sprintf("%d", listener.Priority),
+                        listenerMode(listener),
                         listener.Source,
                         listener.Owner,
                         listener.ListenerName,
@@ -247,10 +255,19 @@ func(leftIndex int, rightIndex int) bool {
 
 type eventListItem struct {
     EventName            string `json:"eventName"`
+    PayloadType          string `json:"payloadType"`
     ListenerCount        int    `json:"listenerCount"`
     FromSubscriberCount  int    `json:"fromSubscriberCount"`
     SubscriberOwnerCount int    `json:"subscriberOwnerCount"`
     Priorities           string `json:"priorities"`
 }
 
+func listenerMode(listener eventcontract.RegisteredListener) string {
+    if "" == listener.Mode {
+        return eventcontract.RegisteredListenerModeSync
+    }
+
+    return listener.Mode
+}
+
 var _ clicontract.Command = (*EventCommand)(nil)
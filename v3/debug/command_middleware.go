@@ -2,27 +2,44 @@
 
 import (
     "fmt"
-    "reflect"
-    runtimepkg "runtime"
     "sort"
     "time"
 
     clicontract "github.com/precision-soft/melody/v3/cli/contract"
     "github.com/precision-soft/melody/v3/cli/output"
     httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    middlewarepipeline "github.com/precision-soft/melody/v3/http/middleware/pipeline"
     runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
 )
 
+const (
+    middlewareRoutesFlagName = "routes"
+)
+
 type MiddlewareProvider func() []httpcontract.Middleware
 
+type RouteMiddlewareReportProvider func() ([]*middlewarepipeline.RouteMiddlewareReport, error)
+
 func NewMiddlewareCommand(middlewareProvider MiddlewareProvider) *MiddlewareCommand {
     return &MiddlewareCommand{
         middlewareProvider: middlewareProvider,
     }
 }
 
+/* NewMiddlewareCommandWithRouteReports enables `--routes`, which lists the effective chain (global, group and route middleware) of every route. */
+func NewMiddlewareCommandWithRouteReports(
+    middlewareProvider MiddlewareProvider,
+    routeMiddlewareReportProvider RouteMiddlewareReportProvider,
+) *MiddlewareCommand {
+    return &MiddlewareCommand{
+        middlewareProvider:            middlewareProvider,
+        routeMiddlewareReportProvider: routeMiddlewareReportProvider,
+    }
+}
+
 type MiddlewareCommand struct {
-    middlewareProvider MiddlewareProvider
+    middlewareProvider            MiddlewareProvider
+    routeMiddlewareReportProvider RouteMiddlewareReportProvider
 }
 
 func (instance *MiddlewareCommand) Name() string {
@@ -34,7 +51,20 @@ func (instance *MiddlewareCommand) Description() string {
 }
 
 func (instance *MiddlewareCommand) Flags() []clicontract.Flag {
-    return output.DebugFlags()
+    if nil == instance.routeMiddlewareReportProvider {
+        return output.DebugFlags()
+    }
+
+    return output.MergeFlags(
+        output.DebugFlags(),
+        []clicontract.Flag{
+            &clicontract.BoolFlag{
+                Name:  middlewareRoutesFlagName,
+                Usage: "list the effective middleware chain of every route (global, group and route layers)",
+                Value: false,
+            },
+        },
+    )
 }
 
 func (instance *MiddlewareCommand) Run(
@@ -58,14 +88,15 @@ func (instance *MiddlewareCommand) Run(
 
     envelope := output.NewEnvelope(meta)
 
+    if nil != instance.routeMiddlewareReportProvider && true == commandContext.Bool(middlewareRoutesFlagName) {
+        return instance.renderRoutes(commandContext, option, envelope, startedAt)
+    }
+
     middlewares := instance.middlewareProvider()
 
     items := make([]middlewareListItem, 0, len(middlewares))
     for index, middleware := range middlewares {
-        name := "<nil>"
-        if nil != middleware {
-            name = middlewareFunctionName(middleware)
-        }
+        name := middlewarepipeline.MiddlewareName(middleware)
 
         items = append(
             items,
@@ -93,7 +124,7 @@ func (instance *MiddlewareCommand) Run(
         block := builder.AddBlock(
             "MIDDLEWARE",
             []string{"index", "middleware"},
-        )
+        ).SetFields("index", "name")
 
         for _, item := range items {
             block.AddRow(
@@ -122,23 +153,109 @@ type middlewareListItem struct {
     Name  string `json:"name"`
 }
 
-func middlewareFunctionName(middleware httpcontract.Middleware) string {
-    value := reflect.ValueOf(middleware)
-    if reflect.Func != value.Kind() {
-        return "<unknown>"
+func (instance *MiddlewareCommand) renderRoutes(
+    commandContext *clicontract.CommandContext,
+    option output.Option,
+    envelope output.Envelope,
+    startedAt time.Time,
+) error {
+    reports, reportErr := instance.routeMiddlewareReportProvider()
+    if nil != reportErr {
+        return reportErr
     }
 
-    pointer := value.Pointer()
-    if 0 == pointer {
-        return "<unknown>"
+    items := make([]routeMiddlewareListItem, 0, len(reports))
+    for _, report := range reports {
+        entries := make([]routeMiddlewareEntryItem, 0, len(report.Entries()))
+        for _, entry := range report.Entries() {
+            entries = append(
+                entries,
+                routeMiddlewareEntryItem{
+                    Layer:  entry.Layer(),
+                    Source: entry.Source(),
+                    Name:   entry.Name(),
+                },
+            )
+        }
+
+        items = append(
+            items,
+            routeMiddlewareListItem{
+                RouteName: report.RouteName(),
+                Pattern:   report.Pattern(),
+                Chain:     entries,
+            },
+        )
     }
 
-    function := runtimepkg.FuncForPC(pointer)
-    if nil == function {
-        return "<unknown>"
+    if output.FormatTable == option.Format {
+        builder := output.NewTableBuilder()
+
+        builder.AddSummaryLine(
+            fmt.Sprintf(
+                "ROUTES: %d total",
+                len(items),
+            ),
+        )
+
+        block := builder.AddBlock(
+            "ROUTE MIDDLEWARE",
+            []string{"route", "pattern", "index", "layer", "source", "middleware"},
+        ).SetFields("routeName", "pattern", "chain", "chain", "chain", "chain")
+
+        for _, item := range items {
+            routeName := item.RouteName
+            if "" == routeName {
+                routeName = "-"
+            }
+
+            if 0 == len(item.Chain) {
+                block.AddRow(routeName, item.Pattern, "-", "-", "-", "-")
+                continue
+            }
+
+            for index, entry := range item.Chain {
+                source := entry.Source
+                if "" == source {
+                    source = "-"
+                }
+
+                block.AddRow(
+                    routeName,
+                    item.Pattern,
+                    fmt.Sprintf("%d", index+1),
+                    entry.Layer,
+                    source,
+                    entry.Name,
+                )
+            }
+        }
+
+        envelope.Table = builder.Build()
+    } else {
+        envelope.Data = output.NewListPayload(
+            items,
+            len(items),
+            option.Limit,
+            option.Offset,
+        )
     }
 
-    return function.Name()
+    envelope.Meta.DurationMilliseconds = time.Since(startedAt).Milliseconds()
+
+    return output.Render(commandContext.Writer, envelope, option)
+}
+
+type routeMiddlewareListItem struct {
+    RouteName string                     `json:"routeName"`
+    Pattern   string                     `json:"pattern"`
+    Chain     []routeMiddlewareEntryItem `json:"chain"`
+}
+
+type routeMiddlewareEntryItem struct {
+    Layer  string `json:"layer"`
+    Source string `json:"source"`
+    Name   string `json:"name"`
 }
 
 var _ clicontract.Command = (*MiddlewareCommand)(nil)
@@ -9,6 +9,7 @@
     clicontract "github.com/precision-soft/melody/v3/cli/contract"
     "github.com/precision-soft/melody/v3/cli/output"
     "github.com/precision-soft/melody/v3/config"
+    configcontract "github.com/precision-soft/melody/v3/config/contract"
     runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
 )
 
@@ -144,6 +145,7 @@ func (instance *ParameterCommand) Run(
                 ValueString:      fmt.Sprintf("%v", parameter.Value()),
                 IsDefault:        parameter.IsDefault(),
                 Aliases:          aliases,
+                Source:           parameterSource(parameter),
             },
         )
     }
@@ -160,8 +162,8 @@ func (instance *ParameterCommand) Run(
 
         block := builder.AddBlock(
             "PARAMETERS",
-            []string{"parameter", "environmentKey", "environmentValue", "value", "default", "aliases"},
-        )
+            []string{"parameter", "environmentKey", "environmentValue", "value", "default", "aliases", "source"},
+        ).SetFields("name", "environmentKey", "environmentValue", "value", "isDefault", "aliases", "source")
 
         for _, item := range items {
             block.AddRow(
@@ -171,6 +173,7 @@ func (instance *ParameterCommand) Run(
                 item.ValueString,
                 fmt.Sprintf("%t", item.IsDefault),
                 item.Aliases,
+                item.Source,
             )
         }
 
@@ -196,6 +199,17 @@ type parameterListItem struct {
     ValueString      string `json:"value"`
     IsDefault        bool   `json:"isDefault"`
     Aliases          string `json:"aliases"`
+    Source           string `json:"source"`
+}
+
+/* @info parameterSource reports the environment source a value was loaded from (`yaml:config/app.yaml`, `secret:/run/secrets/db_password`, `default`, ...) */
+func parameterSource(parameter configcontract.Parameter) string {
+    sourcedParameter, isSourcedParameter := parameter.(configcontract.SourcedParameter)
+    if false == isSourcedParameter || "" == sourcedParameter.Source() {
+        return "-"
+    }
+
+    return sourcedParameter.Source()
 }
 
 var _ clicontract.Command = (*ParameterCommand)(nil)
@@ -0,0 +1,281 @@
+package diagnostics
+
+import (
+    "fmt"
+    "io"
+    nethttp "net/http"
+    "os"
+    "path/filepath"
+    "strconv"
+    "strings"
+    "time"
+
+    clicontract "github.com/precision-soft/melody/v3/cli/contract"
+    "github.com/precision-soft/melody/v3/cli/output"
+    "github.com/precision-soft/melody/v3/config"
+    "github.com/precision-soft/melody/v3/exception"
+    "github.com/precision-soft/melody/v3/httpclient"
+    httpclientcontract "github.com/precision-soft/melody/v3/httpclient/contract"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+const (
+    profileUrlFlagName     = "url"
+    profileTypeFlagName    = "type"
+    profileSecondsFlagName = "seconds"
+    profileBearerFlagName  = "bearer"
+    profileHeaderFlagName  = "header"
+    profileOutFlagName     = "out"
+
+    ProfileTypeCpu   = "cpu"
+    ProfileTypeTrace = "trace"
+
+    /* @info extra time allowed on top of the sampling window for the instance to answer and the body to arrive. */
+    profileRequestGrace = 30 * time.Second
+)
+
+func NewProfileCommand(pathPrefix string) *ProfileCommand {
+    return &ProfileCommand{
+        pathPrefix: pathPrefix,
+    }
+}
+
+/* @info ProfileCommand downloads a profile from the diagnostics routes of a running instance into `<cache dir>/profiles`. */
+type ProfileCommand struct {
+    pathPrefix string
+}
+
+func (instance *ProfileCommand) Name() string {
+    return "melody:debug:profile"
+}
+
+func (instance *ProfileCommand) Description() string {
+    return "Capture a cpu/heap/goroutine/... profile or an execution trace from a running instance"
+}
+
+func (instance *ProfileCommand) Flags() []clicontract.Flag {
+    return output.MergeFlags(
+        output.StandardFlags(),
+        []clicontract.Flag{
+            &clicontract.StringFlag{
+                Name:  profileUrlFlagName,
+                Usage: "base url of the running instance (e.g. http://127.0.0.1:8080)",
+                Value: "",
+            },
+            &clicontract.StringFlag{
+                Name:  profileTypeFlagName,
+                Usage: "cpu, trace or a runtime profile name (heap, goroutine, allocs, block, mutex, threadcreate)",
+                Value: ProfileTypeCpu,
+            },
+            &clicontract.IntFlag{
+                Name:  profileSecondsFlagName,
+                Usage: "sampling window of cpu profiles and traces, shorter than the server write timeout",
+                Value: 10,
+            },
+            &clicontract.StringFlag{
+                Name:  profileBearerFlagName,
+                Usage: "bearer token granted the diagnostics role",
+                Value: "",
+            },
+            &clicontract.StringSliceFlag{
+                Name:  profileHeaderFlagName,
+                Usage: "extra request header as \"Name: value\" (repeatable)",
+            },
+            &clicontract.StringFlag{
+                Name:  profileOutFlagName,
+                Usage: "file to write the profile to (default: <cache dir>/profiles/<type>-<time>.pprof)",
+                Value: "",
+            },
+        },
+    )
+}
+
+func (instance *ProfileCommand) Run(
+    runtimeInstance runtimecontract.Runtime,
+    commandContext *clicontract.CommandContext,
+) error {
+    startedAt := time.Now()
+
+    option := output.NormalizeOption(
+        output.ParseOptionFromCommand(commandContext),
+    )
+
+    baseUrl := strings.TrimRight(strings.TrimSpace(commandContext.String(profileUrlFlagName)), "/")
+    if "" == baseUrl {
+        return exception.NewError("the --url flag is required", nil, nil)
+    }
+
+    profileType := strings.TrimSpace(commandContext.String(profileTypeFlagName))
+    if "" == profileType || true == strings.ContainsAny(profileType, "/?#") {
+        return exception.NewError("invalid --type value", map[string]any{"type": profileType}, nil)
+    }
+    seconds := int(commandContext.Int(profileSecondsFlagName))
+    if 0 >= seconds {
+        return exception.NewError("the --seconds flag must be positive", map[string]any{"seconds": seconds}, nil)
+    }
+
+    requestOptions, headersErr := profileRequestOptions(
+        commandContext.String(profileBearerFlagName),
+        commandContext.StringSlice(profileHeaderFlagName),
+    )
+    if nil != headersErr {
+        return headersErr
+    }
+
+    profileUrl := baseUrl + instance.pathPrefix + "/pprof/" + profileEndpoint(profileType)
+    requestTimeout := profileRequestGrace
+    if ProfileTypeCpu == profileType || ProfileTypeTrace == profileType {
+        requestOptions = append(requestOptions, httpclient.WithQuery("seconds", strconv.Itoa(seconds)))
+        requestTimeout += time.Duration(seconds) * time.Second
+    }
+    requestOptions = append(requestOptions, httpclient.WithTimeout(requestTimeout))
+
+    out := strings.TrimSpace(commandContext.String(profileOutFlagName))
+    if "" == out {
+        configuration := config.ConfigMustFromContainer(runtimeInstance.Container())
+
+        out = filepath.Join(
+            configuration.Kernel().CacheDir(),
+            "profiles",
+            profileType+"-"+startedAt.Format("20060102-150405")+profileExtension(profileType),
+        )
+    }
+
+    size, downloadErr := downloadProfile(profileUrl, requestOptions, out)
+    if nil != downloadErr {
+        return downloadErr
+    }
+
+    meta := output.NewMeta(
+        instance.Name(),
+        commandContext.Args().Slice(),
+        option,
+        startedAt,
+        time.Duration(0),
+        output.Version{},
+    )
+
+    envelope := output.NewEnvelope(meta)
+
+    if output.FormatTable == option.Format {
+        builder := output.NewTableBuilder()
+
+        builder.AddSummaryLine(fmt.Sprintf("PROFILE: %s", profileType))
+        builder.AddSummaryLine(fmt.Sprintf("WROTE: %s (%d bytes)", out, size))
+        builder.AddSummaryLine(fmt.Sprintf("INSPECT: go tool %s %s", profileTool(profileType), out))
+
+        envelope.Table = builder.Build()
+    } else {
+        envelope.Data = map[string]any{
+            "type":  profileType,
+            "url":   profileUrl,
+            "path":  out,
+            "bytes": size,
+        }
+    }
+
+    envelope.Meta.DurationMilliseconds = time.Since(startedAt).Milliseconds()
+
+    return output.Render(commandContext.Writer, envelope, option)
+}
+
+func profileRequestOptions(bearer string, headers []string) ([]httpclientcontract.RequestOption, error) {
+    requestOptions := make([]httpclientcontract.RequestOption, 0, len(headers)+1)
+
+    if "" != strings.TrimSpace(bearer) {
+        requestOptions = append(requestOptions, httpclient.WithBearerToken(strings.TrimSpace(bearer)))
+    }
+
+    for _, header := range headers {
+        name, value, found := strings.Cut(header, ":")
+        if false == found || "" == strings.TrimSpace(name) {
+            return nil, exception.NewError("invalid --header value, expected \"Name: value\"", map[string]any{"header": header}, nil)
+        }
+
+        requestOptions = append(requestOptions, httpclient.WithHeader(strings.TrimSpace(name), strings.TrimSpace(value)))
+    }
+
+    return requestOptions, nil
+}
+
+/* downloadProfile streams the profile into a temporary sibling of out and renames it, so a failed capture leaves no partial file. */
+func downloadProfile(profileUrl string, requestOptions []httpclientcontract.RequestOption, out string) (int64, error) {
+    response, requestErr := httpclient.NewDefaultHttpClient().RequestStream(nethttp.MethodGet, profileUrl, requestOptions...)
+    if nil != requestErr {
+        return 0, requestErr
+    }
+    defer func() {
+        _ = response.Close()
+    }()
+
+    if nethttp.StatusOK != response.StatusCode() {
+        body, _ := io.ReadAll(io.LimitReader(response.Body(), 1024))
+
+        return 0, exception.NewError(
+            "the instance refused the profile request",
+            map[string]any{
+                "url":        profileUrl,
+                "statusCode": response.StatusCode(),
+                "body":       strings.TrimSpace(string(body)),
+            },
+            nil,
+        )
+    }
+
+    mkdirErr := os.MkdirAll(filepath.Dir(out), 0o755)
+    if nil != mkdirErr {
+        return 0, exception.NewError("could not create the profile directory", map[string]any{"out": out}, mkdirErr)
+    }
+
+    temporaryFile, createErr := os.CreateTemp(filepath.Dir(out), filepath.Base(out)+".*.tmp")
+    if nil != createErr {
+        return 0, exception.NewError("could not create the profile file", map[string]any{"out": out}, createErr)
+    }
+    temporaryPath := temporaryFile.Name()
+
+    size, copyErr := io.Copy(temporaryFile, response.Body())
+    closeErr := temporaryFile.Close()
+    if nil == copyErr {
+        copyErr = closeErr
+    }
+    if nil != copyErr {
+        _ = os.Remove(temporaryPath)
+
+        return 0, exception.NewError("could not download the profile", map[string]any{"url": profileUrl}, copyErr)
+    }
+
+    renameErr := os.Rename(temporaryPath, out)
+    if nil != renameErr {
+        _ = os.Remove(temporaryPath)
+
+        return 0, exception.NewError("could not write the profile file", map[string]any{"out": out}, renameErr)
+    }
+
+    return size, nil
+}
+
+func profileEndpoint(profileType string) string {
+    if ProfileTypeCpu == profileType {
+        return "profile"
+    }
+
+    return profileType
+}
+
+func profileExtension(profileType string) string {
+    if ProfileTypeTrace == profileType {
+        return ".trace"
+    }
+
+    return ".pprof"
+}
+
+func profileTool(profileType string) string {
+    if ProfileTypeTrace == profileType {
+        return "trace"
+    }
+
+    return "pprof"
+}
+
+var _ clicontract.Command = (*ProfileCommand)(nil)
@@ -0,0 +1,215 @@
+package diagnostics
+
+import (
+    "context"
+    "encoding/json"
+    nethttp "net/http"
+    "net/http/httptest"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/precision-soft/melody/v3/cache"
+    "github.com/precision-soft/melody/v3/clock"
+    "github.com/precision-soft/melody/v3/container"
+    containercontract "github.com/precision-soft/melody/v3/container/contract"
+    "github.com/precision-soft/melody/v3/http"
+    httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    "github.com/precision-soft/melody/v3/internal/testhelper"
+    "github.com/precision-soft/melody/v3/runtime"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+    "github.com/precision-soft/melody/v3/security"
+    securitycontract "github.com/precision-soft/melody/v3/security/contract"
+)
+
+func newDiagnosticsTestRuntime() runtimecontract.Runtime {
+    serviceContainer := container.NewContainer()
+
+    return runtime.New(context.Background(), serviceContainer.NewScope(), serviceContainer)
+}
+
+func newDiagnosticsTestFirewall() *security.CompiledFirewall {
+    return security.NewCompiledFirewall(
+        "main",
+        nil,
+        "m",
+        nil,
+        nil,
+        security.NewAccessControl(),
+        nil,
+        nil,
+        nil,
+        nil,
+        "",
+        "",
+        nil,
+        nil,
+        security.SourceNone,
+        security.SourceNone,
+        security.SourceNone,
+        security.SourceNone,
+        security.SourceNone,
+    )
+}
+
+func TestRoleGuard_RequiresAnAuthenticatedTokenGrantedTheRole(t *testing.T) {
+    for name, testCase := range map[string]struct {
+        token              securitycontract.Token
+        expectedStatusCode int
+    }{
+        "no security context": {token: nil, expectedStatusCode: nethttp.StatusUnauthorized},
+        "anonymous":           {token: security.NewAnonymousToken(), expectedStatusCode: nethttp.StatusUnauthorized},
+        "missing role":        {token: security.NewAuthenticatedToken("alice", []string{"ROLE_USER"}), expectedStatusCode: nethttp.StatusForbidden},
+        "granted":             {token: security.NewAuthenticatedToken("alice", []string{DefaultRole}), expectedStatusCode: nethttp.StatusNoContent},
+    } {
+        runtimeInstance := newDiagnosticsTestRuntime()
+        if nil != testCase.token {
+            security.SecurityContextSetOnRuntime(runtimeInstance, security.NewSecurityContext(newDiagnosticsTestFirewall(), testCase.token))
+        }
+
+        handler := RoleGuard(DefaultRole)(
+            func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+                return http.EmptyResponse(nethttp.StatusNoContent), nil
+            },
+        )
+
+        response, _ := handler(runtimeInstance, httptest.NewRecorder(), testhelper.NewHttpTestRequest(nethttp.MethodGet, DefaultPathPrefix+"/vars"))
+        if testCase.expectedStatusCode != response.StatusCode() {
+            t.Fatalf("%s: expected status %d, got %d", name, testCase.expectedStatusCode, response.StatusCode())
+        }
+    }
+}
+
+func TestCollectVars_ReportsHubsCachesAndStatistics(t *testing.T) {
+    backend := cache.NewInMemoryBackend(10, time.Hour, clock.NewFrozenClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+    defer backend.Close()
+
+    cacheInstance := cache.NewManager(backend, cache.NewJsonSerializer())
+    _ = cacheInstance.Set("a", "1", 0)
+
+    serviceContainer := container.NewContainer()
+    container.MustRegister[*cache.Manager](
+        serviceContainer,
+        cache.ServiceCache,
+        func(resolver containercontract.Resolver) (*cache.Manager, error) {
+            return cacheInstance, nil
+        },
+    )
+    runtimeInstance := runtime.New(context.Background(), serviceContainer.NewScope(), serviceContainer)
+
+    hub := http.NewServerSentEventHub()
+    _ = hub.Subscribe("demo", 1)
+
+    config := NewModuleConfig()
+    config.AddServerSentEventHub("events", hub)
+    config.AddStatistic("answer", func(runtimeInstance runtimecontract.Runtime) any { return 42 })
+
+    vars := collectVars(runtimeInstance, config)
+
+    if 1 != vars["serverSentEvents"].(map[string]any)["events"].(map[string]any)["subscribers"] {
+        t.Fatalf("unexpected server-sent-event vars: %v", vars["serverSentEvents"])
+    }
+
+    if 1 != vars["caches"].(map[string]any)[cache.ServiceCache].(map[string]any)["items"] {
+        t.Fatalf("unexpected cache vars: %v", vars["caches"])
+    }
+
+    if 42 != vars["statistics"].(map[string]any)["answer"] {
+        t.Fatalf("unexpected statistics: %v", vars["statistics"])
+    }
+
+    if 0 >= vars["runtime"].(map[string]any)["goroutines"].(int) {
+        t.Fatalf("expected goroutines to be reported")
+    }
+
+    if _, exists := vars["expvar"].(map[string]json.RawMessage)["memstats"]; false == exists {
+        t.Fatalf("expected the expvar memstats variable")
+    }
+}
+
+func TestPprofNamedHandler_ServesRuntimeProfilesOnly(t *testing.T) {
+    runtimeInstance := newDiagnosticsTestRuntime()
+
+    serve := func(name string) *httptest.ResponseRecorder {
+        httpRequest := httptest.NewRequest(nethttp.MethodGet, DefaultPathPrefix+"/pprof/"+name+"?debug=1", nil)
+        request := http.NewRequest(httpRequest, map[string]string{"name": name}, runtimeInstance, http.NewRequestContext("r1", time.Now()))
+
+        recorder := httptest.NewRecorder()
+        response, _ := pprofNamedHandler()(runtimeInstance, recorder, request)
+        if nil != response {
+            recorder.WriteHeader(response.StatusCode())
+        }
+
+        return recorder
+    }
+
+    goroutineRecorder := serve("goroutine")
+    if nethttp.StatusOK != goroutineRecorder.Code || false == strings.Contains(goroutineRecorder.Body.String(), "goroutine profile") {
+        t.Fatalf("expected the goroutine profile, got %d %q", goroutineRecorder.Code, goroutineRecorder.Body.String())
+    }
+
+    if nethttp.StatusNotFound != serve("unknown").Code {
+        t.Fatalf("expected unknown profiles to return 404")
+    }
+}
+
+func TestDownloadProfile_WritesTheProfileAndLeavesNothingOnFailure(t *testing.T) {
+    server := httptest.NewServer(nethttp.HandlerFunc(func(writer nethttp.ResponseWriter, request *nethttp.Request) {
+        if "Bearer secret" != request.Header.Get("Authorization") {
+            writer.WriteHeader(nethttp.StatusUnauthorized)
+            return
+        }
+
+        _, _ = writer.Write([]byte("profile-bytes"))
+    }))
+    defer server.Close()
+
+    directory := t.TempDir()
+    out := filepath.Join(directory, "profiles", "heap.pprof")
+
+    authorizedOptions, _ := profileRequestOptions("secret", []string{"X-Trace: 1"})
+    size, downloadErr := downloadProfile(server.URL, authorizedOptions, out)
+    if nil != downloadErr || 13 != size {
+        t.Fatalf("unexpected download result: %d %v", size, downloadErr)
+    }
+
+    content, _ := os.ReadFile(out)
+    if "profile-bytes" != string(content) {
+        t.Fatalf("unexpected profile content %q", content)
+    }
+
+    failedOut := filepath.Join(directory, "profiles", "refused.pprof")
+    if _, downloadErr := downloadProfile(server.URL, nil, failedOut); nil == downloadErr {
+        t.Fatalf("expected a refused request to fail")
+    }
+
+    entries, _ := os.ReadDir(filepath.Join(directory, "profiles"))
+    if 1 != len(entries) {
+        t.Fatalf("expected only the first profile on disk, got %d entries", len(entries))
+    }
+
+    if _, headerErr := profileRequestOptions("", []string{"missing-colon"}); nil == headerErr {
+        t.Fatalf("expected an invalid header to be rejected")
+    }
+}
+
+func TestRegisterPprofRoutes_PrefersStaticEndpointsOverNamedProfiles(t *testing.T) {
+    router := http.NewRouter()
+    group := router.Group(DefaultPathPrefix)
+    group.WithNamePrefix(routeNamePrefix)
+    registerPprofRoutes(group)
+
+    for path, expectedRouteName := range map[string]string{
+        DefaultPathPrefix + "/pprof":         routeNamePrefix + "pprof",
+        DefaultPathPrefix + "/pprof/profile": routeNamePrefix + "pprof.profile",
+        DefaultPathPrefix + "/pprof/trace":   routeNamePrefix + "pprof.trace",
+        DefaultPathPrefix + "/pprof/heap":    routeNamePrefix + "pprof.named",
+    } {
+        matchResult, matched := router.Match(nethttp.MethodGet, path, "localhost", "http")
+        if false == matched || expectedRouteName != matchResult.RouteAttributes[http.RouteAttributeName] {
+            t.Fatalf("expected %s to match %s, got %v", path, expectedRouteName, matchResult)
+        }
+    }
+}
@@ -0,0 +1,4 @@
+/*
+Package diagnostics provides an opt-in module serving runtime pprof profiles, execution traces and runtime statistics (goroutines, memory, GC, container services, server-sent-event subscribers, cache sizes, expvar) as Melody routes guarded by a role, and the `melody:debug:profile` command capturing a profile from a running instance.
+*/
+package diagnostics
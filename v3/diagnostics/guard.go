@@ -0,0 +1,31 @@
+package diagnostics
+
+import (
+    nethttp "net/http"
+
+    "github.com/precision-soft/melody/v3/http"
+    httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+    "github.com/precision-soft/melody/v3/security"
+)
+
+/*
+ * RoleGuard answers 401 when the request has no authenticated token and 403 when the token is not granted role. It
+ * fails closed: a request no firewall matched has no security context and is rejected.
+ */
+func RoleGuard(role string) httpcontract.Middleware {
+    return func(next httpcontract.Handler) httpcontract.Handler {
+        return func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            securityContext, exists := security.SecurityContextFromRuntime(runtimeInstance)
+            if false == exists || nil == securityContext.Token() || false == securityContext.Token().IsAuthenticated() {
+                return http.JsonErrorResponse(nethttp.StatusUnauthorized, "authentication required"), nil
+            }
+
+            if false == securityContext.IsGranted(role) {
+                return http.JsonErrorResponse(nethttp.StatusForbidden, "access denied"), nil
+            }
+
+            return next(runtimeInstance, writer, request)
+        }
+    }
+}
@@ -0,0 +1,107 @@
+package diagnostics
+
+import (
+    nethttp "net/http"
+    "strings"
+
+    applicationcontract "github.com/precision-soft/melody/v3/application/contract"
+    clicontract "github.com/precision-soft/melody/v3/cli/contract"
+    "github.com/precision-soft/melody/v3/exception"
+    kernelcontract "github.com/precision-soft/melody/v3/kernel/contract"
+    "github.com/precision-soft/melody/v3/security"
+)
+
+const routeNamePrefix = "melody.diagnostics."
+
+func NewModule(config *ModuleConfig) *Module {
+    if nil == config {
+        exception.Panic(exception.NewError("config is required for diagnostics module", nil, nil))
+    }
+
+    if false == strings.HasPrefix(config.PathPrefix(), "/") || "/" == config.PathPrefix() {
+        exception.Panic(
+            exception.NewError(
+                "invalid diagnostics path prefix",
+                map[string]any{
+                    "pathPrefix": config.PathPrefix(),
+                },
+                nil,
+            ),
+        )
+    }
+
+    if "" == strings.TrimSpace(config.Role()) {
+        exception.Panic(exception.NewError("role is required for diagnostics module", nil, nil))
+    }
+
+    for name, hub := range config.ServerSentEventHubs() {
+        if nil == hub {
+            exception.Panic(
+                exception.NewError("diagnostics server-sent-event hub is nil", map[string]any{"name": name}, nil),
+            )
+        }
+    }
+
+    for name, collector := range config.Statistics() {
+        if nil == collector {
+            exception.Panic(
+                exception.NewError("diagnostics statistic collector is nil", map[string]any{"name": name}, nil),
+            )
+        }
+    }
+
+    return &Module{
+        config: config,
+    }
+}
+
+type Module struct {
+    config *ModuleConfig
+}
+
+func (instance *Module) Name() string {
+    return "diagnostics"
+}
+
+func (instance *Module) Description() string {
+    return "registers the pprof, trace and runtime statistics routes and the profile capture command"
+}
+
+func (instance *Module) Config() *ModuleConfig {
+    return instance.config
+}
+
+/*
+ * AccessControlRule returns the rule requiring the configured role on the diagnostics path prefix. Add it to the
+ * access control of the firewall covering the prefix; the routes also check the role themselves (see RoleGuard) so
+ * they stay closed when no firewall matches.
+ */
+func (instance *Module) AccessControlRule() security.AccessControlRule {
+    return security.NewAccessControlRuleWithSegmentPrefix(instance.config.PathPrefix(), instance.config.Role())
+}
+
+func (instance *Module) RegisterHttpRoutes(kernelInstance kernelcontract.Kernel) {
+    group := kernelInstance.HttpRouter().Group(instance.config.PathPrefix())
+    group.WithNamePrefix(routeNamePrefix)
+    group.Use(RoleGuard(instance.config.Role()))
+
+    if true == instance.config.Pprof() {
+        registerPprofRoutes(group)
+    }
+
+    if true == instance.config.Vars() {
+        group.HandleNamed("vars", nethttp.MethodGet, "/vars", VarsHandler(instance.config))
+    }
+}
+
+func (instance *Module) RegisterCliCommands(kernelInstance kernelcontract.Kernel) []clicontract.Command {
+    return []clicontract.Command{
+        NewProfileCommand(instance.config.PathPrefix()),
+    }
+}
+
+var (
+    _ applicationcontract.Module     = (*Module)(nil)
+    _ applicationcontract.HttpModule = (*Module)(nil)
+    _ applicationcontract.CliModule  = (*Module)(nil)
+)
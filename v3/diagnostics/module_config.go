@@ -0,0 +1,89 @@
+package diagnostics
+
+import (
+    "github.com/precision-soft/melody/v3/cache"
+    "github.com/precision-soft/melody/v3/http"
+    "github.com/precision-soft/melody/v3/internal"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+const (
+    DefaultPathPrefix = "/_diagnostics"
+    DefaultRole       = "ROLE_ADMIN"
+)
+
+/* StatisticCollector returns a json-serializable value added to the `statistics` of the vars endpoint under its name. */
+type StatisticCollector func(runtimeInstance runtimecontract.Runtime) any
+
+type ModuleConfig struct {
+    pathPrefix          string
+    role                string
+    pprof               bool
+    vars                bool
+    serverSentEventHubs map[string]*http.ServerSentEventHub
+    cacheServiceNames   []string
+    statistics          map[string]StatisticCollector
+}
+
+/* @info defaults: routes under DefaultPathPrefix, DefaultRole required, pprof and vars enabled, the size of cache.ServiceCache reported. */
+func NewModuleConfig() *ModuleConfig {
+    return &ModuleConfig{
+        pathPrefix:          DefaultPathPrefix,
+        role:                DefaultRole,
+        pprof:               true,
+        vars:                true,
+        serverSentEventHubs: map[string]*http.ServerSentEventHub{},
+        cacheServiceNames:   []string{cache.ServiceCache},
+        statistics:          map[string]StatisticCollector{},
+    }
+}
+
+func (instance *ModuleConfig) PathPrefix() string { return instance.pathPrefix }
+
+func (instance *ModuleConfig) SetPathPrefix(pathPrefix string) {
+    instance.pathPrefix = pathPrefix
+}
+
+func (instance *ModuleConfig) Role() string { return instance.role }
+
+func (instance *ModuleConfig) SetRole(role string) {
+    instance.role = role
+}
+
+func (instance *ModuleConfig) Pprof() bool { return instance.pprof }
+
+/* SetPprof toggles the pprof and trace routes. */
+func (instance *ModuleConfig) SetPprof(pprof bool) {
+    instance.pprof = pprof
+}
+
+func (instance *ModuleConfig) Vars() bool { return instance.vars }
+
+func (instance *ModuleConfig) SetVars(vars bool) {
+    instance.vars = vars
+}
+
+func (instance *ModuleConfig) ServerSentEventHubs() map[string]*http.ServerSentEventHub {
+    return internal.CopyStringMap(instance.serverSentEventHubs)
+}
+
+func (instance *ModuleConfig) AddServerSentEventHub(name string, hub *http.ServerSentEventHub) {
+    instance.serverSentEventHubs[name] = hub
+}
+
+func (instance *ModuleConfig) CacheServiceNames() []string {
+    return append([]string{}, instance.cacheServiceNames...)
+}
+
+/* SetCacheServiceNames lists the cache services whose entry count is reported; caches without cachecontract.ItemCounter are skipped. */
+func (instance *ModuleConfig) SetCacheServiceNames(cacheServiceNames ...string) {
+    instance.cacheServiceNames = append([]string{}, cacheServiceNames...)
+}
+
+func (instance *ModuleConfig) Statistics() map[string]StatisticCollector {
+    return internal.CopyStringMap(instance.statistics)
+}
+
+func (instance *ModuleConfig) AddStatistic(name string, collector StatisticCollector) {
+    instance.statistics[name] = collector
+}
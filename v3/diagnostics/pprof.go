@@ -0,0 +1,97 @@
+package diagnostics
+
+import (
+    "bytes"
+    "html/template"
+    nethttp "net/http"
+    netpprof "net/http/pprof"
+    "runtime/pprof"
+    "strings"
+
+    "github.com/precision-soft/melody/v3/http"
+    httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+/*
+ * registerPprofRoutes serves the net/http/pprof handlers under `<prefix>/pprof`: `profile` (cpu, `?seconds=`),
+ * `trace` (`?seconds=`), `cmdline`, `symbol` and `:name` for the runtime profiles (heap, goroutine, allocs, block,
+ * mutex, threadcreate).
+ */
+func registerPprofRoutes(group httpcontract.RouteGroup) {
+    group.HandleNamed("pprof", nethttp.MethodGet, "/pprof", pprofIndexHandler())
+    group.HandleNamed("pprof.profile", nethttp.MethodGet, "/pprof/profile", wrapHttpHandler(nethttp.HandlerFunc(netpprof.Profile)))
+    group.HandleNamed("pprof.trace", nethttp.MethodGet, "/pprof/trace", wrapHttpHandler(nethttp.HandlerFunc(netpprof.Trace)))
+    group.HandleNamed("pprof.cmdline", nethttp.MethodGet, "/pprof/cmdline", wrapHttpHandler(nethttp.HandlerFunc(netpprof.Cmdline)))
+    group.HandleNamed("pprof.symbol", nethttp.MethodGet, "/pprof/symbol", wrapHttpHandler(nethttp.HandlerFunc(netpprof.Symbol)))
+    group.HandleNamed("pprof.symbol.lookup", nethttp.MethodPost, "/pprof/symbol", wrapHttpHandler(nethttp.HandlerFunc(netpprof.Symbol)))
+    group.HandleNamed("pprof.named", nethttp.MethodGet, "/pprof/:name", pprofNamedHandler())
+}
+
+/* wrapHttpHandler runs a net/http handler writing the response itself; the nil response leaves nothing for the kernel to write. */
+func wrapHttpHandler(handler nethttp.Handler) httpcontract.Handler {
+    return func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+        handler.ServeHTTP(writer, request.HttpRequest())
+
+        return nil, nil
+    }
+}
+
+func pprofNamedHandler() httpcontract.Handler {
+    return func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+        name, _ := request.Param("name")
+        if nil == pprof.Lookup(name) {
+            return http.JsonErrorResponse(nethttp.StatusNotFound, "unknown profile"), nil
+        }
+
+        netpprof.Handler(name).ServeHTTP(writer, request.HttpRequest())
+
+        return nil, nil
+    }
+}
+
+type pprofIndexEntry struct {
+    Name  string
+    Count int
+}
+
+func pprofIndexHandler() httpcontract.Handler {
+    return func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+        profiles := pprof.Profiles()
+
+        entries := make([]pprofIndexEntry, 0, len(profiles))
+        for _, profile := range profiles {
+            entries = append(entries, pprofIndexEntry{Name: profile.Name(), Count: profile.Count()})
+        }
+
+        var buffer bytes.Buffer
+        executeErr := pprofIndexTemplate.Execute(
+            &buffer,
+            map[string]any{
+                "path":     strings.TrimSuffix(request.HttpRequest().URL.Path, "/"),
+                "profiles": entries,
+            },
+        )
+        if nil != executeErr {
+            return nil, executeErr
+        }
+
+        return http.HtmlResponse(nethttp.StatusOK, buffer.String()), nil
+    }
+}
+
+var pprofIndexTemplate = template.Must(template.New("pprof").Parse(`<!doctype html>
+<html><head><meta charset="utf-8"><title>pprof</title></head>
+<body>
+<h1>pprof</h1>
+<table>
+<tr><th>profile</th><th>count</th></tr>
+{{range .profiles}}<tr><td><a href="{{$.path}}/{{.Name}}?debug=1">{{.Name}}</a></td><td>{{.Count}}</td></tr>
+{{end}}</table>
+<ul>
+<li><a href="{{.path}}/profile?seconds=10">cpu profile (10s)</a></li>
+<li><a href="{{.path}}/trace?seconds=5">execution trace (5s)</a></li>
+<li><a href="{{.path}}/cmdline">command line</a></li>
+</ul>
+</body></html>
+`))
@@ -0,0 +1,126 @@
+package diagnostics
+
+import (
+    "encoding/json"
+    "expvar"
+    nethttp "net/http"
+    "runtime"
+    "time"
+
+    "github.com/precision-soft/melody/v3/cache"
+    "github.com/precision-soft/melody/v3/http"
+    httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+/*
+ * VarsHandler serves the runtime statistics as json: runtime, memory and gc figures, the container service count, the
+ * configured server-sent-event hubs and caches, the custom statistics and every published expvar variable.
+ */
+func VarsHandler(config *ModuleConfig) httpcontract.Handler {
+    return func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+        return http.JsonResponse(nethttp.StatusOK, collectVars(runtimeInstance, config))
+    }
+}
+
+func collectVars(runtimeInstance runtimecontract.Runtime, config *ModuleConfig) map[string]any {
+    var memStats runtime.MemStats
+    runtime.ReadMemStats(&memStats)
+
+    lastGc := ""
+    if 0 < memStats.LastGC {
+        lastGc = time.Unix(0, int64(memStats.LastGC)).UTC().Format(time.RFC3339Nano)
+    }
+
+    return map[string]any{
+        "runtime": map[string]any{
+            "goVersion":  runtime.Version(),
+            "goroutines": runtime.NumGoroutine(),
+            "cpus":       runtime.NumCPU(),
+            "gomaxprocs": runtime.GOMAXPROCS(0),
+        },
+        "memory": map[string]any{
+            "alloc":       memStats.Alloc,
+            "totalAlloc":  memStats.TotalAlloc,
+            "sys":         memStats.Sys,
+            "heapAlloc":   memStats.HeapAlloc,
+            "heapInuse":   memStats.HeapInuse,
+            "heapObjects": memStats.HeapObjects,
+            "stackInuse":  memStats.StackInuse,
+            "mallocs":     memStats.Mallocs,
+            "frees":       memStats.Frees,
+        },
+        "gc": map[string]any{
+            "count":           memStats.NumGC,
+            "forcedCount":     memStats.NumForcedGC,
+            "pauseTotal":      time.Duration(memStats.PauseTotalNs),
+            "lastGc":          lastGc,
+            "cpuFraction":     memStats.GCCPUFraction,
+            "lastPause":       time.Duration(memStats.PauseNs[(memStats.NumGC+255)%256]),
+            "nextGcHeapBytes": memStats.NextGC,
+        },
+        "container": map[string]any{
+            "services": len(runtimeInstance.Container().Names()),
+        },
+        "serverSentEvents": collectServerSentEventVars(config),
+        "caches":           collectCacheVars(runtimeInstance, config),
+        "statistics":       collectStatistics(runtimeInstance, config),
+        "expvar":           collectExpvars(),
+    }
+}
+
+func collectServerSentEventVars(config *ModuleConfig) map[string]any {
+    hubs := map[string]any{}
+    for name, hub := range config.ServerSentEventHubs() {
+        hubs[name] = map[string]any{
+            "subscribers":       hub.TotalSubscriberCount(),
+            "droppedEvents":     hub.DroppedEventCount(),
+            "backplaneFailures": hub.BackplaneFailures(),
+        }
+    }
+
+    return hubs
+}
+
+func collectCacheVars(runtimeInstance runtimecontract.Runtime, config *ModuleConfig) map[string]any {
+    caches := map[string]any{}
+    for _, serviceName := range config.CacheServiceNames() {
+        if false == runtimeInstance.Container().Has(serviceName) {
+            continue
+        }
+
+        cacheInstance, getErr := runtimeInstance.Container().Get(serviceName)
+        if nil != getErr {
+            caches[serviceName] = map[string]any{"error": getErr.Error()}
+            continue
+        }
+
+        itemCount, supported := cache.ItemCount(cacheInstance)
+        if false == supported {
+            continue
+        }
+
+        caches[serviceName] = map[string]any{"items": itemCount}
+    }
+
+    return caches
+}
+
+func collectStatistics(runtimeInstance runtimecontract.Runtime, config *ModuleConfig) map[string]any {
+    statistics := map[string]any{}
+    for name, collector := range config.Statistics() {
+        statistics[name] = collector(runtimeInstance)
+    }
+
+    return statistics
+}
+
+/* collectExpvars embeds every published expvar variable (memstats and cmdline included) as its raw json value. */
+func collectExpvars() map[string]json.RawMessage {
+    variables := map[string]json.RawMessage{}
+    expvar.Do(func(keyValue expvar.KeyValue) {
+        variables[keyValue.Key] = json.RawMessage(keyValue.Value.String())
+    })
+
+    return variables
+}
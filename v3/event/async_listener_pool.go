@@ -0,0 +1,127 @@
+package event
+
+import (
+    "context"
+    "sync"
+
+    "github.com/precision-soft/melody/v3/exception"
+    exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+)
+
+func NewAsyncListenerPool(workerCount int, queueCapacity int) *AsyncListenerPool {
+    if 1 > workerCount {
+        exception.Panic(
+            exception.NewError(
+                "async listener pool requires at least one worker",
+                exceptioncontract.Context{
+                    "workerCount": workerCount,
+                },
+                nil,
+            ),
+        )
+    }
+
+    if 0 > queueCapacity {
+        exception.Panic(
+            exception.NewError(
+                "async listener pool queue capacity may not be negative",
+                exceptioncontract.Context{
+                    "queueCapacity": queueCapacity,
+                },
+                nil,
+            ),
+        )
+    }
+
+    pool := &AsyncListenerPool{
+        workerCount: workerCount,
+        queue:       make(chan func(), queueCapacity),
+        draining:    make(chan struct{}),
+        finished:    make(chan struct{}),
+    }
+
+    pool.workerGroup.Add(workerCount)
+    for index := 0; index < workerCount; index++ {
+        go pool.work()
+    }
+
+    go func() {
+        pool.workerGroup.Wait()
+        close(pool.finished)
+    }()
+
+    return pool
+}
+
+type AsyncListenerPool struct {
+    mutex          sync.RWMutex
+    workerCount    int
+    queue          chan func()
+    workerGroup    sync.WaitGroup
+    submitterGroup sync.WaitGroup
+    draining       chan struct{}
+    finished       chan struct{}
+    isDraining     bool
+}
+
+func (instance *AsyncListenerPool) WorkerCount() int {
+    return instance.workerCount
+}
+
+/* Submit blocks while the queue is full (back-pressure) and reports false once the pool is draining, in which case the caller owns running the task. */
+func (instance *AsyncListenerPool) Submit(task func()) bool {
+    instance.mutex.RLock()
+    if true == instance.isDraining {
+        instance.mutex.RUnlock()
+
+        return false
+    }
+    instance.submitterGroup.Add(1)
+    instance.mutex.RUnlock()
+
+    defer instance.submitterGroup.Done()
+
+    /* @important the lock is not held while blocked on a full queue, so Drain can start and release blocked submitters */
+    select {
+    case instance.queue <- task:
+        return true
+    case <-instance.draining:
+        return false
+    }
+}
+
+/* Drain stops accepting tasks, then waits until every queued task finished or the context is done. */
+func (instance *AsyncListenerPool) Drain(ctx context.Context) error {
+    instance.mutex.Lock()
+    if false == instance.isDraining {
+        instance.isDraining = true
+        close(instance.draining)
+
+        go func() {
+            instance.submitterGroup.Wait()
+            close(instance.queue)
+        }()
+    }
+    instance.mutex.Unlock()
+
+    select {
+    case <-instance.finished:
+        return nil
+    case <-ctx.Done():
+        return exception.NewError(
+            "async listener pool drain timed out",
+            exceptioncontract.Context{
+                "pendingTasks": len(instance.queue),
+            },
+            ctx.Err(),
+        )
+    }
+}
+
+func (instance *AsyncListenerPool) work() {
+    defer instance.workerGroup.Done()
+
+    for task := range instance.queue {
+        task()
+    }
+}
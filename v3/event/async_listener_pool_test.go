@@ -0,0 +1,279 @@
+package event
+
+import (
+    "context"
+    "errors"
+    "sync"
+    "sync/atomic"
+    "testing"
+    "time"
+
+    eventcontract "github.com/precision-soft/melody/v3/event/contract"
+    "github.com/precision-soft/melody/v3/internal/testhelper"
+    "github.com/precision-soft/melody/v3/runtime"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+func TestNewAsyncListenerPool_PanicsOnInvalidInput(t *testing.T) {
+    testhelper.AssertPanics(t, func() {
+        _ = NewAsyncListenerPool(0, 1)
+    })
+
+    testhelper.AssertPanics(t, func() {
+        _ = NewAsyncListenerPool(1, -1)
+    })
+}
+
+func TestAsyncListenerPool_DrainWaitsForQueuedTasks(t *testing.T) {
+    pool := NewAsyncListenerPool(2, 10)
+
+    var completed atomic.Int32
+    for index := 0; index < 5; index++ {
+        submitted := pool.Submit(func() {
+            time.Sleep(5 * time.Millisecond)
+            completed.Add(1)
+        })
+        if false == submitted {
+            t.Fatalf("expected task to be accepted")
+        }
+    }
+
+    drainErr := pool.Drain(context.Background())
+    if nil != drainErr {
+        t.Fatalf("unexpected drain error: %v", drainErr)
+    }
+
+    if 5 != completed.Load() {
+        t.Fatalf("expected 5 completed tasks, got: %d", completed.Load())
+    }
+
+    if true == pool.Submit(func() {}) {
+        t.Fatalf("expected submit to be rejected after drain")
+    }
+}
+
+func TestAsyncListenerPool_DrainTimesOut(t *testing.T) {
+    pool := NewAsyncListenerPool(1, 1)
+
+    release := make(chan struct{})
+    _ = pool.Submit(func() {
+        <-release
+    })
+
+    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+    defer cancel()
+
+    drainErr := pool.Drain(ctx)
+    if nil == drainErr {
+        t.Fatalf("expected drain timeout error")
+    }
+
+    close(release)
+
+    drainErr = pool.Drain(context.Background())
+    if nil != drainErr {
+        t.Fatalf("unexpected drain error: %v", drainErr)
+    }
+}
+
+func TestAsyncListenerPool_DrainHonoursDeadlineWithBlockedSubmitter(t *testing.T) {
+    pool := NewAsyncListenerPool(1, 1)
+
+    release := make(chan struct{})
+    started := make(chan struct{})
+    _ = pool.Submit(func() {
+        close(started)
+        <-release
+    })
+    <-started
+
+    _ = pool.Submit(func() {})
+
+    blockedSubmit := make(chan bool)
+    go func() {
+        blockedSubmit <- pool.Submit(func() {})
+    }()
+
+    time.Sleep(10 * time.Millisecond)
+
+    ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+    defer cancel()
+
+    drainResult := make(chan error)
+    go func() {
+        drainResult <- pool.Drain(ctx)
+    }()
+
+    select {
+    case drainErr := <-drainResult:
+        if nil == drainErr {
+            t.Fatalf("expected drain timeout error")
+        }
+    case <-time.After(time.Second):
+        t.Fatalf("expected drain to return once its deadline passed")
+    }
+
+    select {
+    case submitted := <-blockedSubmit:
+        if true == submitted {
+            t.Fatalf("expected blocked submit to be rejected once the pool is draining")
+        }
+    case <-time.After(time.Second):
+        t.Fatalf("expected blocked submit to be released by drain")
+    }
+
+    close(release)
+
+    drainErr := pool.Drain(context.Background())
+    if nil != drainErr {
+        t.Fatalf("unexpected drain error: %v", drainErr)
+    }
+}
+
+func TestEventDispatcher_AddAsyncListener_PanicsWithoutPool(t *testing.T) {
+    dispatcher, _ := testNewEventDispatcher()
+
+    testhelper.AssertPanics(t, func() {
+        _ = dispatcher.AddAsyncListener(
+            "test",
+            func(runtimeInstance runtimecontract.Runtime, eventValue eventcontract.Event) error {
+                return nil
+            },
+            0,
+        )
+    })
+}
+
+func TestEventDispatcher_AsyncListener_DoesNotBlockOrFailDispatch(t *testing.T) {
+    dispatcher, _ := testNewEventDispatcher()
+    dispatcher.EnableAsyncListeners(NewAsyncListenerPool(1, 10))
+
+    release := make(chan struct{})
+    var asyncWaitGroup sync.WaitGroup
+    asyncWaitGroup.Add(1)
+
+    var asyncContextErr error
+    var asyncScopeIsDetached bool
+
+    callerRuntime := newEventDispatcherAdapterTestRuntime(t)
+    callerContext, cancelCaller := context.WithCancel(context.Background())
+    defer cancelCaller()
+
+    _ = dispatcher.AddAsyncListener(
+        "test",
+        func(runtimeInstance runtimecontract.Runtime, eventValue eventcontract.Event) error {
+            defer asyncWaitGroup.Done()
+
+            <-release
+
+            asyncContextErr = runtimeInstance.Context().Err()
+            asyncScopeIsDetached = runtimeInstance.Scope() != callerRuntime.Scope()
+
+            return errors.New("async failure")
+        },
+        10,
+    )
+
+    syncInvoked := false
+    _ = dispatcher.AddListener(
+        "test",
+        func(runtimeInstance runtimecontract.Runtime, eventValue eventcontract.Event) error {
+            syncInvoked = true
+            return nil
+        },
+        0,
+    )
+
+    _, dispatchErr := dispatcher.Dispatch(
+        runtime.New(callerContext, callerRuntime.Scope(), callerRuntime.Container()),
+        NewEvent("test", nil, dispatcher.clock),
+    )
+    if nil != dispatchErr {
+        t.Fatalf("expected async listener error to not fail dispatch, got: %v", dispatchErr)
+    }
+
+    if false == syncInvoked {
+        t.Fatalf("expected sync listener to run before async listener finished")
+    }
+
+    cancelCaller()
+    close(release)
+    asyncWaitGroup.Wait()
+
+    if nil != asyncContextErr {
+        t.Fatalf("expected async listener context to outlive the caller, got: %v", asyncContextErr)
+    }
+
+    if false == asyncScopeIsDetached {
+        t.Fatalf("expected async listener to run in its own scope")
+    }
+
+    drainErr := dispatcher.Drain(context.Background())
+    if nil != drainErr {
+        t.Fatalf("unexpected drain error: %v", drainErr)
+    }
+}
+
+func TestEventDispatcher_AsyncListener_RunsInlineWhileDraining(t *testing.T) {
+    dispatcher, _ := testNewEventDispatcher()
+    dispatcher.EnableAsyncListeners(NewAsyncListenerPool(1, 0))
+
+    invoked := false
+    _ = dispatcher.AddAsyncListener(
+        "test",
+        func(runtimeInstance runtimecontract.Runtime, eventValue eventcontract.Event) error {
+            invoked = true
+            return nil
+        },
+        0,
+    )
+
+    drainErr := dispatcher.Drain(context.Background())
+    if nil != drainErr {
+        t.Fatalf("unexpected drain error: %v", drainErr)
+    }
+
+    _, dispatchErr := dispatcher.DispatchName(newEventDispatcherAdapterTestRuntime(t), "test", nil)
+    if nil != dispatchErr {
+        t.Fatalf("unexpected dispatch error: %v", dispatchErr)
+    }
+
+    if false == invoked {
+        t.Fatalf("expected listener to run synchronously once the pool is draining")
+    }
+}
+
+func TestEventDispatcher_AsyncSubscribedEvent_ReportsAsyncMode(t *testing.T) {
+    dispatcher, _ := testNewEventDispatcher()
+    dispatcher.EnableAsyncListeners(NewAsyncListenerPool(1, 1))
+
+    listener := func(runtimeInstance runtimecontract.Runtime, eventValue eventcontract.Event) error {
+        return nil
+    }
+
+    dispatcher.AddSubscriber(
+        &testSubscriber{
+            events: map[string][]eventcontract.SubscribedEvent{
+                "test": {
+                    NewSubscribedEvent(listener, 10),
+                    NewAsyncSubscribedEvent(listener, 0),
+                },
+            },
+        },
+    )
+
+    registeredEvents := dispatcher.RegisteredEvents()
+    if 1 != len(registeredEvents) || 2 != len(registeredEvents[0].Listeners) {
+        t.Fatalf("unexpected registered events: %#v", registeredEvents)
+    }
+
+    if eventcontract.RegisteredListenerModeSync != registeredEvents[0].Listeners[0].Mode {
+        t.Fatalf("expected first listener to be sync, got: %s", registeredEvents[0].Listeners[0].Mode)
+    }
+
+    if eventcontract.RegisteredListenerModeAsync != registeredEvents[0].Listeners[1].Mode {
+        t.Fatalf("expected second listener to be async, got: %s", registeredEvents[0].Listeners[1].Mode)
+    }
+
+    _ = dispatcher.Drain(context.Background())
+}
@@ -0,0 +1,16 @@
+package contract
+
+import (
+    "context"
+)
+
+type AsyncEventDispatcher interface {
+    AddAsyncListener(eventName string, listener EventListener, priority int) ListenerRegistration
+
+    Drain(ctx context.Context) error
+}
+
+/* NamedAsyncListenerDispatcher is the async counterpart of NamedListenerDispatcher, so a wrapped async listener is reported under the function it wraps. */
+type NamedAsyncListenerDispatcher interface {
+    AddNamedAsyncListener(eventName string, listenerName string, listener EventListener, priority int) ListenerRegistration
+}
@@ -22,3 +22,8 @@ type EventDispatcher interface {
 
     DispatchName(runtimeInstance runtimecontract.Runtime, eventName string, payload any) (Event, error)
 }
+
+/* NamedListenerDispatcher lets wrapping helpers (typed listeners, adapters) register a listener under the name of the function they wrap, so introspection does not report the wrapper. */
+type NamedListenerDispatcher interface {
+    AddNamedListener(eventName string, listenerName string, listener EventListener, priority int) ListenerRegistration
+}
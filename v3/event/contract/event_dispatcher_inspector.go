@@ -5,8 +5,9 @@ type EventDispatcherInspector interface {
 }
 
 type RegisteredEvent struct {
-    EventName string               `json:"eventName"`
-    Listeners []RegisteredListener `json:"listeners"`
+    EventName   string               `json:"eventName"`
+    PayloadType string               `json:"payloadType"`
+    Listeners   []RegisteredListener `json:"listeners"`
 }
 
 type RegisteredListener struct {
@@ -15,9 +16,15 @@ type RegisteredListener struct {
     Owner        string `json:"owner"`
     ListenerId   string `json:"listenerId"`
     ListenerName string `json:"listenerName"`
+    Mode         string `json:"mode"`
 }
 
 const (
     RegisteredListenerSourceListener   = "listener"
     RegisteredListenerSourceSubscriber = "subscriber"
 )
+
+const (
+    RegisteredListenerModeSync  = "sync"
+    RegisteredListenerModeAsync = "async"
+)
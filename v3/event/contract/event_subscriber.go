@@ -6,6 +6,18 @@ type SubscribedEvent interface {
     Priority() int
 }
 
+type AsyncSubscribedEvent interface {
+    SubscribedEvent
+
+    IsAsync() bool
+}
+
+type NamedSubscribedEvent interface {
+    SubscribedEvent
+
+    ListenerName() string
+}
+
 type EventSubscriber interface {
     SubscribedEvents() map[string][]SubscribedEvent
 }
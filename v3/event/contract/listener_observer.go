@@ -0,0 +1,23 @@
+package contract
+
+import (
+    "time"
+
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+/* ListenerObserver is called after every synchronous listener call of a dispatch; it must be cheap and safe for concurrent use. */
+type ListenerObserver func(runtimeInstance runtimecontract.Runtime, listenerCall ListenerCall)
+
+type ListenerCall struct {
+    EventName    string
+    ListenerName string
+    Priority     int
+    StartedAt    time.Time
+    Duration     time.Duration
+    Err          error
+}
+
+type ObservableEventDispatcher interface {
+    AddListenerObserver(observer ListenerObserver)
+}
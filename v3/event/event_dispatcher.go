@@ -1,9 +1,10 @@
 package event
 
 import (
+    "context"
     "fmt"
     "reflect"
-    "runtime"
+    runtimepkg "runtime"
     "runtime/debug"
     "sort"
     "sync"
@@ -16,6 +17,7 @@
     "github.com/precision-soft/melody/v3/internal"
     "github.com/precision-soft/melody/v3/logging"
     loggingcontract "github.com/precision-soft/melody/v3/logging/contract"
+    "github.com/precision-soft/melody/v3/runtime"
     runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
 )
 
@@ -39,12 +41,91 @@ type EventDispatcher struct {
     subscriberRegistrations map[uintptr][]subscriberRegistration
     clock                   clockcontract.Clock
     nextListenerId          uint64
+    asyncListenerPool       *AsyncListenerPool
+    listenerObservers       []eventcontract.ListenerObserver
 }
 
 func (instance *EventDispatcher) AddListener(
     eventName string,
     listener eventcontract.EventListener,
     priority int,
+) eventcontract.ListenerRegistration {
+    return instance.addListener(eventName, "", listener, priority, false)
+}
+
+func (instance *EventDispatcher) AddNamedListener(
+    eventName string,
+    listenerName string,
+    listener eventcontract.EventListener,
+    priority int,
+) eventcontract.ListenerRegistration {
+    return instance.addListener(eventName, listenerName, listener, priority, false)
+}
+
+func (instance *EventDispatcher) AddAsyncListener(
+    eventName string,
+    listener eventcontract.EventListener,
+    priority int,
+) eventcontract.ListenerRegistration {
+    return instance.AddNamedAsyncListener(eventName, "", listener, priority)
+}
+
+func (instance *EventDispatcher) AddNamedAsyncListener(
+    eventName string,
+    listenerName string,
+    listener eventcontract.EventListener,
+    priority int,
+) eventcontract.ListenerRegistration {
+    instance.requireAsyncListenerPool(eventName)
+
+    return instance.addListener(eventName, listenerName, listener, priority, true)
+}
+
+/* AddListenerObserver registers an observer notified after every synchronous listener call (e.g. the profiler timeline). */
+func (instance *EventDispatcher) AddListenerObserver(observer eventcontract.ListenerObserver) {
+    if nil == observer {
+        exception.Panic(
+            exception.NewError("listener observer may not be nil", nil, nil),
+        )
+    }
+
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.listenerObservers = append(instance.listenerObservers, observer)
+}
+
+func (instance *EventDispatcher) EnableAsyncListeners(asyncListenerPool *AsyncListenerPool) {
+    if nil == asyncListenerPool {
+        exception.Panic(
+            exception.NewError("async listener pool may not be nil", nil, nil),
+        )
+    }
+
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.asyncListenerPool = asyncListenerPool
+}
+
+func (instance *EventDispatcher) Drain(ctx context.Context) error {
+    instance.mutex.RLock()
+    asyncListenerPool := instance.asyncListenerPool
+    instance.mutex.RUnlock()
+
+    if nil == asyncListenerPool {
+        return nil
+    }
+
+    return asyncListenerPool.Drain(ctx)
+}
+
+func (instance *EventDispatcher) addListener(
+    eventName string,
+    listenerName string,
+    listener eventcontract.EventListener,
+    priority int,
+    isAsync bool,
 ) eventcontract.ListenerRegistration {
     if "" == eventName {
         exception.Panic(
@@ -64,6 +145,10 @@ func (instance *EventDispatcher) AddListener(
         )
     }
 
+    if "" == listenerName {
+        listenerName = listenerFunctionName(listener)
+    }
+
     instance.mutex.Lock()
     instance.nextListenerId++
     listenerId := instance.nextListenerId
@@ -71,9 +156,11 @@ func (instance *EventDispatcher) AddListener(
     instance.listeners[eventName] = append(
         instance.listeners[eventName],
         listenerWithPriority{
-            listener:   listener,
-            listenerId: listenerId,
-            priority:   priority,
+            listener:     listener,
+            listenerName: listenerName,
+            listenerId:   listenerId,
+            priority:     priority,
+            isAsync:      isAsync,
         },
     )
 
@@ -228,10 +315,28 @@ func (instance *EventDispatcher) AddSubscriber(subscriber eventcontract.EventSub
                 )
             }
 
-            registration := instance.AddListener(
+            isAsync := false
+            asyncSubscribedEvent, isAsyncSubscribedEvent := subscribedEvent.(eventcontract.AsyncSubscribedEvent)
+            if true == isAsyncSubscribedEvent {
+                isAsync = asyncSubscribedEvent.IsAsync()
+            }
+
+            if true == isAsync {
+                instance.requireAsyncListenerPool(eventName)
+            }
+
+            listenerName := ""
+            namedSubscribedEvent, isNamedSubscribedEvent := subscribedEvent.(eventcontract.NamedSubscribedEvent)
+            if true == isNamedSubscribedEvent {
+                listenerName = namedSubscribedEvent.ListenerName()
+            }
+
+            registration := instance.addListener(
                 eventName,
+                listenerName,
                 listener,
                 subscribedEvent.Priority(),
+                isAsync,
             )
 
             subscriberType := reflect.TypeOf(subscriber).String()
@@ -353,11 +458,9 @@ func (instance *EventDispatcher) RegisteredEvents() []eventcontract.RegisteredEv
 
             listenerId := fmt.Sprintf("%d", entry.listenerId)
 
-            listenerName := "-"
-            listenerProgramCounter := reflect.ValueOf(entry.listener).Pointer()
-            function := runtime.FuncForPC(listenerProgramCounter)
-            if nil != function {
-                listenerName = function.Name()
+            mode := eventcontract.RegisteredListenerModeSync
+            if true == entry.isAsync {
+                mode = eventcontract.RegisteredListenerModeAsync
             }
 
             registeredListenerList = append(
@@ -367,7 +470,8 @@ function := runtime.FuncForPC(listenerProgramCounter)
                     Source:       source,
                     Owner:        owner,
                     ListenerId:   listenerId,
-                    ListenerName: listenerName,
+                    ListenerName: entry.listenerName,
+                    Mode:         mode,
                 },
             )
         }
@@ -375,8 +479,9 @@ function := runtime.FuncForPC(listenerProgramCounter)
         registeredEvents = append(
             registeredEvents,
             eventcontract.RegisteredEvent{
-                EventName: eventName,
-                Listeners: registeredListenerList,
+                EventName:   eventName,
+                PayloadType: typedEventPayloadType(eventName),
+                Listeners:   registeredListenerList,
             },
         )
     }
@@ -445,6 +550,7 @@ func (instance *EventDispatcher) dispatch(runtimeInstance runtimecontract.Runtim
     instance.mutex.RLock()
     listenerList := instance.listeners[eventName]
     listenerListSnapshot := append([]listenerWithPriority(nil), listenerList...)
+    listenerObservers := instance.listenerObservers
     instance.mutex.RUnlock()
 
     listenerList = listenerListSnapshot
@@ -464,11 +570,19 @@ func (instance *EventDispatcher) dispatch(runtimeInstance runtimecontract.Runtim
     for _, entry := range listenerList {
         listenerStartedAt := time.Now()
 
-        listenerName := "-"
-        listenerProgramCounter := reflect.ValueOf(entry.listener).Pointer()
-        function := runtime.FuncForPC(listenerProgramCounter)
-        if nil != function {
-            listenerName = function.Name()
+        listenerName := entry.listenerName
+
+        if true == entry.isAsync {
+            instance.dispatchAsync(
+                runtimeInstance,
+                eventName,
+                eventValue,
+                entry,
+                listenerName,
+                logger,
+            )
+
+            continue
         }
 
         logger.Debug(
@@ -490,6 +604,22 @@ function := runtime.FuncForPC(listenerProgramCounter)
             listenerStartedAt,
             logger,
         )
+
+        if 0 < len(listenerObservers) {
+            listenerCall := eventcontract.ListenerCall{
+                EventName:    eventName,
+                ListenerName: listenerName,
+                Priority:     entry.priority,
+                StartedAt:    listenerStartedAt,
+                Duration:     time.Since(listenerStartedAt),
+                Err:          err,
+            }
+
+            for _, listenerObserver := range listenerObservers {
+                listenerObserver(runtimeInstance, listenerCall)
+            }
+        }
+
         if nil != err {
             return eventValue, err
         }
@@ -517,6 +647,97 @@ function := runtime.FuncForPC(listenerProgramCounter)
     return eventValue, nil
 }
 
+func (instance *EventDispatcher) requireAsyncListenerPool(eventName string) {
+    instance.mutex.RLock()
+    asyncListenerPool := instance.asyncListenerPool
+    instance.mutex.RUnlock()
+
+    if nil == asyncListenerPool {
+        exception.Panic(
+            exception.NewError(
+                "async listeners are not enabled on the event dispatcher",
+                exceptioncontract.Context{
+                    "eventName": eventName,
+                },
+                nil,
+            ),
+        )
+    }
+}
+
+/* dispatchAsync hands the listener a copy of the event and a runtime detached from the caller: the caller's scope is closed when its request ends, so the task runs in its own scope with the caller's logger and an uncancelled context. */
+func (instance *EventDispatcher) dispatchAsync(
+    runtimeInstance runtimecontract.Runtime,
+    eventName string,
+    eventValue eventcontract.Event,
+    entry listenerWithPriority,
+    listenerName string,
+    logger loggingcontract.Logger,
+) {
+    instance.mutex.RLock()
+    asyncListenerPool := instance.asyncListenerPool
+    instance.mutex.RUnlock()
+
+    asyncEvent := NewEventFromEvent(eventValue)
+
+    task := func() {
+        serviceContainer := runtimeInstance.Container()
+
+        asyncScope := serviceContainer.NewScope()
+        defer func() {
+            scopeCloseErr := asyncScope.Close()
+            if nil != scopeCloseErr {
+                logger.Error("failed to close async listener scope", exception.LogContext(scopeCloseErr))
+            }
+        }()
+
+        overrideErr := asyncScope.OverrideProtectedInstance(logging.ServiceLogger, logger)
+        if nil != overrideErr {
+            logger.Error("failed to override async listener logger", exception.LogContext(overrideErr))
+        }
+
+        asyncRuntime := runtime.New(
+            context.WithoutCancel(runtimeInstance.Context()),
+            asyncScope,
+            serviceContainer,
+        )
+
+        logger.Debug(
+            "async event listener started",
+            loggingcontract.Context{
+                "eventName":        eventName,
+                "listenerName":     listenerName,
+                "listenerPriority": entry.priority,
+            },
+        )
+
+        _ = instance.callListenerSafely(
+            asyncRuntime,
+            eventName,
+            asyncEvent,
+            entry.listener,
+            listenerName,
+            entry.priority,
+            instance.clock.Now(),
+            logger,
+        )
+    }
+
+    if nil != asyncListenerPool && true == asyncListenerPool.Submit(task) {
+        return
+    }
+
+    logger.Warning(
+        "async listener pool is not accepting tasks, running listener synchronously",
+        loggingcontract.Context{
+            "eventName":    eventName,
+            "listenerName": listenerName,
+        },
+    )
+
+    task()
+}
+
 func (instance *EventDispatcher) callListenerSafely(
     runtimeInstance runtimecontract.Runtime,
     eventName string,
@@ -632,9 +853,11 @@ func (instance *EventDispatcher) removeListenerById(eventName string, listenerId
 }
 
 type listenerWithPriority struct {
-    listener   eventcontract.EventListener
-    listenerId uint64
-    priority   int
+    listener     eventcontract.EventListener
+    listenerName string
+    listenerId   uint64
+    priority     int
+    isAsync      bool
 }
 
 type subscriberRegistration struct {
@@ -644,7 +867,11 @@ type subscriberRegistration struct {
 }
 
 var _ eventcontract.EventDispatcher = (*EventDispatcher)(nil)
+var _ eventcontract.ObservableEventDispatcher = (*EventDispatcher)(nil)
 var _ eventcontract.EventDispatcherInspector = (*EventDispatcher)(nil)
+var _ eventcontract.AsyncEventDispatcher = (*EventDispatcher)(nil)
+var _ eventcontract.NamedListenerDispatcher = (*EventDispatcher)(nil)
+var _ eventcontract.NamedAsyncListenerDispatcher = (*EventDispatcher)(nil)
 
 func eventSubscriberPointer(subscriber eventcontract.EventSubscriber) uintptr {
     if nil == subscriber {
@@ -658,3 +885,13 @@ func eventSubscriberPointer(subscriber eventcontract.EventSubscriber) uintptr {
 
     return subscriberValue.Pointer()
 }
+
+/* listenerFunctionName accepts any function so typed listeners are named after the function they wrap. */
+func listenerFunctionName(listener any) string {
+    function := runtimepkg.FuncForPC(reflect.ValueOf(listener).Pointer())
+    if nil == function {
+        return "-"
+    }
+
+    return function.Name()
+}
@@ -1,9 +1,9 @@
 package event
 
 import (
+    "context"
     "fmt"
     "reflect"
-    "runtime"
     "sort"
     "sync"
 
@@ -48,6 +48,15 @@ type EventDispatcherAdapter struct {
 }
 
 func (instance *EventDispatcherAdapter) AddListener(eventName string, listener eventcontract.EventListener, priority int) eventcontract.ListenerRegistration {
+    return instance.AddNamedListener(eventName, "", listener, priority)
+}
+
+func (instance *EventDispatcherAdapter) AddNamedListener(
+    eventName string,
+    listenerName string,
+    listener eventcontract.EventListener,
+    priority int,
+) eventcontract.ListenerRegistration {
     if "" == eventName {
         exception.Panic(
             exception.NewError("event name is required to add a listener", nil, nil),
@@ -68,13 +77,68 @@ func (instance *EventDispatcherAdapter) AddListener(eventName string, listener e
 
     return instance.addListenerRegistration(
         eventName,
+        listenerName,
         listener,
         priority,
+        false,
         eventcontract.RegisteredListenerSourceListener,
         "-",
     )
 }
 
+func (instance *EventDispatcherAdapter) AddAsyncListener(
+    eventName string,
+    listener eventcontract.EventListener,
+    priority int,
+) eventcontract.ListenerRegistration {
+    return instance.AddNamedAsyncListener(eventName, "", listener, priority)
+}
+
+func (instance *EventDispatcherAdapter) AddNamedAsyncListener(
+    eventName string,
+    listenerName string,
+    listener eventcontract.EventListener,
+    priority int,
+) eventcontract.ListenerRegistration {
+    if "" == eventName {
+        exception.Panic(
+            exception.NewError("event name is required to add a listener", nil, nil),
+        )
+    }
+
+    if nil == listener {
+        exception.Panic(
+            exception.NewError(
+                "event listener is required to add a listener",
+                exceptioncontract.Context{
+                    "eventName": eventName,
+                },
+                nil,
+            ),
+        )
+    }
+
+    return instance.addListenerRegistration(
+        eventName,
+        listenerName,
+        listener,
+        priority,
+        true,
+        eventcontract.RegisteredListenerSourceListener,
+        "-",
+    )
+}
+
+/* @info forwards to the wrapped dispatcher; one without async support has nothing in flight to wait for. */
+func (instance *EventDispatcherAdapter) Drain(ctx context.Context) error {
+    asyncEventDispatcher, isAsyncEventDispatcher := instance.eventDispatcher.(eventcontract.AsyncEventDispatcher)
+    if false == isAsyncEventDispatcher {
+        return nil
+    }
+
+    return asyncEventDispatcher.Drain(ctx)
+}
+
 func (instance *EventDispatcherAdapter) RemoveListener(registration eventcontract.ListenerRegistration) bool {
     removed := instance.eventDispatcher.RemoveListener(registration)
     if false == removed {
@@ -204,10 +268,24 @@ func (instance *EventDispatcherAdapter) AddSubscriber(subscriber eventcontract.E
                 )
             }
 
+            isAsync := false
+            asyncSubscribedEvent, isAsyncSubscribedEvent := subscribedEvent.(eventcontract.AsyncSubscribedEvent)
+            if true == isAsyncSubscribedEvent {
+                isAsync = asyncSubscribedEvent.IsAsync()
+            }
+
+            listenerName := ""
+            namedSubscribedEvent, isNamedSubscribedEvent := subscribedEvent.(eventcontract.NamedSubscribedEvent)
+            if true == isNamedSubscribedEvent {
+                listenerName = namedSubscribedEvent.ListenerName()
+            }
+
             registration := instance.addListenerRegistration(
                 eventName,
+                listenerName,
                 listener,
                 subscribedEvent.Priority(),
+                isAsync,
                 eventcontract.RegisteredListenerSourceSubscriber,
                 subscriberType,
             )
@@ -300,10 +378,9 @@ func(i int, j int) bool {
         for _, entry := range listenerList {
             listenerId := fmt.Sprintf("%d", entry.registration.ListenerId)
 
-            listenerName := "-"
-            function := runtime.FuncForPC(entry.listenerProgramCounter)
-            if nil != function {
-                listenerName = function.Name()
+            mode := eventcontract.RegisteredListenerModeSync
+            if true == entry.isAsync {
+                mode = eventcontract.RegisteredListenerModeAsync
             }
 
             registeredListenerList = append(
@@ -313,7 +390,8 @@ function := runtime.FuncForPC(entry.listenerProgramCounter)
                     Source:       entry.source,
                     Owner:        entry.owner,
                     ListenerId:   listenerId,
-                    ListenerName: listenerName,
+                    ListenerName: entry.listenerName,
+                    Mode:         mode,
                 },
             )
         }
@@ -321,8 +399,9 @@ function := runtime.FuncForPC(entry.listenerProgramCounter)
         registeredEvents = append(
             registeredEvents,
             eventcontract.RegisteredEvent{
-                EventName: eventName,
-                Listeners: registeredListenerList,
+                EventName:   eventName,
+                PayloadType: typedEventPayloadType(eventName),
+                Listeners:   registeredListenerList,
             },
         )
     }
@@ -332,15 +411,40 @@ function := runtime.FuncForPC(entry.listenerProgramCounter)
 
 func (instance *EventDispatcherAdapter) addListenerRegistration(
     eventName string,
+    listenerName string,
     listener eventcontract.EventListener,
     priority int,
+    isAsync bool,
     source string,
     owner string,
 ) eventcontract.ListenerRegistration {
-    listenerProgramCounter := reflect.ValueOf(listener).Pointer()
+    if "" == listenerName {
+        listenerName = listenerFunctionName(listener)
+    }
+
+    var asyncEventDispatcher eventcontract.AsyncEventDispatcher
+    if true == isAsync {
+        var isAsyncEventDispatcher bool
+        asyncEventDispatcher, isAsyncEventDispatcher = instance.eventDispatcher.(eventcontract.AsyncEventDispatcher)
+        if false == isAsyncEventDispatcher {
+            exception.Panic(
+                exception.NewError(
+                    "the adapted event dispatcher does not support async listeners",
+                    exceptioncontract.Context{
+                        "eventName":    eventName,
+                        "listenerName": listenerName,
+                    },
+                    nil,
+                ),
+            )
+        }
+    }
 
     wrappedListener := func(runtimeInstance runtimecontract.Runtime, eventValue eventcontract.Event) error {
-        contractEvent := NewEventFromEvent(eventValue)
+        var contractEvent eventcontract.Event = NewEventFromEvent(eventValue)
+        if true == IsBridgedEvent(eventValue) {
+            contractEvent = &bridgedEvent{Event: NewEventFromEvent(eventValue)}
+        }
 
         listenerErr := listener(runtimeInstance, contractEvent)
 
@@ -356,22 +460,48 @@ func (instance *EventDispatcherAdapter) addListenerRegistration(
     registrationIndex := instance.nextRegistrationIndex
     instance.mutex.Unlock()
 
-    registration := instance.eventDispatcher.AddListener(
-        eventName,
-        wrappedListener,
-        priority,
-    )
+    var registration eventcontract.ListenerRegistration
+    namedListenerDispatcher, isNamedListenerDispatcher := instance.eventDispatcher.(eventcontract.NamedListenerDispatcher)
+    namedAsyncListenerDispatcher, isNamedAsyncListenerDispatcher := instance.eventDispatcher.(eventcontract.NamedAsyncListenerDispatcher)
+    if true == isAsync && true == isNamedAsyncListenerDispatcher {
+        registration = namedAsyncListenerDispatcher.AddNamedAsyncListener(
+            eventName,
+            listenerName,
+            wrappedListener,
+            priority,
+        )
+    } else if true == isAsync {
+        registration = asyncEventDispatcher.AddAsyncListener(
+            eventName,
+            wrappedListener,
+            priority,
+        )
+    } else if true == isNamedListenerDispatcher {
+        registration = namedListenerDispatcher.AddNamedListener(
+            eventName,
+            listenerName,
+            wrappedListener,
+            priority,
+        )
+    } else {
+        registration = instance.eventDispatcher.AddListener(
+            eventName,
+            wrappedListener,
+            priority,
+        )
+    }
 
     instance.mutex.Lock()
     instance.listenerRegistrations[eventName] = append(
         instance.listenerRegistrations[eventName],
         adapterListenerRegistration{
-            registration:           registration,
-            priority:               priority,
-            source:                 source,
-            owner:                  owner,
-            listenerProgramCounter: listenerProgramCounter,
-            registrationIndex:      registrationIndex,
+            registration:      registration,
+            priority:          priority,
+            source:            source,
+            owner:             owner,
+            listenerName:      listenerName,
+            registrationIndex: registrationIndex,
+            isAsync:           isAsync,
         },
     )
     instance.mutex.Unlock()
@@ -380,13 +510,17 @@ func (instance *EventDispatcherAdapter) addListenerRegistration(
 }
 
 type adapterListenerRegistration struct {
-    registration           eventcontract.ListenerRegistration
-    priority               int
-    source                 string
-    owner                  string
-    listenerProgramCounter uintptr
-    registrationIndex      uint64
+    registration      eventcontract.ListenerRegistration
+    priority          int
+    source            string
+    owner             string
+    listenerName      string
+    registrationIndex uint64
+    isAsync           bool
 }
 
 var _ eventcontract.EventDispatcher = (*EventDispatcherAdapter)(nil)
 var _ eventcontract.EventDispatcherInspector = (*EventDispatcherAdapter)(nil)
+var _ eventcontract.NamedListenerDispatcher = (*EventDispatcherAdapter)(nil)
+var _ eventcontract.AsyncEventDispatcher = (*EventDispatcherAdapter)(nil)
+var _ eventcontract.NamedAsyncListenerDispatcher = (*EventDispatcherAdapter)(nil)
@@ -1,6 +1,7 @@
 package event
 
 import (
+    "context"
     "sync"
     "testing"
 
@@ -237,3 +238,89 @@ func TestEventDispatcherAdapter_RegisteredEventsIsSafeForConcurrentReaders(t *te
     close(start)
     waitGroup.Wait()
 }
+
+func TestEventDispatcherAdapter_AsyncSubscribedEventRunsAsync(t *testing.T) {
+    dispatcher, clockInstance := testNewEventDispatcher()
+    dispatcher.EnableAsyncListeners(NewAsyncListenerPool(1, 1))
+    adapter := NewEventDispatcherAdapter(dispatcher, clockInstance)
+
+    release := make(chan struct{})
+    finished := make(chan struct{})
+    adapter.AddSubscriber(
+        &testAdapterSubscriber{
+            events: map[string][]eventcontract.SubscribedEvent{
+                "e": {
+                    NewAsyncSubscribedEvent(
+                        func(runtimeInstance runtimecontract.Runtime, eventValue eventcontract.Event) error {
+                            <-release
+                            close(finished)
+                            return nil
+                        },
+                        0,
+                    ),
+                },
+            },
+        },
+    )
+
+    _, err := adapter.DispatchName(newEventDispatcherAdapterTestRuntime(t), "e", nil)
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    registeredEvents := adapter.RegisteredEvents()
+    if 1 != len(registeredEvents) || eventcontract.RegisteredListenerModeAsync != registeredEvents[0].Listeners[0].Mode {
+        t.Fatalf("expected the subscriber listener to be async: %#v", registeredEvents)
+    }
+
+    close(release)
+
+    drainErr := adapter.Drain(context.Background())
+    if nil != drainErr {
+        t.Fatalf("unexpected drain error: %v", drainErr)
+    }
+
+    <-finished
+}
+
+func TestEventDispatcherAdapter_AsyncListenerKeepsItsNameOnTheWrappedDispatcher(t *testing.T) {
+    dispatcher, clockInstance := testNewEventDispatcher()
+    dispatcher.EnableAsyncListeners(NewAsyncListenerPool(1, 1))
+    adapter := NewEventDispatcherAdapter(dispatcher, clockInstance)
+
+    adapter.AddNamedAsyncListener(
+        "e",
+        "audit",
+        func(runtimeInstance runtimecontract.Runtime, eventValue eventcontract.Event) error {
+            return nil
+        },
+        0,
+    )
+
+    registeredEvents := dispatcher.RegisteredEvents()
+    if 1 != len(registeredEvents) || 1 != len(registeredEvents[0].Listeners) {
+        t.Fatalf("expected one registered listener: %#v", registeredEvents)
+    }
+
+    if "audit" != registeredEvents[0].Listeners[0].ListenerName {
+        t.Fatalf("expected the wrapped dispatcher to report the listener name, got %q", registeredEvents[0].Listeners[0].ListenerName)
+    }
+}
+
+func TestEventDispatcherAdapter_AsyncListenerPanicsWithoutAsyncSupport(t *testing.T) {
+    dispatcher, clockInstance := testNewEventDispatcher()
+    adapter := NewEventDispatcherAdapter(
+        struct{ eventcontract.EventDispatcher }{EventDispatcher: dispatcher},
+        clockInstance,
+    )
+
+    testhelper.AssertPanics(t, func() {
+        _ = adapter.AddAsyncListener(
+            "e",
+            func(runtimeInstance runtimecontract.Runtime, eventValue eventcontract.Event) error {
+                return nil
+            },
+            0,
+        )
+    })
+}
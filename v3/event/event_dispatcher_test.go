@@ -599,3 +599,45 @@ func(resolver containercontract.Resolver) (loggingcontract.Logger, error) {
         t.Fatalf("unexpected container close error: %v", containerCloseErr)
     }
 }
+
+func TestEventDispatcher_AddListenerObserver_ReceivesEveryListenerCall(t *testing.T) {
+    dispatcher, clockInstance := testNewEventDispatcher()
+
+    listenerErr := errors.New("failed")
+
+    _ = dispatcher.AddListener(
+        "e",
+        func(runtimeInstance runtimecontract.Runtime, eventValue eventcontract.Event) error {
+            return nil
+        },
+        10,
+    )
+    _ = dispatcher.AddListener(
+        "e",
+        func(runtimeInstance runtimecontract.Runtime, eventValue eventcontract.Event) error {
+            return listenerErr
+        },
+        0,
+    )
+
+    listenerCalls := make([]eventcontract.ListenerCall, 0)
+    dispatcher.AddListenerObserver(
+        func(runtimeInstance runtimecontract.Runtime, listenerCall eventcontract.ListenerCall) {
+            listenerCalls = append(listenerCalls, listenerCall)
+        },
+    )
+
+    _, _ = dispatcher.Dispatch(newEventDispatcherAdapterTestRuntime(t), NewEvent("e", nil, clockInstance))
+
+    if 2 != len(listenerCalls) {
+        t.Fatalf("expected 2 listener calls, got %d", len(listenerCalls))
+    }
+
+    if "e" != listenerCalls[0].EventName || 10 != listenerCalls[0].Priority || nil != listenerCalls[0].Err {
+        t.Fatalf("unexpected first listener call: %+v", listenerCalls[0])
+    }
+
+    if false == errors.Is(listenerCalls[1].Err, listenerErr) {
+        t.Fatalf("expected second listener call to carry the listener error, got %v", listenerCalls[1].Err)
+    }
+}
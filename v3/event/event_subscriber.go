@@ -9,14 +9,30 @@ func NewSubscribedEvent(
     priority int,
 ) *SubscribedEvent {
     return &SubscribedEvent{
-        listener: listener,
-        priority: priority,
+        listener:     listener,
+        listenerName: "",
+        priority:     priority,
+        isAsync:      false,
+    }
+}
+
+func NewAsyncSubscribedEvent(
+    listener eventcontract.EventListener,
+    priority int,
+) *SubscribedEvent {
+    return &SubscribedEvent{
+        listener:     listener,
+        listenerName: "",
+        priority:     priority,
+        isAsync:      true,
     }
 }
 
 type SubscribedEvent struct {
-    listener eventcontract.EventListener
-    priority int
+    listener     eventcontract.EventListener
+    listenerName string
+    priority     int
+    isAsync      bool
 }
 
 func (instance *SubscribedEvent) Listener() eventcontract.EventListener {
@@ -27,4 +43,13 @@ func (instance *SubscribedEvent) Priority() int {
     return instance.priority
 }
 
-var _ eventcontract.SubscribedEvent = (*SubscribedEvent)(nil)
+func (instance *SubscribedEvent) IsAsync() bool {
+    return instance.isAsync
+}
+
+func (instance *SubscribedEvent) ListenerName() string {
+    return instance.listenerName
+}
+
+var _ eventcontract.AsyncSubscribedEvent = (*SubscribedEvent)(nil)
+var _ eventcontract.NamedSubscribedEvent = (*SubscribedEvent)(nil)
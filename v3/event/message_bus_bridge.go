@@ -0,0 +1,177 @@
+package event
+
+import (
+    "fmt"
+    "time"
+
+    eventcontract "github.com/precision-soft/melody/v3/event/contract"
+    "github.com/precision-soft/melody/v3/exception"
+    exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+    "github.com/precision-soft/melody/v3/internal"
+    messagebuscontract "github.com/precision-soft/melody/v3/messagebus/contract"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+/* EventMessage is the message the bridge sends through the bus; routing in the bus is by go type, so every bridged event travels as this type. */
+type EventMessage struct {
+    Name      string    `json:"name"`
+    Payload   any       `json:"payload"`
+    Timestamp time.Time `json:"timestamp"`
+}
+
+func NewMessageBusBridge(
+    bus messagebuscontract.Bus,
+    priority int,
+    eventNames ...string,
+) *MessageBusBridge {
+    if true == internal.IsNilInterface(bus) {
+        exception.Panic(
+            exception.NewError("message bus may not be nil", nil, nil),
+        )
+    }
+
+    if 0 == len(eventNames) {
+        exception.Panic(
+            exception.NewError("message bus bridge requires at least one event name", nil, nil),
+        )
+    }
+
+    for _, eventName := range eventNames {
+        if "" == eventName {
+            exception.Panic(
+                exception.NewError("message bus bridge event name may not be empty", nil, nil),
+            )
+        }
+    }
+
+    return &MessageBusBridge{
+        bus:        bus,
+        priority:   priority,
+        eventNames: append([]string{}, eventNames...),
+    }
+}
+
+/* MessageBusBridge forwards the configured events to a message bus; register it as a subscriber on the dispatcher of the sending side. */
+type MessageBusBridge struct {
+    bus        messagebuscontract.Bus
+    priority   int
+    eventNames []string
+}
+
+func (instance *MessageBusBridge) EventNames() []string {
+    return append([]string{}, instance.eventNames...)
+}
+
+func (instance *MessageBusBridge) SubscribedEvents() map[string][]eventcontract.SubscribedEvent {
+    subscribedEvents := make(map[string][]eventcontract.SubscribedEvent, len(instance.eventNames))
+
+    for _, eventName := range instance.eventNames {
+        subscribedEvents[eventName] = append(
+            subscribedEvents[eventName],
+            NewSubscribedEvent(instance.forward, instance.priority),
+        )
+    }
+
+    return subscribedEvents
+}
+
+func (instance *MessageBusBridge) forward(
+    runtimeInstance runtimecontract.Runtime,
+    eventValue eventcontract.Event,
+) error {
+    /* @important events received from the bus are not sent back, otherwise a dispatcher that both bridges and consumes an event would loop */
+    if true == IsBridgedEvent(eventValue) {
+        return nil
+    }
+
+    _, dispatchErr := instance.bus.Dispatch(
+        runtimeInstance,
+        EventMessage{
+            Name:      eventValue.Name(),
+            Payload:   eventValue.Payload(),
+            Timestamp: eventValue.Timestamp(),
+        },
+    )
+    if nil != dispatchErr {
+        return exception.NewError(
+            "failed to forward event to message bus",
+            exceptioncontract.Context{
+                "eventName": eventValue.Name(),
+            },
+            dispatchErr,
+        )
+    }
+
+    return nil
+}
+
+func NewEventMessageHandler(dispatcher eventcontract.EventDispatcher) *EventMessageHandler {
+    if true == internal.IsNilInterface(dispatcher) {
+        exception.Panic(
+            exception.NewError("event dispatcher may not be nil", nil, nil),
+        )
+    }
+
+    return &EventMessageHandler{
+        dispatcher: dispatcher,
+    }
+}
+
+/* EventMessageHandler re-dispatches received event messages on the local dispatcher; register it for the EventMessage type on the consuming side. */
+type EventMessageHandler struct {
+    dispatcher eventcontract.EventDispatcher
+}
+
+func (instance *EventMessageHandler) Handle(runtimeInstance runtimecontract.Runtime, message any) error {
+    var eventMessage EventMessage
+
+    switch typedMessage := message.(type) {
+    case EventMessage:
+        eventMessage = typedMessage
+    case *EventMessage:
+        if nil == typedMessage {
+            return exception.NewError("event message may not be nil", nil, nil)
+        }
+
+        eventMessage = *typedMessage
+    default:
+        return exception.NewError(
+            "event message handler received unexpected message type",
+            exceptioncontract.Context{
+                "actualType": fmt.Sprintf("%T", message),
+            },
+            nil,
+        )
+    }
+
+    if "" == eventMessage.Name {
+        return exception.NewError("event message name may not be empty", nil, nil)
+    }
+
+    _, dispatchErr := instance.dispatcher.Dispatch(
+        runtimeInstance,
+        &bridgedEvent{
+            Event: NewEventWithTimestamp(
+                eventMessage.Name,
+                eventMessage.Payload,
+                eventMessage.Timestamp,
+            ),
+        },
+    )
+
+    return dispatchErr
+}
+
+func IsBridgedEvent(eventValue eventcontract.Event) bool {
+    _, isBridged := eventValue.(*bridgedEvent)
+
+    return true == isBridged
+}
+
+type bridgedEvent struct {
+    *Event
+}
+
+var _ eventcontract.EventSubscriber = (*MessageBusBridge)(nil)
+var _ messagebuscontract.MessageHandler = (*EventMessageHandler)(nil)
+var _ eventcontract.Event = (*bridgedEvent)(nil)
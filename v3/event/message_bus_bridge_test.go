@@ -0,0 +1,117 @@
+package event
+
+import (
+    "reflect"
+    "testing"
+
+    eventcontract "github.com/precision-soft/melody/v3/event/contract"
+    "github.com/precision-soft/melody/v3/internal/testhelper"
+    "github.com/precision-soft/melody/v3/messagebus"
+    messagebuscontract "github.com/precision-soft/melody/v3/messagebus/contract"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+func TestNewMessageBusBridge_PanicsOnInvalidInput(t *testing.T) {
+    testhelper.AssertPanics(t, func() {
+        _ = NewMessageBusBridge(nil, 0, "test")
+    })
+
+    testhelper.AssertPanics(t, func() {
+        _ = NewMessageBusBridge(messagebus.NewManager("default"), 0)
+    })
+
+    testhelper.AssertPanics(t, func() {
+        _ = NewMessageBusBridge(messagebus.NewManager("default"), 0, "")
+    })
+}
+
+func TestMessageBusBridge_ForwardsEventAndRedispatchesWithoutLoop(t *testing.T) {
+    dispatcher, _ := testNewEventDispatcher()
+
+    locator := messagebus.NewHandlerLocator()
+    locator.Register(reflect.TypeOf(EventMessage{}), NewEventMessageHandler(dispatcher))
+
+    forwardedCount := 0
+    bus := messagebus.NewManager(
+        "default",
+        func(runtimeInstance runtimecontract.Runtime, envelopeInstance messagebuscontract.Envelope, next messagebuscontract.StackNext) (messagebuscontract.Envelope, error) {
+            forwardedCount = forwardedCount + 1
+            return next(runtimeInstance, envelopeInstance)
+        },
+        messagebus.NewHandleMessageMiddleware(locator),
+    )
+
+    dispatcher.AddSubscriber(NewMessageBusBridge(bus, 0, "order.created"))
+
+    receivedPayloads := make([]any, 0)
+    bridgedFlags := make([]bool, 0)
+    _ = dispatcher.AddListener(
+        "order.created",
+        func(runtimeInstance runtimecontract.Runtime, eventValue eventcontract.Event) error {
+            receivedPayloads = append(receivedPayloads, eventValue.Payload())
+            bridgedFlags = append(bridgedFlags, IsBridgedEvent(eventValue))
+            return nil
+        },
+        -10,
+    )
+
+    _, dispatchErr := dispatcher.DispatchName(newEventDispatcherAdapterTestRuntime(t), "order.created", 42)
+    if nil != dispatchErr {
+        t.Fatalf("unexpected dispatch error: %v", dispatchErr)
+    }
+
+    if 1 != forwardedCount {
+        t.Fatalf("expected the event to be forwarded once, got: %d", forwardedCount)
+    }
+
+    if 2 != len(receivedPayloads) {
+        t.Fatalf("expected local and bridged delivery, got: %d", len(receivedPayloads))
+    }
+
+    if 42 != receivedPayloads[0] || 42 != receivedPayloads[1] {
+        t.Fatalf("unexpected payloads: %#v", receivedPayloads)
+    }
+
+    if false == bridgedFlags[0] || true == bridgedFlags[1] {
+        t.Fatalf("expected the bridged delivery first (nested dispatch), got: %#v", bridgedFlags)
+    }
+}
+
+func TestEventMessageHandler_RejectsUnexpectedMessage(t *testing.T) {
+    dispatcher, _ := testNewEventDispatcher()
+
+    handler := NewEventMessageHandler(dispatcher)
+
+    handleErr := handler.Handle(newEventDispatcherAdapterTestRuntime(t), "not an event message")
+    if nil == handleErr {
+        t.Fatalf("expected error for unexpected message type")
+    }
+}
+
+func TestMessageBusBridge_AdapterKeepsBridgedMarker(t *testing.T) {
+    innerDispatcher, clockInstance := testNewEventDispatcher()
+    dispatcher := NewEventDispatcherAdapter(innerDispatcher, clockInstance)
+
+    forwardedCount := 0
+    bus := messagebus.NewManager(
+        "default",
+        func(runtimeInstance runtimecontract.Runtime, envelopeInstance messagebuscontract.Envelope, next messagebuscontract.StackNext) (messagebuscontract.Envelope, error) {
+            forwardedCount = forwardedCount + 1
+            return next(runtimeInstance, envelopeInstance)
+        },
+    )
+
+    dispatcher.AddSubscriber(NewMessageBusBridge(bus, 0, "order.created"))
+
+    handleErr := NewEventMessageHandler(dispatcher).Handle(
+        newEventDispatcherAdapterTestRuntime(t),
+        EventMessage{Name: "order.created", Payload: 1},
+    )
+    if nil != handleErr {
+        t.Fatalf("unexpected handle error: %v", handleErr)
+    }
+
+    if 0 != forwardedCount {
+        t.Fatalf("expected a bridged event to not be forwarded again, got: %d", forwardedCount)
+    }
+}
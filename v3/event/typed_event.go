@@ -0,0 +1,139 @@
+package event
+
+import (
+    "fmt"
+    "reflect"
+    "sync"
+
+    eventcontract "github.com/precision-soft/melody/v3/event/contract"
+    "github.com/precision-soft/melody/v3/exception"
+    exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+    "github.com/precision-soft/melody/v3/internal"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+/* @info the event name of a typed event is a pure function of its payload type, so the name -> type index is process wide and shared by every dispatcher */
+var (
+    typedEventPayloadTypeMutex sync.RWMutex
+    typedEventPayloadTypes     = map[string]string{}
+)
+
+/* EventName returns the event name derived from the payload type: the package path and type name for named types (pointers keep their `*`), the type literal otherwise. */
+func EventName[T any]() string {
+    payloadType := reflect.TypeOf((*T)(nil)).Elem()
+
+    pointerPrefix := ""
+    baseType := payloadType
+    for reflect.Pointer == baseType.Kind() {
+        pointerPrefix = pointerPrefix + "*"
+        baseType = baseType.Elem()
+    }
+
+    eventName := payloadType.String()
+    if "" != baseType.Name() && "" != baseType.PkgPath() {
+        eventName = pointerPrefix + baseType.PkgPath() + "." + baseType.Name()
+    }
+
+    /* @info the index only ever gains entries, so publishing an already known type stays on the read lock */
+    typedEventPayloadTypeMutex.RLock()
+    _, isKnown := typedEventPayloadTypes[eventName]
+    typedEventPayloadTypeMutex.RUnlock()
+
+    if false == isKnown {
+        typedEventPayloadTypeMutex.Lock()
+        typedEventPayloadTypes[eventName] = payloadType.String()
+        typedEventPayloadTypeMutex.Unlock()
+    }
+
+    return eventName
+}
+
+func Listen[T any](
+    dispatcher eventcontract.EventDispatcher,
+    priority int,
+    listener func(runtimeInstance runtimecontract.Runtime, payload T) error,
+) eventcontract.ListenerRegistration {
+    if true == internal.IsNilInterface(dispatcher) {
+        exception.Panic(
+            exception.NewError("event dispatcher may not be nil", nil, nil),
+        )
+    }
+
+    eventName := EventName[T]()
+    typedListener := TypedListener(listener)
+
+    namedListenerDispatcher, isNamedListenerDispatcher := dispatcher.(eventcontract.NamedListenerDispatcher)
+    if true == isNamedListenerDispatcher {
+        return namedListenerDispatcher.AddNamedListener(
+            eventName,
+            listenerFunctionName(listener),
+            typedListener,
+            priority,
+        )
+    }
+
+    return dispatcher.AddListener(eventName, typedListener, priority)
+}
+
+/* Publish dispatches the payload on the container dispatcher under the event name derived from its type. */
+func Publish[T any](runtimeInstance runtimecontract.Runtime, payload T) (eventcontract.Event, error) {
+    return PublishTo(
+        EventDispatcherMustFromContainer(runtimeInstance.Container()),
+        runtimeInstance,
+        payload,
+    )
+}
+
+func PublishTo[T any](
+    dispatcher eventcontract.EventDispatcher,
+    runtimeInstance runtimecontract.Runtime,
+    payload T,
+) (eventcontract.Event, error) {
+    return dispatcher.DispatchName(runtimeInstance, EventName[T](), payload)
+}
+
+/* TypedListener adapts a typed listener to the untyped contract; a payload of another type is reported as a listener error. */
+func TypedListener[T any](
+    listener func(runtimeInstance runtimecontract.Runtime, payload T) error,
+) eventcontract.EventListener {
+    if nil == listener {
+        exception.Panic(
+            exception.NewError("typed event listener may not be nil", nil, nil),
+        )
+    }
+
+    return func(runtimeInstance runtimecontract.Runtime, eventValue eventcontract.Event) error {
+        payload, isPayloadType := eventValue.Payload().(T)
+        if false == isPayloadType {
+            return exception.NewError(
+                "typed event listener received unexpected payload type",
+                exceptioncontract.Context{
+                    "eventName":    eventValue.Name(),
+                    "expectedType": reflect.TypeOf((*T)(nil)).Elem().String(),
+                    "actualType":   fmt.Sprintf("%T", eventValue.Payload()),
+                },
+                nil,
+            )
+        }
+
+        return listener(runtimeInstance, payload)
+    }
+}
+
+/* NewTypedSubscribedEvent is the subscriber counterpart of Listen; use it under the EventName[T]() key of SubscribedEvents. */
+func NewTypedSubscribedEvent[T any](
+    listener func(runtimeInstance runtimecontract.Runtime, payload T) error,
+    priority int,
+) *SubscribedEvent {
+    subscribedEvent := NewSubscribedEvent(TypedListener(listener), priority)
+    subscribedEvent.listenerName = listenerFunctionName(listener)
+
+    return subscribedEvent
+}
+
+func typedEventPayloadType(eventName string) string {
+    typedEventPayloadTypeMutex.RLock()
+    defer typedEventPayloadTypeMutex.RUnlock()
+
+    return typedEventPayloadTypes[eventName]
+}
@@ -0,0 +1,146 @@
+package event
+
+import (
+    "strings"
+    "testing"
+
+    containercontract "github.com/precision-soft/melody/v3/container/contract"
+    eventcontract "github.com/precision-soft/melody/v3/event/contract"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+type orderCreated struct {
+    OrderId int
+}
+
+type typedTestSubscriber struct {
+    received []int
+}
+
+func (instance *typedTestSubscriber) SubscribedEvents() map[string][]eventcontract.SubscribedEvent {
+    return map[string][]eventcontract.SubscribedEvent{
+        EventName[orderCreated](): {
+            NewTypedSubscribedEvent(instance.onOrderCreated, 0),
+        },
+    }
+}
+
+func (instance *typedTestSubscriber) onOrderCreated(runtimeInstance runtimecontract.Runtime, payload orderCreated) error {
+    instance.received = append(instance.received, payload.OrderId)
+    return nil
+}
+
+func TestEventName_DerivesNameFromPayloadType(t *testing.T) {
+    eventName := EventName[orderCreated]()
+    if "github.com/precision-soft/melody/v3/event.orderCreated" != eventName {
+        t.Fatalf("unexpected event name: %s", eventName)
+    }
+
+    if "*github.com/precision-soft/melody/v3/event.orderCreated" != EventName[*orderCreated]() {
+        t.Fatalf("unexpected event name for pointer payload: %s", EventName[*orderCreated]())
+    }
+
+    if "[]string" != EventName[[]string]() {
+        t.Fatalf("unexpected event name for unnamed type: %s", EventName[[]string]())
+    }
+}
+
+func TestListenAndPublishTo_DeliverTypedPayload(t *testing.T) {
+    dispatcher, _ := testNewEventDispatcher()
+
+    received := 0
+    _ = Listen(
+        dispatcher,
+        0,
+        func(runtimeInstance runtimecontract.Runtime, payload orderCreated) error {
+            received = payload.OrderId
+            return nil
+        },
+    )
+
+    _, dispatchErr := PublishTo(dispatcher, newEventDispatcherAdapterTestRuntime(t), orderCreated{OrderId: 7})
+    if nil != dispatchErr {
+        t.Fatalf("unexpected dispatch error: %v", dispatchErr)
+    }
+
+    if 7 != received {
+        t.Fatalf("expected typed listener to receive order 7, got: %d", received)
+    }
+}
+
+func TestPublish_UsesContainerDispatcher(t *testing.T) {
+    dispatcher, _ := testNewEventDispatcher()
+    runtimeInstance := newEventDispatcherAdapterTestRuntime(t)
+
+    registerErr := runtimeInstance.Container().Register(
+        ServiceEventDispatcher,
+        func(resolver containercontract.Resolver) (eventcontract.EventDispatcher, error) {
+            return dispatcher, nil
+        },
+    )
+    if nil != registerErr {
+        t.Fatalf("unexpected register error: %v", registerErr)
+    }
+
+    subscriber := &typedTestSubscriber{}
+    dispatcher.AddSubscriber(subscriber)
+
+    _, dispatchErr := Publish(runtimeInstance, orderCreated{OrderId: 3})
+    if nil != dispatchErr {
+        t.Fatalf("unexpected dispatch error: %v", dispatchErr)
+    }
+
+    if 1 != len(subscriber.received) || 3 != subscriber.received[0] {
+        t.Fatalf("unexpected subscriber deliveries: %#v", subscriber.received)
+    }
+}
+
+func TestTypedListener_RejectsMismatchedPayload(t *testing.T) {
+    dispatcher, _ := testNewEventDispatcher()
+
+    _ = Listen(
+        dispatcher,
+        0,
+        func(runtimeInstance runtimecontract.Runtime, payload orderCreated) error {
+            return nil
+        },
+    )
+
+    _, dispatchErr := dispatcher.DispatchName(
+        newEventDispatcherAdapterTestRuntime(t),
+        EventName[orderCreated](),
+        "not an order",
+    )
+    if nil == dispatchErr {
+        t.Fatalf("expected error for mismatched payload type")
+    }
+}
+
+func TestTypedListeners_AreIntrospectedWithPayloadTypeAndListenerName(t *testing.T) {
+    innerDispatcher, clockInstance := testNewEventDispatcher()
+
+    for _, dispatcher := range []interface {
+        eventcontract.EventDispatcher
+        eventcontract.EventDispatcherInspector
+    }{
+        innerDispatcher,
+        NewEventDispatcherAdapter(NewEventDispatcher(clockInstance), clockInstance),
+    } {
+        subscriber := &typedTestSubscriber{}
+        dispatcher.AddSubscriber(subscriber)
+
+        registeredEvents := dispatcher.RegisteredEvents()
+        if 1 != len(registeredEvents) {
+            t.Fatalf("expected one registered event, got: %#v", registeredEvents)
+        }
+
+        if "event.orderCreated" != registeredEvents[0].PayloadType {
+            t.Fatalf("unexpected payload type: %s", registeredEvents[0].PayloadType)
+        }
+
+        listenerName := registeredEvents[0].Listeners[0].ListenerName
+        if false == strings.Contains(listenerName, "onOrderCreated") {
+            t.Fatalf("expected listener name of the wrapped function, got: %s", listenerName)
+        }
+    }
+}
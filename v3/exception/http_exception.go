@@ -14,6 +14,8 @@ type HttpException struct {
     context       exceptioncontract.Context
     causeErr      error
     alreadyLogged bool
+    problemType   string
+    extensions    map[string]any
 }
 
 func (instance *HttpException) Error() string {
@@ -52,6 +54,28 @@ func (instance *HttpException) StatusCode() int {
     return instance.statusCode
 }
 
+/* @info the RFC 9457 problem type URI; empty means "about:blank". */
+func (instance *HttpException) ProblemType() string {
+    return instance.problemType
+}
+
+func (instance *HttpException) SetProblemType(problemType string) {
+    instance.problemType = problemType
+}
+
+/* @info RFC 9457 extension members rendered next to the standard problem details members. */
+func (instance *HttpException) Extensions() map[string]any {
+    return copyStringMap(instance.extensions)
+}
+
+func (instance *HttpException) SetExtension(key string, value any) {
+    if nil == instance.extensions {
+        instance.extensions = make(map[string]any)
+    }
+
+    instance.extensions[key] = value
+}
+
 func (instance *HttpException) AlreadyLogged() bool {
     return true == instance.alreadyLogged
 }
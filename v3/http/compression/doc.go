@@ -0,0 +1,5 @@
+/*
+Package compression provides the content codings shared by the compression middleware, the static file server and
+the precompress command: an encoder registry, Accept-Encoding negotiation and precompression of a directory.
+*/
+package compression
@@ -0,0 +1,141 @@
+package compression
+
+import (
+    "compress/gzip"
+    "io"
+    "sort"
+    "strings"
+    "sync"
+
+    "github.com/precision-soft/melody/v3/exception"
+    exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+)
+
+const (
+    EncodingBrotli   = "br"
+    EncodingZstd     = "zstd"
+    EncodingGzip     = "gzip"
+    EncodingIdentity = "identity"
+)
+
+/* @info the order codings are preferred in when the client weights several of them equally. */
+var preferredEncodings = []string{EncodingBrotli, EncodingZstd, EncodingGzip}
+
+var siblingExtensions = map[string]string{
+    EncodingBrotli: ".br",
+    EncodingZstd:   ".zst",
+    EncodingGzip:   ".gz",
+}
+
+/*
+ * Encoder wraps writer with a compressor. level is passed through as configured; -1 selects the encoder's default.
+ * The returned writer should implement Flush() error so streamed responses can be flushed mid-stream.
+ */
+type Encoder func(writer io.Writer, level int) (io.WriteCloser, error)
+
+func GzipEncoder(writer io.Writer, level int) (io.WriteCloser, error) {
+    if gzip.HuffmanOnly > level || gzip.BestCompression < level {
+        level = gzip.DefaultCompression
+    }
+
+    return gzip.NewWriterLevel(writer, level)
+}
+
+var defaultEncoderRegistry = NewEncoderRegistry()
+
+/*
+ * DefaultEncoderRegistry is the registry the compression middleware, the static file server and the precompress
+ * command use unless configured otherwise. Melody ships a gzip encoder only, so br and zstd are neither negotiated nor
+ * precompressed until encoders from a library of your choice are registered at startup, for example:
+ *
+ *     compression.DefaultEncoderRegistry().Register(compression.EncodingBrotli, func(writer io.Writer, level int) (io.WriteCloser, error) {
+ *         return brotli.NewWriterLevel(writer, level), nil
+ *     })
+ */
+func DefaultEncoderRegistry() *EncoderRegistry {
+    return defaultEncoderRegistry
+}
+
+func NewEncoderRegistry() *EncoderRegistry {
+    return &EncoderRegistry{
+        encoders: map[string]Encoder{
+            EncodingGzip: GzipEncoder,
+        },
+    }
+}
+
+type EncoderRegistry struct {
+    mutex    sync.RWMutex
+    encoders map[string]Encoder
+}
+
+func (instance *EncoderRegistry) Register(encoding string, encoder Encoder) {
+    encoding = strings.ToLower(strings.TrimSpace(encoding))
+    if "" == encoding || EncodingIdentity == encoding || nil == encoder {
+        exception.Panic(
+            exception.NewError(
+                "invalid compression encoder registration",
+                exceptioncontract.Context{
+                    "encoding": encoding,
+                },
+                nil,
+            ),
+        )
+    }
+
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.encoders[encoding] = encoder
+}
+
+func (instance *EncoderRegistry) Encoder(encoding string) (Encoder, bool) {
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    encoder, exists := instance.encoders[strings.ToLower(encoding)]
+
+    return encoder, exists
+}
+
+/* @info Encodings lists the registered codings by server preference: br, zstd, gzip, then any other alphabetically. */
+func (instance *EncoderRegistry) Encodings() []string {
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    return orderEncodings(instance.encoders)
+}
+
+/* @info SiblingExtension is the file suffix a precompressed sibling uses for encoding (".br", ".zst", ".gz"). */
+func SiblingExtension(encoding string) (string, bool) {
+    extension, exists := siblingExtensions[strings.ToLower(encoding)]
+
+    return extension, exists
+}
+
+/* @info SiblingEncodings lists the codings that have a sibling extension, by server preference. */
+func SiblingEncodings() []string {
+    return append([]string{}, preferredEncodings...)
+}
+
+func orderEncodings[T any](encodings map[string]T) []string {
+    ordered := make([]string, 0, len(encodings))
+    for _, encoding := range preferredEncodings {
+        _, exists := encodings[encoding]
+        if true == exists {
+            ordered = append(ordered, encoding)
+        }
+    }
+
+    var others []string
+    for encoding := range encodings {
+        _, isPreferred := siblingExtensions[encoding]
+        if false == isPreferred {
+            others = append(others, encoding)
+        }
+    }
+
+    sort.Strings(others)
+
+    return append(ordered, others...)
+}
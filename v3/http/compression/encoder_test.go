@@ -0,0 +1,55 @@
+package compression
+
+import (
+    "io"
+    "reflect"
+    "testing"
+
+    "github.com/precision-soft/melody/v3/internal/testhelper"
+)
+
+type encoderTestWriteCloser struct {
+    io.Writer
+}
+
+func (instance *encoderTestWriteCloser) Close() error {
+    return nil
+}
+
+func encoderTestIdentity(writer io.Writer, level int) (io.WriteCloser, error) {
+    return &encoderTestWriteCloser{Writer: writer}, nil
+}
+
+func TestEncoderRegistry_OrdersByServerPreference(t *testing.T) {
+    registry := NewEncoderRegistry()
+
+    if false == reflect.DeepEqual([]string{EncodingGzip}, registry.Encodings()) {
+        t.Fatalf("expected gzip only, got %v", registry.Encodings())
+    }
+
+    registry.Register("deflate", encoderTestIdentity)
+    registry.Register(EncodingZstd, encoderTestIdentity)
+    registry.Register("BR", encoderTestIdentity)
+
+    expected := []string{EncodingBrotli, EncodingZstd, EncodingGzip, "deflate"}
+    if false == reflect.DeepEqual(expected, registry.Encodings()) {
+        t.Fatalf("unexpected order: %v", registry.Encodings())
+    }
+
+    _, exists := registry.Encoder(EncodingBrotli)
+    if false == exists {
+        t.Fatalf("expected br encoder")
+    }
+}
+
+func TestEncoderRegistry_RejectsIdentityAndNilEncoder(t *testing.T) {
+    registry := NewEncoderRegistry()
+
+    testhelper.AssertPanics(t, func() {
+        registry.Register(EncodingIdentity, encoderTestIdentity)
+    })
+
+    testhelper.AssertPanics(t, func() {
+        registry.Register(EncodingBrotli, nil)
+    })
+}
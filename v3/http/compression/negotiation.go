@@ -0,0 +1,74 @@
+package compression
+
+import (
+    "strconv"
+    "strings"
+)
+
+/*
+ * Negotiate picks the coding to respond with from available (in server preference order) for an Accept-Encoding
+ * header: the highest q-value wins, ties go to the earlier available coding, "*" covers codings the header does not
+ * name and q=0 excludes one. It returns "" when the response should not be encoded, including for an empty header.
+ */
+func Negotiate(acceptEncoding string, available []string) string {
+    if "" == strings.TrimSpace(acceptEncoding) {
+        return ""
+    }
+
+    qualities := parseAcceptEncoding(acceptEncoding)
+
+    selected := ""
+    selectedQuality := 0.0
+
+    for _, encoding := range available {
+        encoding = strings.ToLower(encoding)
+
+        quality, named := qualities[encoding]
+        if false == named {
+            quality, named = qualities["*"]
+        }
+
+        if false == named || quality <= selectedQuality {
+            continue
+        }
+
+        selected = encoding
+        selectedQuality = quality
+    }
+
+    return selected
+}
+
+func parseAcceptEncoding(acceptEncoding string) map[string]float64 {
+    qualities := make(map[string]float64)
+
+    for _, rawEntry := range strings.Split(acceptEncoding, ",") {
+        parts := strings.Split(strings.TrimSpace(rawEntry), ";")
+
+        encoding := strings.ToLower(strings.TrimSpace(parts[0]))
+        if "" == encoding {
+            continue
+        }
+
+        if "x-gzip" == encoding {
+            encoding = EncodingGzip
+        }
+
+        quality := 1.0
+        for _, rawParameter := range parts[1:] {
+            parameter := strings.TrimSpace(rawParameter)
+            if false == strings.HasPrefix(parameter, "q=") {
+                continue
+            }
+
+            parsedQuality, parseErr := strconv.ParseFloat(strings.TrimSpace(parameter[2:]), 64)
+            if nil == parseErr {
+                quality = parsedQuality
+            }
+        }
+
+        qualities[encoding] = quality
+    }
+
+    return qualities
+}
@@ -0,0 +1,37 @@
+package compression
+
+import (
+    "testing"
+)
+
+func TestNegotiate_PicksHighestQualityThenServerPreference(t *testing.T) {
+    available := []string{EncodingBrotli, EncodingZstd, EncodingGzip}
+
+    cases := map[string]string{
+        "":                               "",
+        "gzip, deflate, br, zstd":        EncodingBrotli,
+        "gzip;q=1, br;q=0.8":             EncodingGzip,
+        "zstd, gzip":                     EncodingZstd,
+        "br;q=0, *":                      EncodingZstd,
+        "*;q=0.5, gzip":                  EncodingGzip,
+        "x-gzip":                         EncodingGzip,
+        "identity":                       "",
+        "deflate":                        "",
+        "*;q=0":                          "",
+        "BR;Q=0.4, gzip;q=0.3":           EncodingBrotli,
+        "gzip;q=0.5, zstd;q=0.5, br;q=0": EncodingZstd,
+    }
+
+    for acceptEncoding, expected := range cases {
+        actual := Negotiate(acceptEncoding, available)
+        if expected != actual {
+            t.Fatalf("Negotiate(%q) = %q, want %q", acceptEncoding, actual, expected)
+        }
+    }
+}
+
+func TestNegotiate_OnlyConsidersAvailableEncodings(t *testing.T) {
+    if EncodingGzip != Negotiate("br, gzip;q=0.1", []string{EncodingGzip}) {
+        t.Fatalf("expected gzip")
+    }
+}
@@ -0,0 +1,278 @@
+package compression
+
+import (
+    "bytes"
+    "compress/gzip"
+    "io/fs"
+    "os"
+    "path/filepath"
+    "strings"
+
+    "github.com/precision-soft/melody/v3/exception"
+    exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+)
+
+const (
+    PrecompressStatusWritten    = "written"
+    PrecompressStatusFresh      = "fresh"
+    PrecompressStatusNotSmaller = "not-smaller"
+)
+
+func NewPrecompressConfig(level int, minSize int, extensions []string, force bool) *PrecompressConfig {
+    var copiedExtensions []string
+    if nil != extensions {
+        copiedExtensions = append([]string{}, extensions...)
+    }
+
+    return &PrecompressConfig{
+        level:      level,
+        minSize:    minSize,
+        extensions: copiedExtensions,
+        force:      force,
+    }
+}
+
+func DefaultPrecompressConfig() *PrecompressConfig {
+    return NewPrecompressConfig(
+        gzip.BestCompression,
+        256,
+        []string{".html", ".htm", ".css", ".js", ".mjs", ".json", ".map", ".svg", ".xml", ".txt", ".wasm", ".ico", ".ttf", ".otf"},
+        false,
+    )
+}
+
+type PrecompressConfig struct {
+    level      int
+    minSize    int
+    extensions []string
+    force      bool
+}
+
+func (instance *PrecompressConfig) Level() int { return instance.level }
+
+func (instance *PrecompressConfig) SetLevel(level int) { instance.level = level }
+
+func (instance *PrecompressConfig) MinSize() int { return instance.minSize }
+
+func (instance *PrecompressConfig) SetMinSize(minSize int) { instance.minSize = minSize }
+
+func (instance *PrecompressConfig) Extensions() []string {
+    if nil == instance.extensions {
+        return nil
+    }
+
+    return append([]string{}, instance.extensions...)
+}
+
+func (instance *PrecompressConfig) SetExtensions(extensions []string) {
+    if nil == extensions {
+        instance.extensions = nil
+        return
+    }
+
+    instance.extensions = append([]string{}, extensions...)
+}
+
+func (instance *PrecompressConfig) Force() bool { return instance.force }
+
+func (instance *PrecompressConfig) SetForce(force bool) { instance.force = force }
+
+type PrecompressedFile struct {
+    Path           string `json:"path"`
+    Encoding       string `json:"encoding"`
+    Status         string `json:"status"`
+    OriginalSize   int64  `json:"originalSize"`
+    CompressedSize int64  `json:"compressedSize"`
+}
+
+/*
+ * Precompress writes a sibling (".br", ".zst" or ".gz") next to every file under directory whose extension is listed
+ * in config, for each of those codings registry has an encoder for; with the default registry that is gzip only. A sibling that is not smaller than the original
+ * is removed rather than written, and one at least as new as the original is kept unless config forces a rebuild.
+ * Siblings get the original's modification time so Last-Modified and ETag stay stable across builds.
+ */
+func Precompress(directory string, registry *EncoderRegistry, config *PrecompressConfig) ([]PrecompressedFile, error) {
+    if nil == registry {
+        registry = DefaultEncoderRegistry()
+    }
+
+    if nil == config {
+        config = DefaultPrecompressConfig()
+    }
+
+    extensions := make(map[string]bool)
+    for _, extension := range config.Extensions() {
+        extensions[strings.ToLower(extension)] = true
+    }
+
+    var encodings []string
+    for _, encoding := range registry.Encodings() {
+        _, hasSibling := SiblingExtension(encoding)
+        if true == hasSibling {
+            encodings = append(encodings, encoding)
+        }
+    }
+
+    var results []PrecompressedFile
+
+    walkErr := filepath.WalkDir(directory, func(path string, entry fs.DirEntry, walkErr error) error {
+        if nil != walkErr {
+            return walkErr
+        }
+
+        if true == entry.IsDir() || false == entry.Type().IsRegular() {
+            return nil
+        }
+
+        if false == extensions[strings.ToLower(filepath.Ext(path))] {
+            return nil
+        }
+
+        sourceInfo, infoErr := entry.Info()
+        if nil != infoErr {
+            return infoErr
+        }
+
+        if int64(config.MinSize()) > sourceInfo.Size() {
+            return nil
+        }
+
+        var content []byte
+
+        for _, encoding := range encodings {
+            extension, _ := SiblingExtension(encoding)
+            siblingPath := path + extension
+
+            relativePath, _ := filepath.Rel(directory, siblingPath)
+            result := PrecompressedFile{
+                Path:         filepath.ToSlash(relativePath),
+                Encoding:     encoding,
+                OriginalSize: sourceInfo.Size(),
+            }
+
+            siblingInfo, statErr := os.Stat(siblingPath)
+            if nil == statErr && false == config.Force() && false == siblingInfo.ModTime().Before(sourceInfo.ModTime()) {
+                result.Status = PrecompressStatusFresh
+                result.CompressedSize = siblingInfo.Size()
+                results = append(results, result)
+                continue
+            }
+
+            if nil == content {
+                readContent, readErr := os.ReadFile(path)
+                if nil != readErr {
+                    return readErr
+                }
+
+                content = readContent
+            }
+
+            compressed, compressErr := compressContent(registry, encoding, config.Level(), content)
+            if nil != compressErr {
+                return exception.NewError(
+                    "failed to precompress file",
+                    exceptioncontract.Context{
+                        "path":     path,
+                        "encoding": encoding,
+                    },
+                    compressErr,
+                )
+            }
+
+            result.CompressedSize = int64(len(compressed))
+
+            if len(compressed) >= len(content) {
+                removeErr := os.Remove(siblingPath)
+                if nil != removeErr && false == os.IsNotExist(removeErr) {
+                    return removeErr
+                }
+
+                result.Status = PrecompressStatusNotSmaller
+                results = append(results, result)
+                continue
+            }
+
+            writeErr := writeSibling(siblingPath, compressed, sourceInfo)
+            if nil != writeErr {
+                return writeErr
+            }
+
+            result.Status = PrecompressStatusWritten
+            results = append(results, result)
+        }
+
+        return nil
+    })
+    if nil != walkErr {
+        return results, exception.NewError(
+            "failed to precompress directory",
+            exceptioncontract.Context{
+                "directory": directory,
+            },
+            walkErr,
+        )
+    }
+
+    return results, nil
+}
+
+func compressContent(registry *EncoderRegistry, encoding string, level int, content []byte) ([]byte, error) {
+    encoder, exists := registry.Encoder(encoding)
+    if false == exists {
+        return nil, exception.NewError("compression encoder is not registered", exceptioncontract.Context{"encoding": encoding}, nil)
+    }
+
+    buffer := &bytes.Buffer{}
+
+    writer, encoderErr := encoder(buffer, level)
+    if nil != encoderErr {
+        return nil, encoderErr
+    }
+
+    _, writeErr := writer.Write(content)
+    if nil != writeErr {
+        _ = writer.Close()
+        return nil, writeErr
+    }
+
+    closeErr := writer.Close()
+    if nil != closeErr {
+        return nil, closeErr
+    }
+
+    return buffer.Bytes(), nil
+}
+
+/* @info writes through a temporary file so a server reading the sibling never sees a partial one. */
+func writeSibling(siblingPath string, compressed []byte, sourceInfo fs.FileInfo) error {
+    temporaryFile, createErr := os.CreateTemp(filepath.Dir(siblingPath), "."+filepath.Base(siblingPath)+".*")
+    if nil != createErr {
+        return createErr
+    }
+
+    temporaryPath := temporaryFile.Name()
+
+    _, writeErr := temporaryFile.Write(compressed)
+    closeErr := temporaryFile.Close()
+    if nil == writeErr {
+        writeErr = closeErr
+    }
+
+    if nil == writeErr {
+        writeErr = os.Chmod(temporaryPath, sourceInfo.Mode().Perm())
+    }
+
+    if nil == writeErr {
+        writeErr = os.Chtimes(temporaryPath, sourceInfo.ModTime(), sourceInfo.ModTime())
+    }
+
+    if nil == writeErr {
+        writeErr = os.Rename(temporaryPath, siblingPath)
+    }
+
+    if nil != writeErr {
+        _ = os.Remove(temporaryPath)
+    }
+
+    return writeErr
+}
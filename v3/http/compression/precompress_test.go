@@ -0,0 +1,96 @@
+package compression
+
+import (
+    "compress/gzip"
+    "io"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestPrecompress_WritesSmallerSiblingsAndSkipsFreshOnes(t *testing.T) {
+    directory := t.TempDir()
+
+    content := strings.Repeat("body { color: red; }\n", 100)
+    writeErr := os.MkdirAll(filepath.Join(directory, "css"), 0o755)
+    if nil == writeErr {
+        writeErr = os.WriteFile(filepath.Join(directory, "css", "app.css"), []byte(content), 0o644)
+    }
+    if nil == writeErr {
+        writeErr = os.WriteFile(filepath.Join(directory, "tiny.js"), []byte("x"), 0o644)
+    }
+    if nil == writeErr {
+        writeErr = os.WriteFile(filepath.Join(directory, "logo.png"), []byte(content), 0o644)
+    }
+    if nil != writeErr {
+        t.Fatalf("write error: %v", writeErr)
+    }
+
+    results, err := Precompress(directory, NewEncoderRegistry(), nil)
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    if 1 != len(results) || "css/app.css.gz" != results[0].Path || PrecompressStatusWritten != results[0].Status {
+        t.Fatalf("unexpected results: %#v", results)
+    }
+
+    siblingFile, openErr := os.Open(filepath.Join(directory, "css", "app.css.gz"))
+    if nil != openErr {
+        t.Fatalf("open error: %v", openErr)
+    }
+    defer siblingFile.Close()
+
+    gzipReader, readerErr := gzip.NewReader(siblingFile)
+    if nil != readerErr {
+        t.Fatalf("gzip reader error: %v", readerErr)
+    }
+
+    decompressed, _ := io.ReadAll(gzipReader)
+    if content != string(decompressed) {
+        t.Fatalf("unexpected sibling content")
+    }
+
+    results, err = Precompress(directory, NewEncoderRegistry(), nil)
+    if nil != err || PrecompressStatusFresh != results[0].Status {
+        t.Fatalf("expected a fresh sibling, got %#v (%v)", results, err)
+    }
+
+    future := time.Now().Add(time.Hour)
+    _ = os.Chtimes(filepath.Join(directory, "css", "app.css"), future, future)
+
+    results, err = Precompress(directory, NewEncoderRegistry(), nil)
+    if nil != err || PrecompressStatusWritten != results[0].Status {
+        t.Fatalf("expected a rebuilt sibling, got %#v (%v)", results, err)
+    }
+}
+
+func TestPrecompress_RemovesSiblingThatIsNotSmaller(t *testing.T) {
+    directory := t.TempDir()
+
+    config := DefaultPrecompressConfig()
+    config.SetMinSize(0)
+
+    sourcePath := filepath.Join(directory, "a.txt")
+    _ = os.WriteFile(sourcePath, []byte("ab"), 0o644)
+    _ = os.WriteFile(sourcePath+".gz", []byte("stale"), 0o644)
+
+    past := time.Now().Add(-time.Hour)
+    _ = os.Chtimes(sourcePath+".gz", past, past)
+
+    results, err := Precompress(directory, NewEncoderRegistry(), config)
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    if 1 != len(results) || PrecompressStatusNotSmaller != results[0].Status {
+        t.Fatalf("unexpected results: %#v", results)
+    }
+
+    _, statErr := os.Stat(sourcePath + ".gz")
+    if false == os.IsNotExist(statErr) {
+        t.Fatalf("expected stale sibling removed")
+    }
+}
@@ -0,0 +1,29 @@
+package contract
+
+const (
+    RouteMiddlewareLayerGlobal = "global"
+    RouteMiddlewareLayerGroup  = "group"
+    RouteMiddlewareLayerRoute  = "route"
+)
+
+/* RouteMiddleware is one middleware of a route chain with the layer that attached it; Source is the group path prefix or the route name. */
+type RouteMiddleware struct {
+    Layer      string
+    Source     string
+    Middleware Middleware
+}
+
+/* MiddlewareRouteOptions carries the group and route middleware of a route; groups prepend their own, so the chain runs outer group -> inner group -> route. */
+type MiddlewareRouteOptions interface {
+    RouteOptions
+
+    RouteMiddlewares() []RouteMiddleware
+
+    SetRouteMiddlewares(routeMiddlewares []RouteMiddleware)
+}
+
+type MiddlewareRouteDefinition interface {
+    RouteDefinition
+
+    RouteMiddlewares() []RouteMiddleware
+}
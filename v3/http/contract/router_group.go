@@ -8,4 +8,8 @@ type RouteGroup interface {
     WithRequirements(requirements map[string]string)
 
     WithDefaults(defaults map[string]string)
+
+    Use(middlewares ...Middleware)
+
+    Group(pathPrefix string) RouteGroup
 }
@@ -0,0 +1,15 @@
+package contract
+
+import (
+    "reflect"
+)
+
+type TypedHandler interface {
+    Handler() Handler
+
+    RequestType() reflect.Type
+
+    ResponseType() reflect.Type
+
+    Status() int
+}
@@ -20,7 +20,25 @@
     KernelExceptionListenerPriority = -1000
 )
 
-func RegisterKernelExceptionListener(eventDispatcher eventcontract.EventDispatcher, debugMode bool) {
+type ExceptionListenerOption func(*exceptionListenerOptions)
+
+type exceptionListenerOptions struct {
+    problemDetails bool
+}
+
+/* @info renders non-html error responses as RFC 9457 application/problem+json (see ProblemDetailsFromError). */
+func WithProblemDetails() ExceptionListenerOption {
+    return func(options *exceptionListenerOptions) {
+        options.problemDetails = true
+    }
+}
+
+func RegisterKernelExceptionListener(eventDispatcher eventcontract.EventDispatcher, debugMode bool, options ...ExceptionListenerOption) {
+    settings := &exceptionListenerOptions{}
+    for _, option := range options {
+        option(settings)
+    }
+
     eventDispatcher.AddListener(
         kernelcontract.EventKernelException,
         func(runtimeInstance runtimecontract.Runtime, eventValue eventcontract.Event) error {
@@ -109,6 +127,32 @@ func(runtimeInstance runtimecontract.Runtime, eventValue eventcontract.Event) er
                     "</body></html>"
 
                 response = HtmlResponse(statusCode, htmlBody)
+            } else if true == settings.problemDetails {
+                problemDetails := ProblemDetailsFromError(exceptionEvent.Err(), exceptionEvent.Request(), debugMode)
+
+                if true == debugMode {
+                    var melodyError *exception.Error
+                    ok = errors.As(exceptionEvent.Err(), &melodyError)
+                    if true == ok && nil != melodyError {
+                        if nil == problemDetails.Extensions {
+                            problemDetails.Extensions = map[string]any{}
+                        }
+
+                        problemDetails.Extensions["context"] = melodyError.Context()
+
+                        causeErr := melodyError.CauseErr()
+                        if nil != causeErr {
+                            problemDetails.Extensions["cause"] = causeErr.Error()
+                        }
+                    }
+                }
+
+                problemResponse, problemResponseErr := ProblemDetailsResponse(problemDetails)
+                if nil == problemResponseErr {
+                    response = problemResponse
+                } else {
+                    response = JsonErrorResponse(statusCode, message)
+                }
             } else {
                 payload := map[string]any{
                     "error": message,
@@ -1,6 +1,7 @@
 package http
 
 import (
+    "encoding/json"
     "errors"
     "io"
     "net/http/httptest"
@@ -13,6 +14,7 @@
     httpcontract "github.com/precision-soft/melody/v3/http/contract"
     "github.com/precision-soft/melody/v3/internal/testhelper"
     kernelcontract "github.com/precision-soft/melody/v3/kernel/contract"
+    "github.com/precision-soft/melody/v3/validation"
 )
 
 func TestExceptionListener_HtmlResponse_EscapesXss(t *testing.T) {
@@ -320,3 +322,88 @@ func readResponseBody(t *testing.T, response httpcontract.Response) string {
 
     return string(data)
 }
+
+func TestExceptionListener_ProblemDetails_RendersValidationErrorsAndExtensions(t *testing.T) {
+    clockInstance := clock.NewSystemClock()
+    dispatcher := event.NewEventDispatcher(clockInstance)
+    runtimeInstance := newTestRuntime()
+
+    RegisterKernelExceptionListener(dispatcher, false, WithProblemDetails())
+
+    httpErr := exception.BadRequest("validation failed")
+    httpErr.SetContextValue(
+        "errors",
+        validation.ValidationErrors{
+            validation.NewValidationError("email", "invalid email", "email", map[string]any{"value": "x"}),
+        },
+    )
+    httpErr.SetProblemType("https://example.com/problems/validation")
+    httpErr.SetExtension("traceId", "t-1")
+
+    req := httptest.NewRequest("POST", "/api/users", nil)
+    req.Header.Set("Accept", "application/json")
+
+    melodyRequest := testhelper.NewHttpTestRequestFromHttpRequest(req)
+
+    exceptionEvent := NewKernelExceptionEvent(runtimeInstance, melodyRequest, httpErr)
+
+    _, dispatchErr := dispatcher.DispatchName(runtimeInstance, kernelcontract.EventKernelException, exceptionEvent)
+    if nil != dispatchErr {
+        t.Fatalf("unexpected dispatch error: %v", dispatchErr)
+    }
+
+    response := exceptionEvent.Response()
+    if nil == response {
+        t.Fatalf("expected response to be set")
+    }
+
+    if ContentTypeProblemJson != response.Headers().Get("Content-Type") {
+        t.Fatalf("expected problem json content type, got: %q", response.Headers().Get("Content-Type"))
+    }
+
+    var payload map[string]any
+    unmarshalErr := json.Unmarshal([]byte(readResponseBody(t, response)), &payload)
+    if nil != unmarshalErr {
+        t.Fatalf("invalid json body: %v", unmarshalErr)
+    }
+
+    if "https://example.com/problems/validation" != payload["type"] || "Bad Request" != payload["title"] || float64(400) != payload["status"] || "validation failed" != payload["detail"] {
+        t.Fatalf("unexpected problem members: %v", payload)
+    }
+
+    if "t-1" != payload["traceId"] {
+        t.Fatalf("expected the extension member, got: %v", payload)
+    }
+
+    problemErrors, ok := payload["errors"].([]any)
+    if false == ok || 1 != len(problemErrors) {
+        t.Fatalf("expected one validation error, got: %v", payload["errors"])
+    }
+
+    firstError := problemErrors[0].(map[string]any)
+    if "email" != firstError["field"] || "email" != firstError["code"] || "invalid email" != firstError["message"] {
+        t.Fatalf("unexpected validation error member: %v", firstError)
+    }
+}
+
+func TestExceptionListener_ProblemDetails_HidesInternalErrors(t *testing.T) {
+    clockInstance := clock.NewSystemClock()
+    dispatcher := event.NewEventDispatcher(clockInstance)
+    runtimeInstance := newTestRuntime()
+
+    RegisterKernelExceptionListener(dispatcher, false, WithProblemDetails())
+
+    req := httptest.NewRequest("GET", "/api/test", nil)
+
+    exceptionEvent := NewKernelExceptionEvent(runtimeInstance, testhelper.NewHttpTestRequestFromHttpRequest(req), errors.New("sensitive internal details"))
+
+    _, dispatchErr := dispatcher.DispatchName(runtimeInstance, kernelcontract.EventKernelException, exceptionEvent)
+    if nil != dispatchErr {
+        t.Fatalf("unexpected dispatch error: %v", dispatchErr)
+    }
+
+    body := readResponseBody(t, exceptionEvent.Response())
+    if true == strings.Contains(body, "sensitive internal details") || false == strings.Contains(body, `"type":"about:blank"`) {
+        t.Fatalf("unexpected problem body: %s", body)
+    }
+}
@@ -205,11 +205,22 @@ func (instance *Kernel) ServeHttp(serviceContainer containercontract.Container)
         melodyRequest.Attributes().Set(RequestAttributeSession, sessionInstance)
 
         for key, value := range routeAttributes {
+            if routeAttributeGroupMiddlewares == key {
+                continue
+            }
+
             melodyRequest.Attributes().Set(key, value)
         }
 
         routeName := melodyRequest.RouteName()
 
+        if "" != routeName {
+            requestLogContext := logging.LogContextFromRuntime(runtimeInstance)
+            if nil != requestLogContext {
+                requestLogContext.Set("routeName", routeName)
+            }
+        }
+
         if nil != handler {
             requestLogger.Info(
                 "route matched",
@@ -519,6 +530,14 @@ func (instance *Kernel) ServeHttp(serviceContainer containercontract.Container)
             }
         }
 
+        /* @important a preflight of a grouped route has no OPTIONS route of its own, so the group middleware (e.g. cors) wraps the automatic response */
+        if nil == handler {
+            methodMismatchMiddlewares, hasMethodMismatchMiddlewares := routeAttributes[routeAttributeGroupMiddlewares].([]httpcontract.Middleware)
+            if true == hasMethodMismatchMiddlewares {
+                baseHandler = wrapWithMiddlewares(baseHandler, methodMismatchMiddlewares)
+            }
+        }
+
         kernelControllerEvent := NewKernelControllerEvent(runtimeInstance, melodyRequest)
         _, eventKernelControllerErr := eventDispatcher.DispatchName(runtimeInstance, kernelcontract.EventKernelController, kernelControllerEvent)
         instance.logEventDispatchError(requestLogger, "kernel controller error", eventKernelControllerErr)
@@ -678,7 +697,18 @@ func (instance *Kernel) requestIdLogger(
         return nil, requestId, exception.NewError("failed to get base logger", nil, nil)
     }
 
-    requestLogger := logging.NewRequestLogger(baseLogger, requestId, "requestId")
+    /* @info listeners and middleware enrich the request log context (logging.LogContextFromRuntime) and may override its level */
+    logContext := logging.NewLogContext()
+
+    logContextErr := scope.OverrideProtectedInstance(logging.ServiceLogContext, logContext)
+    if nil != logContextErr {
+        return nil, requestId, exception.NewError("failed to override request log context", nil, logContextErr)
+    }
+
+    requestLogger := logging.NewContextLogger(
+        logging.NewRequestLogger(baseLogger, requestId, "requestId"),
+        logContext,
+    )
 
     err := scope.OverrideProtectedInstance(logging.ServiceLogger, requestLogger)
     if nil != err {
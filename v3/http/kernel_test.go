@@ -9,6 +9,7 @@
     eventcontract "github.com/precision-soft/melody/v3/event/contract"
     httpcontract "github.com/precision-soft/melody/v3/http/contract"
     kernelcontract "github.com/precision-soft/melody/v3/kernel/contract"
+    "github.com/precision-soft/melody/v3/logging"
     runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
     "github.com/precision-soft/melody/v3/session"
     sessioncontract "github.com/precision-soft/melody/v3/session/contract"
@@ -390,3 +391,28 @@ func TestKernel_ServeHttpClosesScopeWhenRequestLoggerSetupFails(t *testing.T) {
 
     handler.ServeHTTP(recorder, request)
 }
+
+func TestKernel_ProvidesRequestLogContextWithRouteName(t *testing.T) {
+    var routeName any
+    router := NewRouter()
+    router.HandleNamed(
+        "hello_show",
+        nethttp.MethodGet,
+        "/hello",
+        func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            logContext := logging.LogContextFromRuntime(runtimeInstance)
+            if nil != logContext {
+                routeName, _ = logContext.Get("routeName")
+            }
+
+            return TextResponse(nethttp.StatusOK, "ok"), nil
+        },
+    )
+
+    handler := NewKernel(router).ServeHttp(newHttpTestContainer())
+    handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(nethttp.MethodGet, "/hello", nil))
+
+    if "hello_show" != routeName {
+        t.Fatalf("expected route name in the request log context, got %v", routeName)
+    }
+}
@@ -9,6 +9,8 @@
     "strings"
 
     "github.com/precision-soft/melody/v3/exception"
+    exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+    "github.com/precision-soft/melody/v3/http/compression"
     httpcontract "github.com/precision-soft/melody/v3/http/contract"
     runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
 )
@@ -18,6 +20,7 @@ type CompressionConfig struct {
     minSize              int
     excludedContentTypes []string
     excludedPaths        []string
+    encoderRegistry      *compression.EncoderRegistry
 }
 
 func NewCompressionConfig(
@@ -86,6 +89,19 @@ func (instance *CompressionConfig) SetExcludedPaths(excludedPaths []string) {
     instance.excludedPaths = append([]string{}, excludedPaths...)
 }
 
+/* @info EncoderRegistry defaults to compression.DefaultEncoderRegistry(); its codings are negotiated against Accept-Encoding. */
+func (instance *CompressionConfig) EncoderRegistry() *compression.EncoderRegistry {
+    if nil == instance.encoderRegistry {
+        return compression.DefaultEncoderRegistry()
+    }
+
+    return instance.encoderRegistry
+}
+
+func (instance *CompressionConfig) SetEncoderRegistry(encoderRegistry *compression.EncoderRegistry) {
+    instance.encoderRegistry = encoderRegistry
+}
+
 func DefaultCompressionConfig() *CompressionConfig {
     return NewCompressionConfig(
         gzip.DefaultCompression,
@@ -113,22 +129,30 @@ func CompressionMiddleware(config *CompressionConfig) httpcontract.Middleware {
 
     return func(next httpcontract.Handler) httpcontract.Handler {
         return func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
-            response, nextMiddlewareErr := next(runtimeInstance, writer, request)
-            if nil != nextMiddlewareErr || nil == response {
-                return response, nextMiddlewareErr
-            }
-
             httpRequest := request.HttpRequest()
             if nil == httpRequest {
-                return response, nil
+                return next(runtimeInstance, writer, request)
             }
 
             for _, excludedPath := range config.ExcludedPaths() {
                 if "" != excludedPath && true == strings.HasPrefix(httpRequest.URL.Path, excludedPath) {
-                    return response, nil
+                    return next(runtimeInstance, writer, request)
                 }
             }
 
+            encoderRegistry := config.EncoderRegistry()
+            encoding := compression.Negotiate(httpRequest.Header.Get("Accept-Encoding"), encoderRegistry.Encodings())
+            encoder, _ := encoderRegistry.Encoder(encoding)
+
+            /* @info handlers that write to the writer themselves (for example Server-Sent Events) are compressed as they stream. */
+            streamWriter := newCompressionResponseWriter(writer, httpRequest, config, encoding, encoder)
+            defer streamWriter.finish()
+
+            response, nextMiddlewareErr := next(runtimeInstance, streamWriter, request)
+            if nil != nextMiddlewareErr || nil == response {
+                return response, nextMiddlewareErr
+            }
+
             if nil == response.BodyReader() {
                 return response, nil
             }
@@ -137,16 +161,13 @@ func CompressionMiddleware(config *CompressionConfig) httpcontract.Middleware {
                 return response, nil
             }
 
-            contentType := response.Headers().Get("Content-Type")
-            for _, excludedContentType := range config.ExcludedContentTypes() {
-                if "" != excludedContentType && true == strings.HasPrefix(contentType, excludedContentType) {
-                    return response, nil
-                }
+            if true == isExcludedContentType(config, response.Headers().Get("Content-Type")) {
+                return response, nil
             }
 
             addVaryAcceptEncoding(response.Headers())
 
-            if false == acceptsGzip(httpRequest.Header.Get("Accept-Encoding")) {
+            if nil == encoder {
                 return response, nil
             }
 
@@ -187,10 +208,10 @@ func CompressionMiddleware(config *CompressionConfig) httpcontract.Middleware {
             source := io.MultiReader(bytes.NewReader(peekBuffer[:peeked]), originalReader)
 
             pipeReader, pipeWriter := io.Pipe()
-            go streamGzipCompressInto(pipeWriter, source, originalReader, config.Level())
+            go streamCompressInto(pipeWriter, source, originalReader, encoding, encoder, config.Level())
 
             response.SetBodyReader(pipeReader)
-            response.Headers().Set("Content-Encoding", "gzip")
+            response.Headers().Set("Content-Encoding", encoding)
             response.Headers().Del("Content-Length")
 
             return response, nil
@@ -198,54 +219,13 @@ func CompressionMiddleware(config *CompressionConfig) httpcontract.Middleware {
     }
 }
 
-func acceptsGzip(acceptEncoding string) bool {
-    if "" == acceptEncoding {
-        return false
-    }
-
-    gzipQuality := -1.0
-    starQuality := -1.0
-
-    for _, rawEntry := range strings.Split(acceptEncoding, ",") {
-        entry := strings.TrimSpace(rawEntry)
-        if "" == entry {
-            continue
-        }
-
-        parts := strings.Split(entry, ";")
-        codingName := strings.ToLower(strings.TrimSpace(parts[0]))
-        if "" == codingName {
-            continue
-        }
-
-        quality := 1.0
-        for _, rawParam := range parts[1:] {
-            param := strings.TrimSpace(rawParam)
-            if false == strings.HasPrefix(param, "q=") {
-                continue
-            }
-
-            parsedQuality, parseErr := strconv.ParseFloat(strings.TrimSpace(param[2:]), 64)
-            if nil == parseErr {
-                quality = parsedQuality
-            }
-        }
-
-        if "gzip" == codingName {
-            gzipQuality = quality
-        } else if "*" == codingName {
-            starQuality = quality
+func isExcludedContentType(config *CompressionConfig, contentType string) bool {
+    for _, excludedContentType := range config.ExcludedContentTypes() {
+        if "" != excludedContentType && true == strings.HasPrefix(contentType, excludedContentType) {
+            return true
         }
     }
 
-    if 0 <= gzipQuality {
-        return 0 < gzipQuality
-    }
-
-    if 0 <= starQuality {
-        return 0 < starQuality
-    }
-
     return false
 }
 
@@ -270,25 +250,32 @@ func closeBodyReaderQuiet(reader io.Reader) {
     _ = closer.Close()
 }
 
-func streamGzipCompressInto(pipeWriter *io.PipeWriter, source io.Reader, sourceCloser io.Reader, level int) {
+func streamCompressInto(
+    pipeWriter *io.PipeWriter,
+    source io.Reader,
+    sourceCloser io.Reader,
+    encoding string,
+    encoder compression.Encoder,
+    level int,
+) {
     defer closeBodyReaderQuiet(sourceCloser)
 
-    gzipWriter, gzipErr := gzip.NewWriterLevel(pipeWriter, level)
-    if nil != gzipErr {
+    compressor, encoderErr := encoder(pipeWriter, level)
+    if nil != encoderErr {
         _ = pipeWriter.CloseWithError(
-            exception.NewError("failed to initialize gzip writer", nil, gzipErr),
+            exception.NewError("failed to initialize compressor", exceptioncontract.Context{"encoding": encoding}, encoderErr),
         )
         return
     }
 
-    _, copyErr := io.Copy(gzipWriter, source)
+    _, copyErr := io.Copy(compressor, source)
     if nil != copyErr {
-        _ = gzipWriter.Close()
+        _ = compressor.Close()
         _ = pipeWriter.CloseWithError(copyErr)
         return
     }
 
-    closeErr := gzipWriter.Close()
+    closeErr := compressor.Close()
     if nil != closeErr {
         _ = pipeWriter.CloseWithError(closeErr)
         return
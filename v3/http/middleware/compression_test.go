@@ -11,6 +11,7 @@
     "testing"
 
     "github.com/precision-soft/melody/v3/http"
+    "github.com/precision-soft/melody/v3/http/compression"
     httpcontract "github.com/precision-soft/melody/v3/http/contract"
     "github.com/precision-soft/melody/v3/internal/testhelper"
     runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
@@ -424,9 +425,9 @@ func TestAcceptsGzip_Cases(t *testing.T) {
     }
 
     for input, expected := range cases {
-        got := acceptsGzip(input)
+        got := compression.EncodingGzip == compression.Negotiate(input, []string{compression.EncodingGzip})
         if expected != got {
-            t.Fatalf("acceptsGzip(%q) = %v, want %v", input, got, expected)
+            t.Fatalf("Negotiate(%q) = %v, want %v", input, got, expected)
         }
     }
 }
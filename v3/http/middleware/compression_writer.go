@@ -0,0 +1,154 @@
+package middleware
+
+import (
+    "bufio"
+    "io"
+    "net"
+    nethttp "net/http"
+    "strconv"
+
+    "github.com/precision-soft/melody/v3/exception"
+    "github.com/precision-soft/melody/v3/http/compression"
+)
+
+func newCompressionResponseWriter(
+    responseWriter nethttp.ResponseWriter,
+    request *nethttp.Request,
+    config *CompressionConfig,
+    encoding string,
+    encoder compression.Encoder,
+) *compressionResponseWriter {
+    return &compressionResponseWriter{
+        ResponseWriter: responseWriter,
+        request:        request,
+        config:         config,
+        encoding:       encoding,
+        encoder:        encoder,
+    }
+}
+
+/*
+ * compressionResponseWriter compresses what a handler writes to the response writer directly. The decision is taken
+ * when the headers are committed, from the status, Content-Encoding, Content-Type and Content-Length the handler set.
+ * Flush flushes the compressor before the connection, so each Server-Sent Event reaches the client as it is sent.
+ */
+type compressionResponseWriter struct {
+    nethttp.ResponseWriter
+    request     *nethttp.Request
+    config      *CompressionConfig
+    encoding    string
+    encoder     compression.Encoder
+    wroteHeader bool
+    compressor  io.WriteCloser
+}
+
+func (instance *compressionResponseWriter) WriteHeader(statusCode int) {
+    if true == instance.wroteHeader {
+        instance.ResponseWriter.WriteHeader(statusCode)
+        return
+    }
+
+    if nethttp.StatusContinue <= statusCode && nethttp.StatusOK > statusCode {
+        instance.ResponseWriter.WriteHeader(statusCode)
+        return
+    }
+
+    instance.wroteHeader = true
+
+    if true == instance.shouldCompress(statusCode) {
+        headers := instance.ResponseWriter.Header()
+        addVaryAcceptEncoding(headers)
+
+        compressor, encoderErr := instance.encoder(instance.ResponseWriter, instance.config.Level())
+        if nil == encoderErr {
+            instance.compressor = compressor
+            headers.Set("Content-Encoding", instance.encoding)
+            headers.Del("Content-Length")
+        }
+    }
+
+    instance.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (instance *compressionResponseWriter) Write(data []byte) (int, error) {
+    if false == instance.wroteHeader {
+        if "" == instance.ResponseWriter.Header().Get("Content-Type") {
+            instance.ResponseWriter.Header().Set("Content-Type", nethttp.DetectContentType(data))
+        }
+
+        instance.WriteHeader(nethttp.StatusOK)
+    }
+
+    if nil != instance.compressor {
+        return instance.compressor.Write(data)
+    }
+
+    return instance.ResponseWriter.Write(data)
+}
+
+/* @info Flush pushes the compressor's pending output before flushing the connection; without it a compressed stream would sit in the compressor's buffer. */
+func (instance *compressionResponseWriter) Flush() {
+    if false == instance.wroteHeader {
+        instance.WriteHeader(nethttp.StatusOK)
+    }
+
+    flushableCompressor, isFlushable := instance.compressor.(interface{ Flush() error })
+    if true == isFlushable {
+        _ = flushableCompressor.Flush()
+    }
+
+    flusher, isFlusher := instance.ResponseWriter.(nethttp.Flusher)
+    if true == isFlusher {
+        flusher.Flush()
+    }
+}
+
+/* @info Hijack is forwarded for connection upgrades; a hijacked connection is never compressed. */
+func (instance *compressionResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+    hijacker, isHijacker := instance.ResponseWriter.(nethttp.Hijacker)
+    if false == isHijacker {
+        return nil, nil, exception.NewError("response writer does not support hijacking", nil, nil)
+    }
+
+    instance.wroteHeader = true
+
+    return hijacker.Hijack()
+}
+
+func (instance *compressionResponseWriter) Unwrap() nethttp.ResponseWriter {
+    return instance.ResponseWriter
+}
+
+func (instance *compressionResponseWriter) finish() {
+    if nil == instance.compressor {
+        return
+    }
+
+    _ = instance.compressor.Close()
+    instance.compressor = nil
+}
+
+func (instance *compressionResponseWriter) shouldCompress(statusCode int) bool {
+    if nil == instance.encoder {
+        return false
+    }
+
+    if nethttp.StatusNoContent == statusCode || nethttp.StatusNotModified == statusCode || nethttp.MethodHead == instance.request.Method {
+        return false
+    }
+
+    headers := instance.ResponseWriter.Header()
+    if "" != headers.Get("Content-Encoding") || true == isExcludedContentType(instance.config, headers.Get("Content-Type")) {
+        return false
+    }
+
+    contentLengthString := headers.Get("Content-Length")
+    if "" != contentLengthString {
+        value, parseErr := strconv.Atoi(contentLengthString)
+        if nil == parseErr && instance.config.MinSize() > value {
+            return false
+        }
+    }
+
+    return true
+}
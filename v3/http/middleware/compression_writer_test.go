@@ -0,0 +1,155 @@
+package middleware
+
+import (
+    "bytes"
+    "compress/gzip"
+    "io"
+    nethttp "net/http"
+    "net/http/httptest"
+    "testing"
+
+    "github.com/precision-soft/melody/v3/http"
+    "github.com/precision-soft/melody/v3/http/compression"
+    httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    "github.com/precision-soft/melody/v3/internal/testhelper"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+type compressionWriterTestEncoder struct {
+    writer  io.Writer
+    flushes int
+}
+
+func (instance *compressionWriterTestEncoder) Write(data []byte) (int, error) {
+    return instance.writer.Write(bytes.ToUpper(data))
+}
+
+func (instance *compressionWriterTestEncoder) Flush() error {
+    instance.flushes++
+    return nil
+}
+
+func (instance *compressionWriterTestEncoder) Close() error {
+    return nil
+}
+
+func TestCompressionMiddleware_CompressesStreamedResponsesAndFlushes(t *testing.T) {
+    middleware := CompressionMiddleware(NewCompressionConfig(6, 1024, nil, nil))
+
+    recorder := httptest.NewRecorder()
+    var flushedBeforeReturn []byte
+
+    handler := middleware(
+        func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            eventWriter, writerErr := http.NewServerSentEventWriter(writer)
+            if nil != writerErr {
+                return nil, writerErr
+            }
+
+            sendErr := eventWriter.Send(http.ServerSentEvent{Data: "first"})
+            if nil != sendErr {
+                return nil, sendErr
+            }
+
+            flushedBeforeReturn = append([]byte{}, recorder.Body.Bytes()...)
+
+            return nil, nil
+        },
+    )
+
+    request := httptest.NewRequest(nethttp.MethodGet, "/events", nil)
+    request.Header.Set("Accept-Encoding", "gzip")
+
+    _, err := handler(nil, recorder, testhelper.NewHttpTestRequestFromHttpRequest(request))
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    if "gzip" != recorder.Header().Get("Content-Encoding") {
+        t.Fatalf("expected gzip content encoding, got %q", recorder.Header().Get("Content-Encoding"))
+    }
+
+    gzipReader, readerErr := gzip.NewReader(bytes.NewReader(flushedBeforeReturn))
+    if nil != readerErr {
+        t.Fatalf("gzip reader error: %v", readerErr)
+    }
+
+    event := make([]byte, len("data: first\n\n"))
+    _, readErr := io.ReadFull(gzipReader, event)
+    if nil != readErr || "data: first\n\n" != string(event) {
+        t.Fatalf("expected the flushed event to be decodable before the handler returned, got %q (%v)", string(event), readErr)
+    }
+
+    decompressed, _ := gzip.NewReader(bytes.NewReader(recorder.Body.Bytes()))
+    all, allErr := io.ReadAll(decompressed)
+    if nil != allErr || "data: first\n\n" != string(all) {
+        t.Fatalf("expected a complete gzip stream, got %q (%v)", string(all), allErr)
+    }
+}
+
+func TestCompressionMiddleware_NegotiatesRegisteredEncodings(t *testing.T) {
+    encoder := &compressionWriterTestEncoder{}
+
+    registry := compression.NewEncoderRegistry()
+    registry.Register(compression.EncodingBrotli, func(writer io.Writer, level int) (io.WriteCloser, error) {
+        encoder.writer = writer
+        return encoder, nil
+    })
+
+    config := NewCompressionConfig(6, 1024, nil, nil)
+    config.SetEncoderRegistry(registry)
+
+    recorder := httptest.NewRecorder()
+
+    handler := CompressionMiddleware(config)(
+        func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            writer.Header().Set("Content-Type", "text/plain")
+            _, _ = writer.Write([]byte("streamed"))
+            writer.(nethttp.Flusher).Flush()
+
+            return nil, nil
+        },
+    )
+
+    request := httptest.NewRequest(nethttp.MethodGet, "/", nil)
+    request.Header.Set("Accept-Encoding", "gzip;q=0.9, br")
+
+    _, err := handler(nil, recorder, testhelper.NewHttpTestRequestFromHttpRequest(request))
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    if "br" != recorder.Header().Get("Content-Encoding") || "STREAMED" != recorder.Body.String() {
+        t.Fatalf("expected the br encoder, got %q %q", recorder.Header().Get("Content-Encoding"), recorder.Body.String())
+    }
+
+    if 1 != encoder.flushes {
+        t.Fatalf("expected the encoder to be flushed once, got %d", encoder.flushes)
+    }
+
+    if "Accept-Encoding" != recorder.Header().Get("Vary") {
+        t.Fatalf("expected vary header")
+    }
+}
+
+func TestCompressionMiddleware_DoesNotCompressStreamedExcludedContentType(t *testing.T) {
+    recorder := httptest.NewRecorder()
+
+    handler := DefaultCompressionMiddleware()(
+        func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            writer.Header().Set("Content-Type", "image/png")
+            _, _ = writer.Write([]byte("png"))
+
+            return nil, nil
+        },
+    )
+
+    request := httptest.NewRequest(nethttp.MethodGet, "/", nil)
+    request.Header.Set("Accept-Encoding", "gzip")
+
+    _, _ = handler(nil, recorder, testhelper.NewHttpTestRequestFromHttpRequest(request))
+
+    if "" != recorder.Header().Get("Content-Encoding") || "png" != recorder.Body.String() {
+        t.Fatalf("expected an uncompressed body")
+    }
+}
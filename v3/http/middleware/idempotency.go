@@ -0,0 +1,396 @@
+package middleware
+
+import (
+    "bytes"
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/json"
+    "errors"
+    "io"
+    nethttp "net/http"
+    "strings"
+    "time"
+
+    cachecontract "github.com/precision-soft/melody/v3/cache/contract"
+    "github.com/precision-soft/melody/v3/exception"
+    exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+    "github.com/precision-soft/melody/v3/http"
+    httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    "github.com/precision-soft/melody/v3/internal"
+    lockcontract "github.com/precision-soft/melody/v3/lock/contract"
+    "github.com/precision-soft/melody/v3/logging"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+const (
+    HeaderIdempotencyKey     = "Idempotency-Key"
+    HeaderIdempotentReplayed = "Idempotent-Replayed"
+    idempotencyKeyMaxLength  = 255
+)
+
+/* @info headers of the stored response that belong to the original exchange and are not replayed. */
+var idempotencyExcludedHeaders = map[string]bool{
+    "Set-Cookie":         true,
+    http.HeaderRequestId: true,
+}
+
+type IdempotencyScopeResolver = func(runtimeInstance runtimecontract.Runtime, request httpcontract.Request) string
+
+type IdempotencyConfig struct {
+    cache         cachecontract.Cache
+    locker        lockcontract.Locker
+    routeNames    []string
+    methods       []string
+    ttl           time.Duration
+    lockTtl       time.Duration
+    keyRequired   bool
+    keyPrefix     string
+    scopeResolver IdempotencyScopeResolver
+}
+
+/* @info routeNames limits the middleware to those routes; an empty list applies it to every route using one of the configured methods. */
+func NewIdempotencyConfig(
+    cache cachecontract.Cache,
+    locker lockcontract.Locker,
+    routeNames []string,
+) *IdempotencyConfig {
+    var copiedRouteNames []string
+    if nil != routeNames {
+        copiedRouteNames = append([]string{}, routeNames...)
+    }
+
+    return &IdempotencyConfig{
+        cache:      cache,
+        locker:     locker,
+        routeNames: copiedRouteNames,
+        methods:    []string{nethttp.MethodPost, nethttp.MethodPatch},
+        ttl:        24 * time.Hour,
+        lockTtl:    time.Minute,
+        keyPrefix:  "idempotency:",
+    }
+}
+
+func (instance *IdempotencyConfig) Cache() cachecontract.Cache { return instance.cache }
+
+func (instance *IdempotencyConfig) Locker() lockcontract.Locker { return instance.locker }
+
+func (instance *IdempotencyConfig) RouteNames() []string {
+    return append([]string{}, instance.routeNames...)
+}
+
+func (instance *IdempotencyConfig) SetRouteNames(routeNames []string) {
+    instance.routeNames = append([]string{}, routeNames...)
+}
+
+func (instance *IdempotencyConfig) Methods() []string {
+    return append([]string{}, instance.methods...)
+}
+
+func (instance *IdempotencyConfig) SetMethods(methods []string) {
+    instance.methods = append([]string{}, methods...)
+}
+
+/* @info Ttl is how long a stored response is replayed for; the key can be reused once it expires. */
+func (instance *IdempotencyConfig) Ttl() time.Duration { return instance.ttl }
+
+func (instance *IdempotencyConfig) SetTtl(ttl time.Duration) { instance.ttl = ttl }
+
+/* @info LockTtl bounds how long a request in flight blocks duplicates; it should exceed the slowest handler. */
+func (instance *IdempotencyConfig) LockTtl() time.Duration { return instance.lockTtl }
+
+func (instance *IdempotencyConfig) SetLockTtl(lockTtl time.Duration) { instance.lockTtl = lockTtl }
+
+/* @info KeyRequired rejects requests without an Idempotency-Key with 400 instead of passing them through. */
+func (instance *IdempotencyConfig) KeyRequired() bool { return instance.keyRequired }
+
+func (instance *IdempotencyConfig) SetKeyRequired(keyRequired bool) {
+    instance.keyRequired = keyRequired
+}
+
+func (instance *IdempotencyConfig) KeyPrefix() string { return instance.keyPrefix }
+
+func (instance *IdempotencyConfig) SetKeyPrefix(keyPrefix string) { instance.keyPrefix = keyPrefix }
+
+func (instance *IdempotencyConfig) ScopeResolver() IdempotencyScopeResolver {
+    return instance.scopeResolver
+}
+
+/* @info SetScopeResolver replaces the default scope, the authenticated user identifier of the security token. */
+func (instance *IdempotencyConfig) SetScopeResolver(scopeResolver IdempotencyScopeResolver) {
+    instance.scopeResolver = scopeResolver
+}
+
+func (instance *IdempotencyConfig) applies(request httpcontract.Request) bool {
+    methodMatches := false
+    for _, method := range instance.methods {
+        if true == strings.EqualFold(method, request.HttpRequest().Method) {
+            methodMatches = true
+            break
+        }
+    }
+
+    if false == methodMatches {
+        return false
+    }
+
+    if 0 == len(instance.routeNames) {
+        return true
+    }
+
+    for _, routeName := range instance.routeNames {
+        if routeName == request.RouteName() {
+            return true
+        }
+    }
+
+    return false
+}
+
+/* @info the stored first response; Fingerprint is the hash of the method, path, query and body it answered. */
+type idempotencyRecord struct {
+    Fingerprint string              `json:"fingerprint"`
+    StatusCode  int                 `json:"statusCode"`
+    Headers     map[string][]string `json:"headers"`
+    Body        []byte              `json:"body"`
+}
+
+/*
+ * IdempotencyMiddleware stores the first response to a request carrying an Idempotency-Key, keyed per scope (the
+ * authenticated user by default), and replays it for repeats with the Idempotent-Replayed header. A repeat while the
+ * first request is still running gets 409; reusing a key for a different method, path, query or body gets 422.
+ * Errors, 5xx responses and handlers that write the response themselves (returning no response, such as Server-Sent
+ * Events) are not stored, so the client can retry them with the same key. A returned body is read into memory to be
+ * stored.
+ */
+func IdempotencyMiddleware(config *IdempotencyConfig) httpcontract.Middleware {
+    if true == internal.IsNilInterface(config.Cache()) {
+        exception.Panic(exception.NewError("cache is required for idempotency middleware", nil, nil))
+    }
+
+    if true == internal.IsNilInterface(config.Locker()) {
+        exception.Panic(exception.NewError("locker is required for idempotency middleware", nil, nil))
+    }
+
+    if nil == config.ScopeResolver() {
+        config.SetScopeResolver(defaultIdempotencyScope)
+    }
+
+    return func(next httpcontract.Handler) httpcontract.Handler {
+        return func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            if nil == request.HttpRequest() || false == config.applies(request) {
+                return next(runtimeInstance, writer, request)
+            }
+
+            idempotencyKey := strings.TrimSpace(request.HttpRequest().Header.Get(HeaderIdempotencyKey))
+            if "" == idempotencyKey {
+                if true == config.KeyRequired() {
+                    return nil, exception.BadRequest("the Idempotency-Key header is required")
+                }
+
+                return next(runtimeInstance, writer, request)
+            }
+
+            if idempotencyKeyMaxLength < len(idempotencyKey) {
+                return nil, exception.BadRequest("the Idempotency-Key header is too long")
+            }
+
+            fingerprint, fingerprintErr := idempotencyFingerprint(request.HttpRequest())
+            if nil != fingerprintErr {
+                return nil, fingerprintErr
+            }
+
+            cacheKey := config.KeyPrefix() + idempotencyHash(config.ScopeResolver()(runtimeInstance, request), idempotencyKey)
+
+            record, found, lookupErr := loadIdempotencyRecord(config.Cache(), cacheKey)
+            if nil != lookupErr {
+                return nil, lookupErr
+            }
+
+            if true == found {
+                return replayIdempotencyRecord(record, fingerprint)
+            }
+
+            lock := config.Locker().CreateLock(cacheKey+":lock", config.LockTtl())
+
+            acquired, acquireErr := lock.Acquire(runtimeInstance)
+            if nil != acquireErr {
+                return nil, exception.NewError("failed to acquire idempotency lock", nil, acquireErr)
+            }
+
+            if false == acquired {
+                return nil, exception.Conflict("a request with this Idempotency-Key is already being processed")
+            }
+
+            defer func() {
+                _ = lock.Release(runtimeInstance)
+            }()
+
+            /* @info the first request may have finished between the lookup and the lock. */
+            record, found, lookupErr = loadIdempotencyRecord(config.Cache(), cacheKey)
+            if nil != lookupErr {
+                return nil, lookupErr
+            }
+
+            if true == found {
+                return replayIdempotencyRecord(record, fingerprint)
+            }
+
+            response, nextErr := next(runtimeInstance, writer, request)
+            if nil != nextErr || nil == response || nethttp.StatusInternalServerError <= response.StatusCode() {
+                return response, nextErr
+            }
+
+            return storeIdempotencyRecord(runtimeInstance, config, cacheKey, fingerprint, response)
+        }
+    }
+}
+
+func defaultIdempotencyScope(runtimeInstance runtimecontract.Runtime, request httpcontract.Request) string {
+    return authenticatedUserScope(runtimeInstance)
+}
+
+/* @info reads the body to hash it and puts it back for the handler. */
+func idempotencyFingerprint(httpRequest *nethttp.Request) (string, error) {
+    var body []byte
+    if nil != httpRequest.Body {
+        readBody, readErr := io.ReadAll(httpRequest.Body)
+        if nil != readErr {
+            var maxBytesErr *nethttp.MaxBytesError
+            if true == errors.As(readErr, &maxBytesErr) {
+                return "", exception.NewHttpExceptionWithCause(nethttp.StatusRequestEntityTooLarge, "request body is too large", readErr)
+            }
+
+            return "", exception.NewHttpExceptionWithCause(nethttp.StatusBadRequest, "failed to read request body", readErr)
+        }
+
+        _ = httpRequest.Body.Close()
+        httpRequest.Body = io.NopCloser(bytes.NewReader(readBody))
+        body = readBody
+    }
+
+    hash := sha256.New()
+    hash.Write([]byte(httpRequest.Method))
+    hash.Write([]byte{0})
+    hash.Write([]byte(httpRequest.URL.Path))
+    hash.Write([]byte{0})
+    hash.Write([]byte(httpRequest.URL.RawQuery))
+    hash.Write([]byte{0})
+    hash.Write(body)
+
+    return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func idempotencyHash(scope string, idempotencyKey string) string {
+    hash := sha256.Sum256([]byte(scope + "\x00" + idempotencyKey))
+
+    return hex.EncodeToString(hash[:])
+}
+
+/* @info records are stored as a json string so any cache serializer returns them unchanged. */
+func loadIdempotencyRecord(cache cachecontract.Cache, cacheKey string) (*idempotencyRecord, bool, error) {
+    value, found, getErr := cache.Get(cacheKey)
+    if nil != getErr {
+        return nil, false, exception.NewError("failed to read idempotency record", exceptioncontract.Context{"cacheKey": cacheKey}, getErr)
+    }
+
+    if false == found {
+        return nil, false, nil
+    }
+
+    encoded, isString := value.(string)
+    if false == isString {
+        return nil, false, exception.NewError("invalid idempotency record", exceptioncontract.Context{"cacheKey": cacheKey}, nil)
+    }
+
+    record := &idempotencyRecord{}
+    unmarshalErr := json.Unmarshal([]byte(encoded), record)
+    if nil != unmarshalErr {
+        return nil, false, exception.NewError("invalid idempotency record", exceptioncontract.Context{"cacheKey": cacheKey}, unmarshalErr)
+    }
+
+    return record, true, nil
+}
+
+func replayIdempotencyRecord(record *idempotencyRecord, fingerprint string) (httpcontract.Response, error) {
+    if record.Fingerprint != fingerprint {
+        return nil, exception.UnprocessableEntity("the Idempotency-Key was already used for a different request")
+    }
+
+    response := http.NewResponse(record.StatusCode, record.Body)
+
+    headers := make(nethttp.Header, len(record.Headers)+1)
+    for name, values := range record.Headers {
+        headers[name] = append([]string{}, values...)
+    }
+    headers.Set(HeaderIdempotentReplayed, "true")
+
+    response.SetHeaders(headers)
+
+    return response, nil
+}
+
+/*
+ * @important the handler already ran its side effects, so a record that cannot be stored is logged and the response
+ * still returned; failing the request would make the client retry and repeat them.
+ */
+func storeIdempotencyRecord(
+    runtimeInstance runtimecontract.Runtime,
+    config *IdempotencyConfig,
+    cacheKey string,
+    fingerprint string,
+    response httpcontract.Response,
+) (httpcontract.Response, error) {
+    body, bufferErr := bufferResponseBody(response)
+    if nil != bufferErr {
+        logIdempotencyStoreFailure(runtimeInstance, bufferErr)
+
+        return response, nil
+    }
+
+    headers := make(map[string][]string)
+    for name, values := range response.Headers() {
+        if true == idempotencyExcludedHeaders[nethttp.CanonicalHeaderKey(name)] {
+            continue
+        }
+
+        headers[name] = append([]string{}, values...)
+    }
+
+    statusCode := response.StatusCode()
+    if 0 == statusCode {
+        statusCode = nethttp.StatusOK
+    }
+
+    encoded, marshalErr := json.Marshal(
+        idempotencyRecord{
+            Fingerprint: fingerprint,
+            StatusCode:  statusCode,
+            Headers:     headers,
+            Body:        body,
+        },
+    )
+    if nil != marshalErr {
+        logIdempotencyStoreFailure(runtimeInstance, exception.NewError("failed to encode idempotency record", nil, marshalErr))
+
+        return response, nil
+    }
+
+    setErr := config.Cache().Set(cacheKey, string(encoded), config.Ttl())
+    if nil != setErr {
+        logIdempotencyStoreFailure(
+            runtimeInstance,
+            exception.NewError("failed to store idempotency record", exceptioncontract.Context{"cacheKey": cacheKey}, setErr),
+        )
+    }
+
+    return response, nil
+}
+
+func logIdempotencyStoreFailure(runtimeInstance runtimecontract.Runtime, err error) {
+    logger := logging.LoggerFromRuntime(runtimeInstance)
+    if nil == logger {
+        return
+    }
+
+    logger.Error("idempotency record was not stored, a retry with the same key will run the handler again", exception.LogContext(err))
+}
@@ -0,0 +1,347 @@
+package middleware
+
+import (
+    "context"
+    "errors"
+    "io"
+    nethttp "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/precision-soft/melody/v3/cache"
+    cachecontract "github.com/precision-soft/melody/v3/cache/contract"
+    "github.com/precision-soft/melody/v3/clock"
+    "github.com/precision-soft/melody/v3/container"
+    "github.com/precision-soft/melody/v3/exception"
+    "github.com/precision-soft/melody/v3/http"
+    httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    "github.com/precision-soft/melody/v3/internal/testhelper"
+    "github.com/precision-soft/melody/v3/lock"
+    "github.com/precision-soft/melody/v3/runtime"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+func newIdempotencyTestConfig() *IdempotencyConfig {
+    clockInstance := clock.NewSystemClock()
+
+    return NewIdempotencyConfig(
+        cache.NewManager(cache.NewInMemoryBackend(100, time.Minute, clockInstance), cache.NewJsonSerializer()),
+        lock.NewInMemoryLocker(clockInstance),
+        nil,
+    )
+}
+
+func newIdempotencyTestRuntime() runtimecontract.Runtime {
+    serviceContainer := container.NewContainer()
+
+    return runtime.New(context.Background(), serviceContainer.NewScope(), serviceContainer)
+}
+
+func newIdempotencyTestRequest(path string, key string, body string) httpcontract.Request {
+    httpRequest := httptest.NewRequest(nethttp.MethodPost, path, strings.NewReader(body))
+    if "" != key {
+        httpRequest.Header.Set(HeaderIdempotencyKey, key)
+    }
+
+    return testhelper.NewHttpTestRequestFromHttpRequest(httpRequest)
+}
+
+func idempotencyStatus(t *testing.T, err error) int {
+    t.Helper()
+
+    httpException, isHttpException := err.(*exception.HttpException)
+    if false == isHttpException {
+        t.Fatalf("expected http exception, got: %v", err)
+    }
+
+    return httpException.StatusCode()
+}
+
+func readIdempotencyBody(t *testing.T, response httpcontract.Response) string {
+    t.Helper()
+
+    body, readErr := io.ReadAll(response.BodyReader())
+    if nil != readErr {
+        t.Fatalf("unexpected read error: %v", readErr)
+    }
+
+    return string(body)
+}
+
+func TestIdempotencyMiddleware_ReplaysFirstResponse(t *testing.T) {
+    calls := 0
+    handler := IdempotencyMiddleware(newIdempotencyTestConfig())(
+        func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            calls++
+
+            body, _ := io.ReadAll(request.HttpRequest().Body)
+            response := http.NewResponse(nethttp.StatusCreated, []byte("created:"+string(body)))
+            response.Headers().Set("Content-Type", "text/plain")
+            response.Headers().Set("Set-Cookie", "session=1")
+
+            return response, nil
+        },
+    )
+
+    runtimeInstance := newIdempotencyTestRuntime()
+
+    first, firstErr := handler(runtimeInstance, httptest.NewRecorder(), newIdempotencyTestRequest("/orders", "key-1", "a"))
+    if nil != firstErr {
+        t.Fatalf("unexpected error: %v", firstErr)
+    }
+
+    if "created:a" != readIdempotencyBody(t, first) {
+        t.Fatalf("expected the handler body to reach the client")
+    }
+
+    if "" != first.Headers().Get(HeaderIdempotentReplayed) {
+        t.Fatalf("expected the first response not to be marked as replayed")
+    }
+
+    replayed, replayedErr := handler(runtimeInstance, httptest.NewRecorder(), newIdempotencyTestRequest("/orders", "key-1", "a"))
+    if nil != replayedErr {
+        t.Fatalf("unexpected error: %v", replayedErr)
+    }
+
+    if 1 != calls {
+        t.Fatalf("expected the handler to run once, ran %d times", calls)
+    }
+
+    if nethttp.StatusCreated != replayed.StatusCode() || "created:a" != readIdempotencyBody(t, replayed) {
+        t.Fatalf("expected the stored response to be replayed")
+    }
+
+    if "true" != replayed.Headers().Get(HeaderIdempotentReplayed) || "text/plain" != replayed.Headers().Get("Content-Type") {
+        t.Fatalf("unexpected replay headers: %v", replayed.Headers())
+    }
+
+    if "" != replayed.Headers().Get("Set-Cookie") {
+        t.Fatalf("expected Set-Cookie not to be replayed")
+    }
+}
+
+func TestIdempotencyMiddleware_RejectsKeyReuseWithDifferentRequest(t *testing.T) {
+    handler := IdempotencyMiddleware(newIdempotencyTestConfig())(
+        func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            return http.NewResponse(nethttp.StatusOK, []byte("ok")), nil
+        },
+    )
+
+    runtimeInstance := newIdempotencyTestRuntime()
+
+    _, firstErr := handler(runtimeInstance, httptest.NewRecorder(), newIdempotencyTestRequest("/orders", "key-1", "a"))
+    if nil != firstErr {
+        t.Fatalf("unexpected error: %v", firstErr)
+    }
+
+    _, bodyErr := handler(runtimeInstance, httptest.NewRecorder(), newIdempotencyTestRequest("/orders", "key-1", "b"))
+    if nethttp.StatusUnprocessableEntity != idempotencyStatus(t, bodyErr) {
+        t.Fatalf("expected 422 for a different body")
+    }
+
+    _, pathErr := handler(runtimeInstance, httptest.NewRecorder(), newIdempotencyTestRequest("/orders?draft=1", "key-1", "a"))
+    if nethttp.StatusUnprocessableEntity != idempotencyStatus(t, pathErr) {
+        t.Fatalf("expected 422 for a different query")
+    }
+}
+
+func TestIdempotencyMiddleware_RejectsConcurrentDuplicate(t *testing.T) {
+    var handler httpcontract.Handler
+    var duplicateErr error
+
+    runtimeInstance := newIdempotencyTestRuntime()
+
+    handler = IdempotencyMiddleware(newIdempotencyTestConfig())(
+        func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            if nil == duplicateErr {
+                _, duplicateErr = handler(runtimeInstance, httptest.NewRecorder(), newIdempotencyTestRequest("/orders", "key-1", "a"))
+            }
+
+            return http.NewResponse(nethttp.StatusOK, []byte("ok")), nil
+        },
+    )
+
+    _, firstErr := handler(runtimeInstance, httptest.NewRecorder(), newIdempotencyTestRequest("/orders", "key-1", "a"))
+    if nil != firstErr {
+        t.Fatalf("unexpected error: %v", firstErr)
+    }
+
+    if nethttp.StatusConflict != idempotencyStatus(t, duplicateErr) {
+        t.Fatalf("expected 409 for a duplicate in flight")
+    }
+}
+
+func TestIdempotencyMiddleware_DoesNotStoreFailures(t *testing.T) {
+    calls := 0
+    handler := IdempotencyMiddleware(newIdempotencyTestConfig())(
+        func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            calls++
+            if 1 == calls {
+                return http.NewResponse(nethttp.StatusServiceUnavailable, []byte("down")), nil
+            }
+
+            return http.NewResponse(nethttp.StatusOK, []byte("ok")), nil
+        },
+    )
+
+    runtimeInstance := newIdempotencyTestRuntime()
+
+    for index := 0; index < 2; index++ {
+        _, handlerErr := handler(runtimeInstance, httptest.NewRecorder(), newIdempotencyTestRequest("/orders", "key-1", "a"))
+        if nil != handlerErr {
+            t.Fatalf("unexpected error: %v", handlerErr)
+        }
+    }
+
+    if 2 != calls {
+        t.Fatalf("expected the retry after a 5xx to reach the handler, calls: %d", calls)
+    }
+}
+
+func TestIdempotencyMiddleware_ScopesKeysAndSkipsUnconfiguredRequests(t *testing.T) {
+    calls := 0
+    config := newIdempotencyTestConfig()
+    config.SetScopeResolver(
+        func(runtimeInstance runtimecontract.Runtime, request httpcontract.Request) string {
+            return request.HttpRequest().Header.Get("X-User")
+        },
+    )
+
+    handler := IdempotencyMiddleware(config)(
+        func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            calls++
+
+            return http.NewResponse(nethttp.StatusOK, []byte("ok")), nil
+        },
+    )
+
+    runtimeInstance := newIdempotencyTestRuntime()
+
+    for _, user := range []string{"alice", "bob", "alice"} {
+        request := newIdempotencyTestRequest("/orders", "key-1", "a")
+        request.HttpRequest().Header.Set("X-User", user)
+
+        _, handlerErr := handler(runtimeInstance, httptest.NewRecorder(), request)
+        if nil != handlerErr {
+            t.Fatalf("unexpected error: %v", handlerErr)
+        }
+    }
+
+    if 2 != calls {
+        t.Fatalf("expected one handler call per scope, calls: %d", calls)
+    }
+
+    getRequest := testhelper.NewHttpTestRequestFromHttpRequest(httptest.NewRequest(nethttp.MethodGet, "/orders", nil))
+    getRequest.HttpRequest().Header.Set(HeaderIdempotencyKey, "key-1")
+
+    _, _ = handler(runtimeInstance, httptest.NewRecorder(), getRequest)
+    _, _ = handler(runtimeInstance, httptest.NewRecorder(), newIdempotencyTestRequest("/orders", "", "a"))
+
+    if 4 != calls {
+        t.Fatalf("expected GET and keyless requests to pass through, calls: %d", calls)
+    }
+}
+
+func TestIdempotencyMiddleware_RequiresKeyWhenConfigured(t *testing.T) {
+    config := newIdempotencyTestConfig()
+    config.SetKeyRequired(true)
+
+    handler := IdempotencyMiddleware(config)(
+        func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            return http.NewResponse(nethttp.StatusOK, []byte("ok")), nil
+        },
+    )
+
+    _, handlerErr := handler(newIdempotencyTestRuntime(), httptest.NewRecorder(), newIdempotencyTestRequest("/orders", "", "a"))
+    if nethttp.StatusBadRequest != idempotencyStatus(t, handlerErr) {
+        t.Fatalf("expected 400 for a missing key")
+    }
+}
+
+func TestDefaultIdempotencyScope_AnonymousWithoutSecurityContext(t *testing.T) {
+    scope := defaultIdempotencyScope(newIdempotencyTestRuntime(), newIdempotencyTestRequest("/orders", "key-1", ""))
+    if anonymousUserScope != scope {
+        t.Fatalf("expected anonymous scope, got: %s", scope)
+    }
+}
+
+func TestIdempotencyMiddleware_PanicsWithoutCache(t *testing.T) {
+    testhelper.AssertPanics(t, func() {
+        IdempotencyMiddleware(NewIdempotencyConfig(nil, lock.NewInMemoryLocker(clock.NewSystemClock()), nil))
+    })
+}
+
+type failingIdempotencyCache struct {
+    cachecontract.Cache
+}
+
+func (instance *failingIdempotencyCache) Set(key string, value any, ttl time.Duration) error {
+    return errors.New("cache unavailable")
+}
+
+func TestIdempotencyMiddleware_ReturnsHandlerResponseWhenStoreFails(t *testing.T) {
+    clockInstance := clock.NewSystemClock()
+    config := NewIdempotencyConfig(
+        &failingIdempotencyCache{
+            Cache: cache.NewManager(cache.NewInMemoryBackend(100, time.Minute, clockInstance), cache.NewJsonSerializer()),
+        },
+        lock.NewInMemoryLocker(clockInstance),
+        nil,
+    )
+
+    handler := IdempotencyMiddleware(config)(
+        func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            return http.NewResponse(nethttp.StatusCreated, []byte("created")), nil
+        },
+    )
+
+    response, err := handler(newIdempotencyTestRuntime(), httptest.NewRecorder(), newIdempotencyTestRequest("/orders", "key-1", "a"))
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    if nethttp.StatusCreated != response.StatusCode() || "created" != readIdempotencyBody(t, response) {
+        t.Fatalf("expected the handler response to be returned")
+    }
+}
+
+func TestIdempotencyMiddleware_ReturnsHandlerResponseWhenBodyCannotBeBuffered(t *testing.T) {
+    handler := IdempotencyMiddleware(newIdempotencyTestConfig())(
+        func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            response := http.NewResponse(nethttp.StatusCreated, nil)
+            response.SetBodyReader(newFailingReader("created", 3))
+
+            return response, nil
+        },
+    )
+
+    response, err := handler(newIdempotencyTestRuntime(), httptest.NewRecorder(), newIdempotencyTestRequest("/orders", "key-1", "a"))
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    body, readErr := io.ReadAll(response.BodyReader())
+    if nil == readErr || "created" != string(body) {
+        t.Fatalf("expected the partial body followed by the read error, got %q, %v", body, readErr)
+    }
+}
+
+func TestIdempotencyMiddleware_RejectsOversizedBodyWith413(t *testing.T) {
+    handler := IdempotencyMiddleware(newIdempotencyTestConfig())(
+        func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            t.Fatalf("expected the handler not to run")
+
+            return nil, nil
+        },
+    )
+
+    request := newIdempotencyTestRequest("/orders", "key-1", "too large")
+    request.HttpRequest().Body = nethttp.MaxBytesReader(httptest.NewRecorder(), request.HttpRequest().Body, 3)
+
+    _, err := handler(newIdempotencyTestRuntime(), httptest.NewRecorder(), request)
+    if nethttp.StatusRequestEntityTooLarge != idempotencyStatus(t, err) {
+        t.Fatalf("expected 413, got: %v", err)
+    }
+}
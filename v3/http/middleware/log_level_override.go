@@ -0,0 +1,152 @@
+package middleware
+
+import (
+    "crypto/hmac"
+    "crypto/sha256"
+    "encoding/hex"
+    nethttp "net/http"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/precision-soft/melody/v3/clock"
+    clockcontract "github.com/precision-soft/melody/v3/clock/contract"
+    "github.com/precision-soft/melody/v3/exception"
+    httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    "github.com/precision-soft/melody/v3/internal"
+    "github.com/precision-soft/melody/v3/logging"
+    loggingcontract "github.com/precision-soft/melody/v3/logging/contract"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+const HeaderLogLevel = "X-Log-Level"
+
+type LogLevelOverrideConfig struct {
+    secret     []byte
+    headerName string
+    clock      clockcontract.Clock
+}
+
+/* @info secret signs the header value; share it only with the operators allowed to raise the log level of a request. */
+func NewLogLevelOverrideConfig(secret []byte) *LogLevelOverrideConfig {
+    return &LogLevelOverrideConfig{
+        secret:     append([]byte{}, secret...),
+        headerName: HeaderLogLevel,
+        clock:      clock.NewSystemClock(),
+    }
+}
+
+func (instance *LogLevelOverrideConfig) HeaderName() string { return instance.headerName }
+
+func (instance *LogLevelOverrideConfig) SetHeaderName(headerName string) {
+    instance.headerName = headerName
+}
+
+func (instance *LogLevelOverrideConfig) Clock() clockcontract.Clock { return instance.clock }
+
+func (instance *LogLevelOverrideConfig) SetClock(clockInstance clockcontract.Clock) {
+    instance.clock = clockInstance
+}
+
+/*
+ * SignLogLevelOverride returns the header value raising one request to level until expiresAt:
+ * `<level>.<unix expiry>.<hex hmac-sha256 of "<level>.<unix expiry>">`.
+ */
+func SignLogLevelOverride(secret []byte, level loggingcontract.Level, expiresAt time.Time) string {
+    payload := string(level) + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+
+    return payload + "." + logLevelOverrideSignature(secret, payload)
+}
+
+/*
+ * LogLevelOverrideMiddleware sets the level override of the request log context when the request carries a valid,
+ * unexpired signed header (see SignLogLevelOverride). Invalid headers are ignored and logged as a warning, so the
+ * request is served either way.
+ */
+func LogLevelOverrideMiddleware(config *LogLevelOverrideConfig) httpcontract.Middleware {
+    if 0 == len(config.secret) {
+        exception.Panic(exception.NewError("secret is required for log level override middleware", nil, nil))
+    }
+
+    if "" == config.HeaderName() {
+        exception.Panic(exception.NewError("header name is required for log level override middleware", nil, nil))
+    }
+
+    if true == internal.IsNilInterface(config.Clock()) {
+        exception.Panic(exception.NewError("clock is required for log level override middleware", nil, nil))
+    }
+
+    return func(next httpcontract.Handler) httpcontract.Handler {
+        return func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            if nil == request.HttpRequest() {
+                return next(runtimeInstance, writer, request)
+            }
+
+            headerValue := strings.TrimSpace(request.HttpRequest().Header.Get(config.HeaderName()))
+            if "" == headerValue {
+                return next(runtimeInstance, writer, request)
+            }
+
+            logContext := logging.LogContextFromRuntime(runtimeInstance)
+            if nil == logContext {
+                return next(runtimeInstance, writer, request)
+            }
+
+            level, reason := config.verify(headerValue)
+            if "" != reason {
+                logger := logging.LoggerFromRuntime(runtimeInstance)
+                if nil != logger {
+                    logger.Warning(
+                        "ignored log level override header",
+                        loggingcontract.Context{
+                            "header": config.HeaderName(),
+                            "reason": reason,
+                        },
+                    )
+                }
+
+                return next(runtimeInstance, writer, request)
+            }
+
+            logContext.SetLevelOverride(level)
+
+            return next(runtimeInstance, writer, request)
+        }
+    }
+}
+
+/* verify returns the signed level, or the reason the header value is rejected. */
+func (instance *LogLevelOverrideConfig) verify(headerValue string) (loggingcontract.Level, string) {
+    parts := strings.Split(headerValue, ".")
+    if 3 != len(parts) {
+        return "", "malformed"
+    }
+
+    expectedSignature := logLevelOverrideSignature(instance.secret, parts[0]+"."+parts[1])
+    if false == hmac.Equal([]byte(expectedSignature), []byte(parts[2])) {
+        return "", "invalid_signature"
+    }
+
+    expiresAt, parseErr := strconv.ParseInt(parts[1], 10, 64)
+    if nil != parseErr {
+        return "", "malformed"
+    }
+
+    if false == instance.clock.Now().Before(time.Unix(expiresAt, 0)) {
+        return "", "expired"
+    }
+
+    level := loggingcontract.Level(parts[0])
+    if false == logging.IsValidLevel(level) {
+        return "", "invalid_level"
+    }
+
+    return level, ""
+}
+
+func logLevelOverrideSignature(secret []byte, payload string) string {
+    mac := hmac.New(sha256.New, secret)
+    _, _ = mac.Write([]byte(payload))
+
+    return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+    "context"
+    nethttp "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+
+    "github.com/precision-soft/melody/v3/clock"
+    "github.com/precision-soft/melody/v3/container"
+    httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    "github.com/precision-soft/melody/v3/internal/testhelper"
+    "github.com/precision-soft/melody/v3/logging"
+    loggingcontract "github.com/precision-soft/melody/v3/logging/contract"
+    "github.com/precision-soft/melody/v3/runtime"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+var logLevelOverrideTestSecret = []byte("secret")
+
+func runLogLevelOverride(t *testing.T, config *LogLevelOverrideConfig, headerValue string) *logging.LogContext {
+    t.Helper()
+
+    serviceContainer := container.NewContainer()
+    scope := serviceContainer.NewScope()
+
+    logContext := logging.NewLogContext()
+    scope.MustOverrideProtectedInstance(logging.ServiceLogContext, logContext)
+    scope.MustOverrideProtectedInstance(logging.ServiceLogger, logging.NewNopLogger())
+
+    httpRequest := httptest.NewRequest(nethttp.MethodGet, "/", nil)
+    if "" != headerValue {
+        httpRequest.Header.Set(HeaderLogLevel, headerValue)
+    }
+
+    called := false
+    handler := LogLevelOverrideMiddleware(config)(
+        func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            called = true
+
+            return nil, nil
+        },
+    )
+
+    _, _ = handler(
+        runtime.New(context.Background(), scope, serviceContainer),
+        httptest.NewRecorder(),
+        testhelper.NewHttpTestRequestFromHttpRequest(httpRequest),
+    )
+
+    if false == called {
+        t.Fatalf("expected the request to be served")
+    }
+
+    return logContext
+}
+
+func TestLogLevelOverrideMiddleware_AppliesSignedHeader(t *testing.T) {
+    frozenClock := clock.NewFrozenClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+    config := NewLogLevelOverrideConfig(logLevelOverrideTestSecret)
+    config.SetClock(frozenClock)
+
+    headerValue := SignLogLevelOverride(logLevelOverrideTestSecret, loggingcontract.LevelDebug, frozenClock.Now().Add(time.Minute))
+
+    level, overridden := runLogLevelOverride(t, config, headerValue).LevelOverride()
+    if false == overridden || loggingcontract.LevelDebug != level {
+        t.Fatalf("expected debug override, got %q %v", level, overridden)
+    }
+}
+
+func TestLogLevelOverrideMiddleware_IgnoresInvalidHeaders(t *testing.T) {
+    frozenClock := clock.NewFrozenClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+    config := NewLogLevelOverrideConfig(logLevelOverrideTestSecret)
+    config.SetClock(frozenClock)
+
+    for name, headerValue := range map[string]string{
+        "missing":       "",
+        "malformed":     "debug",
+        "wrong secret":  SignLogLevelOverride([]byte("other"), loggingcontract.LevelDebug, frozenClock.Now().Add(time.Minute)),
+        "expired":       SignLogLevelOverride(logLevelOverrideTestSecret, loggingcontract.LevelDebug, frozenClock.Now().Add(-time.Second)),
+        "invalid level": SignLogLevelOverride(logLevelOverrideTestSecret, loggingcontract.Level("verbose"), frozenClock.Now().Add(time.Minute)),
+    } {
+        if _, overridden := runLogLevelOverride(t, config, headerValue).LevelOverride(); true == overridden {
+            t.Fatalf("expected %s header to be ignored", name)
+        }
+    }
+}
@@ -0,0 +1,142 @@
+package pipeline
+
+import (
+    "reflect"
+    "runtime"
+
+    httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    kernelcontract "github.com/precision-soft/melody/v3/kernel/contract"
+)
+
+/* BuildRouteReports builds the global pipeline of the group and describes, per registered route, the effective chain: global middleware, then group middleware (outer group first), then route middleware. */
+func (instance *Builder) BuildRouteReports(
+    kernelInstance kernelcontract.Kernel,
+    group string,
+) ([]*RouteMiddlewareReport, error) {
+    middlewares, buildReport, buildErr := instance.Build(kernelInstance, group)
+    if nil != buildErr {
+        return nil, buildErr
+    }
+
+    globalEntries := make([]*MiddlewareLayerEntry, 0, len(middlewares))
+    selectedNames := buildReport.SelectedNames()
+    for index, middleware := range middlewares {
+        definitionName := ""
+        if index < len(selectedNames) {
+            definitionName = selectedNames[index]
+        }
+
+        globalEntries = append(
+            globalEntries,
+            NewMiddlewareLayerEntry(httpcontract.RouteMiddlewareLayerGlobal, definitionName, MiddlewareName(middleware)),
+        )
+    }
+
+    routeDefinitions := kernelInstance.HttpRouter().RouteDefinitions()
+
+    reports := make([]*RouteMiddlewareReport, 0, len(routeDefinitions))
+    for _, routeDefinition := range routeDefinitions {
+        entries := append([]*MiddlewareLayerEntry{}, globalEntries...)
+
+        middlewareRouteDefinition, isMiddlewareRouteDefinition := routeDefinition.(httpcontract.MiddlewareRouteDefinition)
+        if true == isMiddlewareRouteDefinition {
+            for _, routeMiddleware := range middlewareRouteDefinition.RouteMiddlewares() {
+                entries = append(
+                    entries,
+                    NewMiddlewareLayerEntry(routeMiddleware.Layer, routeMiddleware.Source, MiddlewareName(routeMiddleware.Middleware)),
+                )
+            }
+        }
+
+        reports = append(
+            reports,
+            NewRouteMiddlewareReport(routeDefinition.Name(), routeDefinition.Pattern(), entries),
+        )
+    }
+
+    return reports, nil
+}
+
+func NewMiddlewareLayerEntry(layer string, source string, name string) *MiddlewareLayerEntry {
+    return &MiddlewareLayerEntry{
+        layer:  layer,
+        source: source,
+        name:   name,
+    }
+}
+
+/* MiddlewareLayerEntry is one middleware of an effective route chain; source is the pipeline definition name (global), the group path prefix (group) or the route name (route). */
+type MiddlewareLayerEntry struct {
+    layer  string
+    source string
+    name   string
+}
+
+func (instance *MiddlewareLayerEntry) Layer() string { return instance.layer }
+
+func (instance *MiddlewareLayerEntry) Source() string { return instance.source }
+
+func (instance *MiddlewareLayerEntry) Name() string { return instance.name }
+
+func NewRouteMiddlewareReport(
+    routeName string,
+    pattern string,
+    entries []*MiddlewareLayerEntry,
+) *RouteMiddlewareReport {
+    return &RouteMiddlewareReport{
+        routeName: routeName,
+        pattern:   pattern,
+        entries:   copyMiddlewareLayerEntrySlice(entries),
+    }
+}
+
+type RouteMiddlewareReport struct {
+    routeName string
+    pattern   string
+    entries   []*MiddlewareLayerEntry
+}
+
+func (instance *RouteMiddlewareReport) RouteName() string {
+    return instance.routeName
+}
+
+func (instance *RouteMiddlewareReport) Pattern() string {
+    return instance.pattern
+}
+
+/* Entries is the effective chain, outermost first. */
+func (instance *RouteMiddlewareReport) Entries() []*MiddlewareLayerEntry {
+    return copyMiddlewareLayerEntrySlice(instance.entries)
+}
+
+/* MiddlewareName is the function name of the middleware, `<nil>` or `<unknown>` when it cannot be resolved. */
+func MiddlewareName(middleware httpcontract.Middleware) string {
+    if nil == middleware {
+        return "<nil>"
+    }
+
+    value := reflect.ValueOf(middleware)
+    if reflect.Func != value.Kind() {
+        return "<unknown>"
+    }
+
+    pointer := value.Pointer()
+    if 0 == pointer {
+        return "<unknown>"
+    }
+
+    function := runtime.FuncForPC(pointer)
+    if nil == function {
+        return "<unknown>"
+    }
+
+    return function.Name()
+}
+
+func copyMiddlewareLayerEntrySlice(values []*MiddlewareLayerEntry) []*MiddlewareLayerEntry {
+    if nil == values {
+        return nil
+    }
+
+    return append([]*MiddlewareLayerEntry{}, values...)
+}
@@ -0,0 +1,522 @@
+package middleware
+
+import (
+    "bytes"
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/json"
+    "io"
+    nethttp "net/http"
+    "net/url"
+    "slices"
+    "sort"
+    "strings"
+    "time"
+
+    "github.com/precision-soft/melody/v3/cache"
+    cachecontract "github.com/precision-soft/melody/v3/cache/contract"
+    "github.com/precision-soft/melody/v3/clock"
+    clockcontract "github.com/precision-soft/melody/v3/clock/contract"
+    "github.com/precision-soft/melody/v3/exception"
+    exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+    "github.com/precision-soft/melody/v3/http"
+    httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    "github.com/precision-soft/melody/v3/internal"
+    "github.com/precision-soft/melody/v3/logging"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+const (
+    HeaderResponseCache = "X-Cache"
+    ResponseCacheHit    = "HIT"
+    ResponseCacheMiss   = "MISS"
+)
+
+/* @info request headers that every key varies on, since content negotiation and compression pick the body from them. */
+var responseCacheKeyHeaders = []string{"Accept", "Accept-Encoding"}
+
+/* @info headers replayed on a 304, as RFC 9110 asks for the validators and the caching headers of the full response. */
+var responseCacheNotModifiedHeaders = []string{"Cache-Control", "Content-Location", "Date", "ETag", "Expires", "Last-Modified", "Vary"}
+
+/*
+ * ResponseCachePolicy describes how one route is cached. The cache key is built from the path, the query (every
+ * parameter, or only VaryQuery when set), Accept, Accept-Encoding, the values of the VaryHeaders and, with VaryByUser,
+ * the authenticated user.
+ * Tags may reference route parameters as {name}, e.g. "product:{id}".
+ */
+type ResponseCachePolicy struct {
+    ttl         time.Duration
+    varyHeaders []string
+    varyQuery   []string
+    varyByUser  bool
+    tags        []string
+}
+
+func NewResponseCachePolicy(ttl time.Duration) *ResponseCachePolicy {
+    if 0 >= ttl {
+        exception.Panic(exception.NewError("response cache ttl must be positive", exceptioncontract.Context{"ttl": ttl.String()}, nil))
+    }
+
+    return &ResponseCachePolicy{
+        ttl: ttl,
+    }
+}
+
+func (instance *ResponseCachePolicy) Ttl() time.Duration { return instance.ttl }
+
+func (instance *ResponseCachePolicy) VaryHeaders() []string {
+    return append([]string{}, instance.varyHeaders...)
+}
+
+func (instance *ResponseCachePolicy) SetVaryHeaders(varyHeaders ...string) *ResponseCachePolicy {
+    canonicalHeaders := make([]string, 0, len(varyHeaders))
+    for _, header := range varyHeaders {
+        canonicalHeaders = append(canonicalHeaders, nethttp.CanonicalHeaderKey(header))
+    }
+
+    sort.Strings(canonicalHeaders)
+    instance.varyHeaders = canonicalHeaders
+
+    return instance
+}
+
+func (instance *ResponseCachePolicy) VaryQuery() []string {
+    return append([]string{}, instance.varyQuery...)
+}
+
+func (instance *ResponseCachePolicy) SetVaryQuery(parameterNames ...string) *ResponseCachePolicy {
+    instance.varyQuery = append([]string{}, parameterNames...)
+
+    return instance
+}
+
+func (instance *ResponseCachePolicy) VaryByUser() bool { return instance.varyByUser }
+
+func (instance *ResponseCachePolicy) SetVaryByUser(varyByUser bool) *ResponseCachePolicy {
+    instance.varyByUser = varyByUser
+
+    return instance
+}
+
+func (instance *ResponseCachePolicy) Tags() []string {
+    return append([]string{}, instance.tags...)
+}
+
+func (instance *ResponseCachePolicy) SetTags(tags ...string) *ResponseCachePolicy {
+    instance.tags = append([]string{}, tags...)
+
+    return instance
+}
+
+type ResponseCacheConfig struct {
+    cache         cachecontract.TagAwareCache
+    routePolicies map[string]*ResponseCachePolicy
+    defaultPolicy *ResponseCachePolicy
+    keyPrefix     string
+    clock         clockcontract.Clock
+}
+
+/* @info a cache without tag support is wrapped in cache.NewTaggedCache; only routes with a policy are cached. */
+func NewResponseCacheConfig(cacheInstance cachecontract.Cache) *ResponseCacheConfig {
+    if true == internal.IsNilInterface(cacheInstance) {
+        exception.Panic(exception.NewError("cache is required for response cache middleware", nil, nil))
+    }
+
+    tagAwareCache, isTagAware := cacheInstance.(cachecontract.TagAwareCache)
+    if false == isTagAware {
+        tagAwareCache = cache.NewTaggedCache(cacheInstance)
+    }
+
+    return &ResponseCacheConfig{
+        cache:         tagAwareCache,
+        routePolicies: make(map[string]*ResponseCachePolicy),
+        keyPrefix:     "http_cache:",
+        clock:         clock.NewSystemClock(),
+    }
+}
+
+func (instance *ResponseCacheConfig) Cache() cachecontract.TagAwareCache { return instance.cache }
+
+func (instance *ResponseCacheConfig) RoutePolicy(routeName string) (*ResponseCachePolicy, bool) {
+    policy, exists := instance.routePolicies[routeName]
+
+    return policy, exists
+}
+
+func (instance *ResponseCacheConfig) SetRoutePolicy(routeName string, policy *ResponseCachePolicy) *ResponseCacheConfig {
+    if nil == policy {
+        delete(instance.routePolicies, routeName)
+
+        return instance
+    }
+
+    instance.routePolicies[routeName] = policy
+
+    return instance
+}
+
+/* @info DefaultPolicy applies to routes without their own policy; nil (the default) leaves them uncached. */
+func (instance *ResponseCacheConfig) DefaultPolicy() *ResponseCachePolicy {
+    return instance.defaultPolicy
+}
+
+func (instance *ResponseCacheConfig) SetDefaultPolicy(policy *ResponseCachePolicy) *ResponseCacheConfig {
+    instance.defaultPolicy = policy
+
+    return instance
+}
+
+func (instance *ResponseCacheConfig) KeyPrefix() string { return instance.keyPrefix }
+
+func (instance *ResponseCacheConfig) SetKeyPrefix(keyPrefix string) *ResponseCacheConfig {
+    instance.keyPrefix = keyPrefix
+
+    return instance
+}
+
+func (instance *ResponseCacheConfig) Clock() clockcontract.Clock { return instance.clock }
+
+func (instance *ResponseCacheConfig) SetClock(clockInstance clockcontract.Clock) *ResponseCacheConfig {
+    instance.clock = clockInstance
+
+    return instance
+}
+
+/* @info InvalidateTags drops every cached response stored with one of the tags, on this and any replica sharing the cache. */
+func (instance *ResponseCacheConfig) InvalidateTags(tags ...string) error {
+    return instance.cache.InvalidateTags(tags...)
+}
+
+func (instance *ResponseCacheConfig) policyFor(request httpcontract.Request) *ResponseCachePolicy {
+    policy, exists := instance.routePolicies[request.RouteName()]
+    if true == exists {
+        return policy
+    }
+
+    return instance.defaultPolicy
+}
+
+type responseCacheRecord struct {
+    StatusCode   int                 `json:"statusCode"`
+    Headers      map[string][]string `json:"headers"`
+    Body         []byte              `json:"body"`
+    Etag         string              `json:"etag"`
+    LastModified int64               `json:"lastModified"`
+}
+
+/*
+ * ResponseCacheMiddleware serves GET and HEAD requests of routes with a policy from the cache. A stored response is
+ * replayed with X-Cache: HIT, or answered with 304 when If-None-Match or If-Modified-Since match its validators. A miss
+ * runs the handler and stores a 200 response without Set-Cookie or Cache-Control no-store/private (private is accepted
+ * with VaryByUser), adding an ETag (a body hash) and Last-Modified when the handler did not set them.
+ */
+func ResponseCacheMiddleware(config *ResponseCacheConfig) httpcontract.Middleware {
+    return func(next httpcontract.Handler) httpcontract.Handler {
+        return func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            httpRequest := request.HttpRequest()
+            if nil == httpRequest || (nethttp.MethodGet != httpRequest.Method && nethttp.MethodHead != httpRequest.Method) {
+                return next(runtimeInstance, writer, request)
+            }
+
+            policy := config.policyFor(request)
+            if nil == policy {
+                return next(runtimeInstance, writer, request)
+            }
+
+            cacheKey := config.KeyPrefix() + responseCacheKey(runtimeInstance, request, policy)
+
+            value, found, getErr := config.Cache().Get(cacheKey)
+            if nil != getErr {
+                logResponseCacheFailure(
+                    runtimeInstance,
+                    exception.NewError("failed to read cached response", exceptioncontract.Context{"cacheKey": cacheKey}, getErr),
+                )
+
+                found = false
+            }
+
+            if true == found {
+                record, decodeErr := decodeResponseCacheRecord(value)
+                if nil == decodeErr {
+                    return replayResponseCacheRecord(httpRequest, record), nil
+                }
+
+                /* @info an unreadable record is dropped and treated as a miss, so the handler replaces it */
+                logResponseCacheFailure(
+                    runtimeInstance,
+                    exception.NewError("invalid cached response", exceptioncontract.Context{"cacheKey": cacheKey}, decodeErr),
+                )
+
+                _ = config.Cache().Delete(cacheKey)
+            }
+
+            response, nextErr := next(runtimeInstance, writer, request)
+            if nil != nextErr || nil == response || false == isResponseCacheable(response, policy) {
+                return response, nextErr
+            }
+
+            body, bufferErr := bufferResponseBody(response)
+            if nil != bufferErr {
+                return nil, bufferErr
+            }
+
+            record := newResponseCacheRecord(response, body, policy, config.Clock().Now())
+
+            encoded, marshalErr := json.Marshal(record)
+            if nil != marshalErr {
+                return nil, exception.NewError("failed to encode cached response", nil, marshalErr)
+            }
+
+            response.SetHeaders(record.Headers)
+            response.Headers().Set(HeaderResponseCache, ResponseCacheMiss)
+
+            /* @important the handler already produced a valid response, so a cache outage is logged and the response served uncached */
+            setErr := config.Cache().SetWithTags(cacheKey, string(encoded), policy.Ttl(), resolveResponseCacheTags(policy.Tags(), request))
+            if nil != setErr {
+                logResponseCacheFailure(
+                    runtimeInstance,
+                    exception.NewError("failed to store cached response", exceptioncontract.Context{"cacheKey": cacheKey}, setErr),
+                )
+            }
+
+            if true == isResponseCacheNotModified(httpRequest, record) {
+                return notModifiedResponse(record), nil
+            }
+
+            return response, nil
+        }
+    }
+}
+
+func responseCacheKey(runtimeInstance runtimecontract.Runtime, request httpcontract.Request, policy *ResponseCachePolicy) string {
+    httpRequest := request.HttpRequest()
+
+    query := httpRequest.URL.Query()
+    if 0 < len(policy.varyQuery) {
+        selected := url.Values{}
+        for _, parameterName := range policy.varyQuery {
+            if values, exists := query[parameterName]; true == exists {
+                selected[parameterName] = values
+            }
+        }
+
+        query = selected
+    }
+
+    hash := sha256.New()
+    hash.Write([]byte(httpRequest.Method))
+    hash.Write([]byte{0})
+    hash.Write([]byte(httpRequest.URL.Path))
+    hash.Write([]byte{0})
+    hash.Write([]byte(query.Encode()))
+
+    for _, header := range append(append([]string{}, responseCacheKeyHeaders...), policy.varyHeaders...) {
+        hash.Write([]byte{0})
+        hash.Write([]byte(header + ":" + strings.Join(httpRequest.Header.Values(header), ",")))
+    }
+
+    if true == policy.varyByUser {
+        hash.Write([]byte{0})
+        hash.Write([]byte(authenticatedUserScope(runtimeInstance)))
+    }
+
+    return hex.EncodeToString(hash.Sum(nil))
+}
+
+func isResponseCacheable(response httpcontract.Response, policy *ResponseCachePolicy) bool {
+    if nethttp.StatusOK != response.StatusCode() && 0 != response.StatusCode() {
+        return false
+    }
+
+    if "" != response.Headers().Get("Set-Cookie") {
+        return false
+    }
+
+    for _, directive := range strings.Split(strings.ToLower(response.Headers().Get("Cache-Control")), ",") {
+        directive = strings.TrimSpace(directive)
+        if "no-store" == directive || ("private" == directive && false == policy.varyByUser) {
+            return false
+        }
+    }
+
+    return isResponseVaryKeyed(response.Headers().Values("Vary"), policy)
+}
+
+/* @info a response varying on a header the key does not include would be replayed to clients that sent another value. */
+func isResponseVaryKeyed(varyValues []string, policy *ResponseCachePolicy) bool {
+    for _, varyValue := range varyValues {
+        for _, header := range strings.Split(varyValue, ",") {
+            header = nethttp.CanonicalHeaderKey(strings.TrimSpace(header))
+            if "" == header {
+                continue
+            }
+
+            if "*" == header {
+                return false
+            }
+
+            if false == slices.Contains(responseCacheKeyHeaders, header) && false == slices.Contains(policy.varyHeaders, header) {
+                return false
+            }
+        }
+    }
+
+    return true
+}
+
+func logResponseCacheFailure(runtimeInstance runtimecontract.Runtime, err error) {
+    logger := logging.LoggerFromRuntime(runtimeInstance)
+    if nil == logger {
+        return
+    }
+
+    logger.Warning("response cache is unavailable, serving the response uncached", exception.LogContext(err))
+}
+
+func newResponseCacheRecord(response httpcontract.Response, body []byte, policy *ResponseCachePolicy, now time.Time) *responseCacheRecord {
+    headers := response.Headers().Clone()
+    if nil == headers {
+        headers = nethttp.Header{}
+    }
+
+    etag := headers.Get("ETag")
+    if "" == etag {
+        bodyHash := sha256.Sum256(body)
+        etag = `"` + hex.EncodeToString(bodyHash[:16]) + `"`
+        headers.Set("ETag", etag)
+    }
+
+    lastModified := now.UTC().Truncate(time.Second)
+    if parsed, parseErr := nethttp.ParseTime(headers.Get("Last-Modified")); nil == parseErr {
+        lastModified = parsed.UTC()
+    } else {
+        headers.Set("Last-Modified", lastModified.Format(nethttp.TimeFormat))
+    }
+
+    for _, header := range policy.varyHeaders {
+        headers.Add("Vary", header)
+    }
+
+    headers.Del(HeaderResponseCache)
+
+    return &responseCacheRecord{
+        StatusCode:   nethttp.StatusOK,
+        Headers:      headers,
+        Body:         body,
+        Etag:         etag,
+        LastModified: lastModified.Unix(),
+    }
+}
+
+func decodeResponseCacheRecord(value any) (*responseCacheRecord, error) {
+    encoded, isString := value.(string)
+    if false == isString {
+        return nil, exception.NewError("cached response is not a string", nil, nil)
+    }
+
+    record := &responseCacheRecord{}
+    unmarshalErr := json.Unmarshal([]byte(encoded), record)
+    if nil != unmarshalErr {
+        return nil, unmarshalErr
+    }
+
+    return record, nil
+}
+
+func replayResponseCacheRecord(httpRequest *nethttp.Request, record *responseCacheRecord) httpcontract.Response {
+    if true == isResponseCacheNotModified(httpRequest, record) {
+        return notModifiedResponse(record)
+    }
+
+    response := http.NewResponse(record.StatusCode, record.Body)
+    response.SetHeaders(record.Headers)
+    response.Headers().Set(HeaderResponseCache, ResponseCacheHit)
+
+    return response
+}
+
+func notModifiedResponse(record *responseCacheRecord) httpcontract.Response {
+    storedHeaders := nethttp.Header(record.Headers)
+
+    headers := nethttp.Header{}
+    for _, header := range responseCacheNotModifiedHeaders {
+        for _, value := range storedHeaders.Values(header) {
+            headers.Add(header, value)
+        }
+    }
+
+    response := http.NewResponse(nethttp.StatusNotModified, nil)
+    response.SetHeaders(headers)
+
+    return response
+}
+
+/* @info If-None-Match takes precedence and compares weakly; If-Modified-Since is only used without it (RFC 9110 13.1.3). */
+func isResponseCacheNotModified(httpRequest *nethttp.Request, record *responseCacheRecord) bool {
+    ifNoneMatch := httpRequest.Header.Get("If-None-Match")
+    if "" != ifNoneMatch {
+        for _, candidate := range strings.Split(ifNoneMatch, ",") {
+            candidate = strings.TrimSpace(candidate)
+            if "*" == candidate || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(record.Etag, "W/") {
+                return true
+            }
+        }
+
+        return false
+    }
+
+    ifModifiedSince, parseErr := nethttp.ParseTime(httpRequest.Header.Get("If-Modified-Since"))
+    if nil != parseErr {
+        return false
+    }
+
+    return false == time.Unix(record.LastModified, 0).After(ifModifiedSince)
+}
+
+func resolveResponseCacheTags(tags []string, request httpcontract.Request) []string {
+    if 0 == len(tags) {
+        return nil
+    }
+
+    replacements := make([]string, 0, 2*len(request.Params()))
+    for name, value := range request.Params() {
+        replacements = append(replacements, "{"+name+"}", value)
+    }
+
+    replacer := strings.NewReplacer(replacements...)
+
+    resolved := make([]string, 0, len(tags))
+    for _, tag := range tags {
+        resolved = append(resolved, replacer.Replace(tag))
+    }
+
+    return resolved
+}
+
+/* @info reads the whole body and gives the response a fresh reader over it. */
+/* @info on a read failure the response keeps the bytes read so far followed by the same error, so a caller that still returns it fails the write exactly as the unbuffered body would have */
+func bufferResponseBody(response httpcontract.Response) ([]byte, error) {
+    if nil == response.BodyReader() {
+        return nil, nil
+    }
+
+    body, readErr := io.ReadAll(response.BodyReader())
+    closeBodyReaderQuiet(response.BodyReader())
+    if nil != readErr {
+        response.SetBodyReader(io.MultiReader(bytes.NewReader(body), &failedBodyReader{err: readErr}))
+
+        return nil, exception.NewError("failed to read response body", nil, readErr)
+    }
+
+    response.SetBodyReader(bytes.NewReader(body))
+
+    return body, nil
+}
+
+type failedBodyReader struct {
+    err error
+}
+
+func (instance *failedBodyReader) Read([]byte) (int, error) {
+    return 0, instance.err
+}
@@ -0,0 +1,350 @@
+package middleware
+
+import (
+    "errors"
+    nethttp "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+
+    "github.com/precision-soft/melody/v3/cache"
+    cachecontract "github.com/precision-soft/melody/v3/cache/contract"
+    "github.com/precision-soft/melody/v3/clock"
+    "github.com/precision-soft/melody/v3/http"
+    httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+func newResponseCacheTestConfig(policy *ResponseCachePolicy) *ResponseCacheConfig {
+    frozenClock := clock.NewFrozenClock(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+
+    config := NewResponseCacheConfig(cache.NewManager(cache.NewInMemoryBackend(100, time.Minute, frozenClock), cache.NewJsonSerializer()))
+    config.SetDefaultPolicy(policy)
+    config.SetClock(frozenClock)
+
+    return config
+}
+
+func newResponseCacheTestRequest(target string, params map[string]string, headers map[string]string) httpcontract.Request {
+    httpRequest := httptest.NewRequest(nethttp.MethodGet, target, nil)
+    for name, value := range headers {
+        httpRequest.Header.Set(name, value)
+    }
+
+    return http.NewRequest(httpRequest, params, nil, nil)
+}
+
+func newResponseCacheTestHandler(config *ResponseCacheConfig, calls *int) httpcontract.Handler {
+    return ResponseCacheMiddleware(config)(
+        func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            *calls++
+
+            response := http.NewResponse(nethttp.StatusOK, []byte("product "+request.HttpRequest().URL.RawQuery))
+            response.Headers().Set("Content-Type", "text/plain")
+
+            return response, nil
+        },
+    )
+}
+
+func TestResponseCacheMiddleware_StoresAndReplays(t *testing.T) {
+    calls := 0
+    handler := newResponseCacheTestHandler(newResponseCacheTestConfig(NewResponseCachePolicy(time.Minute)), &calls)
+    runtimeInstance := newIdempotencyTestRuntime()
+
+    first, firstErr := handler(runtimeInstance, httptest.NewRecorder(), newResponseCacheTestRequest("/products/42?b=2&a=1", nil, nil))
+    if nil != firstErr {
+        t.Fatalf("unexpected error: %v", firstErr)
+    }
+
+    if ResponseCacheMiss != first.Headers().Get(HeaderResponseCache) || "" == first.Headers().Get("ETag") || "" == first.Headers().Get("Last-Modified") {
+        t.Fatalf("unexpected miss headers: %v", first.Headers())
+    }
+
+    second, secondErr := handler(runtimeInstance, httptest.NewRecorder(), newResponseCacheTestRequest("/products/42?a=1&b=2", nil, nil))
+    if nil != secondErr {
+        t.Fatalf("unexpected error: %v", secondErr)
+    }
+
+    if 1 != calls {
+        t.Fatalf("expected the handler to run once, ran %d times", calls)
+    }
+
+    if ResponseCacheHit != second.Headers().Get(HeaderResponseCache) || first.Headers().Get("ETag") != second.Headers().Get("ETag") {
+        t.Fatalf("unexpected hit headers: %v", second.Headers())
+    }
+
+    if "product b=2&a=1" != readIdempotencyBody(t, second) || "text/plain" != second.Headers().Get("Content-Type") {
+        t.Fatalf("expected the stored response to be replayed")
+    }
+
+    _, _ = handler(runtimeInstance, httptest.NewRecorder(), newResponseCacheTestRequest("/products/42?a=2", nil, nil))
+    if 2 != calls {
+        t.Fatalf("expected a different query to miss, calls: %d", calls)
+    }
+}
+
+func TestResponseCacheMiddleware_AnswersConditionalRequests(t *testing.T) {
+    calls := 0
+    handler := newResponseCacheTestHandler(newResponseCacheTestConfig(NewResponseCachePolicy(time.Minute)), &calls)
+    runtimeInstance := newIdempotencyTestRuntime()
+
+    first, _ := handler(runtimeInstance, httptest.NewRecorder(), newResponseCacheTestRequest("/products", nil, nil))
+    etag := first.Headers().Get("ETag")
+
+    byEtag, _ := handler(runtimeInstance, httptest.NewRecorder(), newResponseCacheTestRequest("/products", nil, map[string]string{"If-None-Match": `"other", W/` + etag}))
+    if nethttp.StatusNotModified != byEtag.StatusCode() || etag != byEtag.Headers().Get("ETag") {
+        t.Fatalf("expected 304 by etag, got: %d %v", byEtag.StatusCode(), byEtag.Headers())
+    }
+
+    if "" != byEtag.Headers().Get("Content-Type") {
+        t.Fatalf("expected a 304 without representation headers")
+    }
+
+    byDate, _ := handler(runtimeInstance, httptest.NewRecorder(), newResponseCacheTestRequest("/products", nil, map[string]string{"If-Modified-Since": first.Headers().Get("Last-Modified")}))
+    if nethttp.StatusNotModified != byDate.StatusCode() {
+        t.Fatalf("expected 304 by last-modified, got: %d", byDate.StatusCode())
+    }
+
+    mismatch, _ := handler(runtimeInstance, httptest.NewRecorder(), newResponseCacheTestRequest("/products", nil, map[string]string{"If-None-Match": `"other"`, "If-Modified-Since": first.Headers().Get("Last-Modified")}))
+    if nethttp.StatusOK != mismatch.StatusCode() {
+        t.Fatalf("expected If-None-Match to take precedence, got: %d", mismatch.StatusCode())
+    }
+}
+
+func TestResponseCacheMiddleware_InvalidatesByTag(t *testing.T) {
+    calls := 0
+    config := newResponseCacheTestConfig(NewResponseCachePolicy(time.Minute).SetTags("product:{id}", "catalog"))
+    handler := newResponseCacheTestHandler(config, &calls)
+    runtimeInstance := newIdempotencyTestRuntime()
+
+    for _, id := range []string{"42", "7", "42", "7"} {
+        _, _ = handler(runtimeInstance, httptest.NewRecorder(), newResponseCacheTestRequest("/products/"+id, map[string]string{"id": id}, nil))
+    }
+
+    if 2 != calls {
+        t.Fatalf("expected one handler call per product, calls: %d", calls)
+    }
+
+    if invalidateErr := config.InvalidateTags("product:42"); nil != invalidateErr {
+        t.Fatalf("invalidate error: %v", invalidateErr)
+    }
+
+    for _, id := range []string{"42", "7"} {
+        _, _ = handler(runtimeInstance, httptest.NewRecorder(), newResponseCacheTestRequest("/products/"+id, map[string]string{"id": id}, nil))
+    }
+
+    if 3 != calls {
+        t.Fatalf("expected only product 42 to be recomputed, calls: %d", calls)
+    }
+}
+
+func TestResponseCacheMiddleware_VariesByHeadersAndQuery(t *testing.T) {
+    calls := 0
+    handler := newResponseCacheTestHandler(newResponseCacheTestConfig(NewResponseCachePolicy(time.Minute).SetVaryHeaders("accept-language").SetVaryQuery("page")), &calls)
+    runtimeInstance := newIdempotencyTestRuntime()
+
+    first, _ := handler(runtimeInstance, httptest.NewRecorder(), newResponseCacheTestRequest("/products?page=1&utm=a", nil, map[string]string{"Accept-Language": "en"}))
+    _, _ = handler(runtimeInstance, httptest.NewRecorder(), newResponseCacheTestRequest("/products?page=1&utm=b", nil, map[string]string{"Accept-Language": "en"}))
+    _, _ = handler(runtimeInstance, httptest.NewRecorder(), newResponseCacheTestRequest("/products?page=1", nil, map[string]string{"Accept-Language": "ro"}))
+    _, _ = handler(runtimeInstance, httptest.NewRecorder(), newResponseCacheTestRequest("/products?page=2", nil, map[string]string{"Accept-Language": "en"}))
+
+    if 3 != calls {
+        t.Fatalf("expected ignored query parameters to share an entry, calls: %d", calls)
+    }
+
+    if "Accept-Language" != first.Headers().Get("Vary") {
+        t.Fatalf("expected the vary headers to be announced, got: %v", first.Headers())
+    }
+}
+
+func TestResponseCacheMiddleware_SkipsUncacheableResponses(t *testing.T) {
+    calls := 0
+    handler := ResponseCacheMiddleware(newResponseCacheTestConfig(NewResponseCachePolicy(time.Minute)))(
+        func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            calls++
+
+            response := http.NewResponse(nethttp.StatusOK, []byte("private"))
+            response.Headers().Set("Cache-Control", "private, max-age=60")
+
+            return response, nil
+        },
+    )
+    runtimeInstance := newIdempotencyTestRuntime()
+
+    for index := 0; index < 2; index++ {
+        _, _ = handler(runtimeInstance, httptest.NewRecorder(), newResponseCacheTestRequest("/me", nil, nil))
+    }
+
+    postRequest := http.NewRequest(httptest.NewRequest(nethttp.MethodPost, "/me", nil), nil, nil, nil)
+    _, _ = handler(runtimeInstance, httptest.NewRecorder(), postRequest)
+
+    if 3 != calls {
+        t.Fatalf("expected private responses and POST requests to bypass the cache, calls: %d", calls)
+    }
+}
+
+func TestResponseCacheMiddleware_PassesThroughRoutesWithoutPolicy(t *testing.T) {
+    calls := 0
+    handler := newResponseCacheTestHandler(newResponseCacheTestConfig(nil), &calls)
+    runtimeInstance := newIdempotencyTestRuntime()
+
+    for index := 0; index < 2; index++ {
+        response, _ := handler(runtimeInstance, httptest.NewRecorder(), newResponseCacheTestRequest("/products", nil, nil))
+        if "" != response.Headers().Get(HeaderResponseCache) {
+            t.Fatalf("expected no cache header without a policy")
+        }
+    }
+
+    if 2 != calls {
+        t.Fatalf("expected every request to reach the handler, calls: %d", calls)
+    }
+}
+
+func TestResponseCacheMiddleware_KeysNegotiatedResponsesByAccept(t *testing.T) {
+    calls := 0
+    handler := ResponseCacheMiddleware(newResponseCacheTestConfig(NewResponseCachePolicy(time.Minute)))(
+        func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            calls++
+
+            response := http.NewResponse(nethttp.StatusOK, []byte(request.HttpRequest().Header.Get("Accept")))
+            response.Headers().Set("Vary", "Accept")
+
+            return response, nil
+        },
+    )
+    runtimeInstance := newIdempotencyTestRuntime()
+
+    _, _ = handler(runtimeInstance, httptest.NewRecorder(), newResponseCacheTestRequest("/products", nil, map[string]string{"Accept": "application/json"}))
+    second, _ := handler(runtimeInstance, httptest.NewRecorder(), newResponseCacheTestRequest("/products", nil, map[string]string{"Accept": "application/xml"}))
+    third, _ := handler(runtimeInstance, httptest.NewRecorder(), newResponseCacheTestRequest("/products", nil, map[string]string{"Accept": "application/json"}))
+
+    if 2 != calls {
+        t.Fatalf("expected one entry per accept header, calls: %d", calls)
+    }
+
+    if "application/xml" != readIdempotencyBody(t, second) || ResponseCacheHit != third.Headers().Get(HeaderResponseCache) {
+        t.Fatalf("expected responses keyed by the accept header")
+    }
+}
+
+func TestResponseCacheMiddleware_SkipsResponsesVaryingOnUnkeyedHeaders(t *testing.T) {
+    calls := 0
+    handler := ResponseCacheMiddleware(newResponseCacheTestConfig(NewResponseCachePolicy(time.Minute)))(
+        func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            calls++
+
+            response := http.NewResponse(nethttp.StatusOK, []byte("greeting"))
+            response.Headers().Set("Vary", "Accept-Language")
+
+            return response, nil
+        },
+    )
+    runtimeInstance := newIdempotencyTestRuntime()
+
+    for index := 0; index < 2; index++ {
+        response, _ := handler(runtimeInstance, httptest.NewRecorder(), newResponseCacheTestRequest("/greeting", nil, nil))
+        if "" != response.Headers().Get(HeaderResponseCache) {
+            t.Fatalf("expected a response varying on an unkeyed header to bypass the cache")
+        }
+    }
+
+    if 2 != calls {
+        t.Fatalf("expected every request to reach the handler, calls: %d", calls)
+    }
+}
+
+type failingResponseCache struct {
+    cachecontract.TagAwareCache
+}
+
+func (instance *failingResponseCache) SetWithTags(key string, value any, ttl time.Duration, tags []string) error {
+    return errors.New("cache unavailable")
+}
+
+func TestResponseCacheMiddleware_ServesResponseWhenStoreFails(t *testing.T) {
+    frozenClock := clock.NewFrozenClock(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+    config := NewResponseCacheConfig(
+        &failingResponseCache{
+            TagAwareCache: cache.NewTaggedCache(cache.NewManager(cache.NewInMemoryBackend(100, time.Minute, frozenClock), cache.NewJsonSerializer())),
+        },
+    )
+    config.SetDefaultPolicy(NewResponseCachePolicy(time.Minute))
+
+    calls := 0
+    handler := newResponseCacheTestHandler(config, &calls)
+
+    response, err := handler(newIdempotencyTestRuntime(), httptest.NewRecorder(), newResponseCacheTestRequest("/products", nil, nil))
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    if ResponseCacheMiss != response.Headers().Get(HeaderResponseCache) || "product " != readIdempotencyBody(t, response) {
+        t.Fatalf("expected the handler response to be served uncached")
+    }
+}
+
+type corruptResponseCache struct {
+    cachecontract.TagAwareCache
+    deletedKeys []string
+}
+
+func (instance *corruptResponseCache) Get(key string) (any, bool, error) {
+    return "not a cached response", true, nil
+}
+
+func (instance *corruptResponseCache) Delete(key string) error {
+    instance.deletedKeys = append(instance.deletedKeys, key)
+
+    return instance.TagAwareCache.Delete(key)
+}
+
+func TestResponseCacheMiddleware_TreatsUndecodableRecordAsMiss(t *testing.T) {
+    frozenClock := clock.NewFrozenClock(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+    corruptCache := &corruptResponseCache{
+        TagAwareCache: cache.NewTaggedCache(cache.NewManager(cache.NewInMemoryBackend(100, time.Minute, frozenClock), cache.NewJsonSerializer())),
+    }
+    config := NewResponseCacheConfig(corruptCache)
+    config.SetDefaultPolicy(NewResponseCachePolicy(time.Minute))
+
+    calls := 0
+    handler := newResponseCacheTestHandler(config, &calls)
+
+    response, err := handler(newIdempotencyTestRuntime(), httptest.NewRecorder(), newResponseCacheTestRequest("/products", nil, nil))
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    if 1 != calls || ResponseCacheMiss != response.Headers().Get(HeaderResponseCache) {
+        t.Fatalf("expected the handler to serve the request, calls: %d", calls)
+    }
+
+    if 1 != len(corruptCache.deletedKeys) {
+        t.Fatalf("expected the undecodable record to be deleted, got %v", corruptCache.deletedKeys)
+    }
+}
+
+func TestResponseCacheMiddleware_KeysEntriesByMethod(t *testing.T) {
+    frozenClock := clock.NewFrozenClock(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+    config := NewResponseCacheConfig(cache.NewTaggedCache(cache.NewManager(cache.NewInMemoryBackend(100, time.Minute, frozenClock), cache.NewJsonSerializer())))
+    config.SetDefaultPolicy(NewResponseCachePolicy(time.Minute))
+
+    calls := 0
+    handler := newResponseCacheTestHandler(config, &calls)
+
+    headRequest := newResponseCacheTestRequest("/products", nil, nil)
+    headRequest.HttpRequest().Method = nethttp.MethodHead
+
+    if _, err := handler(newIdempotencyTestRuntime(), httptest.NewRecorder(), headRequest); nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    response, err := handler(newIdempotencyTestRuntime(), httptest.NewRecorder(), newResponseCacheTestRequest("/products", nil, nil))
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    if 2 != calls || ResponseCacheMiss != response.Headers().Get(HeaderResponseCache) {
+        t.Fatalf("expected GET not to reuse the HEAD entry, calls: %d", calls)
+    }
+}
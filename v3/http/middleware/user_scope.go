@@ -0,0 +1,24 @@
+package middleware
+
+import (
+    "github.com/precision-soft/melody/v3/internal"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+    "github.com/precision-soft/melody/v3/security"
+)
+
+const anonymousUserScope = "anonymous"
+
+/* @info "user:<identifier>" for an authenticated security token, "anonymous" otherwise. */
+func authenticatedUserScope(runtimeInstance runtimecontract.Runtime) string {
+    securityContext, exists := security.SecurityContextFromRuntime(runtimeInstance)
+    if false == exists || true == internal.IsNilInterface(securityContext.Token()) {
+        return anonymousUserScope
+    }
+
+    token := securityContext.Token()
+    if false == token.IsAuthenticated() || "" == token.UserIdentifier() {
+        return anonymousUserScope
+    }
+
+    return "user:" + token.UserIdentifier()
+}
@@ -0,0 +1,77 @@
+package http
+
+import (
+    nethttp "net/http"
+    "strings"
+
+    "github.com/precision-soft/melody/v3/exception"
+    httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+    "github.com/precision-soft/melody/v3/serializer"
+    serializercontract "github.com/precision-soft/melody/v3/serializer/contract"
+)
+
+/*
+ * NegotiatedResponse serializes value with the serializer the request Accept header selects from the runtime
+ * serializer manager (json only when the runtime has none). Unlike the result handler it does not fall back to json:
+ * when no listed type is registered it returns a 406 http exception whose message, context and problem details
+ * extension "supported" list the registered types.
+ */
+func NegotiatedResponse(
+    runtimeInstance runtimecontract.Runtime,
+    request httpcontract.Request,
+    statusCode int,
+    value any,
+) (*Response, error) {
+    serializerManager := negotiationSerializerManager(runtimeInstance)
+
+    acceptHeader := ""
+    if nil != request && nil != request.HttpRequest() {
+        acceptHeader = request.HttpRequest().Header.Get("Accept")
+    }
+
+    serializerInstance, negotiateErr := serializerManager.Negotiate(acceptHeader)
+    if nil != negotiateErr {
+        return nil, NotAcceptableException(serializerManager.Mimes(), negotiateErr)
+    }
+
+    serializedBytes, serializeErr := serializerInstance.Serialize(value)
+    if nil != serializeErr {
+        return nil, exception.NewError("failed to serialize negotiated response", nil, serializeErr)
+    }
+
+    response := NewResponse(statusCode, serializedBytes)
+    response.headers.Set("Content-Type", serializerInstance.ContentType())
+    response.headers.Add("Vary", "Accept")
+
+    return response, nil
+}
+
+func NotAcceptableException(supportedMimes []string, causeErr error) *exception.HttpException {
+    httpException := exception.NewHttpExceptionWithCause(
+        nethttp.StatusNotAcceptable,
+        "not acceptable, supported types: "+strings.Join(supportedMimes, ", "),
+        causeErr,
+    )
+    httpException.SetContextValue("supported", supportedMimes)
+    httpException.SetExtension("supported", supportedMimes)
+
+    return httpException
+}
+
+func negotiationSerializerManager(runtimeInstance runtimecontract.Runtime) *serializer.SerializerManager {
+    if nil != runtimeInstance && true == runtimeInstance.Container().Has(serializer.ServiceSerializerManager) {
+        serializerManager := serializer.SerializerManagerFromRuntime(runtimeInstance)
+        if nil != serializerManager {
+            return serializerManager
+        }
+    }
+
+    serializerManager, _ := serializer.NewSerializerManager(
+        map[string]serializercontract.Serializer{
+            serializer.MimeApplicationJson: serializer.NewJsonSerializer(),
+        },
+    )
+
+    return serializerManager
+}
@@ -0,0 +1,110 @@
+package http
+
+import (
+    "context"
+    "errors"
+    "io"
+    nethttp "net/http"
+    "reflect"
+    "strings"
+    "testing"
+
+    "github.com/precision-soft/melody/v3/container"
+    containercontract "github.com/precision-soft/melody/v3/container/contract"
+    "github.com/precision-soft/melody/v3/exception"
+    "github.com/precision-soft/melody/v3/internal/testhelper"
+    "github.com/precision-soft/melody/v3/runtime"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+    "github.com/precision-soft/melody/v3/serializer"
+    serializercontract "github.com/precision-soft/melody/v3/serializer/contract"
+)
+
+type negotiatedResponseTestRow struct {
+    Id   int    `json:"id"`
+    Name string `json:"name"`
+}
+
+func newNegotiatedResponseRuntime() runtimecontract.Runtime {
+    serviceContainer := container.NewContainer()
+
+    serviceContainer.MustRegister(
+        serializer.ServiceSerializerManager,
+        func(resolver containercontract.Resolver) (*serializer.SerializerManager, error) {
+            return serializer.NewSerializerManager(
+                map[string]serializercontract.Serializer{
+                    serializer.MimeApplicationJson: serializer.NewJsonSerializer(),
+                    serializer.MimeTextCsv:         serializer.NewCsvSerializer(),
+                },
+            )
+        },
+    )
+
+    return runtime.New(context.Background(), serviceContainer.NewScope(), serviceContainer)
+}
+
+func TestNegotiatedResponse_UsesSerializerSelectedByAccept(t *testing.T) {
+    request := testhelper.NewHttpTestRequestWithAccept(nethttp.MethodGet, "http://example.com/export", "text/csv")
+
+    response, err := NegotiatedResponse(newNegotiatedResponseRuntime(), request, nethttp.StatusOK, []negotiatedResponseTestRow{{Id: 1, Name: "a"}})
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    if false == strings.HasPrefix(response.Headers().Get("Content-Type"), "text/csv") {
+        t.Fatalf("unexpected content type: %s", response.Headers().Get("Content-Type"))
+    }
+
+    if "Accept" != response.Headers().Get("Vary") {
+        t.Fatalf("expected vary accept header")
+    }
+
+    body, _ := io.ReadAll(response.BodyReader())
+    if "id,name\n1,a\n" != string(body) {
+        t.Fatalf("unexpected body: %q", string(body))
+    }
+}
+
+func TestNegotiatedResponse_ReturnsNotAcceptableWithSupportedTypes(t *testing.T) {
+    request := testhelper.NewHttpTestRequestWithAccept(nethttp.MethodGet, "http://example.com/export", "application/xml")
+
+    _, err := NegotiatedResponse(newNegotiatedResponseRuntime(), request, nethttp.StatusOK, negotiatedResponseTestRow{Id: 1})
+    if nil == err {
+        t.Fatalf("expected error")
+    }
+
+    var httpException *exception.HttpException
+    if false == errors.As(err, &httpException) {
+        t.Fatalf("expected http exception, got %T", err)
+    }
+
+    if nethttp.StatusNotAcceptable != httpException.StatusCode() {
+        t.Fatalf("unexpected status: %d", httpException.StatusCode())
+    }
+
+    expected := []string{"application/json", "text/csv"}
+    if false == reflect.DeepEqual(expected, httpException.Extensions()["supported"]) {
+        t.Fatalf("unexpected supported types: %v", httpException.Extensions()["supported"])
+    }
+
+    if false == strings.Contains(httpException.Message(), "application/json, text/csv") {
+        t.Fatalf("unexpected message: %s", httpException.Message())
+    }
+}
+
+func TestNegotiatedResponse_DefaultsToJsonWithoutRuntime(t *testing.T) {
+    request := testhelper.NewHttpTestRequestWithAccept(nethttp.MethodGet, "http://example.com/", "")
+
+    response, err := NegotiatedResponse(nil, request, nethttp.StatusCreated, map[string]int{"id": 1})
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    if nethttp.StatusCreated != response.StatusCode() {
+        t.Fatalf("unexpected status: %d", response.StatusCode())
+    }
+
+    body, _ := io.ReadAll(response.BodyReader())
+    if `{"id":1}` != strings.TrimSpace(string(body)) {
+        t.Fatalf("unexpected body: %q", string(body))
+    }
+}
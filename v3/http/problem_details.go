@@ -0,0 +1,173 @@
+package http
+
+import (
+    "encoding/json"
+    "errors"
+    nethttp "net/http"
+
+    "github.com/precision-soft/melody/v3/exception"
+    httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    "github.com/precision-soft/melody/v3/validation"
+    validationcontract "github.com/precision-soft/melody/v3/validation/contract"
+)
+
+const (
+    ContentTypeProblemJson = "application/problem+json"
+    ProblemTypeBlank       = "about:blank"
+)
+
+/* @info the context keys under which http exceptions carry validation errors (see BindJsonAndValidate and exception.ValidationFailed). */
+var problemDetailsValidationContextKeys = []string{"errors", "validationErrors"}
+
+/* @info an RFC 9457 problem details object; Extensions are rendered as top-level members next to the standard ones. */
+type ProblemDetails struct {
+    Type       string                `json:"type"`
+    Title      string                `json:"title"`
+    Status     int                   `json:"status"`
+    Detail     string                `json:"detail,omitempty"`
+    Instance   string                `json:"instance,omitempty"`
+    Errors     []ProblemDetailsError `json:"errors,omitempty"`
+    Extensions map[string]any        `json:"-"`
+}
+
+type ProblemDetailsError struct {
+    Field   string         `json:"field"`
+    Code    string         `json:"code"`
+    Message string         `json:"message"`
+    Context map[string]any `json:"context,omitempty"`
+}
+
+func NewProblemDetails(status int, detail string) *ProblemDetails {
+    return &ProblemDetails{
+        Type:   ProblemTypeBlank,
+        Title:  nethttp.StatusText(status),
+        Status: status,
+        Detail: detail,
+    }
+}
+
+func (instance *ProblemDetails) MarshalJSON() ([]byte, error) {
+    members := make(map[string]any, len(instance.Extensions)+6)
+    for key, value := range instance.Extensions {
+        members[key] = value
+    }
+
+    problemType := instance.Type
+    if "" == problemType {
+        problemType = ProblemTypeBlank
+    }
+
+    members["type"] = problemType
+    members["title"] = instance.Title
+    members["status"] = instance.Status
+
+    if "" != instance.Detail {
+        members["detail"] = instance.Detail
+    }
+
+    if "" != instance.Instance {
+        members["instance"] = instance.Instance
+    }
+
+    if 0 < len(instance.Errors) {
+        members["errors"] = instance.Errors
+    }
+
+    return json.Marshal(members)
+}
+
+/*
+ * ProblemDetailsFromError maps err to problem details: http exceptions keep their status, message, problem type and
+ * extensions, validation errors found in the error chain or the exception context become the errors member, and any
+ * other error is an opaque 500 unless debugMode exposes its message.
+ */
+func ProblemDetailsFromError(err error, request httpcontract.Request, debugMode bool) *ProblemDetails {
+    statusCode := nethttp.StatusInternalServerError
+    detail := "internal server error"
+
+    httpException := exception.AsHttpException(err)
+    if nil != httpException {
+        statusCode = httpException.StatusCode()
+        detail = httpException.Message()
+    } else if true == debugMode && nil != err {
+        detail = err.Error()
+    }
+
+    problemDetails := NewProblemDetails(statusCode, detail)
+
+    if nil != request && nil != request.RequestContext() {
+        problemDetails.Instance = request.RequestContext().RequestId()
+    }
+
+    if nil != httpException {
+        if "" != httpException.ProblemType() {
+            problemDetails.Type = httpException.ProblemType()
+        }
+
+        extensions := httpException.Extensions()
+        if 0 < len(extensions) {
+            problemDetails.Extensions = extensions
+        }
+    }
+
+    problemDetails.Errors = problemDetailsErrors(err, httpException)
+
+    return problemDetails
+}
+
+func ProblemDetailsResponse(problemDetails *ProblemDetails) (*Response, error) {
+    response, jsonResponseErr := JsonResponse(problemDetails.Status, problemDetails)
+    if nil != jsonResponseErr {
+        return nil, jsonResponseErr
+    }
+
+    response.Headers().Set("Content-Type", ContentTypeProblemJson)
+
+    return response, nil
+}
+
+func problemDetailsErrors(err error, httpException *exception.HttpException) []ProblemDetailsError {
+    var validationErrors validation.ValidationErrors
+    if true == errors.As(err, &validationErrors) {
+        return mapProblemDetailsErrors(validationErrors)
+    }
+
+    if nil == httpException {
+        return nil
+    }
+
+    context := httpException.Context()
+    for _, key := range problemDetailsValidationContextKeys {
+        switch value := context[key].(type) {
+        case validation.ValidationErrors:
+            return mapProblemDetailsErrors(value)
+        case []validationcontract.ValidationError:
+            return mapProblemDetailsErrors(value)
+        case validationcontract.ValidationError:
+            return mapProblemDetailsErrors([]validationcontract.ValidationError{value})
+        }
+    }
+
+    return nil
+}
+
+func mapProblemDetailsErrors(validationErrors []validationcontract.ValidationError) []ProblemDetailsError {
+    problemDetailsErrors := make([]ProblemDetailsError, 0, len(validationErrors))
+    for _, validationError := range validationErrors {
+        if nil == validationError {
+            continue
+        }
+
+        problemDetailsErrors = append(
+            problemDetailsErrors,
+            ProblemDetailsError{
+                Field:   validationError.Field(),
+                Code:    validationError.Code(),
+                Message: validationError.Message(),
+                Context: validationError.Context(),
+            },
+        )
+    }
+
+    return problemDetailsErrors
+}
@@ -0,0 +1,480 @@
+package http
+
+import (
+    "bytes"
+    "encoding"
+    "encoding/json"
+    "errors"
+    "io"
+    "mime/multipart"
+    nethttp "net/http"
+    "reflect"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/precision-soft/melody/v3/config"
+    "github.com/precision-soft/melody/v3/exception"
+    exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+    httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    "github.com/precision-soft/melody/v3/serializer"
+)
+
+const (
+    BindingTagPath    = "path"
+    BindingTagQuery   = "query"
+    BindingTagHeader  = "header"
+    BindingTagCookie  = "cookie"
+    BindingTagForm    = "form"
+    BindingTagDefault = "default"
+
+    defaultBindingMaxRequestBodyBytes = 1048576
+)
+
+/* @info the order in which the input sources are checked for a field; a field is bound from the first binding tag it carries. */
+var bindingTags = []string{
+    BindingTagPath,
+    BindingTagQuery,
+    BindingTagHeader,
+    BindingTagCookie,
+    BindingTagForm,
+}
+
+var (
+    textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+    durationType        = reflect.TypeOf(time.Duration(0))
+    fileHeaderType      = reflect.TypeOf((*multipart.FileHeader)(nil))
+)
+
+/*
+ * BindRequest fills target (a pointer to a struct) from the request: the body is decoded first (json, or the serializer
+ * registered for the content type), then every field tagged with path, query, header, cookie or form is overwritten
+ * from that source. Conversion failures are reported as 400 http exceptions.
+ */
+func BindRequest(request httpcontract.Request, target any) error {
+    targetValue := reflect.ValueOf(target)
+    if false == targetValue.IsValid() || reflect.Ptr != targetValue.Kind() || true == targetValue.IsNil() || reflect.Struct != targetValue.Elem().Kind() {
+        return exception.NewError(
+            "bind target must be a non-nil pointer to a struct",
+            exceptioncontract.Context{
+                "type": reflect.TypeOf(target),
+            },
+            nil,
+        )
+    }
+
+    bindBodyErr := bindRequestBody(request, target)
+    if nil != bindBodyErr {
+        return bindBodyErr
+    }
+
+    return bindRequestFields(request, targetValue.Elem())
+}
+
+func bindRequestBody(request httpcontract.Request, target any) error {
+    httpRequest := request.HttpRequest()
+    if nil == httpRequest.Body || nethttp.NoBody == httpRequest.Body {
+        return nil
+    }
+
+    mediaType := requestMediaType(request)
+    if "application/x-www-form-urlencoded" == mediaType || "multipart/form-data" == mediaType {
+        return parseRequestForm(request, mediaType)
+    }
+
+    maxBytes := bindingMaxRequestBodyBytes(request)
+
+    bodyBytes, readErr := io.ReadAll(io.LimitReader(httpRequest.Body, int64(maxBytes)+1))
+    if nil != readErr {
+        var maxBytesError *nethttp.MaxBytesError
+        if true == errors.As(readErr, &maxBytesError) {
+            return exception.NewHttpException(nethttp.StatusRequestEntityTooLarge, "payload too large")
+        }
+
+        return exception.NewHttpException(nethttp.StatusBadRequest, "bad request")
+    }
+
+    if maxBytes < len(bodyBytes) {
+        return exception.NewHttpException(nethttp.StatusRequestEntityTooLarge, "payload too large")
+    }
+
+    if 0 == len(bytes.TrimSpace(bodyBytes)) {
+        return nil
+    }
+
+    if "" == mediaType || "application/json" == mediaType || true == strings.HasSuffix(mediaType, "+json") {
+        decoder := json.NewDecoder(bytes.NewReader(bodyBytes))
+
+        decodeErr := decoder.Decode(target)
+        if nil != decodeErr || true == decoder.More() {
+            return exception.NewHttpException(nethttp.StatusBadRequest, "invalid json")
+        }
+
+        return nil
+    }
+
+    runtimeInstance := request.RuntimeInstance()
+    if nil != runtimeInstance && true == runtimeInstance.Container().Has(serializer.ServiceSerializerManager) {
+        serializerManager := serializer.SerializerManagerFromRuntime(runtimeInstance)
+        if nil != serializerManager {
+            serializerInstance, exists := serializerManager.Get(mediaType)
+            if true == exists {
+                deserializeErr := serializerInstance.Deserialize(bodyBytes, target)
+                if nil != deserializeErr {
+                    return exception.NewHttpExceptionWithCause(nethttp.StatusBadRequest, "invalid request body", deserializeErr)
+                }
+
+                return nil
+            }
+        }
+    }
+
+    httpException := exception.NewHttpException(nethttp.StatusUnsupportedMediaType, "unsupported media type")
+    httpException.SetContextValue("contentType", mediaType)
+
+    return httpException
+}
+
+func parseRequestForm(request httpcontract.Request, mediaType string) error {
+    httpRequest := request.HttpRequest()
+
+    var parseErr error
+    if "multipart/form-data" == mediaType {
+        if nil == httpRequest.MultipartForm {
+            parseErr = httpRequest.ParseMultipartForm(int64(bindingMaxRequestBodyBytes(request)))
+        }
+    } else {
+        parseErr = httpRequest.ParseForm()
+    }
+
+    if nil != parseErr {
+        return exception.NewHttpExceptionWithCause(nethttp.StatusBadRequest, "invalid form data", parseErr)
+    }
+
+    return nil
+}
+
+func bindRequestFields(request httpcontract.Request, structValue reflect.Value) error {
+    structType := structValue.Type()
+
+    for index := 0; index < structType.NumField(); index++ {
+        field := structType.Field(index)
+        fieldValue := structValue.Field(index)
+
+        source, name, tagged := RequestBindingTag(field)
+
+        if false == tagged && true == field.Anonymous && reflect.Struct == field.Type.Kind() {
+            bindFieldsErr := bindRequestFields(request, fieldValue)
+            if nil != bindFieldsErr {
+                return bindFieldsErr
+            }
+
+            continue
+        }
+
+        if false == tagged || false == field.IsExported() {
+            continue
+        }
+
+        if BindingTagForm == source && true == isFileHeaderType(field.Type) {
+            bindFileErr := bindRequestFiles(request, fieldValue, name)
+            if nil != bindFileErr {
+                return bindFileErr
+            }
+
+            continue
+        }
+
+        if false == isRequestBindingTypeSupported(field.Type) {
+            return unsupportedBindingTypeError(field.Type)
+        }
+
+        values := requestBindingValues(request, source, name)
+        if 0 == len(values) {
+            defaultValue, hasDefault := field.Tag.Lookup(BindingTagDefault)
+            if false == hasDefault {
+                continue
+            }
+
+            values = []string{defaultValue}
+        }
+
+        setErr := setRequestBindingValue(fieldValue, values)
+        if nil != setErr {
+            httpException := exception.NewHttpExceptionWithCause(nethttp.StatusBadRequest, "invalid request parameter", setErr)
+            httpException.SetContext(
+                exceptioncontract.Context{
+                    "source": source,
+                    "name":   name,
+                },
+            )
+
+            return httpException
+        }
+    }
+
+    return nil
+}
+
+/* @info returns the input source (path, query, header, cookie or form) and the input name a struct field is bound from. */
+func RequestBindingTag(field reflect.StructField) (string, string, bool) {
+    for _, source := range bindingTags {
+        tag, exists := field.Tag.Lookup(source)
+        if false == exists {
+            continue
+        }
+
+        name := strings.TrimSpace(strings.Split(tag, ",")[0])
+        if "-" == name {
+            return "", "", false
+        }
+
+        if "" == name {
+            name = field.Name
+        }
+
+        return source, name, true
+    }
+
+    return "", "", false
+}
+
+func requestBindingValues(request httpcontract.Request, source string, name string) []string {
+    httpRequest := request.HttpRequest()
+
+    switch source {
+    case BindingTagPath:
+        value, exists := request.Param(name)
+        if false == exists || "" == value {
+            return nil
+        }
+
+        return []string{value}
+    case BindingTagQuery:
+        return httpRequest.URL.Query()[name]
+    case BindingTagHeader:
+        return httpRequest.Header.Values(name)
+    case BindingTagCookie:
+        cookie, cookieErr := httpRequest.Cookie(name)
+        if nil != cookieErr {
+            return nil
+        }
+
+        return []string{cookie.Value}
+    case BindingTagForm:
+        if nil != httpRequest.MultipartForm {
+            values, exists := httpRequest.MultipartForm.Value[name]
+            if true == exists {
+                return values
+            }
+        }
+
+        if nil != httpRequest.PostForm {
+            return httpRequest.PostForm[name]
+        }
+
+        return nil
+    default:
+        return nil
+    }
+}
+
+func isFileHeaderType(fieldType reflect.Type) bool {
+    if fieldType == fileHeaderType {
+        return true
+    }
+
+    return reflect.Slice == fieldType.Kind() && fieldType.Elem() == fileHeaderType
+}
+
+func bindRequestFiles(request httpcontract.Request, fieldValue reflect.Value, name string) error {
+    httpRequest := request.HttpRequest()
+    if nil == httpRequest.MultipartForm {
+        return nil
+    }
+
+    files := httpRequest.MultipartForm.File[name]
+    if 0 == len(files) {
+        return nil
+    }
+
+    if fieldValue.Type() == fileHeaderType {
+        fieldValue.Set(reflect.ValueOf(files[0]))
+
+        return nil
+    }
+
+    fieldValue.Set(reflect.ValueOf(files))
+
+    return nil
+}
+
+func setRequestBindingValue(fieldValue reflect.Value, values []string) error {
+    fieldType := fieldValue.Type()
+
+    if reflect.Ptr == fieldType.Kind() {
+        pointerValue := reflect.New(fieldType.Elem())
+
+        setErr := setRequestBindingValue(pointerValue.Elem(), values)
+        if nil != setErr {
+            return setErr
+        }
+
+        fieldValue.Set(pointerValue)
+
+        return nil
+    }
+
+    if reflect.Slice == fieldType.Kind() && reflect.Uint8 != fieldType.Elem().Kind() && false == reflect.PointerTo(fieldType).Implements(textUnmarshalerType) {
+        sliceValue := reflect.MakeSlice(fieldType, len(values), len(values))
+        for index, value := range values {
+            setErr := setRequestBindingScalar(sliceValue.Index(index), value)
+            if nil != setErr {
+                return setErr
+            }
+        }
+
+        fieldValue.Set(sliceValue)
+
+        return nil
+    }
+
+    return setRequestBindingScalar(fieldValue, values[0])
+}
+
+func setRequestBindingScalar(fieldValue reflect.Value, value string) error {
+    fieldType := fieldValue.Type()
+
+    if reflect.Ptr == fieldType.Kind() {
+        pointerValue := reflect.New(fieldType.Elem())
+
+        setErr := setRequestBindingScalar(pointerValue.Elem(), value)
+        if nil != setErr {
+            return setErr
+        }
+
+        fieldValue.Set(pointerValue)
+
+        return nil
+    }
+
+    if true == fieldValue.CanAddr() && true == fieldValue.Addr().Type().Implements(textUnmarshalerType) {
+        return fieldValue.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+    }
+
+    if fieldType == durationType {
+        duration, parseErr := time.ParseDuration(value)
+        if nil != parseErr {
+            return parseErr
+        }
+
+        fieldValue.SetInt(int64(duration))
+
+        return nil
+    }
+
+    switch fieldType.Kind() {
+    case reflect.String:
+        fieldValue.SetString(value)
+    case reflect.Bool:
+        parsed, parseErr := strconv.ParseBool(value)
+        if nil != parseErr {
+            return parseErr
+        }
+
+        fieldValue.SetBool(parsed)
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+        parsed, parseErr := strconv.ParseInt(value, 10, fieldType.Bits())
+        if nil != parseErr {
+            return parseErr
+        }
+
+        fieldValue.SetInt(parsed)
+    case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+        parsed, parseErr := strconv.ParseUint(value, 10, fieldType.Bits())
+        if nil != parseErr {
+            return parseErr
+        }
+
+        fieldValue.SetUint(parsed)
+    case reflect.Float32, reflect.Float64:
+        parsed, parseErr := strconv.ParseFloat(value, fieldType.Bits())
+        if nil != parseErr {
+            return parseErr
+        }
+
+        fieldValue.SetFloat(parsed)
+    case reflect.Slice:
+        if reflect.Uint8 != fieldType.Elem().Kind() {
+            return unsupportedBindingTypeError(fieldType)
+        }
+
+        fieldValue.SetBytes([]byte(value))
+    default:
+        return unsupportedBindingTypeError(fieldType)
+    }
+
+    return nil
+}
+
+func isRequestBindingTypeSupported(fieldType reflect.Type) bool {
+    if reflect.Ptr == fieldType.Kind() {
+        return isRequestBindingTypeSupported(fieldType.Elem())
+    }
+
+    if true == reflect.PointerTo(fieldType).Implements(textUnmarshalerType) || fieldType == durationType {
+        return true
+    }
+
+    switch fieldType.Kind() {
+    case reflect.String, reflect.Bool,
+        reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+        reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+        reflect.Float32, reflect.Float64:
+        return true
+    case reflect.Slice:
+        if reflect.Uint8 == fieldType.Elem().Kind() {
+            return true
+        }
+
+        elementType := fieldType.Elem()
+        if reflect.Slice == elementType.Kind() && false == reflect.PointerTo(elementType).Implements(textUnmarshalerType) {
+            return false
+        }
+
+        return isRequestBindingTypeSupported(elementType)
+    default:
+        return false
+    }
+}
+
+func unsupportedBindingTypeError(fieldType reflect.Type) error {
+    return exception.NewError(
+        "unsupported request binding field type",
+        exceptioncontract.Context{
+            "type": fieldType.String(),
+        },
+        nil,
+    )
+}
+
+func requestMediaType(request httpcontract.Request) string {
+    typedRequest, ok := request.(*Request)
+    if true == ok {
+        return strings.ToLower(typedRequest.ContentType())
+    }
+
+    contentType := request.Header("Content-Type")
+    mediaType := strings.TrimSpace(strings.Split(contentType, ";")[0])
+
+    return strings.ToLower(mediaType)
+}
+
+func bindingMaxRequestBodyBytes(request httpcontract.Request) int {
+    runtimeInstance := request.RuntimeInstance()
+    if nil == runtimeInstance || false == runtimeInstance.Container().Has(config.ServiceConfig) {
+        return defaultBindingMaxRequestBodyBytes
+    }
+
+    return maxRequestBodyBytes(request)
+}
@@ -63,6 +63,10 @@ func (instance *Request) BindJsonAndValidate(target any) error {
         return nil
     }
 
+    return validationFailedException(validationError)
+}
+
+func validationFailedException(validationError error) *exception.HttpException {
     validationErrors, ok := validationError.(validation.ValidationErrors)
     if false == ok {
         httpException := exception.BadRequest("validation failed")
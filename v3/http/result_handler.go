@@ -34,6 +34,15 @@ func NormalizeResultToResponse(
     runtimeInstance runtimecontract.Runtime,
     request httpcontract.Request,
     value any,
+) (httpcontract.Response, error) {
+    return normalizeResultToResponseWithStatus(runtimeInstance, request, nethttp.StatusOK, value)
+}
+
+func normalizeResultToResponseWithStatus(
+    runtimeInstance runtimecontract.Runtime,
+    request httpcontract.Request,
+    statusCode int,
+    value any,
 ) (httpcontract.Response, error) {
     if nil == value {
         return nil, nil
@@ -50,12 +59,12 @@ func NormalizeResultToResponse(
 
     stringValue, ok := value.(string)
     if true == ok {
-        return TextResponse(nethttp.StatusOK, stringValue), nil
+        return TextResponse(statusCode, stringValue), nil
     }
 
     bytesValue, ok := value.([]byte)
     if true == ok {
-        return NewResponse(nethttp.StatusOK, bytesValue), nil
+        return NewResponse(statusCode, bytesValue), nil
     }
 
     if nil != runtimeInstance {
@@ -74,7 +83,7 @@ func NormalizeResultToResponse(
                     return nil, exception.NewError("failed to serialize controller result", map[string]any{}, err)
                 }
 
-                response := NewResponse(nethttp.StatusOK, serializedBytes)
+                response := NewResponse(statusCode, serializedBytes)
                 if nil == response.headers {
                     response.headers = make(nethttp.Header)
                 }
@@ -91,7 +100,7 @@ func NormalizeResultToResponse(
                 return nil, exception.NewError("failed to serialize controller result", map[string]any{}, err)
             }
 
-            response := NewResponse(nethttp.StatusOK, serializedBytes)
+            response := NewResponse(statusCode, serializedBytes)
             if nil == response.headers {
                 response.headers = make(nethttp.Header)
             }
@@ -101,7 +110,7 @@ func NormalizeResultToResponse(
         }
     }
 
-    response, jsonResponseErr := JsonResponse(nethttp.StatusOK, value)
+    response, jsonResponseErr := JsonResponse(statusCode, value)
     if nil != jsonResponseErr {
         return nil, exception.NewError("failed to normalize controller result", map[string]any{}, jsonResponseErr)
     }
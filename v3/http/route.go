@@ -14,8 +14,13 @@
     RouteAttributeSchemes = "_schemes"
     RouteAttributeLocales = "_locales"
     RouteAttributeLocale  = "_locale"
+    /* @info holds the httpcontract.TypedHandler of routes registered with HandleTyped, read by the openapi generator */
+    RouteAttributeTypedHandler = "_typed_handler"
 )
 
+/* @info group middleware of the routes matching the path when none allows the method, run around the automatic OPTIONS and 405 responses */
+const routeAttributeGroupMiddlewares = "_group_middlewares"
+
 type route struct {
     name         string
     pattern      string
@@ -29,6 +34,7 @@ type route struct {
     locales      []string
     priority     int
     attributes   map[string]any
+    middlewares  []httpcontract.RouteMiddleware
 }
 
 type routeTreeNode struct {
@@ -1,6 +1,10 @@
 package http
 
 import (
+    "fmt"
+
+    "github.com/precision-soft/melody/v3/exception"
+    exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
     httpcontract "github.com/precision-soft/melody/v3/http/contract"
 )
 
@@ -77,6 +81,7 @@ type RouteOptions struct {
     locales      []string
     priority     int
     attributes   map[string]any
+    middlewares  []httpcontract.RouteMiddleware
 }
 
 func (instance *RouteOptions) Name() string { return instance.name }
@@ -178,4 +183,108 @@ func (instance *RouteOptions) Attributes() map[string]any {
     return copied
 }
 
+func (instance *RouteOptions) RouteMiddlewares() []httpcontract.RouteMiddleware {
+    if nil == instance.middlewares {
+        return nil
+    }
+
+    return append([]httpcontract.RouteMiddleware{}, instance.middlewares...)
+}
+
+func (instance *RouteOptions) SetRouteMiddlewares(routeMiddlewares []httpcontract.RouteMiddleware) {
+    if nil == routeMiddlewares {
+        instance.middlewares = nil
+        return
+    }
+
+    instance.middlewares = append([]httpcontract.RouteMiddleware{}, routeMiddlewares...)
+}
+
+/* WithRouteMiddlewares appends route-level middleware to the options; they run inside the global and group middleware, in the given order. */
+func WithRouteMiddlewares(
+    options httpcontract.RouteOptions,
+    middlewares ...httpcontract.Middleware,
+) httpcontract.RouteOptions {
+    middlewareRouteOptions, isMiddlewareRouteOptions := options.(httpcontract.MiddlewareRouteOptions)
+    if false == isMiddlewareRouteOptions {
+        exception.Panic(
+            exception.NewError(
+                "route options do not support middleware",
+                exceptioncontract.Context{
+                    "optionsType": fmt.Sprintf("%T", options),
+                },
+                nil,
+            ),
+        )
+    }
+
+    routeMiddlewares := middlewareRouteOptions.RouteMiddlewares()
+    for _, middleware := range middlewares {
+        if nil == middleware {
+            exception.Panic(
+                exception.NewError(
+                    "route middleware may not be nil",
+                    exceptioncontract.Context{
+                        "routeName": options.Name(),
+                    },
+                    nil,
+                ),
+            )
+        }
+
+        routeMiddlewares = append(
+            routeMiddlewares,
+            httpcontract.RouteMiddleware{
+                Layer:      httpcontract.RouteMiddlewareLayerRoute,
+                Source:     options.Name(),
+                Middleware: middleware,
+            },
+        )
+    }
+
+    middlewareRouteOptions.SetRouteMiddlewares(routeMiddlewares)
+
+    return options
+}
+
 var _ httpcontract.RouteOptions = (*RouteOptions)(nil)
+var _ httpcontract.MiddlewareRouteOptions = (*RouteOptions)(nil)
+
+/* @info sets the attribute on the given options when they are *RouteOptions, so callers still see the name a group prefixed; other implementations are copied. */
+func routeOptionsWithAttribute(options httpcontract.RouteOptions, key string, value any) httpcontract.RouteOptions {
+    routeOptions, isRouteOptions := options.(*RouteOptions)
+    if true == isRouteOptions {
+        if nil == routeOptions.attributes {
+            routeOptions.attributes = map[string]any{}
+        }
+
+        routeOptions.attributes[key] = value
+
+        return routeOptions
+    }
+
+    attributes := options.Attributes()
+    if nil == attributes {
+        attributes = map[string]any{}
+    }
+    attributes[key] = value
+
+    copied := NewRouteOptions(
+        options.Name(),
+        options.Methods(),
+        options.Host(),
+        options.Schemes(),
+        options.Requirements(),
+        options.Defaults(),
+        options.Locales(),
+        options.Priority(),
+        attributes,
+    ).(*RouteOptions)
+
+    middlewareRouteOptions, isMiddlewareRouteOptions := options.(httpcontract.MiddlewareRouteOptions)
+    if true == isMiddlewareRouteOptions {
+        copied.SetRouteMiddlewares(middlewareRouteOptions.RouteMiddlewares())
+    }
+
+    return copied
+}
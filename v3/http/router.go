@@ -181,6 +181,22 @@ func (instance *Router) addRoute(pattern string, handler httpcontract.Handler, o
         attributes[RouteAttributeLocales] = append([]string{}, options.Locales()...)
     }
 
+    var routeMiddlewares []httpcontract.RouteMiddleware
+    middlewareRouteOptions, isMiddlewareRouteOptions := options.(httpcontract.MiddlewareRouteOptions)
+    if true == isMiddlewareRouteOptions {
+        routeMiddlewares = middlewareRouteOptions.RouteMiddlewares()
+    }
+
+    /* @info group and route middleware wrap the handler once at registration; the kernel middleware still wraps the whole dispatch around them */
+    if 0 < len(routeMiddlewares) {
+        middlewares := make([]httpcontract.Middleware, 0, len(routeMiddlewares))
+        for _, routeMiddleware := range routeMiddlewares {
+            middlewares = append(middlewares, routeMiddleware.Middleware)
+        }
+
+        handler = wrapWithMiddlewares(handler, middlewares)
+    }
+
     instance.routeRegistry.registerRoute(
         route{
             name:         options.Name(),
@@ -195,6 +211,7 @@ func (instance *Router) addRoute(pattern string, handler httpcontract.Handler, o
             locales:      append([]string{}, options.Locales()...),
             priority:     options.Priority(),
             attributes:   attributes,
+            middlewares:  routeMiddlewares,
         },
     )
 
@@ -345,6 +362,8 @@ func (instance *Router) match(method string, path string, host string, scheme st
     var bestAttributes map[string]any
 
     allowedMethodsSet := make(map[string]struct{})
+    var methodMismatchMiddlewares []httpcontract.Middleware
+    methodMismatchIndex := -1
     bestPriority := 0
     bestIndex := -1
     hasBest := false
@@ -400,6 +419,11 @@ func (instance *Router) match(method string, path string, host string, scheme st
             for _, allowedMethod := range routeDefinition.methods {
                 allowedMethodsSet[allowedMethod] = struct{}{}
             }
+
+            if 0 > methodMismatchIndex || index < methodMismatchIndex {
+                methodMismatchIndex = index
+                methodMismatchMiddlewares = groupMiddlewares(routeDefinition.middlewares)
+            }
             continue
         }
 
@@ -432,6 +456,10 @@ func (instance *Router) match(method string, path string, host string, scheme st
             attributes := make(map[string]any)
             attributes[RouteAttributeMethods] = allowedMethods
 
+            if 0 < len(methodMismatchMiddlewares) {
+                attributes[routeAttributeGroupMiddlewares] = methodMismatchMiddlewares
+            }
+
             return nil, nil, attributes
         }
 
@@ -441,6 +469,20 @@ func (instance *Router) match(method string, path string, host string, scheme st
     return bestHandler, bestParams, bestAttributes
 }
 
+/* @info the group layer of a route chain; route middleware belongs to the route method and is left out. */
+func groupMiddlewares(routeMiddlewares []httpcontract.RouteMiddleware) []httpcontract.Middleware {
+    middlewares := make([]httpcontract.Middleware, 0, len(routeMiddlewares))
+    for _, routeMiddleware := range routeMiddlewares {
+        if httpcontract.RouteMiddlewareLayerGroup != routeMiddleware.Layer {
+            continue
+        }
+
+        middlewares = append(middlewares, routeMiddleware.Middleware)
+    }
+
+    return middlewares
+}
+
 func (instance *Router) findRouteCandidates(pathSegments []string) []int {
     result := make([]int, 0)
 
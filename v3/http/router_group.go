@@ -6,21 +6,23 @@
 )
 
 func NewRouteGroup(router httpcontract.Router, pathPrefix string) httpcontract.RouteGroup {
-    return &RouteGroup{
-        router:       router,
-        pathPrefix:   pathPrefix,
-        namePrefix:   "",
-        defaults:     map[string]string{},
-        requirements: map[string]string{},
+    var routeHandler httpcontract.RouteHandler
+    if nil != router {
+        routeHandler = router
     }
+
+    return newRouteGroup(routeHandler, pathPrefix, pathPrefix)
 }
 
 type RouteGroup struct {
-    router       httpcontract.Router
+    router       httpcontract.RouteHandler
     pathPrefix   string
+    fullPrefix   string
     namePrefix   string
     defaults     map[string]string
     requirements map[string]string
+    middlewares  []httpcontract.Middleware
+    hasRoutes    bool
 }
 
 func (instance *RouteGroup) WithNamePrefix(namePrefix string) {
@@ -55,6 +57,34 @@ func (instance *RouteGroup) WithDefaults(defaults map[string]string) {
     instance.defaults = copied
 }
 
+/* Use attaches middleware to every route of the group and of its nested groups; it must be called before the group registers routes. */
+func (instance *RouteGroup) Use(middlewares ...httpcontract.Middleware) {
+    if true == instance.hasRoutes {
+        exception.Panic(
+            exception.NewError(
+                "route group middleware must be registered before the group routes",
+                map[string]any{"pathPrefix": instance.fullPrefix},
+                nil,
+            ),
+        )
+    }
+
+    for _, middleware := range middlewares {
+        if nil == middleware {
+            exception.Panic(
+                exception.NewError("route group middleware may not be nil", map[string]any{"pathPrefix": instance.fullPrefix}, nil),
+            )
+        }
+
+        instance.middlewares = append(instance.middlewares, middleware)
+    }
+}
+
+/* Group creates a nested group: paths, name prefixes, requirements, defaults and middleware of this group apply to it, outer group first. */
+func (instance *RouteGroup) Group(pathPrefix string) httpcontract.RouteGroup {
+    return newRouteGroup(instance, pathPrefix, JoinPaths(instance.fullPrefix, pathPrefix))
+}
+
 func (instance *RouteGroup) Handle(method string, pattern string, handler httpcontract.Handler) {
     instance.HandleWithOptions(
         pattern,
@@ -123,6 +153,8 @@ func (instance *RouteGroup) HandleWithOptions(pattern string, handler httpcontra
         )
     }
 
+    instance.hasRoutes = true
+
     groupedPattern := JoinPaths(instance.pathPrefix, pattern)
 
     if "" != instance.namePrefix && "" != options.Name() {
@@ -169,9 +201,51 @@ func (instance *RouteGroup) HandleWithOptions(pattern string, handler httpcontra
 
     options.SetDefaults(defaults)
 
+    if 0 < len(instance.middlewares) {
+        middlewareRouteOptions, isMiddlewareRouteOptions := options.(httpcontract.MiddlewareRouteOptions)
+        if false == isMiddlewareRouteOptions {
+            exception.Panic(
+                exception.NewError(
+                    "route options do not support group middleware",
+                    map[string]any{"pattern": groupedPattern},
+                    nil,
+                ),
+            )
+        }
+
+        routeMiddlewares := make([]httpcontract.RouteMiddleware, 0, len(instance.middlewares))
+        for _, middleware := range instance.middlewares {
+            routeMiddlewares = append(
+                routeMiddlewares,
+                httpcontract.RouteMiddleware{
+                    Layer:      httpcontract.RouteMiddlewareLayerGroup,
+                    Source:     instance.fullPrefix,
+                    Middleware: middleware,
+                },
+            )
+        }
+
+        middlewareRouteOptions.SetRouteMiddlewares(
+            append(routeMiddlewares, middlewareRouteOptions.RouteMiddlewares()...),
+        )
+    }
+
     instance.router.HandleWithOptions(groupedPattern, handler, options)
 }
 
+func newRouteGroup(router httpcontract.RouteHandler, pathPrefix string, fullPrefix string) *RouteGroup {
+    return &RouteGroup{
+        router:       router,
+        pathPrefix:   pathPrefix,
+        fullPrefix:   fullPrefix,
+        namePrefix:   "",
+        defaults:     map[string]string{},
+        requirements: map[string]string{},
+        middlewares:  make([]httpcontract.Middleware, 0),
+        hasRoutes:    false,
+    }
+}
+
 var _ httpcontract.RouteGroup = (*RouteGroup)(nil)
 
 func (instance *Router) Group(pathPrefix string) httpcontract.RouteGroup {
@@ -2,6 +2,8 @@
 
 import (
     nethttp "net/http"
+    "net/http/httptest"
+    "strings"
     "testing"
 
     httpcontract "github.com/precision-soft/melody/v3/http/contract"
@@ -174,3 +176,150 @@ func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, req
         t.Fatalf("expected error")
     }
 }
+
+func TestRouteGroup_NestedGroupsAndRouteMiddlewareRunOuterFirst(t *testing.T) {
+    router := NewRouter()
+
+    calls := make([]string, 0)
+    recordingMiddleware := func(label string) httpcontract.Middleware {
+        return func(next httpcontract.Handler) httpcontract.Handler {
+            return func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+                calls = append(calls, label)
+                return next(runtimeInstance, writer, request)
+            }
+        }
+    }
+
+    apiGroup := router.Group("/api")
+    apiGroup.WithNamePrefix("api.")
+    apiGroup.Use(recordingMiddleware("api"))
+
+    adminGroup := apiGroup.Group("/admin")
+    adminGroup.WithNamePrefix("admin.")
+    adminGroup.Use(recordingMiddleware("admin-1"), recordingMiddleware("admin-2"))
+
+    adminGroup.HandleWithOptions(
+        "/users",
+        func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            calls = append(calls, "handler")
+            return EmptyResponse(200), nil
+        },
+        WithRouteMiddlewares(
+            NewRouteOptions("users", []string{nethttp.MethodGet}, "", nil, nil, nil, nil, 0, nil),
+            recordingMiddleware("route"),
+        ),
+    )
+
+    matchResult, matched := router.Match(nethttp.MethodGet, "/api/admin/users", "", "")
+    if false == matched {
+        t.Fatalf("expected nested group route to match")
+    }
+
+    _, _ = matchResult.Handler(newTestRuntime(), nil, nil)
+
+    if "api,admin-1,admin-2,route,handler" != strings.Join(calls, ",") {
+        t.Fatalf("unexpected middleware order: %v", calls)
+    }
+
+    routeDefinition, exists := router.RouteDefinition("api.admin.users")
+    if false == exists {
+        t.Fatalf("expected route name to carry both group prefixes")
+    }
+
+    routeMiddlewares := routeDefinition.(httpcontract.MiddlewareRouteDefinition).RouteMiddlewares()
+    if 4 != len(routeMiddlewares) {
+        t.Fatalf("unexpected route middlewares: %#v", routeMiddlewares)
+    }
+
+    if httpcontract.RouteMiddlewareLayerGroup != routeMiddlewares[0].Layer || "/api" != routeMiddlewares[0].Source {
+        t.Fatalf("unexpected outer group layer: %#v", routeMiddlewares[0])
+    }
+
+    if "/api/admin" != routeMiddlewares[1].Source || httpcontract.RouteMiddlewareLayerRoute != routeMiddlewares[3].Layer {
+        t.Fatalf("unexpected layers: %#v", routeMiddlewares)
+    }
+}
+
+func TestRouteGroup_UsePanicsAfterRoutesAreRegistered(t *testing.T) {
+    group := NewRouter().Group("/api")
+
+    group.Handle(
+        nethttp.MethodGet,
+        "/x",
+        func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            return EmptyResponse(200), nil
+        },
+    )
+
+    defer func() {
+        if nil == recover() {
+            t.Fatalf("expected panic")
+        }
+    }()
+
+    group.Use(func(next httpcontract.Handler) httpcontract.Handler {
+        return next
+    })
+}
+
+func TestRouteGroup_MiddlewareAnswersPreflightWithoutOptionsRoute(t *testing.T) {
+    router := NewRouter()
+
+    routeMiddlewareCalls := 0
+    apiGroup := router.Group("/api")
+    apiGroup.Use(
+        func(next httpcontract.Handler) httpcontract.Handler {
+            return func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+                if nethttp.MethodOptions == request.HttpRequest().Method {
+                    response := EmptyResponse(nethttp.StatusNoContent)
+                    response.Headers().Set("Access-Control-Allow-Origin", "*")
+                    return response, nil
+                }
+
+                return next(runtimeInstance, writer, request)
+            }
+        },
+    )
+
+    apiGroup.HandleWithOptions(
+        "/products",
+        func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            return EmptyResponse(nethttp.StatusOK), nil
+        },
+        WithRouteMiddlewares(
+            NewRouteOptions("products", []string{nethttp.MethodPost}, "", nil, nil, nil, nil, 0, nil),
+            func(next httpcontract.Handler) httpcontract.Handler {
+                return func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+                    routeMiddlewareCalls++
+                    return next(runtimeInstance, writer, request)
+                }
+            },
+        ),
+    )
+
+    handler := NewKernel(router).ServeHttp(newHttpTestContainer())
+
+    preflight := httptest.NewRequest(nethttp.MethodOptions, "/api/products", nil)
+    preflight.Header.Set("Origin", "https://example.com")
+    preflight.Header.Set("Access-Control-Request-Method", nethttp.MethodPost)
+    preflightRecorder := httptest.NewRecorder()
+
+    handler.ServeHTTP(preflightRecorder, preflight)
+
+    if nethttp.StatusNoContent != preflightRecorder.Code || "*" != preflightRecorder.Header().Get("Access-Control-Allow-Origin") {
+        t.Fatalf("expected the group middleware to answer the preflight, got %d %v", preflightRecorder.Code, preflightRecorder.Header())
+    }
+
+    if 0 != routeMiddlewareCalls {
+        t.Fatalf("expected route middleware to stay out of the preflight")
+    }
+
+    outside := httptest.NewRequest(nethttp.MethodOptions, "/other", nil)
+    outsideRecorder := httptest.NewRecorder()
+
+    handler.ServeHTTP(outsideRecorder, outside)
+
+    if "" != outsideRecorder.Header().Get("Access-Control-Allow-Origin") {
+        t.Fatalf("expected paths outside the group to skip its middleware")
+    }
+}
@@ -83,6 +83,7 @@ type RouteDefinition struct {
     locales      []string
     priority     int
     attributes   map[string]any
+    middlewares  []httpcontract.RouteMiddleware
 }
 
 func (instance *RouteDefinition) Name() string { return instance.name }
@@ -156,6 +157,15 @@ func (instance *RouteDefinition) Attributes() map[string]any {
     return copied
 }
 
+/* RouteMiddlewares lists the group and route middleware wrapped around the handler, outermost first. */
+func (instance *RouteDefinition) RouteMiddlewares() []httpcontract.RouteMiddleware {
+    if nil == instance.middlewares {
+        return nil
+    }
+
+    return append([]httpcontract.RouteMiddleware{}, instance.middlewares...)
+}
+
 func (instance *RouteDefinition) MarshalJSON() ([]byte, error) {
     type jsonDefinition struct {
         Name         string            `json:"name"`
@@ -185,6 +195,7 @@ type jsonDefinition struct {
 }
 
 var _ httpcontract.RouteDefinition = (*RouteDefinition)(nil)
+var _ httpcontract.MiddlewareRouteDefinition = (*RouteDefinition)(nil)
 
 func (instance *Router) RouteDefinitions() []httpcontract.RouteDefinition {
     return instance.routeRegistry.RouteDefinitions()
@@ -226,7 +237,7 @@ func mapRouteToDefinition(routeValue route) *RouteDefinition {
         attributes[key] = value
     }
 
-    return NewRouteDefinition(
+    definition := NewRouteDefinition(
         routeValue.name,
         routeValue.pattern,
         routeValue.methods,
@@ -238,6 +249,12 @@ func mapRouteToDefinition(routeValue route) *RouteDefinition {
         routeValue.priority,
         attributes,
     )
+
+    if 0 < len(routeValue.middlewares) {
+        definition.middlewares = append([]httpcontract.RouteMiddleware{}, routeValue.middlewares...)
+    }
+
+    return definition
 }
 
 var _ = regexp.Regexp{}
@@ -145,7 +145,32 @@ func (instance *ServerSentEventHub) SubscriberCount(topic string) int {
     return len(instance.subscribersByTopic[topic])
 }
 
+func (instance *ServerSentEventHub) TotalSubscriberCount() int {
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    total := 0
+    for _, subscribers := range instance.subscribersByTopic {
+        total += len(subscribers)
+    }
+
+    return total
+}
+
 func (instance *ServerSentEventHub) Shutdown() {
+    instance.shutdown(nil)
+}
+
+/*
+ * ShutdownWithEvent queues event (typically a "reconnect" event with a Retry hint) to every subscriber before closing
+ * them, so clients reconnect to another instance instead of treating the closed stream as an error. Subscribers whose
+ * buffer is full are closed without the event.
+ */
+func (instance *ServerSentEventHub) ShutdownWithEvent(event ServerSentEvent) {
+    instance.shutdown(&event)
+}
+
+func (instance *ServerSentEventHub) shutdown(finalEvent *ServerSentEvent) {
     instance.mutex.Lock()
     defer instance.mutex.Unlock()
 
@@ -157,6 +182,15 @@ func (instance *ServerSentEventHub) Shutdown() {
 
     for topic, subscribers := range instance.subscribersByTopic {
         for subscriber := range subscribers {
+            if nil != finalEvent {
+                select {
+                case subscriber.channel <- *finalEvent:
+                default:
+                    atomic.AddUint64(&instance.dropped, 1)
+                    atomic.AddUint64(&subscriber.dropped, 1)
+                }
+            }
+
             close(subscriber.channel)
         }
 
@@ -78,6 +78,38 @@ func TestServerSentEventHub_ShutdownClosesSubscribersAndStopsDelivery(t *testing
     hub.Shutdown()
 }
 
+func TestServerSentEventHub_ShutdownWithEventQueuesTheEventBeforeClosing(t *testing.T) {
+    hub := NewServerSentEventHub()
+
+    roomy := hub.Subscribe("demo", 4)
+    full := hub.Subscribe("demo", 1)
+    hub.DeliverLocal("demo", ServerSentEvent{Data: "pending"})
+
+    hub.ShutdownWithEvent(ServerSentEvent{Event: "reconnect", Retry: 1000})
+
+    var events []ServerSentEvent
+    for event := range roomy.Events() {
+        events = append(events, event)
+    }
+
+    if 2 != len(events) || "pending" != events[0].Data || "reconnect" != events[1].Event || 1000 != events[1].Retry {
+        t.Fatalf("expected the pending event followed by the reconnect event, got %+v", events)
+    }
+
+    var fullEvents []ServerSentEvent
+    for event := range full.Events() {
+        fullEvents = append(fullEvents, event)
+    }
+
+    if 1 != len(fullEvents) || "pending" != fullEvents[0].Data {
+        t.Fatalf("expected the full subscriber to be closed without the reconnect event, got %+v", fullEvents)
+    }
+
+    if 1 != full.DroppedCount() {
+        t.Fatalf("expected the skipped reconnect event to count as dropped, got %d", full.DroppedCount())
+    }
+}
+
 func TestServerSentEventHub_SubscribeAfterShutdownReturnsClosedChannel(t *testing.T) {
     hub := NewServerSentEventHub()
     hub.Shutdown()
@@ -110,6 +142,24 @@ func TestServerSentEventHub_UnsubscribeStopsDelivery(t *testing.T) {
     }
 }
 
+func TestServerSentEventHub_TotalSubscriberCountSumsEveryTopic(t *testing.T) {
+    hub := NewServerSentEventHub()
+
+    first := hub.Subscribe("demo", 4)
+    _ = hub.Subscribe("demo", 4)
+    _ = hub.Subscribe("other", 4)
+
+    if 3 != hub.TotalSubscriberCount() {
+        t.Fatalf("expected 3 subscribers, got %d", hub.TotalSubscriberCount())
+    }
+
+    hub.Unsubscribe(first)
+
+    if 2 != hub.TotalSubscriberCount() {
+        t.Fatalf("expected 2 subscribers after unsubscribe, got %d", hub.TotalSubscriberCount())
+    }
+}
+
 /* @info backplane */
 
 type recordingBackplane struct {
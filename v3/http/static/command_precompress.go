@@ -0,0 +1,167 @@
+package static
+
+import (
+    "fmt"
+    "path/filepath"
+    "strings"
+    "time"
+
+    clicontract "github.com/precision-soft/melody/v3/cli/contract"
+    "github.com/precision-soft/melody/v3/cli/output"
+    "github.com/precision-soft/melody/v3/config"
+    "github.com/precision-soft/melody/v3/http/compression"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+const (
+    precompressDirectoryFlagName = "dir"
+    precompressLevelFlagName     = "level"
+    precompressMinSizeFlagName   = "min-size"
+    precompressForceFlagName     = "force"
+)
+
+func NewPrecompressCommand(encoderRegistry *compression.EncoderRegistry) *PrecompressCommand {
+    return &PrecompressCommand{
+        encoderRegistry: encoderRegistry,
+    }
+}
+
+/* @info PrecompressCommand writes the siblings FileServer serves; run it at build time, before the public directory is embedded. */
+type PrecompressCommand struct {
+    encoderRegistry *compression.EncoderRegistry
+}
+
+func (instance *PrecompressCommand) Name() string {
+    return "static:precompress"
+}
+
+func (instance *PrecompressCommand) Description() string {
+    return "Write compressed siblings of the public directory assets for every registered coding (.gz by default)"
+}
+
+func (instance *PrecompressCommand) Flags() []clicontract.Flag {
+    defaultConfig := compression.DefaultPrecompressConfig()
+
+    return output.MergeFlags(
+        output.StandardFlags(),
+        []clicontract.Flag{
+            &clicontract.StringFlag{
+                Name:  precompressDirectoryFlagName,
+                Usage: "directory to precompress (default: the configured public directory)",
+                Value: "",
+            },
+            &clicontract.IntFlag{
+                Name:  precompressLevelFlagName,
+                Usage: "compression level passed to every encoder",
+                Value: defaultConfig.Level(),
+            },
+            &clicontract.IntFlag{
+                Name:  precompressMinSizeFlagName,
+                Usage: "skip files smaller than this many bytes",
+                Value: defaultConfig.MinSize(),
+            },
+            &clicontract.BoolFlag{
+                Name:  precompressForceFlagName,
+                Usage: "rebuild siblings that are already up to date",
+                Value: false,
+            },
+        },
+    )
+}
+
+func (instance *PrecompressCommand) Run(
+    runtimeInstance runtimecontract.Runtime,
+    commandContext *clicontract.CommandContext,
+) error {
+    startedAt := time.Now()
+
+    option := output.NormalizeOption(
+        output.ParseOptionFromCommand(commandContext),
+    )
+
+    directory := strings.TrimSpace(commandContext.String(precompressDirectoryFlagName))
+    if "" == directory {
+        configuration := config.ConfigMustFromContainer(runtimeInstance.Container())
+
+        directory = configuration.Http().PublicDir()
+        if false == filepath.IsAbs(directory) {
+            directory = filepath.Join(configuration.Kernel().ProjectDir(), directory)
+        }
+    }
+
+    precompressConfig := compression.DefaultPrecompressConfig()
+    precompressConfig.SetLevel(int(commandContext.Int(precompressLevelFlagName)))
+    precompressConfig.SetMinSize(int(commandContext.Int(precompressMinSizeFlagName)))
+    precompressConfig.SetForce(commandContext.Bool(precompressForceFlagName))
+
+    encoderRegistry := instance.encoderRegistry
+    if nil == encoderRegistry {
+        encoderRegistry = compression.DefaultEncoderRegistry()
+    }
+
+    results, precompressErr := compression.Precompress(directory, encoderRegistry, precompressConfig)
+    if nil != precompressErr {
+        return precompressErr
+    }
+
+    meta := output.NewMeta(
+        instance.Name(),
+        commandContext.Args().Slice(),
+        option,
+        startedAt,
+        time.Duration(0),
+        output.Version{},
+    )
+
+    envelope := output.NewEnvelope(meta)
+
+    counts := make(map[string]int)
+    for _, result := range results {
+        counts[result.Status]++
+    }
+
+    if output.FormatTable == option.Format {
+        builder := output.NewTableBuilder()
+
+        builder.AddSummaryLine(fmt.Sprintf("DIRECTORY: %s", directory))
+        builder.AddSummaryLine(fmt.Sprintf("ENCODINGS: %s", strings.Join(encoderRegistry.Encodings(), ", ")))
+        builder.AddSummaryLine(
+            fmt.Sprintf(
+                "FILES: %d written, %d fresh, %d not smaller",
+                counts[compression.PrecompressStatusWritten],
+                counts[compression.PrecompressStatusFresh],
+                counts[compression.PrecompressStatusNotSmaller],
+            ),
+        )
+
+        block := builder.AddBlock(
+            "FILES",
+            []string{"path", "encoding", "status", "original", "compressed"},
+        ).SetFields("path", "encoding", "status", "originalSize", "compressedSize")
+
+        for _, result := range results {
+            block.AddRow(
+                result.Path,
+                result.Encoding,
+                result.Status,
+                fmt.Sprintf("%d", result.OriginalSize),
+                fmt.Sprintf("%d", result.CompressedSize),
+            )
+        }
+
+        envelope.Table = builder.Build()
+    } else {
+        envelope.Data = output.NewListPayload(
+            results,
+            len(results),
+            option.Limit,
+            option.Offset,
+        )
+    }
+
+    envelope.Meta.DurationMilliseconds = time.Since(startedAt).Milliseconds()
+
+    return output.Render(commandContext.Writer, envelope, option)
+}
+
+var _ clicontract.Command = (*PrecompressCommand)(nil)
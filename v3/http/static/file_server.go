@@ -13,6 +13,7 @@
 
     "github.com/precision-soft/melody/v3/exception"
     exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+    "github.com/precision-soft/melody/v3/http/compression"
     httpcontract "github.com/precision-soft/melody/v3/http/contract"
     "github.com/precision-soft/melody/v3/logging"
     loggingcontract "github.com/precision-soft/melody/v3/logging/contract"
@@ -354,6 +355,10 @@ func (instance *FileServer) resolveAndOpen(
         }
     }
 
+    if true == instance.config.Precompressed() {
+        file, fileInfo = instance.openPrecompressedSibling(request, relativePath, file, fileInfo, headers, logger)
+    }
+
     notModified := false
 
     if true == instance.config.enableCache {
@@ -451,6 +456,69 @@ func (instance *FileServer) serveForStreaming(
     return nethttp.StatusOK, resolved.headers, resolved.file, resolved.fileInfo, true
 }
 
+/*
+ * openPrecompressedSibling swaps the file for its ".br", ".zst" or ".gz" sibling when the client accepts that coding,
+ * keeping the original Content-Type. ETag and Content-Length then describe the sibling, which is what is sent.
+ */
+func (instance *FileServer) openPrecompressedSibling(
+    request httpcontract.Request,
+    relativePath string,
+    file fs.File,
+    fileInfo fs.FileInfo,
+    headers nethttp.Header,
+    logger loggingcontract.Logger,
+) (fs.File, fs.FileInfo) {
+    var available []string
+    for _, encoding := range compression.SiblingEncodings() {
+        extension, _ := compression.SiblingExtension(encoding)
+
+        siblingInfo, statErr := fs.Stat(instance.fileSystem, relativePath+extension)
+        if nil == statErr && true == siblingInfo.Mode().IsRegular() {
+            available = append(available, encoding)
+        }
+    }
+
+    if 0 == len(available) {
+        return file, fileInfo
+    }
+
+    headers.Add("Vary", "Accept-Encoding")
+
+    encoding := compression.Negotiate(request.Header("Accept-Encoding"), available)
+    if "" == encoding {
+        return file, fileInfo
+    }
+
+    extension, _ := compression.SiblingExtension(encoding)
+
+    siblingFile, openErr := instance.fileSystem.Open(relativePath + extension)
+    if nil != openErr {
+        return file, fileInfo
+    }
+
+    siblingInfo, statErr := siblingFile.Stat()
+    if nil != statErr {
+        _ = siblingFile.Close()
+        return file, fileInfo
+    }
+
+    _ = file.Close()
+
+    headers.Set("Content-Encoding", encoding)
+
+    if nil != logger {
+        logger.Info(
+            "static serve precompressed sibling",
+            loggingcontract.Context{
+                "relativePath": relativePath,
+                "encoding":     encoding,
+            },
+        )
+    }
+
+    return siblingFile, siblingInfo
+}
+
 func contentTypeByExtension(extension string) string {
     contentType := mime.TypeByExtension(extension)
     if "" != contentType {
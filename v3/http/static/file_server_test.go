@@ -477,3 +477,67 @@ func TestContentTypeByExtension_ResolvesIcoAndIsCaseInsensitive(t *testing.T) {
 func osWriteFile(path string, data []byte) error {
     return os.WriteFile(path, data, 0o644)
 }
+
+func TestFileServer_ServesPrecompressedSiblingForAcceptedEncoding(t *testing.T) {
+    fileSystem := fstest.MapFS{
+        "app.js":    &fstest.MapFile{Data: []byte("console.log('original')")},
+        "app.js.br": &fstest.MapFile{Data: []byte("brotli")},
+        "app.js.gz": &fstest.MapFile{Data: []byte("gzip")},
+    }
+
+    server := NewFileServer(
+        NewOptions(
+            NewFileServerConfig(ModeEmbedded, "", "index.html", "", false, 0, false),
+            "",
+            fileSystem,
+        ),
+    )
+
+    request := testhelper.NewHttpTestRequest(http.MethodGet, "http://example.com/app.js")
+    request.HttpRequest().Header.Set("Accept-Encoding", "gzip, br;q=0.5")
+
+    _, headers, body, served := server.Serve(request, logging.NewNopLogger())
+    if false == served {
+        t.Fatalf("expected served")
+    }
+
+    if "gzip" != string(body) || "gzip" != headers.Get("Content-Encoding") {
+        t.Fatalf("expected the gzip sibling, got %q (%s)", string(body), headers.Get("Content-Encoding"))
+    }
+
+    if false == strings.HasPrefix(headers.Get("Content-Type"), "text/javascript") {
+        t.Fatalf("expected the original content type, got %s", headers.Get("Content-Type"))
+    }
+
+    if "Accept-Encoding" != headers.Get("Vary") {
+        t.Fatalf("expected vary header")
+    }
+
+    request = testhelper.NewHttpTestRequest(http.MethodGet, "http://example.com/app.js")
+    request.HttpRequest().Header.Set("Accept-Encoding", "zstd")
+
+    _, headers, body, _ = server.Serve(request, logging.NewNopLogger())
+    if "console.log('original')" != string(body) || "" != headers.Get("Content-Encoding") {
+        t.Fatalf("expected the original file, got %q", string(body))
+    }
+}
+
+func TestFileServer_PrecompressedCanBeDisabled(t *testing.T) {
+    fileSystem := fstest.MapFS{
+        "app.css":    &fstest.MapFile{Data: []byte("body{}")},
+        "app.css.br": &fstest.MapFile{Data: []byte("brotli")},
+    }
+
+    config := NewFileServerConfig(ModeEmbedded, "", "index.html", "", false, 0, false)
+    config.SetPrecompressed(false)
+
+    server := NewFileServer(NewOptions(config, "", fileSystem))
+
+    request := testhelper.NewHttpTestRequest(http.MethodGet, "http://example.com/app.css")
+    request.HttpRequest().Header.Set("Accept-Encoding", "br")
+
+    _, headers, body, _ := server.Serve(request, logging.NewNopLogger())
+    if "body{}" != string(body) || "" != headers.Get("Content-Encoding") {
+        t.Fatalf("expected the original file, got %q", string(body))
+    }
+}
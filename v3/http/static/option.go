@@ -19,6 +19,8 @@ type FileServerConfig struct {
     enableCache bool
     cacheMaxAge int
     weakEtag    bool
+
+    skipPrecompressed bool
 }
 
 func NewFileServerConfig(
@@ -41,6 +43,15 @@ func NewFileServerConfig(
     }
 }
 
+/* @info Precompressed reports whether ".br", ".zst" and ".gz" siblings are served to clients accepting that coding; enabled by default. */
+func (instance *FileServerConfig) Precompressed() bool {
+    return false == instance.skipPrecompressed
+}
+
+func (instance *FileServerConfig) SetPrecompressed(enabled bool) {
+    instance.skipPrecompressed = false == enabled
+}
+
 type Options struct {
     fileServerConfig *FileServerConfig
     root             string
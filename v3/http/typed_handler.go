@@ -2,10 +2,13 @@
 
 import (
     "encoding/json"
+    "errors"
     nethttp "net/http"
+    "reflect"
 
     "github.com/precision-soft/melody/v3/exception"
     httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    "github.com/precision-soft/melody/v3/internal"
     runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
     "github.com/precision-soft/melody/v3/validation"
 )
@@ -56,7 +59,19 @@ func JsonHandler[Req any](
 
         validationErr := validatorInstance.Validate(body)
         if nil != validationErr {
-            return jsonHandlerError(settings, runtimeInstance, request, nethttp.StatusBadRequest, validationErr.Error())
+            if nil != settings.errorResponder {
+                return settings.errorResponder(runtimeInstance, request, nethttp.StatusBadRequest, validationErr.Error())
+            }
+
+            /* @info the message keeps the flat validation summary; the errors context feeds problem details responses. */
+            httpException := exception.NewHttpException(nethttp.StatusBadRequest, validationErr.Error())
+
+            validationErrors, isValidationErrors := validationErr.(validation.ValidationErrors)
+            if true == isValidationErrors {
+                httpException.SetContextValue("errors", validationErrors)
+            }
+
+            return nil, httpException
         }
 
         return handle(runtimeInstance, request, body)
@@ -76,3 +91,157 @@ func jsonHandlerError(
 
     return nil, exception.NewHttpException(status, message)
 }
+
+type TypedHandlerFunc[Req any, Resp any] func(
+    runtimeInstance runtimecontract.Runtime,
+    request httpcontract.Request,
+    input Req,
+) (Resp, error)
+
+type TypedHandlerOption func(*typedHandlerOptions)
+
+type typedHandlerOptions struct {
+    status         int
+    errorResponder JsonHandlerErrorResponder
+}
+
+func WithTypedHandlerStatus(status int) TypedHandlerOption {
+    return func(options *typedHandlerOptions) {
+        options.status = status
+    }
+}
+
+func WithTypedHandlerErrorResponder(responder JsonHandlerErrorResponder) TypedHandlerOption {
+    return func(options *typedHandlerOptions) {
+        options.errorResponder = responder
+    }
+}
+
+/*
+ * Handler binds Req from the request (see BindRequest), validates it, calls handle and serializes the returned Resp
+ * with the serializer chosen by the Accept header. A Resp that already is an httpcontract.Response is returned as is.
+ */
+func Handler[Req any, Resp any](
+    handle TypedHandlerFunc[Req, Resp],
+    options ...TypedHandlerOption,
+) httpcontract.Handler {
+    return NewTypedHandler(handle, options...).Handler()
+}
+
+/*
+ * HandleTyped registers the typed handler on a router or route group and records it in the route attributes
+ * (RouteAttributeTypedHandler), so the route definition keeps the request/response types and status of the handler.
+ */
+func HandleTyped(
+    routeHandler httpcontract.RouteHandler,
+    pattern string,
+    routeOptions httpcontract.RouteOptions,
+    typedHandler httpcontract.TypedHandler,
+) {
+    if nil == routeOptions {
+        exception.Panic(exception.NewError("the route options is nil", map[string]any{"pattern": pattern}, nil))
+    }
+
+    if true == internal.IsNilInterface(typedHandler) {
+        exception.Panic(exception.NewError("typed handler is nil", map[string]any{"pattern": pattern}, nil))
+    }
+
+    routeHandler.HandleWithOptions(pattern, typedHandler.Handler(), routeOptionsWithAttribute(routeOptions, RouteAttributeTypedHandler, typedHandler))
+}
+
+func NewTypedHandler[Req any, Resp any](
+    handle TypedHandlerFunc[Req, Resp],
+    options ...TypedHandlerOption,
+) *TypedHandler[Req, Resp] {
+    if nil == handle {
+        exception.Panic(exception.NewError("typed handler function is nil", nil, nil))
+    }
+
+    settings := &typedHandlerOptions{
+        status: nethttp.StatusOK,
+    }
+    for _, option := range options {
+        option(settings)
+    }
+
+    return &TypedHandler[Req, Resp]{
+        handle:   handle,
+        settings: settings,
+    }
+}
+
+type TypedHandler[Req any, Resp any] struct {
+    handle   TypedHandlerFunc[Req, Resp]
+    settings *typedHandlerOptions
+}
+
+func (instance *TypedHandler[Req, Resp]) RequestType() reflect.Type {
+    return reflect.TypeOf((*Req)(nil)).Elem()
+}
+
+func (instance *TypedHandler[Req, Resp]) ResponseType() reflect.Type {
+    return reflect.TypeOf((*Resp)(nil)).Elem()
+}
+
+func (instance *TypedHandler[Req, Resp]) Status() int {
+    return instance.settings.status
+}
+
+func (instance *TypedHandler[Req, Resp]) Handler() httpcontract.Handler {
+    return func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+        var input Req
+
+        bindErr := BindRequest(request, &input)
+        if nil != bindErr {
+            return instance.respondWithError(runtimeInstance, request, bindErr)
+        }
+
+        validatorInstance := validation.ValidatorMustFromContainer(runtimeInstance.Container())
+
+        validationErr := validatorInstance.Validate(input)
+        if nil != validationErr {
+            return instance.respondWithError(runtimeInstance, request, validationFailedException(validationErr))
+        }
+
+        output, handleErr := instance.handle(runtimeInstance, request, input)
+        if nil != handleErr {
+            return nil, handleErr
+        }
+
+        var outputValue any = output
+
+        response, isResponse := outputValue.(httpcontract.Response)
+        if true == isResponse {
+            if true == internal.IsNilInterface(response) {
+                return nil, nil
+            }
+
+            return response, nil
+        }
+
+        return normalizeResultToResponseWithStatus(runtimeInstance, request, instance.settings.status, outputValue)
+    }
+}
+
+func (instance *TypedHandler[Req, Resp]) respondWithError(
+    runtimeInstance runtimecontract.Runtime,
+    request httpcontract.Request,
+    err error,
+) (httpcontract.Response, error) {
+    if nil == instance.settings.errorResponder {
+        return nil, err
+    }
+
+    status := nethttp.StatusBadRequest
+    message := err.Error()
+
+    var httpException *exception.HttpException
+    if true == errors.As(err, &httpException) {
+        status = httpException.StatusCode()
+        message = httpException.Message()
+    }
+
+    return instance.settings.errorResponder(runtimeInstance, request, status, message)
+}
+
+var _ httpcontract.TypedHandler = (*TypedHandler[any, any])(nil)
@@ -2,16 +2,23 @@
 
 import (
     "context"
+    "errors"
+    "io"
     nethttp "net/http"
     "net/http/httptest"
+    "reflect"
     "strings"
     "testing"
+    "time"
 
     "github.com/precision-soft/melody/v3/container"
     containercontract "github.com/precision-soft/melody/v3/container/contract"
+    "github.com/precision-soft/melody/v3/exception"
     httpcontract "github.com/precision-soft/melody/v3/http/contract"
     "github.com/precision-soft/melody/v3/runtime"
     runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+    "github.com/precision-soft/melody/v3/serializer"
+    serializercontract "github.com/precision-soft/melody/v3/serializer/contract"
     "github.com/precision-soft/melody/v3/validation"
 )
 
@@ -96,3 +103,176 @@ func TestJsonHandler_RejectsTrailingData(t *testing.T) {
         t.Fatalf("handler must not run when the body carries trailing data")
     }
 }
+
+type typedHandlerTestRequest struct {
+    Id      int           `path:"id"`
+    Page    int           `query:"page" default:"1"`
+    Tags    []string      `query:"tag"`
+    Tenant  string        `header:"X-Tenant" validate:"notBlank"`
+    Session string        `cookie:"session"`
+    Timeout time.Duration `query:"timeout"`
+    Name    string        `json:"name" validate:"notBlank"`
+}
+
+type typedHandlerTestResponse struct {
+    Id     int    `json:"id"`
+    Tenant string `json:"tenant"`
+}
+
+type typedHandlerTestFormRequest struct {
+    Title string `form:"title" validate:"notBlank"`
+    Count *int   `form:"count"`
+}
+
+func newTypedHandlerRuntime() runtimecontract.Runtime {
+    serviceContainer := container.NewContainer()
+
+    serviceContainer.MustRegister(
+        validation.ServiceValidator,
+        func(resolver containercontract.Resolver) (*validation.Validator, error) {
+            return validation.NewValidator(), nil
+        },
+    )
+
+    serviceContainer.MustRegister(
+        serializer.ServiceSerializerManager,
+        func(resolver containercontract.Resolver) (*serializer.SerializerManager, error) {
+            return serializer.NewSerializerManager(
+                map[string]serializercontract.Serializer{
+                    serializer.MimeApplicationJson: serializer.NewJsonSerializer(),
+                },
+            )
+        },
+    )
+
+    return runtime.New(context.Background(), serviceContainer.NewScope(), serviceContainer)
+}
+
+func TestHandler_BindsPathQueryHeaderCookieAndBody(t *testing.T) {
+    runtimeInstance := newTypedHandlerRuntime()
+
+    var captured typedHandlerTestRequest
+    handler := Handler(
+        func(currentRuntime runtimecontract.Runtime, request httpcontract.Request, input typedHandlerTestRequest) (typedHandlerTestResponse, error) {
+            captured = input
+
+            return typedHandlerTestResponse{Id: input.Id, Tenant: input.Tenant}, nil
+        },
+        WithTypedHandlerStatus(nethttp.StatusCreated),
+    )
+
+    httpRequest := httptest.NewRequest(nethttp.MethodPost, "/items/42?tag=a&tag=b&timeout=2s", strings.NewReader(`{"name":"abc"}`))
+    httpRequest.Header.Set("Content-Type", "application/json")
+    httpRequest.Header.Set("X-Tenant", "acme")
+    httpRequest.AddCookie(&nethttp.Cookie{Name: "session", Value: "s1"})
+    request := NewRequest(httpRequest, map[string]string{"id": "42"}, runtimeInstance, nil)
+
+    response, handleErr := handler(runtimeInstance, httptest.NewRecorder(), request)
+    if nil != handleErr {
+        t.Fatalf("unexpected error: %v", handleErr)
+    }
+
+    if 42 != captured.Id || 1 != captured.Page || "acme" != captured.Tenant || "s1" != captured.Session || "abc" != captured.Name {
+        t.Fatalf("unexpected bound input: %+v", captured)
+    }
+
+    if 2 != len(captured.Tags) || "b" != captured.Tags[1] || 2*time.Second != captured.Timeout {
+        t.Fatalf("unexpected bound query values: %+v", captured)
+    }
+
+    if nethttp.StatusCreated != response.StatusCode() {
+        t.Fatalf("expected status 201, got %d", response.StatusCode())
+    }
+
+    if false == strings.HasPrefix(response.Headers().Get("Content-Type"), serializer.MimeApplicationJson) {
+        t.Fatalf("unexpected content type %q", response.Headers().Get("Content-Type"))
+    }
+
+    body, _ := io.ReadAll(response.BodyReader())
+    if false == strings.Contains(string(body), `"tenant":"acme"`) {
+        t.Fatalf("unexpected body %s", body)
+    }
+}
+
+func TestHandler_RejectsInvalidParameterAndFailedValidation(t *testing.T) {
+    runtimeInstance := newTypedHandlerRuntime()
+
+    handled := false
+    handler := Handler(func(currentRuntime runtimecontract.Runtime, request httpcontract.Request, input typedHandlerTestRequest) (typedHandlerTestResponse, error) {
+        handled = true
+
+        return typedHandlerTestResponse{}, nil
+    })
+
+    httpRequest := httptest.NewRequest(nethttp.MethodPost, "/items/x?page=nope", strings.NewReader(`{"name":"abc"}`))
+    httpRequest.Header.Set("X-Tenant", "acme")
+    request := NewRequest(httpRequest, map[string]string{"id": "42"}, runtimeInstance, nil)
+
+    _, handleErr := handler(runtimeInstance, httptest.NewRecorder(), request)
+
+    var httpException *exception.HttpException
+    if false == errors.As(handleErr, &httpException) || nethttp.StatusBadRequest != httpException.StatusCode() {
+        t.Fatalf("expected a 400 http exception for an invalid query value, got %v", handleErr)
+    }
+
+    if "query" != httpException.Context()["source"] || "page" != httpException.Context()["name"] {
+        t.Fatalf("expected the failing input in the context, got %v", httpException.Context())
+    }
+
+    httpRequest = httptest.NewRequest(nethttp.MethodPost, "/items/42", strings.NewReader(`{"name":"abc"}`))
+    request = NewRequest(httpRequest, map[string]string{"id": "42"}, runtimeInstance, nil)
+
+    _, handleErr = handler(runtimeInstance, httptest.NewRecorder(), request)
+    if false == errors.As(handleErr, &httpException) || "validation failed" != httpException.Message() {
+        t.Fatalf("expected a validation failure for the missing tenant header, got %v", handleErr)
+    }
+
+    if true == handled {
+        t.Fatalf("handler must not run when binding or validation fails")
+    }
+}
+
+func TestHandler_BindsFormFields(t *testing.T) {
+    runtimeInstance := newTypedHandlerRuntime()
+
+    var captured typedHandlerTestFormRequest
+    handler := Handler(func(currentRuntime runtimecontract.Runtime, request httpcontract.Request, input typedHandlerTestFormRequest) (*Response, error) {
+        captured = input
+
+        return EmptyResponse(nethttp.StatusNoContent), nil
+    })
+
+    httpRequest := httptest.NewRequest(nethttp.MethodPost, "/items", strings.NewReader("title=hello&count=3"))
+    httpRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+    request := NewRequest(httpRequest, nil, runtimeInstance, nil)
+
+    response, handleErr := handler(runtimeInstance, httptest.NewRecorder(), request)
+    if nil != handleErr {
+        t.Fatalf("unexpected error: %v", handleErr)
+    }
+
+    if "hello" != captured.Title || nil == captured.Count || 3 != *captured.Count {
+        t.Fatalf("unexpected bound form: %+v", captured)
+    }
+
+    if nethttp.StatusNoContent != response.StatusCode() {
+        t.Fatalf("expected the returned response to be used as is, got status %d", response.StatusCode())
+    }
+}
+
+func TestTypedHandler_ExposesTypesAndStatus(t *testing.T) {
+    typedHandler := NewTypedHandler(
+        func(currentRuntime runtimecontract.Runtime, request httpcontract.Request, input typedHandlerTestRequest) (typedHandlerTestResponse, error) {
+            return typedHandlerTestResponse{}, nil
+        },
+        WithTypedHandlerStatus(nethttp.StatusAccepted),
+    )
+
+    if reflect.TypeOf(typedHandlerTestRequest{}) != typedHandler.RequestType() || reflect.TypeOf(typedHandlerTestResponse{}) != typedHandler.ResponseType() {
+        t.Fatalf("unexpected types %v %v", typedHandler.RequestType(), typedHandler.ResponseType())
+    }
+
+    if nethttp.StatusAccepted != typedHandler.Status() {
+        t.Fatalf("unexpected status %d", typedHandler.Status())
+    }
+}
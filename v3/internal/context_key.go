@@ -0,0 +1,114 @@
+package internal
+
+import (
+    "reflect"
+    "strings"
+)
+
+/* @important maxSanitizeDepth bounds the walk over nested context values, so a map that contains itself cannot recurse until the stack overflows; deeper values are kept as they are. */
+const maxSanitizeDepth = 64
+
+/* ContextKeyMatcher matches context keys exactly (case-sensitive) or by a lowercase substring of the lowercased key. */
+type ContextKeyMatcher struct {
+    exactKeys  map[string]struct{}
+    substrings []string
+}
+
+func NewContextKeyMatcher(exactKeys []string, substrings []string) *ContextKeyMatcher {
+    matcher := &ContextKeyMatcher{
+        exactKeys:  make(map[string]struct{}, len(exactKeys)),
+        substrings: make([]string, 0, len(substrings)),
+    }
+
+    for _, exactKey := range exactKeys {
+        matcher.exactKeys[exactKey] = struct{}{}
+    }
+
+    for _, substring := range substrings {
+        if "" == substring {
+            continue
+        }
+
+        matcher.substrings = append(matcher.substrings, strings.ToLower(substring))
+    }
+
+    return matcher
+}
+
+func (instance *ContextKeyMatcher) Matches(key string) bool {
+    if _, exists := instance.exactKeys[key]; true == exists {
+        return true
+    }
+
+    lowerKey := strings.ToLower(key)
+    for _, substring := range instance.substrings {
+        if true == strings.Contains(lowerKey, substring) {
+            return true
+        }
+    }
+
+    return false
+}
+
+/*
+ * SanitizeContextValue walks nested string keyed maps and slices and rewrites every entry whose key matches: the entry is
+ * dropped when replacement is nil, otherwise its value becomes replacement. Maps are rebuilt as map[string]any and slices
+ * as []any; the input is never modified.
+ */
+func SanitizeContextValue(value any, matcher *ContextKeyMatcher, replacement any) any {
+    return sanitizeContextValueAtDepth(value, matcher, replacement, 0)
+}
+
+func SanitizeContextMap(value map[string]any, matcher *ContextKeyMatcher, replacement any) map[string]any {
+    return sanitizeContextMapAtDepth(value, matcher, replacement, 0)
+}
+
+func sanitizeContextValueAtDepth(value any, matcher *ContextKeyMatcher, replacement any, depth int) any {
+    if nil == value || depth >= maxSanitizeDepth {
+        return value
+    }
+
+    switch typedValue := value.(type) {
+    case map[string]any:
+        return sanitizeContextMapAtDepth(typedValue, matcher, replacement, depth)
+    case []any:
+        sanitized := make([]any, 0, len(typedValue))
+        for _, itemValue := range typedValue {
+            sanitized = append(sanitized, sanitizeContextValueAtDepth(itemValue, matcher, replacement, depth+1))
+        }
+
+        return sanitized
+    }
+
+    reflectedValue := reflect.ValueOf(value)
+    if reflect.Map != reflectedValue.Kind() || reflect.String != reflectedValue.Type().Key().Kind() || true == reflectedValue.IsNil() {
+        return value
+    }
+
+    /* @info named map types (logging or exception contexts nested in a context) are walked like plain maps */
+    converted := make(map[string]any, reflectedValue.Len())
+    iterator := reflectedValue.MapRange()
+    for iterator.Next() {
+        converted[iterator.Key().String()] = iterator.Value().Interface()
+    }
+
+    return sanitizeContextMapAtDepth(converted, matcher, replacement, depth)
+}
+
+func sanitizeContextMapAtDepth(value map[string]any, matcher *ContextKeyMatcher, replacement any, depth int) map[string]any {
+    sanitized := make(map[string]any, len(value))
+
+    for key, itemValue := range value {
+        if true == matcher.Matches(key) {
+            if nil != replacement {
+                sanitized[key] = replacement
+            }
+
+            continue
+        }
+
+        sanitized[key] = sanitizeContextValueAtDepth(itemValue, matcher, replacement, depth+1)
+    }
+
+    return sanitized
+}
@@ -0,0 +1,65 @@
+package internal
+
+import (
+    "testing"
+)
+
+func TestContextKeyMatcher_MatchesExactAndSubstring(t *testing.T) {
+    matcher := NewContextKeyMatcher([]string{"trace"}, []string{"Secret"})
+
+    if false == matcher.Matches("trace") || true == matcher.Matches("Trace") {
+        t.Fatalf("expected exact keys to be case-sensitive")
+    }
+
+    if false == matcher.Matches("clientSECRETValue") {
+        t.Fatalf("expected substring to match case-insensitively")
+    }
+
+    if true == matcher.Matches("userId") {
+        t.Fatalf("expected unrelated key not to match")
+    }
+}
+
+func TestSanitizeContextMap_DropsOrReplacesNestedKeys(t *testing.T) {
+    type namedContext map[string]any
+
+    matcher := NewContextKeyMatcher(nil, []string{"token"})
+    original := map[string]any{
+        "token": "a",
+        "nested": namedContext{
+            "accessToken": "b",
+            "id":          1,
+        },
+        "list": []any{map[string]any{"refreshToken": "c"}},
+    }
+
+    dropped := SanitizeContextMap(original, matcher, nil)
+    if _, exists := dropped["token"]; true == exists {
+        t.Fatalf("expected key to be dropped")
+    }
+
+    nested := dropped["nested"].(map[string]any)
+    if _, exists := nested["accessToken"]; true == exists || 1 != nested["id"] {
+        t.Fatalf("unexpected nested map: %v", nested)
+    }
+
+    replaced := SanitizeContextMap(original, matcher, "***")
+    if "***" != replaced["token"] || "***" != replaced["list"].([]any)[0].(map[string]any)["refreshToken"] {
+        t.Fatalf("unexpected replaced map: %v", replaced)
+    }
+
+    if "a" != original["token"] {
+        t.Fatalf("expected original map to be left untouched")
+    }
+}
+
+func TestSanitizeContextValue_StopsOnSelfReference(t *testing.T) {
+    matcher := NewContextKeyMatcher(nil, []string{"token"})
+
+    recursive := map[string]any{}
+    recursive["self"] = recursive
+
+    if nil == SanitizeContextValue(recursive, matcher, nil) {
+        t.Fatalf("expected a value")
+    }
+}
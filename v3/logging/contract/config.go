@@ -1,7 +1,28 @@
 package contract
 
+import (
+    "time"
+)
+
 const LoggingConfigurationName = "logging"
 
 type LoggingConfiguration interface {
     LevelLabels() LevelLabels
 }
+
+/* FileRotationConfiguration is implemented by logging configurations that make the kernel log file a rotating file. */
+type FileRotationConfiguration interface {
+    /* @info nil keeps the plain append-only log file */
+    FileRotation() FileRotation
+}
+
+/* FileRotation describes how the log file rotates; zero values disable the corresponding behavior. */
+type FileRotation interface {
+    MaxSizeBytes() int64
+    Interval() time.Duration
+    MaxBackups() int
+    MaxAge() time.Duration
+    Compress() bool
+    BufferSize() int
+    ReopenOnSighup() bool
+}
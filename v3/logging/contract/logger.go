@@ -15,3 +15,8 @@ type Logger interface {
 
     Emergency(message string, context Context)
 }
+
+/* MinLevelLogger is implemented by loggers that can derive a logger sharing their output with a different minimum level. */
+type MinLevelLogger interface {
+    WithMinLevel(minLevel Level) Logger
+}
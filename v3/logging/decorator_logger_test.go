@@ -0,0 +1,218 @@
+package logging
+
+import (
+    "bytes"
+    "strconv"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/precision-soft/melody/v3/clock"
+    loggingcontract "github.com/precision-soft/melody/v3/logging/contract"
+)
+
+func TestFanOutLogger_RespectsSinkMinLevels(t *testing.T) {
+    all := &testRecordingLogger{}
+    errorsOnly := &testRecordingLogger{}
+
+    logger := NewFanOutLogger(
+        NewFanOutSink(all, loggingcontract.LevelDebug),
+        NewFanOutSink(errorsOnly, loggingcontract.LevelError),
+    )
+
+    logger.Info("info", nil)
+    logger.Error("error", nil)
+    logger.Emergency("emergency", nil)
+
+    if 3 != len(all.entries) {
+        t.Fatalf("expected 3 entries in debug sink, got %d", len(all.entries))
+    }
+
+    if 2 != len(errorsOnly.entries) || "error" != errorsOnly.entries[0].message {
+        t.Fatalf("unexpected error sink entries: %+v", errorsOnly.entries)
+    }
+
+    err := logger.(interface{ Close() error }).Close()
+    if nil != err {
+        t.Fatalf("unexpected close error: %v", err)
+    }
+
+    if 1 != all.closed || 1 != errorsOnly.closed {
+        t.Fatalf("expected every sink to be closed")
+    }
+}
+
+func TestFanOutLogger_PanicsWithoutSinks(t *testing.T) {
+    defer func() {
+        if nil == recover() {
+            t.Fatalf("expected panic")
+        }
+    }()
+
+    NewFanOutLogger()
+}
+
+func TestSamplingLogger_LogsFirstThenEveryThereafter(t *testing.T) {
+    recorder := &testRecordingLogger{}
+    frozenClock := clock.NewFrozenClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+    logger := NewSamplingLogger(
+        recorder,
+        NewSamplingConfig(time.Second, 2, 3).SetClock(frozenClock),
+    )
+
+    for index := 0; index < 8; index++ {
+        logger.Info("repeated", nil)
+    }
+
+    /* @info occurrences 1, 2, 5 and 8 */
+    if 4 != len(recorder.entries) {
+        t.Fatalf("expected 4 sampled entries, got %d", len(recorder.entries))
+    }
+
+    logger.Info("other", nil)
+    if 5 != len(recorder.entries) {
+        t.Fatalf("expected distinct message to be counted separately")
+    }
+
+    frozenClock.Advance(time.Second)
+    logger.Info("repeated", nil)
+    if 6 != len(recorder.entries) {
+        t.Fatalf("expected new window to log again")
+    }
+}
+
+func TestSamplingLogger_PassesDistinctMessagesThroughPastTheTrackingCap(t *testing.T) {
+    recorder := &testRecordingLogger{}
+    frozenClock := clock.NewFrozenClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+    samplingLoggerInstance := NewSamplingLogger(
+        recorder,
+        NewSamplingConfig(time.Second, 1, 0).SetClock(frozenClock),
+    ).(*samplingLogger)
+
+    for index := 0; index < samplingMaxTrackedMessages+10; index++ {
+        samplingLoggerInstance.Info("message "+strconv.Itoa(index), nil)
+    }
+
+    if samplingMaxTrackedMessages != len(samplingLoggerInstance.state.counters) {
+        t.Fatalf("expected the tracked messages to stop at the cap, got %d", len(samplingLoggerInstance.state.counters))
+    }
+
+    if samplingMaxTrackedMessages+10 != len(recorder.entries) {
+        t.Fatalf("expected untracked messages to pass through, got %d entries", len(recorder.entries))
+    }
+
+    frozenClock.Advance(time.Second)
+    samplingLoggerInstance.Info("after window", nil)
+    samplingLoggerInstance.Info("after window", nil)
+
+    if 1 != len(samplingLoggerInstance.state.counters) {
+        t.Fatalf("expected expired windows to be swept, got %d tracked messages", len(samplingLoggerInstance.state.counters))
+    }
+
+    if samplingMaxTrackedMessages+11 != len(recorder.entries) {
+        t.Fatalf("expected the new message to be sampled again, got %d entries", len(recorder.entries))
+    }
+}
+
+func TestSamplingLogger_DiscardedEntriesDoNotUseTheAllowanceOfARaisedLogger(t *testing.T) {
+    buffer := &bytes.Buffer{}
+
+    logger := NewSamplingLogger(
+        NewJsonLogger(buffer, loggingcontract.LevelInfo),
+        NewSamplingConfig(time.Minute, 1, 0),
+    )
+
+    for index := 0; index < 3; index++ {
+        logger.Debug("query executed", nil)
+    }
+
+    if 0 != buffer.Len() {
+        t.Fatalf("expected the base logger to discard debug entries, got %s", buffer.String())
+    }
+
+    raisedLogger := WithMinLevel(logger, loggingcontract.LevelDebug)
+    raisedLogger.Debug("query executed", nil)
+    raisedLogger.Debug("query executed", nil)
+
+    if 1 != strings.Count(buffer.String(), "query executed") {
+        t.Fatalf("expected the raised logger to log the first occurrence once, got %s", buffer.String())
+    }
+}
+
+func TestSamplingLogger_RateLimitsAndNeverSamplesErrors(t *testing.T) {
+    recorder := &testRecordingLogger{}
+
+    logger := NewSamplingLogger(recorder, NewSamplingConfig(time.Minute, 1, 0))
+
+    for index := 0; index < 5; index++ {
+        logger.Warning("flood", nil)
+        logger.Error("failure", nil)
+    }
+
+    warnings := 0
+    for _, entry := range recorder.entries {
+        if loggingcontract.LevelWarning == entry.level {
+            warnings++
+        }
+    }
+
+    if 1 != warnings {
+        t.Fatalf("expected one warning, got %d", warnings)
+    }
+
+    if 6 != len(recorder.entries) {
+        t.Fatalf("expected every error to be logged, got %d entries", len(recorder.entries))
+    }
+}
+
+func TestRedactingLogger_RedactsNestedKeys(t *testing.T) {
+    recorder := &testRecordingLogger{}
+    logger := NewRedactingLogger(recorder)
+
+    original := loggingcontract.Context{
+        "userId":   7,
+        "Password": "hunter2",
+        "headers": map[string]any{
+            "Authorization": "Bearer abc",
+            "Accept":        "application/json",
+        },
+        "items": []any{
+            map[string]any{"apiKey": "k"},
+        },
+    }
+
+    logger.Info("login", original)
+
+    context := recorder.entries[0].context
+    if 7 != context["userId"] || RedactedValue != context["Password"] {
+        t.Fatalf("unexpected context: %v", context)
+    }
+
+    headers := context["headers"].(map[string]any)
+    if RedactedValue != headers["Authorization"] || "application/json" != headers["Accept"] {
+        t.Fatalf("unexpected headers: %v", headers)
+    }
+
+    item := context["items"].([]any)[0].(map[string]any)
+    if RedactedValue != item["apiKey"] {
+        t.Fatalf("unexpected item: %v", item)
+    }
+
+    if "hunter2" != original["Password"] {
+        t.Fatalf("expected caller context to be left untouched")
+    }
+}
+
+func TestRedactingLogger_UsesCustomKeys(t *testing.T) {
+    recorder := &testRecordingLogger{}
+    logger := NewRedactingLogger(recorder, "iban")
+
+    logger.Info("payment", loggingcontract.Context{"customerIban": "RO49", "password": "kept"})
+
+    context := recorder.entries[0].context
+    if RedactedValue != context["customerIban"] || "kept" != context["password"] {
+        t.Fatalf("unexpected context: %v", context)
+    }
+}
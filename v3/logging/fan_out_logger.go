@@ -0,0 +1,141 @@
+package logging
+
+import (
+    "errors"
+
+    "github.com/precision-soft/melody/v3/exception"
+    "github.com/precision-soft/melody/v3/internal"
+    loggingcontract "github.com/precision-soft/melody/v3/logging/contract"
+)
+
+func NewFanOutSink(logger loggingcontract.Logger, minLevel loggingcontract.Level) FanOutSink {
+    if true == internal.IsNilInterface(logger) {
+        exception.Panic(exception.NewError("logger is not provided for fan out sink", nil, nil))
+    }
+
+    if false == IsValidLevel(minLevel) {
+        exception.Panic(
+            exception.NewError(
+                "invalid fan out sink min level",
+                map[string]any{
+                    "level": string(minLevel),
+                },
+                nil,
+            ),
+        )
+    }
+
+    return FanOutSink{
+        logger:   logger,
+        minLevel: minLevel,
+    }
+}
+
+type FanOutSink struct {
+    logger   loggingcontract.Logger
+    minLevel loggingcontract.Level
+}
+
+func (instance FanOutSink) Logger() loggingcontract.Logger {
+    return instance.logger
+}
+
+func (instance FanOutSink) MinLevel() loggingcontract.Level {
+    return instance.minLevel
+}
+
+/* NewFanOutLogger writes every entry to each sink whose minimum level it reaches, in sink order; Close closes every sink. */
+func NewFanOutLogger(sinks ...FanOutSink) loggingcontract.Logger {
+    if 0 == len(sinks) {
+        exception.Panic(exception.NewError("fan out logger requires at least one sink", nil, nil))
+    }
+
+    for _, sink := range sinks {
+        if true == internal.IsNilInterface(sink.logger) {
+            exception.Panic(exception.NewError("fan out sink must be created with NewFanOutSink", nil, nil))
+        }
+    }
+
+    return &fanOutLogger{
+        sinks: append([]FanOutSink{}, sinks...),
+    }
+}
+
+type fanOutLogger struct {
+    sinks []FanOutSink
+}
+
+func (instance *fanOutLogger) Log(level loggingcontract.Level, message string, context loggingcontract.Context) {
+    priority := priorityForLevel(level)
+
+    for _, sink := range instance.sinks {
+        if priority < priorityForLevel(sink.minLevel) {
+            continue
+        }
+
+        sink.logger.Log(level, message, context)
+    }
+}
+
+func (instance *fanOutLogger) Debug(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelDebug, message, context)
+}
+
+func (instance *fanOutLogger) Info(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelInfo, message, context)
+}
+
+func (instance *fanOutLogger) Warning(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelWarning, message, context)
+}
+
+func (instance *fanOutLogger) Error(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelError, message, context)
+}
+
+func (instance *fanOutLogger) Emergency(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelEmergency, message, context)
+}
+
+/* WithMinLevel applies minLevel to the sink loggers; the sink minimum levels still route the entries. */
+func (instance *fanOutLogger) WithMinLevel(minLevel loggingcontract.Level) loggingcontract.Logger {
+    sinks := make([]FanOutSink, 0, len(instance.sinks))
+    for _, sink := range instance.sinks {
+        sinks = append(
+            sinks,
+            FanOutSink{
+                logger:   WithMinLevel(sink.logger, minLevel),
+                minLevel: sink.minLevel,
+            },
+        )
+    }
+
+    return &fanOutLogger{
+        sinks: sinks,
+    }
+}
+
+func (instance *fanOutLogger) Close() error {
+    closeErrs := make([]error, 0)
+    for _, sink := range instance.sinks {
+        closeErr := closeLogger(sink.logger)
+        if nil != closeErr {
+            closeErrs = append(closeErrs, closeErr)
+        }
+    }
+
+    return errors.Join(closeErrs...)
+}
+
+var _ loggingcontract.Logger = (*fanOutLogger)(nil)
+var _ loggingcontract.MinLevelLogger = (*fanOutLogger)(nil)
+
+/* closeLogger closes loggers that own a resource (e.g. the json logger file); decorators call it for the logger they wrap. */
+func closeLogger(logger loggingcontract.Logger) error {
+    closer, isCloser := logger.(interface{ Close() error })
+    if false == isCloser {
+        return nil
+    }
+
+    return closer.Close()
+}
@@ -0,0 +1,117 @@
+package logging
+
+import (
+    "time"
+
+    "github.com/precision-soft/melody/v3/exception"
+    loggingcontract "github.com/precision-soft/melody/v3/logging/contract"
+)
+
+/* NewFileRotation returns a rotation that never rotates, keeps every backup and writes synchronously; enable what you need with the setters. */
+func NewFileRotation() *FileRotation {
+    return &FileRotation{}
+}
+
+type FileRotation struct {
+    maxSizeBytes   int64
+    interval       time.Duration
+    maxBackups     int
+    maxAge         time.Duration
+    compress       bool
+    bufferSize     int
+    reopenOnSighup bool
+}
+
+/* SetMaxSizeBytes rotates before a write would grow the file past maxSizeBytes. */
+func (instance *FileRotation) SetMaxSizeBytes(maxSizeBytes int64) *FileRotation {
+    instance.maxSizeBytes = mustNotBeNegative("maxSizeBytes", maxSizeBytes)
+
+    return instance
+}
+
+func (instance *FileRotation) MaxSizeBytes() int64 {
+    return instance.maxSizeBytes
+}
+
+/* SetInterval rotates on every interval boundary (e.g. 24h rotates at midnight UTC). */
+func (instance *FileRotation) SetInterval(interval time.Duration) *FileRotation {
+    instance.interval = mustNotBeNegative("interval", interval)
+
+    return instance
+}
+
+func (instance *FileRotation) Interval() time.Duration {
+    return instance.interval
+}
+
+func (instance *FileRotation) SetMaxBackups(maxBackups int) *FileRotation {
+    instance.maxBackups = mustNotBeNegative("maxBackups", maxBackups)
+
+    return instance
+}
+
+func (instance *FileRotation) MaxBackups() int {
+    return instance.maxBackups
+}
+
+func (instance *FileRotation) SetMaxAge(maxAge time.Duration) *FileRotation {
+    instance.maxAge = mustNotBeNegative("maxAge", maxAge)
+
+    return instance
+}
+
+func (instance *FileRotation) MaxAge() time.Duration {
+    return instance.maxAge
+}
+
+/* SetCompress gzips rotated files in the background. */
+func (instance *FileRotation) SetCompress(compress bool) *FileRotation {
+    instance.compress = compress
+
+    return instance
+}
+
+func (instance *FileRotation) Compress() bool {
+    return instance.compress
+}
+
+/* SetBufferSize makes writes non-blocking: up to bufferSize lines are queued for a background writer and lines that do not fit are dropped and counted. */
+func (instance *FileRotation) SetBufferSize(bufferSize int) *FileRotation {
+    instance.bufferSize = mustNotBeNegative("bufferSize", bufferSize)
+
+    return instance
+}
+
+func (instance *FileRotation) BufferSize() int {
+    return instance.bufferSize
+}
+
+/* SetReopenOnSighup reopens the file on SIGHUP, for external tools that move it away. */
+func (instance *FileRotation) SetReopenOnSighup(reopenOnSighup bool) *FileRotation {
+    instance.reopenOnSighup = reopenOnSighup
+
+    return instance
+}
+
+func (instance *FileRotation) ReopenOnSighup() bool {
+    return instance.reopenOnSighup
+}
+
+var _ loggingcontract.FileRotation = (*FileRotation)(nil)
+
+func mustNotBeNegative[T int | int64 | time.Duration](name string, value T) T {
+    if 0 > value {
+        exception.Panic(
+            exception.NewError(
+                "file rotation value may not be negative",
+                map[string]any{
+                    "name":  name,
+                    "value": value,
+                },
+                nil,
+            ),
+        )
+    }
+
+    return value
+}
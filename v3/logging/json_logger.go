@@ -50,7 +50,11 @@ type jsonLogger struct {
 }
 
 func (instance *jsonLogger) Log(level loggingcontract.Level, message string, context loggingcontract.Context) {
-    if priorityForLevel(level) < priorityForLevel(instance.minLevel) {
+    instance.log(instance.minLevel, level, message, context)
+}
+
+func (instance *jsonLogger) log(minLevel loggingcontract.Level, level loggingcontract.Level, message string, context loggingcontract.Context) {
+    if priorityForLevel(level) < priorityForLevel(minLevel) {
         return
     }
 
@@ -121,7 +125,64 @@ func (instance *jsonLogger) Close() error {
     return closer.Close()
 }
 
+/* WithMinLevel returns a logger writing to the same output with minLevel; closing the json logger closes it too. */
+func (instance *jsonLogger) WithMinLevel(minLevel loggingcontract.Level) loggingcontract.Logger {
+    if false == IsValidLevel(minLevel) {
+        exception.Panic(
+            exception.NewError(
+                "invalid json logger min level",
+                map[string]any{
+                    "level": string(minLevel),
+                },
+                nil,
+            ),
+        )
+    }
+
+    return &jsonLoggerWithMinLevel{
+        base:     instance,
+        minLevel: minLevel,
+    }
+}
+
 var _ loggingcontract.Logger = (*jsonLogger)(nil)
+var _ loggingcontract.MinLevelLogger = (*jsonLogger)(nil)
+
+type jsonLoggerWithMinLevel struct {
+    base     *jsonLogger
+    minLevel loggingcontract.Level
+}
+
+func (instance *jsonLoggerWithMinLevel) Log(level loggingcontract.Level, message string, context loggingcontract.Context) {
+    instance.base.log(instance.minLevel, level, message, context)
+}
+
+func (instance *jsonLoggerWithMinLevel) Debug(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelDebug, message, context)
+}
+
+func (instance *jsonLoggerWithMinLevel) Info(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelInfo, message, context)
+}
+
+func (instance *jsonLoggerWithMinLevel) Warning(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelWarning, message, context)
+}
+
+func (instance *jsonLoggerWithMinLevel) Error(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelError, message, context)
+}
+
+func (instance *jsonLoggerWithMinLevel) Emergency(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelEmergency, message, context)
+}
+
+func (instance *jsonLoggerWithMinLevel) WithMinLevel(minLevel loggingcontract.Level) loggingcontract.Logger {
+    return instance.base.WithMinLevel(minLevel)
+}
+
+var _ loggingcontract.Logger = (*jsonLoggerWithMinLevel)(nil)
+var _ loggingcontract.MinLevelLogger = (*jsonLoggerWithMinLevel)(nil)
 
 func normalizeJsonContext(input map[string]any) map[string]any {
     if nil == input {
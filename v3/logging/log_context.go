@@ -0,0 +1,173 @@
+package logging
+
+import (
+    "sync"
+
+    "github.com/precision-soft/melody/v3/exception"
+    "github.com/precision-soft/melody/v3/internal"
+    loggingcontract "github.com/precision-soft/melody/v3/logging/contract"
+)
+
+func NewLogContext() *LogContext {
+    return &LogContext{
+        fields: map[string]any{},
+    }
+}
+
+/*
+ * LogContext holds the fields every log call of one request includes (route name, user identifier, trace id, ...) and
+ * an optional minimum level override for that request. It is safe for concurrent use.
+ */
+type LogContext struct {
+    mutex         sync.RWMutex
+    fields        map[string]any
+    levelOverride loggingcontract.Level
+}
+
+func (instance *LogContext) Set(key string, value any) {
+    if "" == key {
+        return
+    }
+
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.fields[key] = value
+}
+
+func (instance *LogContext) Get(key string) (any, bool) {
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    value, exists := instance.fields[key]
+
+    return value, exists
+}
+
+func (instance *LogContext) Remove(key string) {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    delete(instance.fields, key)
+}
+
+func (instance *LogContext) Fields() map[string]any {
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    return internal.CopyAnyMap(instance.fields)
+}
+
+/* SetLevelOverride makes the loggers bound to this context use level as minimum level instead of the configured one. */
+func (instance *LogContext) SetLevelOverride(level loggingcontract.Level) {
+    if false == IsValidLevel(level) {
+        exception.Panic(
+            exception.NewError(
+                "invalid log context level override",
+                map[string]any{
+                    "level": string(level),
+                },
+                nil,
+            ),
+        )
+    }
+
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.levelOverride = level
+}
+
+func (instance *LogContext) ClearLevelOverride() {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.levelOverride = ""
+}
+
+func (instance *LogContext) LevelOverride() (loggingcontract.Level, bool) {
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    return instance.levelOverride, "" != instance.levelOverride
+}
+
+/*
+ * NewContextLogger returns a logger adding the fields of logContext to every entry (the fields passed to the call win)
+ * and applying its level override through WithMinLevel.
+ */
+func NewContextLogger(logger loggingcontract.Logger, logContext *LogContext) loggingcontract.Logger {
+    if true == internal.IsNilInterface(logger) {
+        exception.Panic(exception.NewError("logger is not provided for context logger", nil, nil))
+    }
+
+    if nil == logContext {
+        exception.Panic(exception.NewError("log context is not provided for context logger", nil, nil))
+    }
+
+    return &contextLogger{
+        base:       logger,
+        logContext: logContext,
+    }
+}
+
+type contextLogger struct {
+    base       loggingcontract.Logger
+    logContext *LogContext
+}
+
+func (instance *contextLogger) LogContext() *LogContext {
+    return instance.logContext
+}
+
+func (instance *contextLogger) Log(level loggingcontract.Level, message string, context loggingcontract.Context) {
+    target := instance.base
+    if levelOverride, overridden := instance.logContext.LevelOverride(); true == overridden {
+        target = WithMinLevel(instance.base, levelOverride)
+    }
+
+    target.Log(level, message, instance.mergeContext(context))
+}
+
+func (instance *contextLogger) Debug(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelDebug, message, context)
+}
+
+func (instance *contextLogger) Info(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelInfo, message, context)
+}
+
+func (instance *contextLogger) Warning(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelWarning, message, context)
+}
+
+func (instance *contextLogger) Error(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelError, message, context)
+}
+
+func (instance *contextLogger) Emergency(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelEmergency, message, context)
+}
+
+func (instance *contextLogger) WithMinLevel(minLevel loggingcontract.Level) loggingcontract.Logger {
+    return &contextLogger{
+        base:       WithMinLevel(instance.base, minLevel),
+        logContext: instance.logContext,
+    }
+}
+
+func (instance *contextLogger) mergeContext(context loggingcontract.Context) loggingcontract.Context {
+    fields := instance.logContext.Fields()
+    if 0 == len(fields) {
+        return context
+    }
+
+    for key, value := range context {
+        fields[key] = value
+    }
+
+    return fields
+}
+
+var _ loggingcontract.Logger = (*contextLogger)(nil)
+var _ loggingcontract.MinLevelLogger = (*contextLogger)(nil)
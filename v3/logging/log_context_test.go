@@ -0,0 +1,68 @@
+package logging
+
+import (
+    "bytes"
+    "strings"
+    "testing"
+
+    loggingcontract "github.com/precision-soft/melody/v3/logging/contract"
+)
+
+func TestContextLogger_AddsLogContextFields(t *testing.T) {
+    recorder := &testRecordingLogger{}
+    logContext := NewLogContext()
+    logger := NewContextLogger(recorder, logContext)
+
+    logContext.Set("routeName", "user_show")
+    logContext.Set("userIdentifier", "alice")
+
+    logger.Info("loaded", loggingcontract.Context{"userIdentifier": "bob", "count": 2})
+
+    context := recorder.entries[0].context
+    if "user_show" != context["routeName"] || "bob" != context["userIdentifier"] || 2 != context["count"] {
+        t.Fatalf("unexpected context: %v", context)
+    }
+
+    logContext.Remove("routeName")
+    logger.Info("again", nil)
+
+    if _, exists := recorder.entries[1].context["routeName"]; true == exists {
+        t.Fatalf("expected removed field to be gone")
+    }
+}
+
+func TestContextLogger_AppliesLevelOverride(t *testing.T) {
+    buffer := &bytes.Buffer{}
+    logContext := NewLogContext()
+    logger := NewContextLogger(
+        NewRequestLogger(NewJsonLogger(buffer, loggingcontract.LevelInfo), "r1", "requestId"),
+        logContext,
+    )
+
+    logger.Debug("hidden", nil)
+    if 0 != buffer.Len() {
+        t.Fatalf("expected debug entry to be filtered, got %q", buffer.String())
+    }
+
+    logContext.SetLevelOverride(loggingcontract.LevelDebug)
+    logger.Debug("visible", nil)
+
+    if false == strings.Contains(buffer.String(), `"visible"`) || false == strings.Contains(buffer.String(), `"requestId":"r1"`) {
+        t.Fatalf("expected debug entry with request id, got %q", buffer.String())
+    }
+
+    buffer.Reset()
+    logContext.ClearLevelOverride()
+    logger.Debug("hidden again", nil)
+    if 0 != buffer.Len() {
+        t.Fatalf("expected debug entry to be filtered after clearing the override, got %q", buffer.String())
+    }
+}
+
+func TestWithMinLevel_LeavesUnsupportedLoggersUnchanged(t *testing.T) {
+    recorder := &testRecordingLogger{}
+
+    if recorder != WithMinLevel(recorder, loggingcontract.LevelDebug) {
+        t.Fatalf("expected logger without WithMinLevel to be returned unchanged")
+    }
+}
@@ -63,6 +63,16 @@ func IsValidLevel(value loggingcontract.Level) bool {
         loggingcontract.LevelEmergency == value
 }
 
+/* WithMinLevel returns logger with minLevel when it implements loggingcontract.MinLevelLogger, and logger unchanged otherwise. */
+func WithMinLevel(logger loggingcontract.Logger, minLevel loggingcontract.Level) loggingcontract.Logger {
+    minLevelLogger, isMinLevelLogger := logger.(loggingcontract.MinLevelLogger)
+    if false == isMinLevelLogger {
+        return logger
+    }
+
+    return minLevelLogger.WithMinLevel(minLevel)
+}
+
 func priorityForLevel(level loggingcontract.Level) int {
     switch level {
     case loggingcontract.LevelDebug:
@@ -4,6 +4,7 @@
     "fmt"
 
     "github.com/precision-soft/melody/v3/exception"
+    "github.com/precision-soft/melody/v3/internal"
     loggingcontract "github.com/precision-soft/melody/v3/logging/contract"
 )
 
@@ -11,14 +12,53 @@ func NewLoggingConfiguration(labels loggingcontract.LevelLabels) loggingcontract
     return &loggingConfiguration{levelLabels: labels}
 }
 
+/* NewLoggingConfigurationWithFileRotation also makes the kernel log file (`kernel.log_path`) a RotatingFileWriter. */
+func NewLoggingConfigurationWithFileRotation(
+    labels loggingcontract.LevelLabels,
+    fileRotation loggingcontract.FileRotation,
+) loggingcontract.LoggingConfiguration {
+    if true == internal.IsNilInterface(fileRotation) {
+        exception.Panic(exception.NewError("file rotation is not provided for logging configuration", nil, nil))
+    }
+
+    if nil == labels {
+        labels = loggingcontract.DefaultLevelLabels()
+    }
+
+    return &loggingConfiguration{
+        levelLabels:  labels,
+        fileRotation: fileRotation,
+    }
+}
+
 type loggingConfiguration struct {
-    levelLabels loggingcontract.LevelLabels
+    levelLabels  loggingcontract.LevelLabels
+    fileRotation loggingcontract.FileRotation
 }
 
 func (instance *loggingConfiguration) LevelLabels() loggingcontract.LevelLabels {
     return instance.levelLabels
 }
 
+func (instance *loggingConfiguration) FileRotation() loggingcontract.FileRotation {
+    return instance.fileRotation
+}
+
+/* FileRotationFromConfiguration returns the file rotation of a logging configuration, or nil when it has none. */
+func FileRotationFromConfiguration(configuration loggingcontract.LoggingConfiguration) loggingcontract.FileRotation {
+    rotationConfiguration, isRotationConfiguration := configuration.(loggingcontract.FileRotationConfiguration)
+    if false == isRotationConfiguration {
+        return nil
+    }
+
+    fileRotation := rotationConfiguration.FileRotation()
+    if true == internal.IsNilInterface(fileRotation) {
+        return nil
+    }
+
+    return fileRotation
+}
+
 func LoggingConfigurationFromModules(moduleConfigurations map[string]any) loggingcontract.LoggingConfiguration {
     if nil == moduleConfigurations {
         return &loggingConfiguration{levelLabels: loggingcontract.DefaultLevelLabels()}
@@ -66,3 +106,4 @@ func LoggingConfigurationFromModules(moduleConfigurations map[string]any) loggin
 }
 
 var _ loggingcontract.LoggingConfiguration = (*loggingConfiguration)(nil)
+var _ loggingcontract.FileRotationConfiguration = (*loggingConfiguration)(nil)
@@ -0,0 +1,93 @@
+package logging
+
+import (
+    "github.com/precision-soft/melody/v3/exception"
+    "github.com/precision-soft/melody/v3/internal"
+    loggingcontract "github.com/precision-soft/melody/v3/logging/contract"
+)
+
+const RedactedValue = "[redacted]"
+
+/* DefaultRedactedKeys are matched case-insensitively as substrings of the context keys (`userPassword`, `X-Api-Key`, ...). */
+func DefaultRedactedKeys() []string {
+    return []string{
+        "password",
+        "passwd",
+        "secret",
+        "token",
+        "authorization",
+        "cookie",
+        "apikey",
+        "api_key",
+        "api-key",
+        "credential",
+        "privatekey",
+        "private_key",
+    }
+}
+
+/*
+ * NewRedactingLogger replaces the value of every context key containing one of keys (DefaultRedactedKeys when none are
+ * given), at any depth of nested maps and slices, with RedactedValue before the entry reaches logger.
+ */
+func NewRedactingLogger(logger loggingcontract.Logger, keys ...string) loggingcontract.Logger {
+    if true == internal.IsNilInterface(logger) {
+        exception.Panic(exception.NewError("logger is not provided for redacting logger", nil, nil))
+    }
+
+    if 0 == len(keys) {
+        keys = DefaultRedactedKeys()
+    }
+
+    return &redactingLogger{
+        logger:  logger,
+        matcher: internal.NewContextKeyMatcher(nil, keys),
+    }
+}
+
+type redactingLogger struct {
+    logger  loggingcontract.Logger
+    matcher *internal.ContextKeyMatcher
+}
+
+func (instance *redactingLogger) Log(level loggingcontract.Level, message string, context loggingcontract.Context) {
+    if 0 < len(context) {
+        context = internal.SanitizeContextMap(context, instance.matcher, RedactedValue)
+    }
+
+    instance.logger.Log(level, message, context)
+}
+
+func (instance *redactingLogger) Debug(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelDebug, message, context)
+}
+
+func (instance *redactingLogger) Info(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelInfo, message, context)
+}
+
+func (instance *redactingLogger) Warning(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelWarning, message, context)
+}
+
+func (instance *redactingLogger) Error(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelError, message, context)
+}
+
+func (instance *redactingLogger) Emergency(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelEmergency, message, context)
+}
+
+func (instance *redactingLogger) WithMinLevel(minLevel loggingcontract.Level) loggingcontract.Logger {
+    return &redactingLogger{
+        logger:  WithMinLevel(instance.logger, minLevel),
+        matcher: instance.matcher,
+    }
+}
+
+func (instance *redactingLogger) Close() error {
+    return closeLogger(instance.logger)
+}
+
+var _ loggingcontract.Logger = (*redactingLogger)(nil)
+var _ loggingcontract.MinLevelLogger = (*redactingLogger)(nil)
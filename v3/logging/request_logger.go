@@ -60,6 +60,14 @@ func (instance *requestLogger) Emergency(message string, context loggingcontract
     instance.base.Emergency(message, instance.mergeContextWithRequestId(context, instance.requestId))
 }
 
+func (instance *requestLogger) WithMinLevel(minLevel loggingcontract.Level) loggingcontract.Logger {
+    return &requestLogger{
+        base:       WithMinLevel(instance.base, minLevel),
+        requestId:  instance.requestId,
+        contextKey: instance.contextKey,
+    }
+}
+
 func (instance *requestLogger) mergeContextWithRequestId(context loggingcontract.Context, requestId string) map[string]any {
     if "" == requestId {
         return context
@@ -86,3 +94,4 @@ func (instance *requestLogger) mergeContextWithRequestId(context loggingcontract
 }
 
 var _ loggingcontract.Logger = (*requestLogger)(nil)
+var _ loggingcontract.MinLevelLogger = (*requestLogger)(nil)
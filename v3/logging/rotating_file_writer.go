@@ -0,0 +1,509 @@
+package logging
+
+import (
+    "compress/gzip"
+    "io"
+    "os"
+    "os/signal"
+    "path/filepath"
+    "sort"
+    "strings"
+    "sync"
+    "sync/atomic"
+    "syscall"
+    "time"
+
+    "github.com/precision-soft/melody/v3/clock"
+    clockcontract "github.com/precision-soft/melody/v3/clock/contract"
+    "github.com/precision-soft/melody/v3/exception"
+    "github.com/precision-soft/melody/v3/internal"
+    loggingcontract "github.com/precision-soft/melody/v3/logging/contract"
+)
+
+const (
+    rotatedFileTimeLayout = "2006-01-02T15-04-05.000"
+    compressedFileSuffix  = ".gz"
+)
+
+/*
+ * NewRotatingFileWriter opens path for appending and rotates it as described by fileRotation: the current file is renamed
+ * to `<name>-<time><ext>` (UTC) and a new one is opened at path. Compression and pruning of the backups run in the
+ * background. Close flushes the buffer, waits for the background work and closes the file.
+ */
+func NewRotatingFileWriter(path string, fileRotation loggingcontract.FileRotation) (*RotatingFileWriter, error) {
+    if "" == path {
+        return nil, exception.NewError("rotating file writer path is empty", nil, nil)
+    }
+
+    if true == internal.IsNilInterface(fileRotation) {
+        return nil, exception.NewError("file rotation is not provided for rotating file writer", nil, nil)
+    }
+
+    instance := &RotatingFileWriter{
+        path:                path,
+        fileRotation:        fileRotation,
+        clock:               clock.NewSystemClock(),
+        maintenanceRequests: make(chan struct{}, 1),
+        maintenanceDone:     make(chan struct{}),
+    }
+
+    openErr := instance.open()
+    if nil != openErr {
+        return nil, openErr
+    }
+
+    go instance.runMaintenance()
+
+    if 0 < fileRotation.BufferSize() {
+        instance.queue = make(chan []byte, fileRotation.BufferSize())
+        instance.queueDone = make(chan struct{})
+
+        go instance.runQueue()
+    }
+
+    if true == fileRotation.ReopenOnSighup() {
+        instance.signals = make(chan os.Signal, 1)
+        instance.signalStop = make(chan struct{})
+        instance.signalDone = make(chan struct{})
+        signal.Notify(instance.signals, syscall.SIGHUP)
+
+        go instance.runSignals()
+    }
+
+    return instance, nil
+}
+
+type RotatingFileWriter struct {
+    path         string
+    fileRotation loggingcontract.FileRotation
+    clock        clockcontract.Clock
+
+    fileMutex      sync.Mutex
+    file           *os.File
+    size           int64
+    nextRotationAt time.Time
+
+    stateMutex sync.RWMutex
+    closed     bool
+    queue      chan []byte
+    queueDone  chan struct{}
+    dropped    atomic.Uint64
+
+    maintenanceRequests chan struct{}
+    maintenanceDone     chan struct{}
+
+    signals    chan os.Signal
+    signalStop chan struct{}
+    signalDone chan struct{}
+}
+
+func (instance *RotatingFileWriter) Path() string {
+    return instance.path
+}
+
+/* Dropped returns how many writes were discarded because the buffer was full. */
+func (instance *RotatingFileWriter) Dropped() uint64 {
+    return instance.dropped.Load()
+}
+
+func (instance *RotatingFileWriter) Write(payload []byte) (int, error) {
+    instance.stateMutex.RLock()
+    defer instance.stateMutex.RUnlock()
+
+    if true == instance.closed {
+        return 0, exception.NewError("rotating file writer is closed", map[string]any{"path": instance.path}, nil)
+    }
+
+    if nil == instance.queue {
+        return instance.write(payload)
+    }
+
+    /* @info the caller may reuse payload once Write returns */
+    line := append([]byte(nil), payload...)
+
+    select {
+    case instance.queue <- line:
+    default:
+        instance.dropped.Add(1)
+    }
+
+    return len(payload), nil
+}
+
+/* Rotate rotates the file now, regardless of its size or age. */
+func (instance *RotatingFileWriter) Rotate() error {
+    instance.stateMutex.RLock()
+    defer instance.stateMutex.RUnlock()
+
+    if true == instance.closed {
+        return exception.NewError("rotating file writer is closed", map[string]any{"path": instance.path}, nil)
+    }
+
+    instance.fileMutex.Lock()
+    defer instance.fileMutex.Unlock()
+
+    return instance.rotate()
+}
+
+/* Reopen opens path again, after an external tool moved it away; the previous file stays in use when path cannot be opened. */
+func (instance *RotatingFileWriter) Reopen() error {
+    instance.stateMutex.RLock()
+    defer instance.stateMutex.RUnlock()
+
+    if true == instance.closed {
+        return exception.NewError("rotating file writer is closed", map[string]any{"path": instance.path}, nil)
+    }
+
+    instance.fileMutex.Lock()
+    defer instance.fileMutex.Unlock()
+
+    return instance.open()
+}
+
+func (instance *RotatingFileWriter) Close() error {
+    instance.stateMutex.Lock()
+    if true == instance.closed {
+        instance.stateMutex.Unlock()
+
+        return nil
+    }
+
+    instance.closed = true
+    if nil != instance.queue {
+        close(instance.queue)
+    }
+    instance.stateMutex.Unlock()
+
+    if nil != instance.queueDone {
+        <-instance.queueDone
+    }
+
+    if nil != instance.signals {
+        signal.Stop(instance.signals)
+        close(instance.signalStop)
+        <-instance.signalDone
+    }
+
+    close(instance.maintenanceRequests)
+    <-instance.maintenanceDone
+
+    instance.fileMutex.Lock()
+    defer instance.fileMutex.Unlock()
+
+    return instance.file.Close()
+}
+
+func (instance *RotatingFileWriter) write(payload []byte) (int, error) {
+    instance.fileMutex.Lock()
+    defer instance.fileMutex.Unlock()
+
+    if true == instance.shouldRotate(int64(len(payload))) {
+        rotateErr := instance.rotate()
+        if nil != rotateErr {
+            EmergencyLogger().Error(
+                "failed to rotate log file",
+                loggingcontract.Context{
+                    "path":  instance.path,
+                    "error": rotateErr,
+                },
+            )
+        }
+    }
+
+    written, writeErr := instance.file.Write(payload)
+    instance.size += int64(written)
+
+    return written, writeErr
+}
+
+func (instance *RotatingFileWriter) shouldRotate(payloadSize int64) bool {
+    maxSizeBytes := instance.fileRotation.MaxSizeBytes()
+    if 0 < maxSizeBytes && 0 < instance.size && maxSizeBytes < instance.size+payloadSize {
+        return true
+    }
+
+    return false == instance.nextRotationAt.IsZero() && false == instance.clock.Now().Before(instance.nextRotationAt)
+}
+
+/* @important the caller holds fileMutex */
+func (instance *RotatingFileWriter) rotate() error {
+    rotatedPath := instance.rotatedPath(instance.clock.Now())
+    renameErr := os.Rename(instance.path, rotatedPath)
+
+    openErr := instance.open()
+    if nil != openErr {
+        return openErr
+    }
+
+    if nil != renameErr && false == os.IsNotExist(renameErr) {
+        return exception.NewError(
+            "failed to rename log file",
+            map[string]any{
+                "path":        instance.path,
+                "rotatedPath": rotatedPath,
+            },
+            renameErr,
+        )
+    }
+
+    select {
+    case instance.maintenanceRequests <- struct{}{}:
+    default:
+    }
+
+    return nil
+}
+
+/*
+ * @important the caller holds fileMutex, or is the constructor. The new file replaces the current one only once it is
+ * open, so a failure (permissions, full disk, removed directory) keeps writes going to the previous file.
+ */
+func (instance *RotatingFileWriter) open() error {
+    file, openErr := os.OpenFile(instance.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+    if nil != openErr {
+        return exception.NewError("failed to open log file", map[string]any{"path": instance.path}, openErr)
+    }
+
+    fileInfo, statErr := file.Stat()
+    if nil != statErr {
+        _ = file.Close()
+
+        return exception.NewError("failed to stat log file", map[string]any{"path": instance.path}, statErr)
+    }
+
+    previousFile := instance.file
+
+    instance.file = file
+    instance.size = fileInfo.Size()
+    instance.nextRotationAt = time.Time{}
+
+    /* @info an existing file written before the current interval boundary rotates on the first write */
+    interval := instance.fileRotation.Interval()
+    if 0 < interval {
+        startedAt := instance.clock.Now()
+        if 0 < instance.size {
+            startedAt = fileInfo.ModTime()
+        }
+
+        instance.nextRotationAt = startedAt.UTC().Truncate(interval).Add(interval)
+    }
+
+    if nil != previousFile {
+        _ = previousFile.Close()
+    }
+
+    return nil
+}
+
+func (instance *RotatingFileWriter) rotatedPath(rotatedAt time.Time) string {
+    prefix, extension := instance.rotatedNameParts()
+
+    /* @info two rotations within the same millisecond must not overwrite each other's backup */
+    for {
+        rotatedPath := filepath.Join(filepath.Dir(instance.path), prefix+rotatedAt.UTC().Format(rotatedFileTimeLayout)+extension)
+
+        _, rotatedStatErr := os.Stat(rotatedPath)
+        _, compressedStatErr := os.Stat(rotatedPath + compressedFileSuffix)
+        if true == os.IsNotExist(rotatedStatErr) && true == os.IsNotExist(compressedStatErr) {
+            return rotatedPath
+        }
+
+        rotatedAt = rotatedAt.Add(time.Millisecond)
+    }
+}
+
+func (instance *RotatingFileWriter) rotatedNameParts() (string, string) {
+    baseName := filepath.Base(instance.path)
+    extension := filepath.Ext(baseName)
+
+    return strings.TrimSuffix(baseName, extension) + "-", extension
+}
+
+func (instance *RotatingFileWriter) runQueue() {
+    defer close(instance.queueDone)
+
+    for line := range instance.queue {
+        _, _ = instance.write(line)
+    }
+}
+
+func (instance *RotatingFileWriter) runSignals() {
+    defer close(instance.signalDone)
+
+    for {
+        select {
+        case <-instance.signalStop:
+            return
+        case <-instance.signals:
+            instance.fileMutex.Lock()
+            _ = instance.file.Close()
+            openErr := instance.open()
+            instance.fileMutex.Unlock()
+
+            if nil != openErr {
+                EmergencyLogger().Error(
+                    "failed to reopen log file",
+                    loggingcontract.Context{
+                        "path":  instance.path,
+                        "error": openErr,
+                    },
+                )
+            }
+        }
+    }
+}
+
+func (instance *RotatingFileWriter) runMaintenance() {
+    defer close(instance.maintenanceDone)
+
+    for range instance.maintenanceRequests {
+        instance.maintain()
+    }
+}
+
+type rotatedFile struct {
+    path       string
+    rotatedAt  time.Time
+    compressed bool
+}
+
+func (instance *RotatingFileWriter) maintain() {
+    rotatedFiles, listErr := instance.rotatedFiles()
+    if nil != listErr {
+        EmergencyLogger().Error(
+            "failed to list rotated log files",
+            loggingcontract.Context{
+                "path":  instance.path,
+                "error": listErr,
+            },
+        )
+
+        return
+    }
+
+    maxBackups := instance.fileRotation.MaxBackups()
+    maxAge := instance.fileRotation.MaxAge()
+    now := instance.clock.Now()
+
+    for index, rotated := range rotatedFiles {
+        expired := (0 < maxBackups && maxBackups <= index) || (0 < maxAge && maxAge < now.Sub(rotated.rotatedAt))
+        if true == expired {
+            removeErr := os.Remove(rotated.path)
+            if nil != removeErr && false == os.IsNotExist(removeErr) {
+                EmergencyLogger().Error(
+                    "failed to remove rotated log file",
+                    loggingcontract.Context{
+                        "path":  rotated.path,
+                        "error": removeErr,
+                    },
+                )
+            }
+
+            continue
+        }
+
+        if true == instance.fileRotation.Compress() && false == rotated.compressed {
+            compressErr := compressFile(rotated.path)
+            if nil != compressErr {
+                EmergencyLogger().Error(
+                    "failed to compress rotated log file",
+                    loggingcontract.Context{
+                        "path":  rotated.path,
+                        "error": compressErr,
+                    },
+                )
+            }
+        }
+    }
+}
+
+/* rotatedFiles returns the backups of the log file, newest first. */
+func (instance *RotatingFileWriter) rotatedFiles() ([]rotatedFile, error) {
+    directory := filepath.Dir(instance.path)
+
+    entries, readErr := os.ReadDir(directory)
+    if nil != readErr {
+        return nil, readErr
+    }
+
+    prefix, extension := instance.rotatedNameParts()
+
+    rotatedFiles := make([]rotatedFile, 0)
+    for _, entry := range entries {
+        if true == entry.IsDir() || false == strings.HasPrefix(entry.Name(), prefix) {
+            continue
+        }
+
+        name := strings.TrimPrefix(entry.Name(), prefix)
+
+        compressed := strings.HasSuffix(name, extension+compressedFileSuffix)
+        if true == compressed {
+            name = strings.TrimSuffix(name, extension+compressedFileSuffix)
+        } else if true == strings.HasSuffix(name, extension) {
+            name = strings.TrimSuffix(name, extension)
+        } else {
+            continue
+        }
+
+        rotatedAt, parseErr := time.ParseInLocation(rotatedFileTimeLayout, name, time.UTC)
+        if nil != parseErr {
+            continue
+        }
+
+        rotatedFiles = append(
+            rotatedFiles,
+            rotatedFile{
+                path:       filepath.Join(directory, entry.Name()),
+                rotatedAt:  rotatedAt,
+                compressed: compressed,
+            },
+        )
+    }
+
+    sort.SliceStable(
+        rotatedFiles,
+        func(left int, right int) bool {
+            return rotatedFiles[left].rotatedAt.After(rotatedFiles[right].rotatedAt)
+        },
+    )
+
+    return rotatedFiles, nil
+}
+
+func compressFile(path string) error {
+    source, openErr := os.Open(path)
+    if nil != openErr {
+        return openErr
+    }
+    defer source.Close()
+
+    temporaryPath := path + compressedFileSuffix + ".tmp"
+    target, createErr := os.OpenFile(temporaryPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+    if nil != createErr {
+        return createErr
+    }
+
+    gzipWriter := gzip.NewWriter(target)
+    _, copyErr := io.Copy(gzipWriter, source)
+    closeGzipErr := gzipWriter.Close()
+    closeTargetErr := target.Close()
+
+    for _, err := range []error{copyErr, closeGzipErr, closeTargetErr} {
+        if nil != err {
+            _ = os.Remove(temporaryPath)
+
+            return err
+        }
+    }
+
+    renameErr := os.Rename(temporaryPath, path+compressedFileSuffix)
+    if nil != renameErr {
+        _ = os.Remove(temporaryPath)
+
+        return renameErr
+    }
+
+    return os.Remove(path)
+}
+
+var _ io.WriteCloser = (*RotatingFileWriter)(nil)
@@ -0,0 +1,238 @@
+package logging
+
+import (
+    "compress/gzip"
+    "io"
+    "os"
+    "path/filepath"
+    "sort"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/precision-soft/melody/v3/clock"
+    loggingcontract "github.com/precision-soft/melody/v3/logging/contract"
+)
+
+func testNewRotatingFileWriter(t *testing.T, fileRotation *FileRotation) (*RotatingFileWriter, string) {
+    t.Helper()
+
+    directory := t.TempDir()
+    writer, err := NewRotatingFileWriter(filepath.Join(directory, "app.log"), fileRotation)
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    return writer, directory
+}
+
+func testRotatedFileNames(t *testing.T, directory string) []string {
+    t.Helper()
+
+    entries, err := os.ReadDir(directory)
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    names := make([]string, 0)
+    for _, entry := range entries {
+        if "app.log" != entry.Name() {
+            names = append(names, entry.Name())
+        }
+    }
+    sort.Strings(names)
+
+    return names
+}
+
+func TestRotatingFileWriter_RotatesBySize(t *testing.T) {
+    writer, directory := testNewRotatingFileWriter(t, NewFileRotation().SetMaxSizeBytes(10))
+
+    for _, line := range []string{"first\n", "second\n", "third\n"} {
+        _, err := writer.Write([]byte(line))
+        if nil != err {
+            t.Fatalf("unexpected error: %v", err)
+        }
+    }
+
+    err := writer.Close()
+    if nil != err {
+        t.Fatalf("unexpected close error: %v", err)
+    }
+
+    current, _ := os.ReadFile(filepath.Join(directory, "app.log"))
+    if "third\n" != string(current) {
+        t.Fatalf("unexpected current file: %q", current)
+    }
+
+    rotated := testRotatedFileNames(t, directory)
+    if 2 != len(rotated) {
+        t.Fatalf("expected 2 backups, got %v", rotated)
+    }
+
+    for _, name := range rotated {
+        if false == strings.HasPrefix(name, "app-") || false == strings.HasSuffix(name, ".log") {
+            t.Fatalf("unexpected backup name: %s", name)
+        }
+    }
+}
+
+func TestRotatingFileWriter_RotatesByInterval(t *testing.T) {
+    writer, directory := testNewRotatingFileWriter(t, NewFileRotation().SetInterval(time.Hour))
+
+    frozenClock := clock.NewFrozenClock(time.Now())
+    writer.clock = frozenClock
+
+    _, _ = writer.Write([]byte("before\n"))
+    frozenClock.Advance(2 * time.Hour)
+    _, _ = writer.Write([]byte("after\n"))
+
+    _ = writer.Close()
+
+    rotated := testRotatedFileNames(t, directory)
+    if 1 != len(rotated) {
+        t.Fatalf("expected 1 backup, got %v", rotated)
+    }
+
+    content, _ := os.ReadFile(filepath.Join(directory, rotated[0]))
+    if "before\n" != string(content) {
+        t.Fatalf("unexpected backup content: %q", content)
+    }
+}
+
+func TestRotatingFileWriter_CompressesAndPrunesBackups(t *testing.T) {
+    writer, directory := testNewRotatingFileWriter(t, NewFileRotation().SetMaxBackups(2).SetCompress(true))
+
+    frozenClock := clock.NewFrozenClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+    writer.clock = frozenClock
+
+    for _, line := range []string{"one\n", "two\n", "three\n"} {
+        _, _ = writer.Write([]byte(line))
+        frozenClock.Advance(time.Minute)
+
+        err := writer.Rotate()
+        if nil != err {
+            t.Fatalf("unexpected rotate error: %v", err)
+        }
+    }
+
+    _ = writer.Close()
+
+    rotated := testRotatedFileNames(t, directory)
+    if 2 != len(rotated) {
+        t.Fatalf("expected 2 backups, got %v", rotated)
+    }
+
+    newest := rotated[1]
+    if false == strings.HasSuffix(newest, ".log.gz") {
+        t.Fatalf("expected compressed backup, got %s", newest)
+    }
+
+    file, _ := os.Open(filepath.Join(directory, newest))
+    defer file.Close()
+
+    reader, err := gzip.NewReader(file)
+    if nil != err {
+        t.Fatalf("invalid gzip: %v", err)
+    }
+
+    content, _ := io.ReadAll(reader)
+    if "three\n" != string(content) {
+        t.Fatalf("unexpected backup content: %q", content)
+    }
+}
+
+func TestRotatingFileWriter_DropsWhenBufferIsFull(t *testing.T) {
+    writer, directory := testNewRotatingFileWriter(t, NewFileRotation().SetBufferSize(1))
+
+    writer.fileMutex.Lock()
+    for index := 0; index < 10; index++ {
+        written, err := writer.Write([]byte("line\n"))
+        if nil != err || 5 != written {
+            t.Fatalf("expected non-blocking write, got %d %v", written, err)
+        }
+    }
+    writer.fileMutex.Unlock()
+
+    _ = writer.Close()
+
+    if 8 > writer.Dropped() {
+        t.Fatalf("expected at least 8 dropped writes, got %d", writer.Dropped())
+    }
+
+    content, _ := os.ReadFile(filepath.Join(directory, "app.log"))
+    if uint64(strings.Count(string(content), "\n"))+writer.Dropped() != 10 {
+        t.Fatalf("expected written and dropped lines to add up, got %q and %d", content, writer.Dropped())
+    }
+
+    _, err := writer.Write([]byte("closed\n"))
+    if nil == err {
+        t.Fatalf("expected write after close to fail")
+    }
+}
+
+func TestRotatingFileWriter_Reopen(t *testing.T) {
+    writer, directory := testNewRotatingFileWriter(t, NewFileRotation())
+
+    _, _ = writer.Write([]byte("old\n"))
+
+    err := os.Rename(filepath.Join(directory, "app.log"), filepath.Join(directory, "moved.log"))
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    err = writer.Reopen()
+    if nil != err {
+        t.Fatalf("unexpected reopen error: %v", err)
+    }
+
+    _, _ = writer.Write([]byte("new\n"))
+    _ = writer.Close()
+
+    content, _ := os.ReadFile(filepath.Join(directory, "app.log"))
+    if "new\n" != string(content) {
+        t.Fatalf("unexpected content after reopen: %q", content)
+    }
+}
+
+func TestRotatingFileWriter_KeepsWritingWhenTheNewFileCannotBeOpened(t *testing.T) {
+    writer, directory := testNewRotatingFileWriter(t, NewFileRotation())
+    defer func() {
+        _ = writer.Close()
+    }()
+
+    removeErr := os.RemoveAll(directory)
+    if nil != removeErr {
+        t.Fatalf("unexpected error: %v", removeErr)
+    }
+
+    if nil == writer.Reopen() {
+        t.Fatalf("expected reopen to fail without the directory")
+    }
+
+    if nil == writer.Rotate() {
+        t.Fatalf("expected rotate to fail without the directory")
+    }
+
+    _, writeErr := writer.Write([]byte("still written\n"))
+    if nil != writeErr {
+        t.Fatalf("expected writes to keep going to the previous file, got %v", writeErr)
+    }
+}
+
+func TestFileRotationFromConfiguration(t *testing.T) {
+    if nil != FileRotationFromConfiguration(NewLoggingConfiguration(loggingcontract.DefaultLevelLabels())) {
+        t.Fatalf("expected no file rotation")
+    }
+
+    fileRotation := NewFileRotation().SetMaxSizeBytes(1024)
+    configuration := NewLoggingConfigurationWithFileRotation(nil, fileRotation)
+
+    if fileRotation != FileRotationFromConfiguration(configuration) {
+        t.Fatalf("expected configured file rotation")
+    }
+
+    if nil == configuration.LevelLabels() {
+        t.Fatalf("expected default level labels")
+    }
+}
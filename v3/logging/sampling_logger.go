@@ -0,0 +1,239 @@
+package logging
+
+import (
+    "sync"
+    "time"
+
+    "github.com/precision-soft/melody/v3/clock"
+    clockcontract "github.com/precision-soft/melody/v3/clock/contract"
+    "github.com/precision-soft/melody/v3/exception"
+    "github.com/precision-soft/melody/v3/internal"
+    loggingcontract "github.com/precision-soft/melody/v3/logging/contract"
+)
+
+/* @info hard cap on tracked messages; at the cap expired windows are swept at most once per window, and new messages that still do not fit are logged unsampled instead of being tracked */
+const samplingMaxTrackedMessages = 1024
+
+/*
+ * NewSamplingConfig logs the first `first` occurrences of a message (same level and message text) per window, then every
+ * `thereafter`-th one; a `thereafter` of 0 drops the rest of the window, which rate-limits the message.
+ */
+func NewSamplingConfig(window time.Duration, first int, thereafter int) *SamplingConfig {
+    if 0 >= window {
+        exception.Panic(exception.NewError("sampling window must be positive", nil, nil))
+    }
+
+    if 0 > first || 0 > thereafter {
+        exception.Panic(
+            exception.NewError(
+                "sampling counts may not be negative",
+                map[string]any{
+                    "first":      first,
+                    "thereafter": thereafter,
+                },
+                nil,
+            ),
+        )
+    }
+
+    return &SamplingConfig{
+        window:          window,
+        first:           first,
+        thereafter:      thereafter,
+        maxSampledLevel: loggingcontract.LevelWarning,
+        clock:           clock.NewSystemClock(),
+    }
+}
+
+type SamplingConfig struct {
+    window          time.Duration
+    first           int
+    thereafter      int
+    maxSampledLevel loggingcontract.Level
+    clock           clockcontract.Clock
+}
+
+func (instance *SamplingConfig) Window() time.Duration {
+    return instance.window
+}
+
+func (instance *SamplingConfig) First() int {
+    return instance.first
+}
+
+func (instance *SamplingConfig) Thereafter() int {
+    return instance.thereafter
+}
+
+/* SetMaxSampledLevel sets the highest sampled level (warning by default); entries above it are always logged. */
+func (instance *SamplingConfig) SetMaxSampledLevel(level loggingcontract.Level) *SamplingConfig {
+    if false == IsValidLevel(level) {
+        exception.Panic(
+            exception.NewError(
+                "invalid sampling max level",
+                map[string]any{
+                    "level": string(level),
+                },
+                nil,
+            ),
+        )
+    }
+
+    instance.maxSampledLevel = level
+
+    return instance
+}
+
+func (instance *SamplingConfig) MaxSampledLevel() loggingcontract.Level {
+    return instance.maxSampledLevel
+}
+
+func (instance *SamplingConfig) SetClock(clockInstance clockcontract.Clock) *SamplingConfig {
+    if true == internal.IsNilInterface(clockInstance) {
+        exception.Panic(exception.NewError("sampling clock may not be nil", nil, nil))
+    }
+
+    instance.clock = clockInstance
+
+    return instance
+}
+
+func (instance *SamplingConfig) Clock() clockcontract.Clock {
+    return instance.clock
+}
+
+func NewSamplingLogger(logger loggingcontract.Logger, config *SamplingConfig) loggingcontract.Logger {
+    if true == internal.IsNilInterface(logger) {
+        exception.Panic(exception.NewError("logger is not provided for sampling logger", nil, nil))
+    }
+
+    if nil == config {
+        exception.Panic(exception.NewError("sampling config is required", nil, nil))
+    }
+
+    return &samplingLogger{
+        logger: logger,
+        config: config,
+        state: &samplingState{
+            counters: make(map[samplingKey]*samplingCounter),
+        },
+        minLevel: "",
+    }
+}
+
+type samplingKey struct {
+    minLevel loggingcontract.Level
+    level    loggingcontract.Level
+    message  string
+}
+
+type samplingCounter struct {
+    windowStart time.Time
+    count       int
+}
+
+/* @info shared by the loggers derived with WithMinLevel; counters are kept per minimum level, so entries the base logger discards never use up the allowance of a logger raised to a lower level */
+type samplingState struct {
+    mutex     sync.Mutex
+    counters  map[samplingKey]*samplingCounter
+    lastSweep time.Time
+}
+
+type samplingLogger struct {
+    logger   loggingcontract.Logger
+    config   *SamplingConfig
+    state    *samplingState
+    minLevel loggingcontract.Level
+}
+
+func (instance *samplingLogger) Log(level loggingcontract.Level, message string, context loggingcontract.Context) {
+    /* @info a derived logger knows its minimum level, so entries the wrapped logger would discard are not counted */
+    if "" != instance.minLevel && priorityForLevel(level) < priorityForLevel(instance.minLevel) {
+        return
+    }
+
+    if priorityForLevel(level) > priorityForLevel(instance.config.maxSampledLevel) || true == instance.sample(level, message) {
+        instance.logger.Log(level, message, context)
+    }
+}
+
+func (instance *samplingLogger) Debug(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelDebug, message, context)
+}
+
+func (instance *samplingLogger) Info(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelInfo, message, context)
+}
+
+func (instance *samplingLogger) Warning(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelWarning, message, context)
+}
+
+func (instance *samplingLogger) Error(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelError, message, context)
+}
+
+func (instance *samplingLogger) Emergency(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelEmergency, message, context)
+}
+
+func (instance *samplingLogger) WithMinLevel(minLevel loggingcontract.Level) loggingcontract.Logger {
+    return &samplingLogger{
+        logger:   WithMinLevel(instance.logger, minLevel),
+        config:   instance.config,
+        state:    instance.state,
+        minLevel: minLevel,
+    }
+}
+
+func (instance *samplingLogger) Close() error {
+    return closeLogger(instance.logger)
+}
+
+func (instance *samplingLogger) sample(level loggingcontract.Level, message string) bool {
+    now := instance.config.clock.Now()
+    key := samplingKey{minLevel: instance.minLevel, level: level, message: message}
+
+    instance.state.mutex.Lock()
+    defer instance.state.mutex.Unlock()
+
+    counter, exists := instance.state.counters[key]
+    if false == exists && samplingMaxTrackedMessages <= len(instance.state.counters) {
+        if instance.config.window <= now.Sub(instance.state.lastSweep) {
+            instance.sweep(now)
+            instance.state.lastSweep = now
+        }
+
+        if samplingMaxTrackedMessages <= len(instance.state.counters) {
+            return true
+        }
+    }
+
+    if false == exists || instance.config.window <= now.Sub(counter.windowStart) {
+        counter = &samplingCounter{windowStart: now}
+        instance.state.counters[key] = counter
+    }
+
+    counter.count++
+
+    if counter.count <= instance.config.first {
+        return true
+    }
+
+    if 0 == instance.config.thereafter {
+        return false
+    }
+
+    return 0 == (counter.count-instance.config.first)%instance.config.thereafter
+}
+
+func (instance *samplingLogger) sweep(now time.Time) {
+    for key, counter := range instance.state.counters {
+        if instance.config.window <= now.Sub(counter.windowStart) {
+            delete(instance.state.counters, key)
+        }
+    }
+}
+
+var _ loggingcontract.Logger = (*samplingLogger)(nil)
+var _ loggingcontract.MinLevelLogger = (*samplingLogger)(nil)
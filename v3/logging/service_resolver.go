@@ -5,13 +5,15 @@
     containercontract "github.com/precision-soft/melody/v3/container/contract"
     "github.com/precision-soft/melody/v3/exception"
     exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+    "github.com/precision-soft/melody/v3/internal"
     loggingcontract "github.com/precision-soft/melody/v3/logging/contract"
     "github.com/precision-soft/melody/v3/runtime"
     runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
 )
 
 const (
-    ServiceLogger = "service.logger"
+    ServiceLogger     = "service.logger"
+    ServiceLogContext = "service.logging.context"
 )
 
 func LoggerMustFromRuntime(runtimeInstance runtimecontract.Runtime) loggingcontract.Logger {
@@ -54,3 +56,21 @@ func LoggerMustFromResolver(resolver containercontract.Resolver) loggingcontract
 func LoggerFromResolver(resolver containercontract.Resolver) (loggingcontract.Logger, error) {
     return container.FromResolver[loggingcontract.Logger](resolver, ServiceLogger)
 }
+
+/* LogContextFromRuntime returns the log context of the current request scope, or nil outside of one. */
+func LogContextFromRuntime(runtimeInstance runtimecontract.Runtime) *LogContext {
+    if true == internal.IsNilInterface(runtimeInstance) || true == internal.IsNilInterface(runtimeInstance.Scope()) {
+        return nil
+    }
+
+    if false == runtimeInstance.Scope().Has(ServiceLogContext) {
+        return nil
+    }
+
+    logContext, err := runtime.FromRuntime[*LogContext](runtimeInstance, ServiceLogContext)
+    if nil != err {
+        return nil
+    }
+
+    return logContext
+}
@@ -0,0 +1,226 @@
+package logging
+
+import (
+    "context"
+    "log/slog"
+
+    "github.com/precision-soft/melody/v3/exception"
+    "github.com/precision-soft/melody/v3/internal"
+    loggingcontract "github.com/precision-soft/melody/v3/logging/contract"
+)
+
+/* SlogLevelEmergency is the slog level a Melody emergency maps to, since slog has no level above error. */
+const SlogLevelEmergency = slog.Level(12)
+
+func SlogLevel(level loggingcontract.Level) slog.Level {
+    switch level {
+    case loggingcontract.LevelDebug:
+        return slog.LevelDebug
+    case loggingcontract.LevelInfo:
+        return slog.LevelInfo
+    case loggingcontract.LevelWarning:
+        return slog.LevelWarn
+    case loggingcontract.LevelError:
+        return slog.LevelError
+    case loggingcontract.LevelEmergency:
+        return SlogLevelEmergency
+    default:
+        return slog.LevelInfo
+    }
+}
+
+/* LevelFromSlog maps a slog level to the highest Melody level it reaches (e.g. slog.LevelWarn+2 is still a warning). */
+func LevelFromSlog(level slog.Level) loggingcontract.Level {
+    switch {
+    case SlogLevelEmergency <= level:
+        return loggingcontract.LevelEmergency
+    case slog.LevelError <= level:
+        return loggingcontract.LevelError
+    case slog.LevelWarn <= level:
+        return loggingcontract.LevelWarning
+    case slog.LevelInfo <= level:
+        return loggingcontract.LevelInfo
+    default:
+        return loggingcontract.LevelDebug
+    }
+}
+
+/* NewSlogLogger returns a *slog.Logger writing to logger, to hand to libraries that log through log/slog. */
+func NewSlogLogger(logger loggingcontract.Logger) *slog.Logger {
+    return slog.New(NewSlogHandler(logger))
+}
+
+func NewSlogHandler(logger loggingcontract.Logger) *SlogHandler {
+    return NewSlogHandlerWithLevel(logger, slog.LevelDebug)
+}
+
+func NewSlogHandlerWithLevel(logger loggingcontract.Logger, minLevel slog.Leveler) *SlogHandler {
+    if true == internal.IsNilInterface(logger) {
+        exception.Panic(exception.NewError("logger is not provided for slog handler", nil, nil))
+    }
+
+    if true == internal.IsNilInterface(minLevel) {
+        minLevel = slog.LevelDebug
+    }
+
+    return &SlogHandler{
+        logger:   logger,
+        minLevel: minLevel,
+        context:  map[string]any{},
+        groups:   []string{},
+    }
+}
+
+/*
+ * SlogHandler is a slog.Handler writing records to a Melody logger: attributes become the log context, groups become
+ * nested maps and the record level is mapped with LevelFromSlog. Level filtering beyond minLevel is left to the logger.
+ */
+type SlogHandler struct {
+    logger   loggingcontract.Logger
+    minLevel slog.Leveler
+    context  map[string]any
+    groups   []string
+}
+
+func (instance *SlogHandler) Logger() loggingcontract.Logger {
+    return instance.logger
+}
+
+func (instance *SlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+    return instance.minLevel.Level() <= level
+}
+
+func (instance *SlogHandler) Handle(ctx context.Context, record slog.Record) error {
+    logContext := internal.CopyAnyMap(instance.context)
+
+    target := groupContext(logContext, instance.groups)
+    record.Attrs(func(attr slog.Attr) bool {
+        addSlogAttr(target, attr)
+
+        return true
+    })
+
+    instance.logger.Log(LevelFromSlog(record.Level), record.Message, pruneEmptyGroups(logContext, instance.groups))
+
+    return nil
+}
+
+func (instance *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+    if 0 == len(attrs) {
+        return instance
+    }
+
+    logContext := internal.CopyAnyMap(instance.context)
+
+    target := groupContext(logContext, instance.groups)
+    for _, attr := range attrs {
+        addSlogAttr(target, attr)
+    }
+
+    return &SlogHandler{
+        logger:   instance.logger,
+        minLevel: instance.minLevel,
+        context:  logContext,
+        groups:   instance.groups,
+    }
+}
+
+func (instance *SlogHandler) WithGroup(name string) slog.Handler {
+    if "" == name {
+        return instance
+    }
+
+    groups := make([]string, 0, len(instance.groups)+1)
+    groups = append(groups, instance.groups...)
+    groups = append(groups, name)
+
+    return &SlogHandler{
+        logger:   instance.logger,
+        minLevel: instance.minLevel,
+        context:  instance.context,
+        groups:   groups,
+    }
+}
+
+var _ slog.Handler = (*SlogHandler)(nil)
+
+func groupContext(logContext map[string]any, groups []string) map[string]any {
+    target := logContext
+    for _, group := range groups {
+        nested, isMap := target[group].(map[string]any)
+        if false == isMap {
+            nested = map[string]any{}
+            target[group] = nested
+        }
+
+        target = nested
+    }
+
+    return target
+}
+
+/* @info slog drops a group that ends up without attributes, so do the open groups here */
+func pruneEmptyGroups(logContext map[string]any, groups []string) map[string]any {
+    if 0 == len(groups) {
+        return logContext
+    }
+
+    parents := make([]map[string]any, 0, len(groups))
+    target := logContext
+    for _, group := range groups {
+        parents = append(parents, target)
+        target = target[group].(map[string]any)
+    }
+
+    for index := len(groups) - 1; 0 <= index; index-- {
+        nested := parents[index][groups[index]].(map[string]any)
+        if 0 < len(nested) {
+            break
+        }
+
+        delete(parents[index], groups[index])
+    }
+
+    return logContext
+}
+
+func addSlogAttr(target map[string]any, attr slog.Attr) {
+    value := attr.Value.Resolve()
+    if "" == attr.Key && slog.KindGroup != value.Kind() {
+        return
+    }
+
+    switch value.Kind() {
+    case slog.KindGroup:
+        groupAttrs := value.Group()
+        if 0 == len(groupAttrs) {
+            return
+        }
+
+        groupTarget := target
+        if "" != attr.Key {
+            nested, isMap := target[attr.Key].(map[string]any)
+            if false == isMap {
+                nested = map[string]any{}
+                target[attr.Key] = nested
+            }
+
+            groupTarget = nested
+        }
+
+        for _, groupAttr := range groupAttrs {
+            addSlogAttr(groupTarget, groupAttr)
+        }
+    case slog.KindAny:
+        anyValue := value.Any()
+        if err, isError := anyValue.(error); true == isError {
+            target[attr.Key] = err.Error()
+
+            return
+        }
+
+        target[attr.Key] = anyValue
+    default:
+        target[attr.Key] = value.Any()
+    }
+}
@@ -0,0 +1,104 @@
+package logging
+
+import (
+    "context"
+    "log/slog"
+    "sort"
+    "time"
+
+    "github.com/precision-soft/melody/v3/exception"
+    "github.com/precision-soft/melody/v3/internal"
+    loggingcontract "github.com/precision-soft/melody/v3/logging/contract"
+)
+
+/*
+ * NewSlogBackedLogger returns a Melody logger writing to any slog.Handler (slog.NewJSONHandler, an OpenTelemetry bridge,
+ * ...). Context keys become attributes in sorted order and levels are mapped with SlogLevel. A handler made by
+ * NewSlogHandler without attributes or groups is unwrapped to its logger instead of looping through slog.
+ */
+func NewSlogBackedLogger(handler slog.Handler) loggingcontract.Logger {
+    if true == internal.IsNilInterface(handler) {
+        exception.Panic(exception.NewError("slog handler is not provided for slog backed logger", nil, nil))
+    }
+
+    if slogHandler, isSlogHandler := handler.(*SlogHandler); true == isSlogHandler && 0 == len(slogHandler.context) && 0 == len(slogHandler.groups) {
+        return slogHandler.logger
+    }
+
+    return &slogBackedLogger{
+        handler:  handler,
+        minLevel: "",
+    }
+}
+
+/* @info Melody log calls carry no context.Context, so handlers always receive a background one */
+var slogRecordContext = context.Background()
+
+type slogBackedLogger struct {
+    handler  slog.Handler
+    minLevel loggingcontract.Level
+}
+
+func (instance *slogBackedLogger) Log(level loggingcontract.Level, message string, context loggingcontract.Context) {
+    slogLevel := SlogLevel(level)
+
+    if false == instance.isEnabled(level, slogLevel) {
+        return
+    }
+
+    record := slog.NewRecord(time.Now(), slogLevel, message, 0)
+
+    keys := make([]string, 0, len(context))
+    for key := range context {
+        keys = append(keys, key)
+    }
+    sort.Strings(keys)
+
+    for _, key := range keys {
+        record.AddAttrs(slog.Any(key, context[key]))
+    }
+
+    _ = instance.handler.Handle(slogRecordContext, record)
+}
+
+func (instance *slogBackedLogger) Debug(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelDebug, message, context)
+}
+
+func (instance *slogBackedLogger) Info(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelInfo, message, context)
+}
+
+func (instance *slogBackedLogger) Warning(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelWarning, message, context)
+}
+
+func (instance *slogBackedLogger) Error(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelError, message, context)
+}
+
+func (instance *slogBackedLogger) Emergency(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelEmergency, message, context)
+}
+
+func (instance *slogBackedLogger) isEnabled(level loggingcontract.Level, slogLevel slog.Level) bool {
+    if "" != instance.minLevel {
+        return priorityForLevel(level) >= priorityForLevel(instance.minLevel)
+    }
+
+    return instance.handler.Enabled(slogRecordContext, slogLevel)
+}
+
+/*
+ * @info WithMinLevel replaces the handler's Enabled check with minLevel, so a request raised to debug reaches the
+ * handler; a handler that filters levels again inside Handle still drops those records.
+ */
+func (instance *slogBackedLogger) WithMinLevel(minLevel loggingcontract.Level) loggingcontract.Logger {
+    return &slogBackedLogger{
+        handler:  instance.handler,
+        minLevel: minLevel,
+    }
+}
+
+var _ loggingcontract.Logger = (*slogBackedLogger)(nil)
+var _ loggingcontract.MinLevelLogger = (*slogBackedLogger)(nil)
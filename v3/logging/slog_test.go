@@ -0,0 +1,170 @@
+package logging
+
+import (
+    "bytes"
+    "encoding/json"
+    "errors"
+    "log/slog"
+    "strings"
+    "testing"
+
+    loggingcontract "github.com/precision-soft/melody/v3/logging/contract"
+)
+
+type testLogEntry struct {
+    level   loggingcontract.Level
+    message string
+    context loggingcontract.Context
+}
+
+type testRecordingLogger struct {
+    entries []testLogEntry
+    closed  int
+}
+
+func (instance *testRecordingLogger) Log(level loggingcontract.Level, message string, context loggingcontract.Context) {
+    instance.entries = append(instance.entries, testLogEntry{level: level, message: message, context: context})
+}
+
+func (instance *testRecordingLogger) Debug(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelDebug, message, context)
+}
+
+func (instance *testRecordingLogger) Info(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelInfo, message, context)
+}
+
+func (instance *testRecordingLogger) Warning(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelWarning, message, context)
+}
+
+func (instance *testRecordingLogger) Error(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelError, message, context)
+}
+
+func (instance *testRecordingLogger) Emergency(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelEmergency, message, context)
+}
+
+func (instance *testRecordingLogger) Close() error {
+    instance.closed++
+
+    return nil
+}
+
+func TestSlogLevel_RoundTrips(t *testing.T) {
+    for _, level := range []loggingcontract.Level{
+        loggingcontract.LevelDebug,
+        loggingcontract.LevelInfo,
+        loggingcontract.LevelWarning,
+        loggingcontract.LevelError,
+        loggingcontract.LevelEmergency,
+    } {
+        if level != LevelFromSlog(SlogLevel(level)) {
+            t.Fatalf("level %s did not round trip", level)
+        }
+    }
+
+    if loggingcontract.LevelWarning != LevelFromSlog(slog.LevelWarn+2) {
+        t.Fatalf("expected intermediate slog level to map down to warning")
+    }
+}
+
+func TestSlogHandler_WritesAttrsAndGroups(t *testing.T) {
+    recorder := &testRecordingLogger{}
+    logger := NewSlogLogger(recorder)
+
+    logger.With("service", "api").WithGroup("request").Warn(
+        "slow request",
+        "path", "/users",
+        slog.Group("timing", "ms", 120),
+        "err", errors.New("timeout"),
+    )
+
+    if 1 != len(recorder.entries) {
+        t.Fatalf("expected one entry, got %d", len(recorder.entries))
+    }
+
+    entry := recorder.entries[0]
+    if loggingcontract.LevelWarning != entry.level || "slow request" != entry.message {
+        t.Fatalf("unexpected entry: %+v", entry)
+    }
+
+    if "api" != entry.context["service"] {
+        t.Fatalf("expected top level attr, got %v", entry.context)
+    }
+
+    request, isMap := entry.context["request"].(map[string]any)
+    if false == isMap {
+        t.Fatalf("expected request group, got %v", entry.context)
+    }
+
+    if "/users" != request["path"] || "timeout" != request["err"] {
+        t.Fatalf("unexpected request group: %v", request)
+    }
+
+    timing, isMap := request["timing"].(map[string]any)
+    if false == isMap || int64(120) != timing["ms"] {
+        t.Fatalf("unexpected timing group: %v", request["timing"])
+    }
+}
+
+func TestSlogHandler_DropsEmptyGroupsAndFiltersLevel(t *testing.T) {
+    recorder := &testRecordingLogger{}
+    logger := slog.New(NewSlogHandlerWithLevel(recorder, slog.LevelInfo))
+
+    logger.Debug("ignored")
+    logger.WithGroup("empty").Info("no attrs")
+
+    if 1 != len(recorder.entries) {
+        t.Fatalf("expected one entry, got %d", len(recorder.entries))
+    }
+
+    if _, exists := recorder.entries[0].context["empty"]; true == exists {
+        t.Fatalf("expected empty group to be dropped")
+    }
+}
+
+func TestSlogBackedLogger_WritesToHandler(t *testing.T) {
+    buffer := &bytes.Buffer{}
+    logger := NewSlogBackedLogger(slog.NewJSONHandler(buffer, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+    logger.Debug("ignored", nil)
+    logger.Error("failed", loggingcontract.Context{"userId": 7})
+
+    lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+    if 1 != len(lines) {
+        t.Fatalf("expected one line, got %q", buffer.String())
+    }
+
+    var payload map[string]any
+    err := json.Unmarshal([]byte(lines[0]), &payload)
+    if nil != err {
+        t.Fatalf("invalid json: %v", err)
+    }
+
+    if "ERROR" != payload["level"] || "failed" != payload["msg"] || float64(7) != payload["userId"] {
+        t.Fatalf("unexpected payload: %v", payload)
+    }
+}
+
+func TestSlogBackedLogger_WithMinLevelOverridesTheHandlerLevel(t *testing.T) {
+    buffer := &bytes.Buffer{}
+    logger := NewSlogBackedLogger(slog.NewJSONHandler(buffer, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+    WithMinLevel(logger, loggingcontract.LevelDebug).Debug("raised", nil)
+    WithMinLevel(logger, loggingcontract.LevelError).Warning("suppressed", nil)
+    logger.Debug("ignored", nil)
+
+    if false == strings.Contains(buffer.String(), "raised") || true == strings.Contains(buffer.String(), "suppressed") || true == strings.Contains(buffer.String(), "ignored") {
+        t.Fatalf("unexpected output: %q", buffer.String())
+    }
+}
+
+func TestSlogBackedLogger_UnwrapsPlainSlogHandler(t *testing.T) {
+    recorder := &testRecordingLogger{}
+
+    if recorder != NewSlogBackedLogger(NewSlogHandler(recorder)) {
+        t.Fatalf("expected plain slog handler to be unwrapped")
+    }
+}
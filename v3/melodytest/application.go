@@ -0,0 +1,140 @@
+package melodytest
+
+import (
+    "bytes"
+    "context"
+    "io/fs"
+    nethttp "net/http"
+    "testing"
+    "testing/fstest"
+
+    "github.com/precision-soft/melody/v3/application"
+    "github.com/precision-soft/melody/v3/config"
+    "github.com/precision-soft/melody/v3/exception"
+    kernelcontract "github.com/precision-soft/melody/v3/kernel/contract"
+)
+
+/*
+ * NewApplication creates an application whose environment is exactly the given values (on top of the framework defaults,
+ * with MELODY_ENV set to dev unless given) and whose project directory is a temporary directory, so logs and cache never
+ * land in the working tree. The application is closed when the test finishes.
+ */
+func NewApplication(t testing.TB, environment map[string]string) *Application {
+    return NewApplicationWithPublicFiles(t, environment, fstest.MapFS{})
+}
+
+func NewApplicationWithPublicFiles(t testing.TB, environment map[string]string, publicFiles fs.FS) *Application {
+    if nil == t {
+        exception.Panic(exception.NewError("testing t may not be nil", nil, nil))
+    }
+
+    t.Helper()
+
+    values := map[string]string{
+        config.EnvKey: config.EnvDevelopment,
+    }
+    for key, value := range environment {
+        values[key] = value
+    }
+
+    ctx, cancel := context.WithCancel(context.Background())
+
+    testApplication := &Application{
+        t: t,
+        application: application.NewApplicationWithProjectDirectory(
+            ctx,
+            t.TempDir(),
+            newEnvironmentSource(values),
+            publicFiles,
+        ),
+        cancel: cancel,
+    }
+
+    t.Cleanup(testApplication.Close)
+
+    return testApplication
+}
+
+type Application struct {
+    t           testing.TB
+    application *application.Application
+    cancel      context.CancelFunc
+    closed      bool
+}
+
+/* Application exposes the wrapped application to register modules, routes, middlewares and commands before boot. */
+func (instance *Application) Application() *application.Application {
+    return instance.application
+}
+
+func (instance *Application) Kernel() kernelcontract.Kernel {
+    return instance.application.Boot()
+}
+
+/* Override replaces a registered service instance after boot; framework services (the `service.` prefix) need OverrideProtected. */
+func (instance *Application) Override(serviceName string, value any) *Application {
+    instance.t.Helper()
+
+    overrideErr := instance.Kernel().ServiceContainer().OverrideInstance(serviceName, value)
+    if nil != overrideErr {
+        instance.t.Fatalf("failed to override service `%s`: %v", serviceName, overrideErr)
+    }
+
+    return instance
+}
+
+func (instance *Application) OverrideProtected(serviceName string, value any) *Application {
+    instance.t.Helper()
+
+    overrideErr := instance.Kernel().ServiceContainer().OverrideProtectedInstance(serviceName, value)
+    if nil != overrideErr {
+        instance.t.Fatalf("failed to override protected service `%s`: %v", serviceName, overrideErr)
+    }
+
+    return instance
+}
+
+/* HttpHandler is the production handler (kernel listeners and http middlewares included) the client sends requests through. */
+func (instance *Application) HttpHandler() nethttp.Handler {
+    return instance.application.HttpHandler()
+}
+
+func (instance *Application) Client() *Client {
+    return newClient(instance.t, instance.HttpHandler())
+}
+
+/*
+ * RunCommand runs a cli command (arguments without the program name) and captures its output. Like a cli process, the
+ * command closes the service container when it finishes, so use a fresh application for anything that follows.
+ */
+func (instance *Application) RunCommand(arguments ...string) *CommandResult {
+    instance.t.Helper()
+
+    kernelInstance := instance.Kernel()
+
+    stdout := &bytes.Buffer{}
+    stderr := &bytes.Buffer{}
+
+    programArguments := append([]string{kernelInstance.Config().Cli().Name()}, arguments...)
+
+    runErr := instance.application.RunCli(context.Background(), programArguments, stdout, stderr)
+
+    return newCommandResult(instance.t, stdout.String(), stderr.String(), runErr)
+}
+
+/* RecordEvents records every dispatch of the named events from now on. */
+func (instance *Application) RecordEvents(eventNames ...string) *EventRecorder {
+    instance.t.Helper()
+
+    return NewEventRecorder(instance.t, instance.Kernel().EventDispatcher(), eventNames...)
+}
+
+func (instance *Application) Close() {
+    if true == instance.closed {
+        return
+    }
+
+    instance.closed = true
+    instance.cancel()
+    instance.application.Close()
+}
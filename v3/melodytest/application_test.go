@@ -0,0 +1,331 @@
+package melodytest
+
+import (
+    "encoding/json"
+    "fmt"
+    nethttp "net/http"
+    "strconv"
+    "strings"
+    "testing"
+
+    "github.com/precision-soft/melody/v3/cache"
+    clicontract "github.com/precision-soft/melody/v3/cli/contract"
+    containercontract "github.com/precision-soft/melody/v3/container/contract"
+    "github.com/precision-soft/melody/v3/diagnostics"
+    "github.com/precision-soft/melody/v3/event"
+    "github.com/precision-soft/melody/v3/http"
+    httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    "github.com/precision-soft/melody/v3/logging"
+    "github.com/precision-soft/melody/v3/mailer"
+    mailercontract "github.com/precision-soft/melody/v3/mailer/contract"
+    "github.com/precision-soft/melody/v3/messagebus"
+    "github.com/precision-soft/melody/v3/profiler"
+    "github.com/precision-soft/melody/v3/runtime"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+const (
+    serviceGreeter   = "app.greeter"
+    eventTaskCreated = "app.task_created"
+)
+
+type greeter struct {
+    greeting string
+}
+
+type taskCreated struct {
+    Title string
+}
+
+type greetCommand struct {
+}
+
+func (instance *greetCommand) Name() string {
+    return "app:greet"
+}
+
+func (instance *greetCommand) Description() string {
+    return "prints the greeting"
+}
+
+func (instance *greetCommand) Flags() []clicontract.Flag {
+    return []clicontract.Flag{}
+}
+
+func (instance *greetCommand) Run(runtimeInstance runtimecontract.Runtime, commandContext *clicontract.CommandContext) error {
+    greeterInstance := runtime.MustFromRuntime[*greeter](runtimeInstance, serviceGreeter)
+
+    _, _ = fmt.Fprintln(commandContext.Writer, greeterInstance.greeting+" from cli")
+
+    return nil
+}
+
+func newTaskApplication(
+    t *testing.T,
+    mailTransport *mailer.InMemoryTransport,
+    messageTransport *messagebus.InMemoryTransport,
+) *Application {
+    testApplication := NewApplication(t, map[string]string{"APP_NAME": "tasks"})
+
+    application := testApplication.Application()
+
+    application.RegisterService(
+        serviceGreeter,
+        func(resolver containercontract.Resolver) (*greeter, error) {
+            return &greeter{greeting: "hello"}, nil
+        },
+    )
+
+    application.RegisterService(
+        mailer.ServiceMailer,
+        func(resolver containercontract.Resolver) (mailercontract.Mailer, error) {
+            return mailer.NewManager(mailTransport), nil
+        },
+    )
+
+    application.RegisterHttpRoute(
+        nethttp.MethodGet,
+        "/hello",
+        func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            visits := 0
+            if cookie, cookieErr := request.HttpRequest().Cookie("visits"); nil == cookieErr {
+                visits, _ = strconv.Atoi(cookie.Value)
+            }
+
+            response, responseErr := http.JsonResponse(
+                nethttp.StatusOK,
+                map[string]any{
+                    "greeting":      runtime.MustFromRuntime[*greeter](runtimeInstance, serviceGreeter).greeting,
+                    "authorization": request.Header("Authorization"),
+                    "visits":        visits,
+                },
+            )
+            if nil != responseErr {
+                return nil, responseErr
+            }
+
+            response.Headers().Add("Set-Cookie", (&nethttp.Cookie{Name: "visits", Value: strconv.Itoa(visits + 1), Path: "/"}).String())
+
+            return response, nil
+        },
+    )
+
+    application.RegisterHttpRoute(
+        nethttp.MethodPost,
+        "/tasks",
+        func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            payload := taskCreated{}
+            decodeErr := json.NewDecoder(request.HttpRequest().Body).Decode(&payload)
+            if nil != decodeErr {
+                return nil, decodeErr
+            }
+
+            sendErr := mailer.MailerMustFromContainer(runtimeInstance.Container()).Send(
+                runtimeInstance,
+                mailercontract.Message{
+                    From:    mailercontract.Address{Email: "app@example.com"},
+                    To:      []mailercontract.Address{{Email: "owner@example.com"}},
+                    Subject: "task " + payload.Title,
+                    Text:    "created",
+                },
+            )
+            if nil != sendErr {
+                return nil, sendErr
+            }
+
+            transportErr := messageTransport.Send(runtimeInstance, messagebus.NewEnvelope(payload))
+            if nil != transportErr {
+                return nil, transportErr
+            }
+
+            _, dispatchErr := event.EventDispatcherMustFromContainer(runtimeInstance.Container()).DispatchName(runtimeInstance, eventTaskCreated, payload)
+            if nil != dispatchErr {
+                return nil, dispatchErr
+            }
+
+            return http.JsonResponse(nethttp.StatusCreated, map[string]any{"title": payload.Title})
+        },
+    )
+
+    application.RegisterCliCommand(&greetCommand{})
+
+    return testApplication
+}
+
+func TestClient_SendsRequestsThroughTheApplication(t *testing.T) {
+    testApplication := newTaskApplication(t, mailer.NewInMemoryTransport(), messagebus.NewInMemoryTransport(4))
+
+    testApplication.Client().
+        WithBearerToken("secret").
+        Get("/hello").
+        AssertStatus(nethttp.StatusOK).
+        AssertJsonPath("greeting", "hello").
+        AssertJsonPath("authorization", "Bearer secret").
+        AssertJson(map[string]any{"greeting": "hello", "authorization": "Bearer secret", "visits": 0})
+}
+
+func TestClient_KeepsCookiesAcrossRequests(t *testing.T) {
+    testApplication := newTaskApplication(t, mailer.NewInMemoryTransport(), messagebus.NewInMemoryTransport(4))
+    client := testApplication.Client()
+
+    client.Get("/hello").AssertCookie("visits", "1").AssertJsonPath("visits", 0)
+    client.Get("/hello").AssertCookie("visits", "2").AssertJsonPath("visits", 1)
+
+    if cookie := client.Cookie("visits"); nil == cookie || "2" != cookie.Value {
+        t.Fatalf("expected the client to keep the last visits cookie, got %v", cookie)
+    }
+
+    client.Request(nethttp.MethodGet, "/hello").
+        WithCookie(&nethttp.Cookie{Name: "visits", Value: "10"}).
+        Send().
+        AssertJsonPath("visits", 10)
+}
+
+func TestClient_UnknownRouteReturnsNotFound(t *testing.T) {
+    testApplication := newTaskApplication(t, mailer.NewInMemoryTransport(), messagebus.NewInMemoryTransport(4))
+
+    testApplication.Client().Get("/missing").AssertStatus(nethttp.StatusNotFound)
+}
+
+func TestApplication_OverrideSwapsServices(t *testing.T) {
+    testApplication := newTaskApplication(t, mailer.NewInMemoryTransport(), messagebus.NewInMemoryTransport(4))
+
+    testApplication.Override(serviceGreeter, &greeter{greeting: "hi"})
+
+    testApplication.Client().Get("/hello").AssertJsonPath("greeting", "hi")
+}
+
+func TestRecorders_CaptureMailMessagesAndEvents(t *testing.T) {
+    mailTransport := mailer.NewInMemoryTransport()
+    messageTransport := messagebus.NewInMemoryTransport(4)
+    testApplication := newTaskApplication(t, mailTransport, messageTransport)
+
+    mailRecorder := NewMailRecorder(t, mailTransport)
+    messageRecorder := NewMessageRecorder(t, messageTransport)
+    eventRecorder := testApplication.RecordEvents(eventTaskCreated)
+
+    testApplication.Client().
+        PostJson("/tasks", taskCreated{Title: "write docs"}).
+        AssertStatus(nethttp.StatusCreated).
+        AssertJsonPath("title", "write docs")
+
+    mailRecorder.AssertSentCount(1).AssertSentTo("owner@example.com")
+    if lastMessage, found := mailRecorder.Last(); false == found || "task write docs" != lastMessage.Subject {
+        t.Fatalf("unexpected last message: %+v", lastMessage)
+    }
+
+    messageRecorder.AssertSentCount(1)
+    if tasks := MessagesOfType[taskCreated](messageRecorder); 1 != len(tasks) || "write docs" != tasks[0].Title {
+        t.Fatalf("unexpected recorded messages: %+v", tasks)
+    }
+
+    eventRecorder.AssertDispatchedTimes(eventTaskCreated, 1)
+    if payload, isTask := eventRecorder.EventsNamed(eventTaskCreated)[0].Payload().(taskCreated); false == isTask || "write docs" != payload.Title {
+        t.Fatalf("unexpected event payload: %+v", eventRecorder.EventsNamed(eventTaskCreated)[0].Payload())
+    }
+
+    mailRecorder.Reset()
+    messageRecorder.Reset()
+    eventRecorder.Reset()
+    eventRecorder.Stop()
+
+    testApplication.Client().PostJson("/tasks", taskCreated{Title: "review"}).AssertStatus(nethttp.StatusCreated)
+
+    mailRecorder.AssertSentCount(1)
+    messageRecorder.AssertSentCount(1)
+    eventRecorder.AssertNotDispatched(eventTaskCreated)
+}
+
+func TestApplication_RunCommandCapturesOutput(t *testing.T) {
+    testApplication := newTaskApplication(t, mailer.NewInMemoryTransport(), messagebus.NewInMemoryTransport(4))
+
+    testApplication.RunCommand("app:greet").
+        AssertSuccess().
+        AssertExitCode(0).
+        AssertOutputContains("hello from cli")
+}
+
+func TestApplication_RunCommandReportsUnknownCommands(t *testing.T) {
+    testApplication := newTaskApplication(t, mailer.NewInMemoryTransport(), messagebus.NewInMemoryTransport(4))
+
+    testApplication.RunCommand("app:gret").
+        AssertExitCode(2).
+        AssertErrorOutputContains("app:greet")
+}
+
+func TestProfiler_ProfilesRequestsAndServesProfiles(t *testing.T) {
+    testApplication := newTaskApplication(t, mailer.NewInMemoryTransport(), messagebus.NewInMemoryTransport(4))
+    testApplication.Application().EnableProfiler(profiler.NewProfilerConfig())
+    testApplication.Application().RegisterHttpRoute(
+        nethttp.MethodGet,
+        "/cached",
+        func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            cacheInstance := cache.CacheMustFromResolver(runtimeInstance.Scope())
+            _, _, _ = cacheInstance.Get("greeting")
+
+            logging.LoggerMustFromRuntime(runtimeInstance).Info("served cached page", nil)
+
+            return http.HtmlResponse(nethttp.StatusOK, "<html><body>cached</body></html>"), nil
+        },
+    )
+
+    client := testApplication.Client()
+
+    response := client.Get("/cached").AssertStatus(nethttp.StatusOK).AssertBodyContains("melody-profiler-toolbar")
+    token := response.Header().Get(profiler.HeaderDebugToken)
+    if "" == token {
+        t.Fatalf("expected the %s header", profiler.HeaderDebugToken)
+    }
+
+    client.Get("/_profiler/"+token).
+        AssertStatus(nethttp.StatusOK).
+        AssertJsonPath("statusCode", nethttp.StatusOK).
+        AssertJsonPath("path", "/cached").
+        AssertJsonPath("cache.0.operation", "get").
+        AssertJsonPath("cache.0.hit", false).
+        AssertJsonPath("logs.0.message", "served cached page")
+
+    client.Get("/_profiler").AssertStatus(nethttp.StatusOK).AssertJsonPath("profiles.0.token", token)
+
+    client.Request(nethttp.MethodGet, "/_profiler/"+token).
+        WithHeader("Accept", "text/html").
+        Send().
+        AssertStatus(nethttp.StatusOK).
+        AssertBodyContains("Timeline")
+
+    client.Get("/_profiler/missing").AssertStatus(nethttp.StatusNotFound)
+}
+
+func TestDiagnostics_RoutesStayClosedWithoutAFirewall(t *testing.T) {
+    testApplication := newTaskApplication(t, mailer.NewInMemoryTransport(), messagebus.NewInMemoryTransport(4))
+    testApplication.Application().RegisterModule(diagnostics.NewModule(diagnostics.NewModuleConfig()))
+
+    client := testApplication.Client()
+
+    client.Get("/_diagnostics/vars").AssertStatus(nethttp.StatusUnauthorized)
+    client.Get("/_diagnostics/pprof/heap").AssertStatus(nethttp.StatusUnauthorized)
+
+    result := testApplication.RunCommand("melody:debug:profile", "--type=heap")
+    if nil == result.Err() || false == strings.Contains(result.Err().Error(), "--url") {
+        t.Fatalf("expected the missing --url flag to be reported, got %v", result.Err())
+    }
+}
+
+func TestApplication_CompletionAndDocumentationListRegisteredCommands(t *testing.T) {
+    testApplication := newTaskApplication(t, mailer.NewInMemoryTransport(), messagebus.NewInMemoryTransport(4))
+
+    completion := testApplication.RunCommand("melody:completion", "bash").
+        AssertSuccess().
+        AssertOutputContains("app:greet").
+        AssertOutputContains("--no-interaction")
+    if true == strings.Contains(completion.Stdout(), "[started]") {
+        t.Fatalf("expected the completion script without the command banner")
+    }
+
+    testApplication = newTaskApplication(t, mailer.NewInMemoryTransport(), messagebus.NewInMemoryTransport(4))
+
+    testApplication.RunCommand("melody:docs:cli").
+        AssertSuccess().
+        AssertOutputContains("#### app:greet").
+        AssertOutputContains("### melody")
+}
@@ -0,0 +1,262 @@
+package melodytest
+
+import (
+    "bytes"
+    "encoding/base64"
+    "encoding/json"
+    "io"
+    nethttp "net/http"
+    "net/http/cookiejar"
+    "net/http/httptest"
+    "net/url"
+    "strings"
+    "testing"
+)
+
+const BaseUrl = "http://localhost"
+
+func newClient(t testing.TB, handler nethttp.Handler) *Client {
+    t.Helper()
+
+    jar, jarErr := cookiejar.New(nil)
+    if nil != jarErr {
+        t.Fatalf("failed to create the cookie jar: %v", jarErr)
+    }
+
+    return &Client{
+        t:       t,
+        handler: handler,
+        headers: nethttp.Header{},
+        jar:     jar,
+    }
+}
+
+/*
+ * Client sends requests in-process through the application http handler. Headers set on the client go out with every
+ * request and cookies set by responses are kept and sent back, like a browser session.
+ */
+type Client struct {
+    t       testing.TB
+    handler nethttp.Handler
+    headers nethttp.Header
+    jar     *cookiejar.Jar
+}
+
+func (instance *Client) WithHeader(name string, value string) *Client {
+    instance.headers.Set(name, value)
+
+    return instance
+}
+
+func (instance *Client) WithBearerToken(token string) *Client {
+    return instance.WithHeader("Authorization", "Bearer "+token)
+}
+
+func (instance *Client) WithBasicAuth(username string, password string) *Client {
+    return instance.WithHeader("Authorization", basicAuthorization(username, password))
+}
+
+func (instance *Client) WithCookie(cookie *nethttp.Cookie) *Client {
+    instance.jar.SetCookies(jarUrl("/"), []*nethttp.Cookie{cookie})
+
+    return instance
+}
+
+func (instance *Client) Cookie(name string) *nethttp.Cookie {
+    for _, cookie := range instance.jar.Cookies(jarUrl("/")) {
+        if name == cookie.Name {
+            return cookie
+        }
+    }
+
+    return nil
+}
+
+func (instance *Client) Cookies() []*nethttp.Cookie {
+    return instance.jar.Cookies(jarUrl("/"))
+}
+
+func (instance *Client) Request(method string, target string) *Request {
+    return &Request{
+        client:  instance,
+        method:  method,
+        target:  target,
+        headers: nethttp.Header{},
+        query:   url.Values{},
+        cookies: make([]*nethttp.Cookie, 0),
+    }
+}
+
+func (instance *Client) Get(target string) *Response {
+    return instance.Request(nethttp.MethodGet, target).Send()
+}
+
+func (instance *Client) Head(target string) *Response {
+    return instance.Request(nethttp.MethodHead, target).Send()
+}
+
+func (instance *Client) Delete(target string) *Response {
+    return instance.Request(nethttp.MethodDelete, target).Send()
+}
+
+func (instance *Client) PostJson(target string, value any) *Response {
+    return instance.Request(nethttp.MethodPost, target).WithJson(value).Send()
+}
+
+func (instance *Client) PutJson(target string, value any) *Response {
+    return instance.Request(nethttp.MethodPut, target).WithJson(value).Send()
+}
+
+func (instance *Client) PatchJson(target string, value any) *Response {
+    return instance.Request(nethttp.MethodPatch, target).WithJson(value).Send()
+}
+
+func (instance *Client) PostForm(target string, values url.Values) *Response {
+    return instance.Request(nethttp.MethodPost, target).WithForm(values).Send()
+}
+
+/* Do sends a prepared request as is, adding only the client headers and cookies. */
+func (instance *Client) Do(httpRequest *nethttp.Request) *Response {
+    instance.t.Helper()
+
+    for name, values := range instance.headers {
+        if "" == httpRequest.Header.Get(name) {
+            httpRequest.Header[name] = append([]string{}, values...)
+        }
+    }
+
+    for _, cookie := range instance.jar.Cookies(jarUrl(httpRequest.URL.Path)) {
+        if _, cookieErr := httpRequest.Cookie(cookie.Name); nil != cookieErr {
+            httpRequest.AddCookie(cookie)
+        }
+    }
+
+    recorder := httptest.NewRecorder()
+    instance.handler.ServeHTTP(recorder, httpRequest)
+
+    result := recorder.Result()
+    instance.jar.SetCookies(jarUrl(httpRequest.URL.Path), result.Cookies())
+
+    body, readErr := io.ReadAll(result.Body)
+    if nil != readErr {
+        instance.t.Fatalf("failed to read the response body: %v", readErr)
+    }
+
+    return newResponse(instance.t, result, body)
+}
+
+type Request struct {
+    client      *Client
+    method      string
+    target      string
+    headers     nethttp.Header
+    query       url.Values
+    cookies     []*nethttp.Cookie
+    body        []byte
+    contentType string
+}
+
+func (instance *Request) WithHeader(name string, value string) *Request {
+    instance.headers.Set(name, value)
+
+    return instance
+}
+
+func (instance *Request) WithQuery(name string, value string) *Request {
+    instance.query.Add(name, value)
+
+    return instance
+}
+
+func (instance *Request) WithBearerToken(token string) *Request {
+    return instance.WithHeader("Authorization", "Bearer "+token)
+}
+
+func (instance *Request) WithBasicAuth(username string, password string) *Request {
+    return instance.WithHeader("Authorization", basicAuthorization(username, password))
+}
+
+/* WithCookie sends the cookie with this request only; use Client.WithCookie to keep it for the session. */
+func (instance *Request) WithCookie(cookie *nethttp.Cookie) *Request {
+    instance.cookies = append(instance.cookies, cookie)
+
+    return instance
+}
+
+func (instance *Request) WithBody(contentType string, body []byte) *Request {
+    instance.contentType = contentType
+    instance.body = append([]byte{}, body...)
+
+    return instance
+}
+
+func (instance *Request) WithJson(value any) *Request {
+    instance.client.t.Helper()
+
+    body, marshalErr := json.Marshal(value)
+    if nil != marshalErr {
+        instance.client.t.Fatalf("failed to encode the json request body: %v", marshalErr)
+    }
+
+    if "" == instance.headers.Get("Accept") {
+        instance.headers.Set("Accept", "application/json")
+    }
+
+    return instance.WithBody("application/json", body)
+}
+
+func (instance *Request) WithForm(values url.Values) *Request {
+    return instance.WithBody("application/x-www-form-urlencoded", []byte(values.Encode()))
+}
+
+func (instance *Request) Send() *Response {
+    instance.client.t.Helper()
+
+    target := instance.target
+    if 0 < len(instance.query) {
+        separator := "?"
+        if true == strings.Contains(target, "?") {
+            separator = "&"
+        }
+
+        target = target + separator + instance.query.Encode()
+    }
+
+    if false == strings.HasPrefix(target, "http://") && false == strings.HasPrefix(target, "https://") {
+        target = BaseUrl + target
+    }
+
+    var body io.Reader
+    if nil != instance.body {
+        body = bytes.NewReader(instance.body)
+    }
+
+    httpRequest := httptest.NewRequest(instance.method, target, body)
+
+    if "" != instance.contentType {
+        httpRequest.Header.Set("Content-Type", instance.contentType)
+    }
+
+    for name, values := range instance.headers {
+        httpRequest.Header[name] = append([]string{}, values...)
+    }
+
+    for _, cookie := range instance.cookies {
+        httpRequest.AddCookie(cookie)
+    }
+
+    return instance.client.Do(httpRequest)
+}
+
+/* @info the jar always sees an https origin so cookies marked Secure are kept and sent back like any other. */
+func jarUrl(path string) *url.URL {
+    if "" == path {
+        path = "/"
+    }
+
+    return &url.URL{Scheme: "https", Host: "localhost", Path: path}
+}
+
+func basicAuthorization(username string, password string) string {
+    return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
@@ -0,0 +1,94 @@
+package melodytest
+
+import (
+    "errors"
+    "strings"
+    "testing"
+
+    "github.com/precision-soft/melody/v3/exception"
+)
+
+func newCommandResult(t testing.TB, stdout string, stderr string, err error) *CommandResult {
+    exitCode := 0
+    if nil != err {
+        exitCode = 1
+
+        var exitError *exception.ExitError
+        if true == errors.As(err, &exitError) {
+            exitCode = exitError.ExitCode()
+        }
+    }
+
+    return &CommandResult{
+        t:        t,
+        stdout:   stdout,
+        stderr:   stderr,
+        err:      err,
+        exitCode: exitCode,
+    }
+}
+
+type CommandResult struct {
+    t        testing.TB
+    stdout   string
+    stderr   string
+    err      error
+    exitCode int
+}
+
+func (instance *CommandResult) Stdout() string {
+    return instance.stdout
+}
+
+func (instance *CommandResult) Stderr() string {
+    return instance.stderr
+}
+
+func (instance *CommandResult) Err() error {
+    return instance.err
+}
+
+/* ExitCode is 0 on success, the code of an exception.ExitError, or 1 for any other error. */
+func (instance *CommandResult) ExitCode() int {
+    return instance.exitCode
+}
+
+func (instance *CommandResult) AssertSuccess() *CommandResult {
+    instance.t.Helper()
+
+    if nil != instance.err {
+        instance.t.Fatalf("expected the command to succeed, got: %v\nstdout: %s\nstderr: %s", instance.err, instance.stdout, instance.stderr)
+    }
+
+    return instance
+}
+
+func (instance *CommandResult) AssertExitCode(exitCode int) *CommandResult {
+    instance.t.Helper()
+
+    if exitCode != instance.exitCode {
+        instance.t.Fatalf("expected exit code %d, got %d (error: %v)\nstderr: %s", exitCode, instance.exitCode, instance.err, instance.stderr)
+    }
+
+    return instance
+}
+
+func (instance *CommandResult) AssertOutputContains(expected string) *CommandResult {
+    instance.t.Helper()
+
+    if false == strings.Contains(instance.stdout, expected) {
+        instance.t.Fatalf("expected the output to contain %q\nstdout: %s", expected, instance.stdout)
+    }
+
+    return instance
+}
+
+func (instance *CommandResult) AssertErrorOutputContains(expected string) *CommandResult {
+    instance.t.Helper()
+
+    if false == strings.Contains(instance.stderr, expected) {
+        instance.t.Fatalf("expected the error output to contain %q\nstderr: %s", expected, instance.stderr)
+    }
+
+    return instance
+}
@@ -0,0 +1,4 @@
+/*
+Package melodytest provides functional-testing helpers for Melody applications: a booted test application, an in-process http client with response assertions, cli command runs with captured output, and recorders for mail, message bus envelopes and dispatched events.
+*/
+package melodytest
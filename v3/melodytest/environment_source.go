@@ -0,0 +1,42 @@
+package melodytest
+
+import (
+    configcontract "github.com/precision-soft/melody/v3/config/contract"
+)
+
+const EnvironmentOrigin = "melodytest"
+
+func newEnvironmentSource(values map[string]string) *environmentSource {
+    copied := make(map[string]string, len(values))
+    for key, value := range values {
+        copied[key] = value
+    }
+
+    return &environmentSource{
+        values: copied,
+    }
+}
+
+type environmentSource struct {
+    values map[string]string
+}
+
+func (instance *environmentSource) Load() (map[string]string, error) {
+    values, _, loadErr := instance.LoadTraced()
+
+    return values, loadErr
+}
+
+func (instance *environmentSource) LoadTraced() (map[string]string, map[string]string, error) {
+    values := make(map[string]string, len(instance.values))
+    origins := make(map[string]string, len(instance.values))
+
+    for key, value := range instance.values {
+        values[key] = value
+        origins[key] = EnvironmentOrigin
+    }
+
+    return values, origins, nil
+}
+
+var _ configcontract.TracedEnvironmentSource = (*environmentSource)(nil)
@@ -0,0 +1,132 @@
+package melodytest
+
+import (
+    "math"
+    "sync"
+    "testing"
+
+    eventcontract "github.com/precision-soft/melody/v3/event/contract"
+    "github.com/precision-soft/melody/v3/exception"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+/* EventRecorderPriority puts the recording listener ahead of every other listener, so events are recorded even when a listener stops propagation. */
+const EventRecorderPriority = math.MaxInt32
+
+func NewEventRecorder(
+    t testing.TB,
+    eventDispatcher eventcontract.EventDispatcher,
+    eventNames ...string,
+) *EventRecorder {
+    if nil == eventDispatcher {
+        exception.Panic(exception.NewError("event dispatcher may not be nil", nil, nil))
+    }
+
+    if 0 == len(eventNames) {
+        exception.Panic(exception.NewError("at least one event name is required to record events", nil, nil))
+    }
+
+    recorder := &EventRecorder{
+        t:               t,
+        eventDispatcher: eventDispatcher,
+        events:          make([]eventcontract.Event, 0),
+        registrations:   make([]eventcontract.ListenerRegistration, 0, len(eventNames)),
+    }
+
+    for _, eventName := range eventNames {
+        recorder.registrations = append(
+            recorder.registrations,
+            eventDispatcher.AddListener(eventName, recorder.record, EventRecorderPriority),
+        )
+    }
+
+    return recorder
+}
+
+type EventRecorder struct {
+    t               testing.TB
+    eventDispatcher eventcontract.EventDispatcher
+    mutex           sync.Mutex
+    events          []eventcontract.Event
+    registrations   []eventcontract.ListenerRegistration
+}
+
+func (instance *EventRecorder) Events() []eventcontract.Event {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    return append([]eventcontract.Event{}, instance.events...)
+}
+
+func (instance *EventRecorder) EventsNamed(eventName string) []eventcontract.Event {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    events := make([]eventcontract.Event, 0)
+    for _, event := range instance.events {
+        if eventName == event.Name() {
+            events = append(events, event)
+        }
+    }
+
+    return events
+}
+
+func (instance *EventRecorder) Count(eventName string) int {
+    return len(instance.EventsNamed(eventName))
+}
+
+func (instance *EventRecorder) Reset() {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.events = make([]eventcontract.Event, 0)
+}
+
+/* Stop removes the recording listeners; the events recorded so far are kept. */
+func (instance *EventRecorder) Stop() {
+    for _, registration := range instance.registrations {
+        instance.eventDispatcher.RemoveListener(registration)
+    }
+
+    instance.registrations = nil
+}
+
+func (instance *EventRecorder) AssertDispatched(eventName string) *EventRecorder {
+    instance.t.Helper()
+
+    if 0 == instance.Count(eventName) {
+        instance.t.Fatalf("expected event `%s` to be dispatched", eventName)
+    }
+
+    return instance
+}
+
+func (instance *EventRecorder) AssertDispatchedTimes(eventName string, times int) *EventRecorder {
+    instance.t.Helper()
+
+    if count := instance.Count(eventName); times != count {
+        instance.t.Fatalf("expected event `%s` to be dispatched %d times, got %d", eventName, times, count)
+    }
+
+    return instance
+}
+
+func (instance *EventRecorder) AssertNotDispatched(eventName string) *EventRecorder {
+    instance.t.Helper()
+
+    if count := instance.Count(eventName); 0 != count {
+        instance.t.Fatalf("expected event `%s` not to be dispatched, got %d dispatches", eventName, count)
+    }
+
+    return instance
+}
+
+func (instance *EventRecorder) record(runtimeInstance runtimecontract.Runtime, event eventcontract.Event) error {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.events = append(instance.events, event)
+
+    return nil
+}
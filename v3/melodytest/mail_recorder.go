@@ -0,0 +1,94 @@
+package melodytest
+
+import (
+    "sync"
+    "testing"
+
+    "github.com/precision-soft/melody/v3/exception"
+    "github.com/precision-soft/melody/v3/mailer"
+    mailercontract "github.com/precision-soft/melody/v3/mailer/contract"
+)
+
+/* NewMailRecorder asserts on the messages sent through transport; wire the same transport into the application mailer. */
+func NewMailRecorder(t testing.TB, transport *mailer.InMemoryTransport) *MailRecorder {
+    if nil == transport {
+        exception.Panic(exception.NewError("mailer transport may not be nil", nil, nil))
+    }
+
+    return &MailRecorder{
+        t:         t,
+        transport: transport,
+    }
+}
+
+type MailRecorder struct {
+    t         testing.TB
+    transport *mailer.InMemoryTransport
+    mutex     sync.Mutex
+    offset    int
+}
+
+func (instance *MailRecorder) Transport() *mailer.InMemoryTransport {
+    return instance.transport
+}
+
+/* Messages returns the messages sent since the recorder was created or last reset. */
+func (instance *MailRecorder) Messages() []mailercontract.Message {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    sent := instance.transport.Sent()
+    if instance.offset >= len(sent) {
+        return []mailercontract.Message{}
+    }
+
+    return sent[instance.offset:]
+}
+
+func (instance *MailRecorder) Count() int {
+    return len(instance.Messages())
+}
+
+func (instance *MailRecorder) Last() (mailercontract.Message, bool) {
+    messages := instance.Messages()
+    if 0 == len(messages) {
+        return mailercontract.Message{}, false
+    }
+
+    return messages[len(messages)-1], true
+}
+
+func (instance *MailRecorder) Reset() {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.offset = len(instance.transport.Sent())
+}
+
+func (instance *MailRecorder) AssertSentCount(count int) *MailRecorder {
+    instance.t.Helper()
+
+    if sent := instance.Count(); count != sent {
+        instance.t.Fatalf("expected %d sent messages, got %d", count, sent)
+    }
+
+    return instance
+}
+
+func (instance *MailRecorder) AssertSentTo(email string) *MailRecorder {
+    instance.t.Helper()
+
+    for _, message := range instance.Messages() {
+        for _, recipients := range [][]mailercontract.Address{message.To, message.Cc, message.Bcc} {
+            for _, recipient := range recipients {
+                if email == recipient.Email {
+                    return instance
+                }
+            }
+        }
+    }
+
+    instance.t.Fatalf("expected a message sent to `%s`", email)
+
+    return instance
+}
@@ -0,0 +1,109 @@
+package melodytest
+
+import (
+    "sync"
+    "testing"
+
+    "github.com/precision-soft/melody/v3/exception"
+    "github.com/precision-soft/melody/v3/messagebus"
+    messagebuscontract "github.com/precision-soft/melody/v3/messagebus/contract"
+)
+
+/*
+ * NewMessageRecorder asserts on the envelopes routed to transport. The recorder takes the envelopes off the transport
+ * queue when inspected, so nothing consumes them afterwards: record a transport no worker reads from.
+ */
+func NewMessageRecorder(t testing.TB, transport *messagebus.InMemoryTransport) *MessageRecorder {
+    if nil == transport {
+        exception.Panic(exception.NewError("message bus transport may not be nil", nil, nil))
+    }
+
+    return &MessageRecorder{
+        t:         t,
+        transport: transport,
+        envelopes: make([]messagebuscontract.Envelope, 0),
+    }
+}
+
+type MessageRecorder struct {
+    t         testing.TB
+    transport *messagebus.InMemoryTransport
+    mutex     sync.Mutex
+    envelopes []messagebuscontract.Envelope
+}
+
+func (instance *MessageRecorder) Transport() *messagebus.InMemoryTransport {
+    return instance.transport
+}
+
+func (instance *MessageRecorder) Envelopes() []messagebuscontract.Envelope {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.drain()
+
+    return append([]messagebuscontract.Envelope{}, instance.envelopes...)
+}
+
+func (instance *MessageRecorder) Messages() []any {
+    envelopes := instance.Envelopes()
+
+    messages := make([]any, 0, len(envelopes))
+    for _, envelope := range envelopes {
+        messages = append(messages, envelope.Message())
+    }
+
+    return messages
+}
+
+func (instance *MessageRecorder) Count() int {
+    return len(instance.Envelopes())
+}
+
+func (instance *MessageRecorder) Reset() {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.drain()
+    instance.envelopes = make([]messagebuscontract.Envelope, 0)
+}
+
+func (instance *MessageRecorder) AssertSentCount(count int) *MessageRecorder {
+    instance.t.Helper()
+
+    if sent := instance.Count(); count != sent {
+        instance.t.Fatalf("expected %d sent messages, got %d", count, sent)
+    }
+
+    return instance
+}
+
+func (instance *MessageRecorder) drain() {
+    /* @info the in-memory transport hands out its queue without touching the runtime */
+    queue, receiveErr := instance.transport.Receive(nil)
+    if nil != receiveErr {
+        return
+    }
+
+    for {
+        select {
+        case envelope := <-queue:
+            instance.envelopes = append(instance.envelopes, envelope)
+        default:
+            return
+        }
+    }
+}
+
+/* MessagesOfType returns the recorded messages of type T, in send order. */
+func MessagesOfType[T any](recorder *MessageRecorder) []T {
+    messages := make([]T, 0)
+    for _, message := range recorder.Messages() {
+        typedMessage, isType := message.(T)
+        if true == isType {
+            messages = append(messages, typedMessage)
+        }
+    }
+
+    return messages
+}
@@ -0,0 +1,201 @@
+package melodytest
+
+import (
+    "encoding/json"
+    nethttp "net/http"
+    "reflect"
+    "strconv"
+    "strings"
+    "testing"
+)
+
+func newResponse(t testing.TB, result *nethttp.Response, body []byte) *Response {
+    return &Response{
+        t:      t,
+        result: result,
+        body:   body,
+    }
+}
+
+/* Response wraps a recorded response; the Assert methods fail the test immediately and return the response for chaining. */
+type Response struct {
+    t      testing.TB
+    result *nethttp.Response
+    body   []byte
+}
+
+func (instance *Response) StatusCode() int {
+    return instance.result.StatusCode
+}
+
+func (instance *Response) Header() nethttp.Header {
+    return instance.result.Header
+}
+
+func (instance *Response) Body() []byte {
+    return instance.body
+}
+
+func (instance *Response) Text() string {
+    return string(instance.body)
+}
+
+func (instance *Response) Cookie(name string) *nethttp.Cookie {
+    for _, cookie := range instance.result.Cookies() {
+        if name == cookie.Name {
+            return cookie
+        }
+    }
+
+    return nil
+}
+
+func (instance *Response) DecodeJson(target any) {
+    instance.t.Helper()
+
+    unmarshalErr := json.Unmarshal(instance.body, target)
+    if nil != unmarshalErr {
+        instance.t.Fatalf("failed to decode the json response body: %v\nbody: %s", unmarshalErr, instance.body)
+    }
+}
+
+func (instance *Response) AssertStatus(statusCode int) *Response {
+    instance.t.Helper()
+
+    if statusCode != instance.result.StatusCode {
+        instance.t.Fatalf("expected status %d, got %d\nbody: %s", statusCode, instance.result.StatusCode, instance.body)
+    }
+
+    return instance
+}
+
+func (instance *Response) AssertHeader(name string, expected string) *Response {
+    instance.t.Helper()
+
+    actual := instance.result.Header.Get(name)
+    if expected != actual {
+        instance.t.Fatalf("expected header `%s` to be %q, got %q", name, expected, actual)
+    }
+
+    return instance
+}
+
+func (instance *Response) AssertHeaderMissing(name string) *Response {
+    instance.t.Helper()
+
+    if values := instance.result.Header.Values(name); 0 < len(values) {
+        instance.t.Fatalf("expected no header `%s`, got %q", name, values)
+    }
+
+    return instance
+}
+
+func (instance *Response) AssertBodyContains(expected string) *Response {
+    instance.t.Helper()
+
+    if false == strings.Contains(string(instance.body), expected) {
+        instance.t.Fatalf("expected the body to contain %q\nbody: %s", expected, instance.body)
+    }
+
+    return instance
+}
+
+func (instance *Response) AssertCookie(name string, expected string) *Response {
+    instance.t.Helper()
+
+    cookie := instance.Cookie(name)
+    if nil == cookie {
+        instance.t.Fatalf("expected the response to set cookie `%s`", name)
+    }
+
+    if expected != cookie.Value {
+        instance.t.Fatalf("expected cookie `%s` to be %q, got %q", name, expected, cookie.Value)
+    }
+
+    return instance
+}
+
+/* AssertJson compares the decoded body with expected encoded to json and decoded back, so structs, maps and literals compare alike. */
+func (instance *Response) AssertJson(expected any) *Response {
+    instance.t.Helper()
+
+    var actualValue any
+    instance.DecodeJson(&actualValue)
+
+    expectedValue := normalizeJson(instance.t, expected)
+    if false == reflect.DeepEqual(expectedValue, actualValue) {
+        instance.t.Fatalf("expected json %s, got %s", mustEncodeJson(expectedValue), instance.body)
+    }
+
+    return instance
+}
+
+/* AssertJsonPath compares a single value of the json body, addressed by dot separated keys and array indexes (e.g. `items.0.id`). */
+func (instance *Response) AssertJsonPath(path string, expected any) *Response {
+    instance.t.Helper()
+
+    var document any
+    instance.DecodeJson(&document)
+
+    actualValue, found := jsonPathValue(document, path)
+    if false == found {
+        instance.t.Fatalf("json path `%s` not found\nbody: %s", path, instance.body)
+    }
+
+    expectedValue := normalizeJson(instance.t, expected)
+    if false == reflect.DeepEqual(expectedValue, actualValue) {
+        instance.t.Fatalf("expected json path `%s` to be %s, got %s", path, mustEncodeJson(expectedValue), mustEncodeJson(actualValue))
+    }
+
+    return instance
+}
+
+func jsonPathValue(document any, path string) (any, bool) {
+    current := document
+    if "" == path {
+        return current, true
+    }
+
+    for _, segment := range strings.Split(path, ".") {
+        switch typedCurrent := current.(type) {
+        case map[string]any:
+            value, exists := typedCurrent[segment]
+            if false == exists {
+                return nil, false
+            }
+
+            current = value
+        case []any:
+            index, indexErr := strconv.Atoi(segment)
+            if nil != indexErr || 0 > index || len(typedCurrent) <= index {
+                return nil, false
+            }
+
+            current = typedCurrent[index]
+        default:
+            return nil, false
+        }
+    }
+
+    return current, true
+}
+
+func normalizeJson(t testing.TB, value any) any {
+    t.Helper()
+
+    encoded, marshalErr := json.Marshal(value)
+    if nil != marshalErr {
+        t.Fatalf("failed to encode the expected json: %v", marshalErr)
+    }
+
+    var normalized any
+    _ = json.Unmarshal(encoded, &normalized)
+
+    return normalized
+}
+
+func mustEncodeJson(value any) string {
+    encoded, _ := json.Marshal(value)
+
+    return string(encoded)
+}
@@ -10,6 +10,7 @@
     "time"
 
     clicontract "github.com/precision-soft/melody/v3/cli/contract"
+    "github.com/precision-soft/melody/v3/cli/worker"
     "github.com/precision-soft/melody/v3/exception"
     "github.com/precision-soft/melody/v3/logging"
     messagebuscontract "github.com/precision-soft/melody/v3/messagebus/contract"
@@ -163,7 +164,7 @@ func (instance *ConsumeCommand) consumeFrom(
     var loopErr error
     var wait sync.WaitGroup
 
-    for worker := 0; worker < concurrency; worker++ {
+    for workerIndex := 0; workerIndex < concurrency; workerIndex++ {
         wait.Add(1)
 
         go func() {
@@ -189,6 +190,8 @@ func (instance *ConsumeCommand) consumeFrom(
                     }
 
                     instance.consume(consumeRuntime, transport, envelopeInstance)
+                    /* @info a no-op unless the command is wrapped by worker.NewCommand, where it counts toward --unit-limit. */
+                    worker.CompleteUnit(runtimeInstance)
 
                     if limit > 0 && atomic.AddInt64(&processed, 1) >= limit {
                         cancelWorkers()
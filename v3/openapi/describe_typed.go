@@ -2,6 +2,11 @@
 
 import (
     "reflect"
+
+    "github.com/precision-soft/melody/v3/exception"
+    exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+    melodyhttp "github.com/precision-soft/melody/v3/http"
+    httpcontract "github.com/precision-soft/melody/v3/http/contract"
 )
 
 type DescribeOption func(*Descriptor)
@@ -24,6 +29,12 @@ func WithTags(tags ...string) DescribeOption {
     }
 }
 
+func WithProblemResponse(statuses ...int) DescribeOption {
+    return func(descriptor *Descriptor) {
+        descriptor.ProblemResponses = append(descriptor.ProblemResponses, statuses...)
+    }
+}
+
 func WithResponse[T any](status int) DescribeOption {
     return func(descriptor *Descriptor) {
         descriptor.Responses[status] = TypeOf[T]()
@@ -44,3 +55,57 @@ func DescribeTyped[Req any, Resp any](registry *Registry, routeName string, stat
 
     return registry.Describe(routeName, descriptor)
 }
+
+/* @info describes routeName from the request/response types and the success status of a typed handler (see http.Handler). */
+func (instance *Registry) DescribeHandler(routeName string, typedHandler httpcontract.TypedHandler, options ...DescribeOption) *Registry {
+    descriptor := describeTypedHandler(typedHandler)
+
+    for _, option := range options {
+        option(&descriptor)
+    }
+
+    return instance.Describe(routeName, descriptor)
+}
+
+/*
+ * @info registers the typed handler with http.HandleTyped, which the generator already describes from the route, and adds
+ * the describe options (summary, tags, extra responses) under the route name.
+ */
+func HandleTyped(
+    routeHandler httpcontract.RouteHandler,
+    registry *Registry,
+    pattern string,
+    routeOptions httpcontract.RouteOptions,
+    typedHandler httpcontract.TypedHandler,
+    options ...DescribeOption,
+) {
+    if 0 < len(options) && (nil == routeOptions || "" == routeOptions.Name()) {
+        exception.Panic(
+            exception.NewError(
+                "typed handler routes must be named to be described",
+                exceptioncontract.Context{
+                    "pattern": pattern,
+                },
+                nil,
+            ),
+        )
+    }
+
+    melodyhttp.HandleTyped(routeHandler, pattern, routeOptions, typedHandler)
+
+    if 0 == len(options) {
+        return
+    }
+
+    /* @info route groups apply their name prefix to routeOptions while registering, so the name is read afterwards. */
+    registry.DescribeHandler(routeOptions.Name(), typedHandler, options...)
+}
+
+func describeTypedHandler(typedHandler httpcontract.TypedHandler) Descriptor {
+    return Descriptor{
+        RequestType: typedHandler.RequestType(),
+        Responses: map[int]reflect.Type{
+            typedHandler.Status(): typedHandler.ResponseType(),
+        },
+    }
+}
@@ -1,8 +1,13 @@
 package openapi
 
 import (
+    "mime/multipart"
     nethttp "net/http"
     "testing"
+
+    melodyhttp "github.com/precision-soft/melody/v3/http"
+    httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
 )
 
 type describeTypedRequest struct {
@@ -41,3 +46,126 @@ func TestDescribeTyped_RegistersRequestAndResponseTypes(t *testing.T) {
         t.Fatalf("unexpected summary %q", descriptor.Summary)
     }
 }
+
+type describeHandlerRequest struct {
+    Id     int    `path:"id"`
+    Page   int    `query:"page" default:"1"`
+    Tenant string `header:"X-Tenant" validate:"notBlank"`
+    Name   string `json:"name" validate:"notBlank"`
+}
+
+type describeHandlerUploadRequest struct {
+    Title string                `form:"title" validate:"notBlank"`
+    File  *multipart.FileHeader `form:"file"`
+}
+
+func TestDescribeHandler_EmitsParametersAndBodyFromBindingTags(t *testing.T) {
+    registry := NewRegistry()
+
+    registry.DescribeHandler(
+        "products.update",
+        melodyhttp.NewTypedHandler(
+            func(runtimeInstance runtimecontract.Runtime, request httpcontract.Request, input describeHandlerRequest) (describeTypedResponse, error) {
+                return describeTypedResponse{}, nil
+            },
+        ),
+    )
+    registry.DescribeHandler(
+        "products.upload",
+        melodyhttp.NewTypedHandler(
+            func(runtimeInstance runtimecontract.Runtime, request httpcontract.Request, input describeHandlerUploadRequest) (describeTypedResponse, error) {
+                return describeTypedResponse{}, nil
+            },
+            melodyhttp.WithTypedHandlerStatus(nethttp.StatusCreated),
+        ),
+    )
+
+    routes := []httpcontract.RouteDefinition{
+        fakeRoute{name: "products.update", pattern: "/products/:id", methods: []string{"PUT"}},
+        fakeRoute{name: "products.upload", pattern: "/products/upload", methods: []string{"POST"}},
+    }
+
+    document := Generate(Info{Title: "Example", Version: "1.0.0"}, routes, registry)
+
+    update := document.Paths["/products/{id}"].Put
+    if nil == update || 3 != len(update.Parameters) {
+        t.Fatalf("expected path, query and header parameters, got %+v", update)
+    }
+
+    if "path" != update.Parameters[0].In || "integer" != update.Parameters[0].Schema.Type {
+        t.Fatalf("expected the path parameter schema to follow the field type, got %+v", update.Parameters[0])
+    }
+
+    if "query" != update.Parameters[1].In || "page" != update.Parameters[1].Name || true == update.Parameters[1].Required {
+        t.Fatalf("unexpected query parameter %+v", update.Parameters[1])
+    }
+
+    if "header" != update.Parameters[2].In || "X-Tenant" != update.Parameters[2].Name || false == update.Parameters[2].Required {
+        t.Fatalf("unexpected header parameter %+v", update.Parameters[2])
+    }
+
+    schema := document.Components.Schemas["describeHandlerRequest"]
+    if nil == schema || 1 != len(schema.Properties) || nil == schema.Properties["name"] {
+        t.Fatalf("expected only the json body fields in the request schema, got %+v", schema)
+    }
+
+    upload := document.Paths["/products/upload"].Post
+    if nil == upload || nil == upload.RequestBody {
+        t.Fatalf("expected an upload request body")
+    }
+
+    formSchema := upload.RequestBody.Content["multipart/form-data"].Schema
+    if nil == formSchema || "binary" != formSchema.Properties["file"].Format || false == containsString(formSchema.Required, "title") {
+        t.Fatalf("unexpected multipart schema %+v", formSchema)
+    }
+
+    if _, hasCreated := upload.Responses["201"]; false == hasCreated {
+        t.Fatalf("expected the typed handler status to be documented")
+    }
+}
+
+func TestGenerate_DescribesTypedHandlersRegisteredOnTheRouter(t *testing.T) {
+    router := melodyhttp.NewRouter()
+    group := router.Group("/api")
+    group.WithNamePrefix("api.")
+
+    melodyhttp.HandleTyped(
+        group,
+        "/products/:id",
+        melodyhttp.NewRouteOptions("products.update", []string{nethttp.MethodPut}, "", nil, nil, nil, nil, 0, nil),
+        melodyhttp.NewTypedHandler(
+            func(runtimeInstance runtimecontract.Runtime, request httpcontract.Request, input describeHandlerRequest) (describeTypedResponse, error) {
+                return describeTypedResponse{}, nil
+            },
+            melodyhttp.WithTypedHandlerStatus(nethttp.StatusAccepted),
+        ),
+    )
+
+    HandleTyped(
+        router,
+        NewRegistry(),
+        "/products",
+        melodyhttp.NewRouteOptions("products.create", []string{nethttp.MethodPost}, "", nil, nil, nil, nil, 0, nil),
+        melodyhttp.NewTypedHandler(
+            func(runtimeInstance runtimecontract.Runtime, request httpcontract.Request, input describeTypedRequest) (describeTypedResponse, error) {
+                return describeTypedResponse{}, nil
+            },
+        ),
+    )
+
+    document := Generate(Info{Title: "Example", Version: "1.0.0"}, router.RouteDefinitions(), nil)
+
+    update := document.Paths["/api/products/{id}"].Put
+    if nil == update || 3 != len(update.Parameters) {
+        t.Fatalf("expected the typed handler inputs without a registry entry, got %+v", update)
+    }
+
+    if _, hasAccepted := update.Responses["202"]; false == hasAccepted {
+        t.Fatalf("expected the typed handler status to be documented")
+    }
+
+    create := document.Paths["/products"].Post
+    if nil == create || nil == create.RequestBody {
+        t.Fatalf("expected the request body of the typed handler, got %+v", create)
+    }
+}
@@ -6,6 +6,7 @@
     "strconv"
     "strings"
 
+    melodyhttp "github.com/precision-soft/melody/v3/http"
     httpcontract "github.com/precision-soft/melody/v3/http/contract"
 )
 
@@ -28,8 +29,18 @@ func Generate(
 
         descriptor := Descriptor{}
         hasDescriptor := false
+        var problemStatuses []int
         if nil != registry {
             descriptor, hasDescriptor = registry.Get(routeDefinition.Name())
+            problemStatuses = registry.ProblemStatuses()
+        }
+
+        if false == hasDescriptor {
+            descriptor, hasDescriptor = typedHandlerDescriptor(routeDefinition)
+        }
+
+        if true == hasDescriptor {
+            problemStatuses = append(problemStatuses, descriptor.ProblemResponses...)
         }
 
         methods := routeDefinition.Methods()
@@ -38,6 +49,7 @@ func Generate(
         for _, method := range methods {
             operationId := operationIdFor(routeDefinition.Name(), method, len(methods))
             operation := buildOperation(operationId, method, pathParameters, descriptor, hasDescriptor, components, componentNames)
+            addProblemResponses(operation, problemStatuses, components, componentNames)
             assignOperation(&pathItem, method, operation)
         }
 
@@ -51,6 +63,16 @@ func Generate(
     return document
 }
 
+/* @info routes registered with http.HandleTyped carry their typed handler, so they are described without a registry entry. */
+func typedHandlerDescriptor(routeDefinition httpcontract.RouteDefinition) (Descriptor, bool) {
+    typedHandler, isTypedHandler := routeDefinition.Attributes()[melodyhttp.RouteAttributeTypedHandler].(httpcontract.TypedHandler)
+    if false == isTypedHandler {
+        return Descriptor{}, false
+    }
+
+    return describeTypedHandler(typedHandler), true
+}
+
 func operationIdFor(routeName string, method string, methodCount int) string {
     if methodCount <= 1 {
         return routeName
@@ -88,12 +110,21 @@ func buildOperation(
         operation.Description = descriptor.Description
         operation.Tags = descriptor.Tags
 
-        if nil != descriptor.RequestType && true == methodAcceptsRequestBody(method) {
-            operation.RequestBody = &RequestBody{
-                Required: true,
-                Content: map[string]MediaType{
-                    "application/json": {Schema: schemaFromType(descriptor.RequestType, components, names)},
-                },
+        if nil != descriptor.RequestType {
+            bindings := collectRequestBindings(descriptor.RequestType)
+            operation.Parameters = applyRequestBindingParameters(operation.Parameters, bindings, components, names)
+
+            if true == methodAcceptsRequestBody(method) {
+                if 0 < len(bindings.formFields) {
+                    operation.RequestBody = formRequestBody(bindings, components, names)
+                } else if false == bindings.bound || true == bindings.bodyFields {
+                    operation.RequestBody = &RequestBody{
+                        Required: true,
+                        Content: map[string]MediaType{
+                            "application/json": {Schema: schemaFromType(descriptor.RequestType, components, names)},
+                        },
+                    }
+                }
             }
         }
 
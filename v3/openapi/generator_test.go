@@ -602,3 +602,40 @@ func TestGenerate_PointerNumericWithBoundIsNotNullable(t *testing.T) {
         t.Fatalf("expected a bound-less pointer field to remain nullable, got: %+v", optional)
     }
 }
+
+func TestGenerate_ProblemResponsesReferenceProblemDetailsSchema(t *testing.T) {
+    registry := NewRegistry().WithProblemDetails(500)
+    registry.Describe("products.create", Descriptor{
+        RequestType:      TypeOf[createProductRequest](),
+        Responses:        map[int]reflect.Type{201: TypeOf[productResponse]()},
+        ProblemResponses: []int{400},
+    })
+
+    routes := []httpcontract.RouteDefinition{
+        fakeRoute{name: "products.create", pattern: "/products", methods: []string{"POST"}},
+    }
+
+    document := Generate(Info{Title: "Example", Version: "1.0.0"}, routes, registry)
+
+    operation := document.Paths["/products"].Post
+    for _, status := range []string{"400", "500"} {
+        response, exists := operation.Responses[status]
+        if false == exists {
+            t.Fatalf("expected a %s problem response", status)
+        }
+
+        mediaType, hasProblemJson := response.Content["application/problem+json"]
+        if false == hasProblemJson || "#/components/schemas/ProblemDetails" != mediaType.Schema.Ref {
+            t.Fatalf("expected the %s response to reference the problem details schema, got: %+v", status, response)
+        }
+    }
+
+    schema := document.Components.Schemas["ProblemDetails"]
+    if nil == schema || false == containsString(schema.Required, "status") || nil == schema.Properties["errors"] || nil != schema.Properties["Extensions"] {
+        t.Fatalf("unexpected problem details schema: %+v", schema)
+    }
+
+    if nil == document.Components.Schemas["ProblemDetailsError"] {
+        t.Fatalf("expected the validation error item schema")
+    }
+}
@@ -0,0 +1,55 @@
+package openapi
+
+import (
+    nethttp "net/http"
+    "reflect"
+    "strconv"
+
+    melodyhttp "github.com/precision-soft/melody/v3/http"
+)
+
+const problemDetailsComponentName = "ProblemDetails"
+
+func addProblemResponses(
+    operation *Operation,
+    statuses []int,
+    components map[string]*Schema,
+    names map[reflect.Type]string,
+) {
+    for _, status := range statuses {
+        statusKey := strconv.Itoa(status)
+        if _, exists := operation.Responses[statusKey]; true == exists {
+            continue
+        }
+
+        operation.Responses[statusKey] = ResponseObject{
+            Description: nethttp.StatusText(status),
+            Content: map[string]MediaType{
+                melodyhttp.ContentTypeProblemJson: {Schema: problemDetailsSchema(components, names)},
+            },
+        }
+    }
+}
+
+/* @info the schema is derived from http.ProblemDetails so the document matches the rendered responses; extension members are allowed as additional properties. */
+func problemDetailsSchema(components map[string]*Schema, names map[reflect.Type]string) *Schema {
+    problemDetailsType := TypeOf[melodyhttp.ProblemDetails]()
+
+    if _, assigned := names[problemDetailsType]; false == assigned && false == componentNameInUse(problemDetailsComponentName, names) {
+        names[problemDetailsType] = problemDetailsComponentName
+    }
+
+    reference := schemaFromType(problemDetailsType, components, names)
+
+    componentSchema := components[names[problemDetailsType]]
+    if nil != componentSchema && 0 == len(componentSchema.Required) {
+        componentSchema.Required = []string{"type", "title", "status"}
+        componentSchema.AdditionalProperties = &Schema{}
+
+        if typeSchema, exists := componentSchema.Properties["type"]; true == exists {
+            typeSchema.Format = "uri-reference"
+        }
+    }
+
+    return reference
+}
@@ -14,6 +14,8 @@ type Descriptor struct {
     Tags        []string
     RequestType reflect.Type
     Responses   map[int]reflect.Type
+    /* @info statuses documented as application/problem+json (see http.ProblemDetails) */
+    ProblemResponses []int
 }
 
 func NewRegistry() *Registry {
@@ -24,6 +26,7 @@ func NewRegistry() *Registry {
 
 type Registry struct {
     descriptorsByRoute map[string]Descriptor
+    problemStatuses    []int
 }
 
 func (instance *Registry) Describe(routeName string, descriptor Descriptor) *Registry {
@@ -35,3 +38,13 @@ func (instance *Registry) Get(routeName string) (Descriptor, bool) {
     descriptor, exists := instance.descriptorsByRoute[routeName]
     return descriptor, exists
 }
+
+/* @info documents the given statuses as application/problem+json responses on every operation, matching an application that enabled problem details. */
+func (instance *Registry) WithProblemDetails(statuses ...int) *Registry {
+    instance.problemStatuses = append(instance.problemStatuses, statuses...)
+    return instance
+}
+
+func (instance *Registry) ProblemStatuses() []int {
+    return append([]int(nil), instance.problemStatuses...)
+}
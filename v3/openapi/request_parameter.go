@@ -0,0 +1,168 @@
+package openapi
+
+import (
+    "mime/multipart"
+    "reflect"
+
+    melodyhttp "github.com/precision-soft/melody/v3/http"
+)
+
+var fileHeaderType = reflect.TypeOf((*multipart.FileHeader)(nil))
+
+type requestBindings struct {
+    parameters []requestBindingField
+    formFields []requestBindingField
+    multipart  bool
+    bound      bool
+    bodyFields bool
+}
+
+type requestBindingField struct {
+    field  reflect.StructField
+    source string
+    name   string
+}
+
+func collectRequestBindings(requestType reflect.Type) requestBindings {
+    bindings := requestBindings{}
+
+    structType := dereferencedStructType(requestType)
+    if reflect.Struct != structType.Kind() || structType == timeType {
+        bindings.bodyFields = true
+
+        return bindings
+    }
+
+    collectRequestBindingFields(structType, &bindings, make(map[reflect.Type]bool))
+
+    return bindings
+}
+
+func collectRequestBindingFields(structType reflect.Type, bindings *requestBindings, visited map[reflect.Type]bool) {
+    if true == visited[structType] {
+        return
+    }
+
+    visited[structType] = true
+
+    for index := 0; index < structType.NumField(); index++ {
+        field := structType.Field(index)
+
+        source, name, bound := melodyhttp.RequestBindingTag(field)
+        if false == bound {
+            if true == field.Anonymous && reflect.Struct == field.Type.Kind() && field.Type != timeType {
+                collectRequestBindingFields(field.Type, bindings, visited)
+                continue
+            }
+
+            if true == field.IsExported() {
+                _, omit := jsonFieldName(field)
+                if false == omit {
+                    bindings.bodyFields = true
+                }
+            }
+
+            continue
+        }
+
+        if false == field.IsExported() {
+            continue
+        }
+
+        bindings.bound = true
+
+        bindingField := requestBindingField{
+            field:  field,
+            source: source,
+            name:   name,
+        }
+
+        if melodyhttp.BindingTagForm == source {
+            bindings.formFields = append(bindings.formFields, bindingField)
+
+            if field.Type == fileHeaderType || (reflect.Slice == field.Type.Kind() && field.Type.Elem() == fileHeaderType) {
+                bindings.multipart = true
+            }
+
+            continue
+        }
+
+        bindings.parameters = append(bindings.parameters, bindingField)
+    }
+}
+
+func applyRequestBindingParameters(
+    parameters []Parameter,
+    bindings requestBindings,
+    components map[string]*Schema,
+    names map[reflect.Type]string,
+) []Parameter {
+    parameters = append([]Parameter(nil), parameters...)
+
+    for _, bindingField := range bindings.parameters {
+        schema := buildSchema(bindingField.field.Type, components, names, make(map[reflect.Type]bool))
+        applyValidation(schema, bindingField.field.Tag.Get("validate"))
+
+        if melodyhttp.BindingTagPath == bindingField.source {
+            replaced := false
+            for index := range parameters {
+                if "path" == parameters[index].In && bindingField.name == parameters[index].Name {
+                    parameters[index].Schema = schema
+                    replaced = true
+                }
+            }
+
+            if true == replaced {
+                continue
+            }
+        }
+
+        _, hasDefault := bindingField.field.Tag.Lookup(melodyhttp.BindingTagDefault)
+
+        parameters = append(parameters, Parameter{
+            Name:     bindingField.name,
+            In:       bindingField.source,
+            Required: melodyhttp.BindingTagPath == bindingField.source || (false == hasDefault && true == isRequired(bindingField.field.Tag.Get("validate"))),
+            Schema:   schema,
+        })
+    }
+
+    return parameters
+}
+
+func formRequestBody(bindings requestBindings, components map[string]*Schema, names map[reflect.Type]string) *RequestBody {
+    schema := &Schema{
+        Type:       "object",
+        Properties: make(map[string]*Schema),
+    }
+
+    for _, bindingField := range bindings.formFields {
+        var propertySchema *Schema
+        if bindingField.field.Type == fileHeaderType {
+            propertySchema = &Schema{Type: "string", Format: "binary"}
+        } else if reflect.Slice == bindingField.field.Type.Kind() && bindingField.field.Type.Elem() == fileHeaderType {
+            propertySchema = &Schema{Type: "array", Items: &Schema{Type: "string", Format: "binary"}}
+        } else {
+            propertySchema = buildSchema(bindingField.field.Type, components, names, make(map[reflect.Type]bool))
+            applyValidation(propertySchema, bindingField.field.Tag.Get("validate"))
+        }
+
+        schema.Properties[bindingField.name] = propertySchema
+
+        if true == isRequired(bindingField.field.Tag.Get("validate")) {
+            schema.Required = append(schema.Required, bindingField.name)
+        }
+    }
+
+    mediaType := "application/x-www-form-urlencoded"
+    if true == bindings.multipart {
+        mediaType = "multipart/form-data"
+    }
+
+    return &RequestBody{
+        Required: true,
+        Content: map[string]MediaType{
+            mediaType: {Schema: schema},
+        },
+    }
+}
@@ -6,6 +6,8 @@
     "strconv"
     "strings"
     "time"
+
+    melodyhttp "github.com/precision-soft/melody/v3/http"
 )
 
 var timeType = reflect.TypeOf(time.Time{})
@@ -161,7 +163,7 @@ func collectStructFields(
             continue
         }
 
-        if false == field.IsExported() {
+        if false == field.IsExported() || true == isRequestBoundField(field) {
             continue
         }
 
@@ -219,7 +221,7 @@ func collectStructFields(
                     continue
                 }
 
-                if false == field.IsExported() {
+                if false == field.IsExported() || true == isRequestBoundField(field) {
                     continue
                 }
 
@@ -352,6 +354,13 @@ func isPromotedEmbed(field reflect.StructField) bool {
     return reflect.Struct == embedded.Kind() && embedded != timeType
 }
 
+/* @info fields bound from path, query, header, cookie or form inputs (see http.BindRequest) are not part of the json body. */
+func isRequestBoundField(field reflect.StructField) bool {
+    _, _, bound := melodyhttp.RequestBindingTag(field)
+
+    return bound
+}
+
 func withNullable(schema *Schema, nullable bool) *Schema {
     if false == nullable {
         return schema
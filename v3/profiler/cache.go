@@ -0,0 +1,198 @@
+package profiler
+
+import (
+    "strings"
+    "time"
+
+    cachecontract "github.com/precision-soft/melody/v3/cache/contract"
+)
+
+/*
+ * NewProfilingCache returns a cache recording every operation of inner into profile. The decorator implements
+ * cachecontract.TagAwareCache and cachecontract.PrefixClearer exactly when inner does, so capability checks on the
+ * decorated cache answer like on inner.
+ */
+func NewProfilingCache(inner cachecontract.Cache, profile *Profile) cachecontract.Cache {
+    base := &profilingCache{
+        inner:   inner,
+        profile: profile,
+    }
+
+    _, isTagAware := inner.(cachecontract.TagAwareCache)
+    _, isPrefixClearer := inner.(cachecontract.PrefixClearer)
+
+    switch {
+    case true == isTagAware && true == isPrefixClearer:
+        return &profilingTagAwarePrefixClearerCache{profilingTagAwareCache: &profilingTagAwareCache{profilingCache: base}}
+    case true == isTagAware:
+        return &profilingTagAwareCache{profilingCache: base}
+    case true == isPrefixClearer:
+        return &profilingPrefixClearerCache{profilingCache: base}
+    default:
+        return base
+    }
+}
+
+type profilingCache struct {
+    inner   cachecontract.Cache
+    profile *Profile
+}
+
+func (instance *profilingCache) Inner() cachecontract.Cache {
+    return instance.inner
+}
+
+func (instance *profilingCache) Get(key string) (any, bool, error) {
+    startedAt := instance.profile.clock.Now()
+    value, exists, getErr := instance.inner.Get(key)
+    instance.record("get", key, exists, startedAt, getErr)
+
+    return value, exists, getErr
+}
+
+func (instance *profilingCache) Set(key string, value any, ttl time.Duration) error {
+    startedAt := instance.profile.clock.Now()
+    setErr := instance.inner.Set(key, value, ttl)
+    instance.record("set", key, false, startedAt, setErr)
+
+    return setErr
+}
+
+func (instance *profilingCache) Delete(key string) error {
+    startedAt := instance.profile.clock.Now()
+    deleteErr := instance.inner.Delete(key)
+    instance.record("delete", key, false, startedAt, deleteErr)
+
+    return deleteErr
+}
+
+func (instance *profilingCache) Has(key string) (bool, error) {
+    startedAt := instance.profile.clock.Now()
+    exists, hasErr := instance.inner.Has(key)
+    instance.record("has", key, exists, startedAt, hasErr)
+
+    return exists, hasErr
+}
+
+func (instance *profilingCache) Clear() error {
+    startedAt := instance.profile.clock.Now()
+    clearErr := instance.inner.Clear()
+    instance.record("clear", "", false, startedAt, clearErr)
+
+    return clearErr
+}
+
+/* @info Many records one entry per key so hits and misses are counted per key. */
+func (instance *profilingCache) Many(keys []string) (map[string]any, error) {
+    startedAt := instance.profile.clock.Now()
+    values, manyErr := instance.inner.Many(keys)
+
+    for _, key := range keys {
+        _, exists := values[key]
+        instance.record("many", key, exists, startedAt, manyErr)
+    }
+
+    return values, manyErr
+}
+
+func (instance *profilingCache) SetMultiple(items map[string]any, ttl time.Duration) error {
+    startedAt := instance.profile.clock.Now()
+    setMultipleErr := instance.inner.SetMultiple(items, ttl)
+
+    for key := range items {
+        instance.record("set_multiple", key, false, startedAt, setMultipleErr)
+    }
+
+    return setMultipleErr
+}
+
+func (instance *profilingCache) DeleteMultiple(keys []string) error {
+    startedAt := instance.profile.clock.Now()
+    deleteMultipleErr := instance.inner.DeleteMultiple(keys)
+    instance.record("delete_multiple", strings.Join(keys, ","), false, startedAt, deleteMultipleErr)
+
+    return deleteMultipleErr
+}
+
+func (instance *profilingCache) Increment(key string, delta int64) (int64, error) {
+    startedAt := instance.profile.clock.Now()
+    value, incrementErr := instance.inner.Increment(key, delta)
+    instance.record("increment", key, false, startedAt, incrementErr)
+
+    return value, incrementErr
+}
+
+func (instance *profilingCache) Decrement(key string, delta int64) (int64, error) {
+    startedAt := instance.profile.clock.Now()
+    value, decrementErr := instance.inner.Decrement(key, delta)
+    instance.record("decrement", key, false, startedAt, decrementErr)
+
+    return value, decrementErr
+}
+
+/* @important the decorator only lives for one request; the inner cache is shared and is not closed with it. */
+func (instance *profilingCache) Close() error {
+    return nil
+}
+
+func (instance *profilingCache) record(operation string, key string, hit bool, startedAt time.Time, err error) {
+    instance.profile.RecordCache(
+        CacheRecord{
+            Operation: operation,
+            Key:       key,
+            Hit:       hit,
+            Duration:  instance.profile.clock.Now().Sub(startedAt),
+            Error:     errorString(err),
+        },
+    )
+}
+
+type profilingTagAwareCache struct {
+    *profilingCache
+}
+
+func (instance *profilingTagAwareCache) SetWithTags(key string, value any, ttl time.Duration, tags []string) error {
+    startedAt := instance.profile.clock.Now()
+    setErr := instance.inner.(cachecontract.TagAwareCache).SetWithTags(key, value, ttl, tags)
+    instance.record("set_with_tags", key, false, startedAt, setErr)
+
+    return setErr
+}
+
+func (instance *profilingTagAwareCache) InvalidateTags(tags ...string) error {
+    startedAt := instance.profile.clock.Now()
+    invalidateErr := instance.inner.(cachecontract.TagAwareCache).InvalidateTags(tags...)
+    instance.record("invalidate_tags", strings.Join(tags, ","), false, startedAt, invalidateErr)
+
+    return invalidateErr
+}
+
+type profilingPrefixClearerCache struct {
+    *profilingCache
+}
+
+func (instance *profilingPrefixClearerCache) ClearByPrefix(prefix string) error {
+    return clearByPrefix(instance.profilingCache, prefix)
+}
+
+type profilingTagAwarePrefixClearerCache struct {
+    *profilingTagAwareCache
+}
+
+func (instance *profilingTagAwarePrefixClearerCache) ClearByPrefix(prefix string) error {
+    return clearByPrefix(instance.profilingCache, prefix)
+}
+
+func clearByPrefix(instance *profilingCache, prefix string) error {
+    startedAt := instance.profile.clock.Now()
+    clearErr := instance.inner.(cachecontract.PrefixClearer).ClearByPrefix(prefix)
+    instance.record("clear_by_prefix", prefix, false, startedAt, clearErr)
+
+    return clearErr
+}
+
+var _ cachecontract.Cache = (*profilingCache)(nil)
+var _ cachecontract.TagAwareCache = (*profilingTagAwareCache)(nil)
+var _ cachecontract.PrefixClearer = (*profilingPrefixClearerCache)(nil)
+var _ cachecontract.TagAwareCache = (*profilingTagAwarePrefixClearerCache)(nil)
+var _ cachecontract.PrefixClearer = (*profilingTagAwarePrefixClearerCache)(nil)
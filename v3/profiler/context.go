@@ -0,0 +1,32 @@
+package profiler
+
+import (
+    "context"
+
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+type profileContextKey struct{}
+
+func ContextWithProfile(ctx context.Context, profile *Profile) context.Context {
+    return context.WithValue(ctx, profileContextKey{}, profile)
+}
+
+/* FromContext returns the profile of the request the context belongs to, or nil when the request is not profiled. */
+func FromContext(ctx context.Context) *Profile {
+    if nil == ctx {
+        return nil
+    }
+
+    profile, _ := ctx.Value(profileContextKey{}).(*Profile)
+
+    return profile
+}
+
+func FromRuntime(runtimeInstance runtimecontract.Runtime) *Profile {
+    if nil == runtimeInstance {
+        return nil
+    }
+
+    return FromContext(runtimeInstance.Context())
+}
@@ -0,0 +1,203 @@
+package profiler
+
+import (
+    "bytes"
+    "html/template"
+    nethttp "net/http"
+    "strconv"
+    "time"
+
+    "github.com/precision-soft/melody/v3/http"
+    httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+const (
+    defaultListLimit = 50
+    maxListLimit     = 1000
+)
+
+/*
+ * RegisterRoutes serves `GET <prefix>` (the last profiles, `?limit=` defaults to 50) and `GET <prefix>/:token` (one
+ * profile). Both answer html to browsers and json otherwise (`?format=json` forces json).
+ */
+func (instance *Profiler) RegisterRoutes(router httpcontract.Router) {
+    router.Handle(nethttp.MethodGet, instance.config.PathPrefix(), instance.ListHandler())
+    router.Handle(nethttp.MethodGet, instance.config.PathPrefix()+"/:token", instance.ShowHandler())
+}
+
+func (instance *Profiler) ListHandler() httpcontract.Handler {
+    return func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+        limit := defaultListLimit
+        if limitValue := request.HttpRequest().URL.Query().Get("limit"); "" != limitValue {
+            parsedLimit, parseErr := strconv.Atoi(limitValue)
+            if nil != parseErr || 0 >= parsedLimit {
+                return http.JsonErrorResponse(nethttp.StatusBadRequest, "invalid limit"), nil
+            }
+
+            limit = min(parsedLimit, maxListLimit)
+        }
+
+        profiles := instance.config.Storage().List(limit)
+
+        if false == prefersHtml(request) {
+            summaries := make([]profileSummary, 0, len(profiles))
+            for _, profile := range profiles {
+                summaries = append(summaries, newProfileSummary(profile))
+            }
+
+            return http.JsonResponse(nethttp.StatusOK, map[string]any{"profiles": summaries})
+        }
+
+        return renderHtml(
+            listTemplate,
+            map[string]any{
+                "pathPrefix": instance.config.PathPrefix(),
+                "profiles":   profiles,
+            },
+        )
+    }
+}
+
+func (instance *Profiler) ShowHandler() httpcontract.Handler {
+    return func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+        token, _ := request.Param("token")
+
+        profile, exists := instance.config.Storage().Find(token)
+        if false == exists {
+            if true == prefersHtml(request) {
+                return http.HtmlResponse(nethttp.StatusNotFound, "profile not found"), nil
+            }
+
+            return http.JsonErrorResponse(nethttp.StatusNotFound, "profile not found"), nil
+        }
+
+        if false == prefersHtml(request) {
+            return http.JsonResponse(nethttp.StatusOK, profile)
+        }
+
+        return renderHtml(
+            showTemplate,
+            map[string]any{
+                "pathPrefix": instance.config.PathPrefix(),
+                "profile":    profile,
+            },
+        )
+    }
+}
+
+type profileSummary struct {
+    Token      string        `json:"token"`
+    Method     string        `json:"method"`
+    Url        string        `json:"url"`
+    RouteName  string        `json:"routeName"`
+    StatusCode int           `json:"statusCode"`
+    StartedAt  time.Time     `json:"startedAt"`
+    Duration   time.Duration `json:"duration"`
+}
+
+func newProfileSummary(profile *Profile) profileSummary {
+    return profileSummary{
+        Token:      profile.Token(),
+        Method:     profile.Method(),
+        Url:        profile.Url(),
+        RouteName:  profile.RouteName(),
+        StatusCode: profile.StatusCode(),
+        StartedAt:  profile.StartedAt(),
+        Duration:   profile.Duration(),
+    }
+}
+
+func prefersHtml(request httpcontract.Request) bool {
+    if "json" == request.HttpRequest().URL.Query().Get("format") {
+        return false
+    }
+
+    return http.PrefersHtml(request)
+}
+
+func renderHtml(htmlTemplate *template.Template, data map[string]any) (httpcontract.Response, error) {
+    buffer := &bytes.Buffer{}
+
+    executeErr := htmlTemplate.Execute(buffer, data)
+    if nil != executeErr {
+        return nil, executeErr
+    }
+
+    return http.HtmlResponse(nethttp.StatusOK, buffer.String()), nil
+}
+
+var templateFunctions = template.FuncMap{
+    "milliseconds": func(duration time.Duration) string {
+        return strconv.FormatFloat(float64(duration)/float64(time.Millisecond), 'f', 2, 64) + " ms"
+    },
+    "time": func(value time.Time) string {
+        return value.Format("2006-01-02 15:04:05.000")
+    },
+}
+
+const layoutHead = `<!doctype html><html><head><meta charset="utf-8"><title>melody profiler</title>
+<style>body{font:13px/1.5 sans-serif;margin:24px;color:#222}table{border-collapse:collapse;width:100%;margin-bottom:24px}
+th,td{border-bottom:1px solid #ddd;padding:4px 8px;text-align:left;vertical-align:top}th{background:#f4f4f4}
+code{font-size:12px;white-space:pre-wrap;word-break:break-all}.error{color:#b00}</style></head><body>`
+
+var listTemplate = template.Must(template.New("list").Funcs(templateFunctions).Parse(layoutHead + `
+<h1>melody profiler</h1>
+<table><tr><th>token</th><th>time</th><th>method</th><th>url</th><th>route</th><th>status</th><th>duration</th></tr>
+{{range .profiles}}<tr><td><a href="{{$.pathPrefix}}/{{.Token}}">{{.Token}}</a></td><td>{{time .StartedAt}}</td><td>{{.Method}}</td>
+<td><code>{{.Url}}</code></td><td>{{.RouteName}}</td><td>{{.StatusCode}}</td><td>{{milliseconds .Duration}}</td></tr>
+{{else}}<tr><td colspan="7">no profiles yet</td></tr>{{end}}
+</table></body></html>`))
+
+var showTemplate = template.Must(template.New("show").Funcs(templateFunctions).Parse(layoutHead + `
+{{with .profile}}
+<p><a href="{{$.pathPrefix}}">all profiles</a> · <a href="{{$.pathPrefix}}/{{.Token}}?format=json">json</a></p>
+<h1>{{.Method}} <code>{{.Url}}</code></h1>
+<table>
+<tr><th>token</th><td>{{.Token}}</td><th>request id</th><td>{{.RequestId}}</td></tr>
+<tr><th>route</th><td>{{.RouteName}} <code>{{.RoutePattern}}</code></td><th>status</th><td>{{.StatusCode}}</td></tr>
+<tr><th>started at</th><td>{{time .StartedAt}}</td><th>duration</th><td>{{milliseconds .Duration}}</td></tr>
+{{if .DroppedRecords}}<tr><th>dropped records</th><td colspan="3" class="error">{{.DroppedRecords}}</td></tr>{{end}}
+</table>
+
+<h2>Timeline</h2>
+<table><tr><th>event</th><th>listener</th><th>priority</th><th>duration</th></tr>
+{{range .Events}}<tr><td>{{.EventName}}</td><td><code>{{.ListenerName}}</code></td><td>{{.Priority}}</td><td>{{milliseconds .Duration}}{{if .Error}} <span class="error">{{.Error}}</span>{{end}}</td></tr>{{end}}
+</table>
+
+<h2>Middleware</h2>
+<table><tr><th>middleware</th><th>self</th><th>total</th></tr>
+{{range .Middlewares}}<tr><td><code>{{.Name}}</code></td><td>{{milliseconds .Duration}}</td><td>{{milliseconds .TotalDuration}}</td></tr>{{end}}
+</table>
+
+<h2>Security</h2>
+{{with .Security}}<table><tr><th>firewall</th><td>{{.Firewall}}</td></tr><tr><th>authenticated</th><td>{{.Authenticated}}</td></tr>
+<tr><th>user</th><td>{{.UserIdentifier}}</td></tr><tr><th>roles</th><td>{{range .Roles}}{{.}} {{end}}</td></tr></table>{{else}}<p>no firewall</p>{{end}}
+<table><tr><th>decision</th><th>attributes</th><th>reason</th></tr>
+{{range .AccessDecisions}}<tr><td>{{if .Granted}}granted{{else}}<span class="error">denied</span>{{end}}</td><td>{{range .Attributes}}{{.}} {{end}}</td><td>{{.Reason}}</td></tr>{{end}}
+</table>
+
+<h2>Queries</h2>
+<table><tr><th>connection</th><th>query</th><th>duration</th></tr>
+{{range .Queries}}<tr><td>{{.Connection}}</td><td><code>{{.Query}}</code>{{if .Error}} <span class="error">{{.Error}}</span>{{end}}</td><td>{{milliseconds .Duration}}</td></tr>{{end}}
+</table>
+
+<h2>Cache</h2>
+<table><tr><th>operation</th><th>key</th><th>hit</th><th>duration</th></tr>
+{{range .CacheRecords}}<tr><td>{{.Operation}}</td><td><code>{{.Key}}</code></td><td>{{.Hit}}</td><td>{{milliseconds .Duration}}{{if .Error}} <span class="error">{{.Error}}</span>{{end}}</td></tr>{{end}}
+</table>
+
+<h2>HTTP client</h2>
+<table><tr><th>method</th><th>url</th><th>status</th><th>duration</th></tr>
+{{range .HttpCalls}}<tr><td>{{.Method}}</td><td><code>{{.Url}}</code></td><td>{{.StatusCode}}</td><td>{{milliseconds .Duration}}{{if .Error}} <span class="error">{{.Error}}</span>{{end}}</td></tr>{{end}}
+</table>
+
+<h2>Logs</h2>
+<table><tr><th>time</th><th>level</th><th>message</th><th>context</th></tr>
+{{range .Logs}}<tr><td>{{time .Time}}</td><td>{{.Level}}</td><td>{{.Message}}</td><td><code>{{.Context}}</code></td></tr>{{end}}
+</table>
+
+<h2>Services</h2>
+<table>{{range .Services}}<tr><td><code>{{.}}</code></td></tr>{{end}}</table>
+{{end}}
+</body></html>`))
@@ -0,0 +1,4 @@
+/*
+Package profiler provides the debug-mode web profiler: per-request profiles (timeline, middleware, services, cache, queries, outgoing http calls, logs, security), an in-memory store of the last profiles, the `/_profiler` UI/JSON API, the `X-Debug-Token` response header and the debug toolbar.
+*/
+package profiler
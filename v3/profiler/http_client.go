@@ -0,0 +1,120 @@
+package profiler
+
+import (
+    nethttp "net/http"
+    "time"
+
+    httpclientcontract "github.com/precision-soft/melody/v3/httpclient/contract"
+    "github.com/precision-soft/melody/v3/internal"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+/* NewProfilingHttpClient returns a client recording every call of inner into profile; urls are recorded as passed (relative to the base url of inner). */
+func NewProfilingHttpClient(inner httpclientcontract.Client, profile *Profile) httpclientcontract.Client {
+    return &profilingHttpClient{
+        inner:   inner,
+        profile: profile,
+    }
+}
+
+/* HttpClientFromRuntime returns client recording into the profile of the request, or client itself when the request is not profiled. */
+func HttpClientFromRuntime(runtimeInstance runtimecontract.Runtime, client httpclientcontract.Client) httpclientcontract.Client {
+    profile := FromRuntime(runtimeInstance)
+    if nil == profile {
+        return client
+    }
+
+    return NewProfilingHttpClient(client, profile)
+}
+
+type profilingHttpClient struct {
+    inner   httpclientcontract.Client
+    profile *Profile
+}
+
+func (instance *profilingHttpClient) Get(urlString string, options ...httpclientcontract.RequestOption) (httpclientcontract.Response, error) {
+    startedAt := instance.profile.clock.Now()
+    response, err := instance.inner.Get(urlString, options...)
+    instance.record(nethttp.MethodGet, urlString, response, startedAt, err)
+
+    return response, err
+}
+
+func (instance *profilingHttpClient) Post(urlString string, body any, options ...httpclientcontract.RequestOption) (httpclientcontract.Response, error) {
+    startedAt := instance.profile.clock.Now()
+    response, err := instance.inner.Post(urlString, body, options...)
+    instance.record(nethttp.MethodPost, urlString, response, startedAt, err)
+
+    return response, err
+}
+
+func (instance *profilingHttpClient) Put(urlString string, body any, options ...httpclientcontract.RequestOption) (httpclientcontract.Response, error) {
+    startedAt := instance.profile.clock.Now()
+    response, err := instance.inner.Put(urlString, body, options...)
+    instance.record(nethttp.MethodPut, urlString, response, startedAt, err)
+
+    return response, err
+}
+
+func (instance *profilingHttpClient) Patch(urlString string, body any, options ...httpclientcontract.RequestOption) (httpclientcontract.Response, error) {
+    startedAt := instance.profile.clock.Now()
+    response, err := instance.inner.Patch(urlString, body, options...)
+    instance.record(nethttp.MethodPatch, urlString, response, startedAt, err)
+
+    return response, err
+}
+
+func (instance *profilingHttpClient) Delete(urlString string, options ...httpclientcontract.RequestOption) (httpclientcontract.Response, error) {
+    startedAt := instance.profile.clock.Now()
+    response, err := instance.inner.Delete(urlString, options...)
+    instance.record(nethttp.MethodDelete, urlString, response, startedAt, err)
+
+    return response, err
+}
+
+func (instance *profilingHttpClient) Request(method string, urlString string, options ...httpclientcontract.RequestOption) (httpclientcontract.Response, error) {
+    startedAt := instance.profile.clock.Now()
+    response, err := instance.inner.Request(method, urlString, options...)
+    instance.record(method, urlString, response, startedAt, err)
+
+    return response, err
+}
+
+/* @info for a stream the recorded duration ends when the response headers are received, not when the body is consumed. */
+func (instance *profilingHttpClient) RequestStream(method string, urlString string, options ...httpclientcontract.RequestOption) (httpclientcontract.StreamResponse, error) {
+    startedAt := instance.profile.clock.Now()
+    response, err := instance.inner.RequestStream(method, urlString, options...)
+
+    statusCode := 0
+    if false == internal.IsNilInterface(response) {
+        statusCode = response.StatusCode()
+    }
+
+    instance.recordStatusCode(method, urlString, statusCode, startedAt, err)
+
+    return response, err
+}
+
+func (instance *profilingHttpClient) record(method string, urlString string, response httpclientcontract.Response, startedAt time.Time, err error) {
+    statusCode := 0
+    if false == internal.IsNilInterface(response) {
+        statusCode = response.StatusCode()
+    }
+
+    instance.recordStatusCode(method, urlString, statusCode, startedAt, err)
+}
+
+func (instance *profilingHttpClient) recordStatusCode(method string, urlString string, statusCode int, startedAt time.Time, err error) {
+    instance.profile.RecordHttpCall(
+        HttpCallRecord{
+            Method:     method,
+            Url:        urlString,
+            StatusCode: statusCode,
+            StartedAt:  startedAt,
+            Duration:   instance.profile.clock.Now().Sub(startedAt),
+            Error:      errorString(err),
+        },
+    )
+}
+
+var _ httpclientcontract.Client = (*profilingHttpClient)(nil)
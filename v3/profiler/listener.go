@@ -0,0 +1,149 @@
+package profiler
+
+import (
+    containercontract "github.com/precision-soft/melody/v3/container/contract"
+    eventcontract "github.com/precision-soft/melody/v3/event/contract"
+    "github.com/precision-soft/melody/v3/http"
+    "github.com/precision-soft/melody/v3/internal"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+    "github.com/precision-soft/melody/v3/security"
+    securitycontract "github.com/precision-soft/melody/v3/security/contract"
+)
+
+const (
+    ProfilerListenerPriority = 0
+)
+
+/* ListenerObserver records every synchronous listener call of a profiled request in its timeline. */
+func (instance *Profiler) ListenerObserver() eventcontract.ListenerObserver {
+    return func(runtimeInstance runtimecontract.Runtime, listenerCall eventcontract.ListenerCall) {
+        profile := FromRuntime(runtimeInstance)
+        if nil == profile {
+            return
+        }
+
+        profile.RecordEvent(
+            EventRecord{
+                EventName:    listenerCall.EventName,
+                ListenerName: listenerCall.ListenerName,
+                Priority:     listenerCall.Priority,
+                StartedAt:    listenerCall.StartedAt,
+                Duration:     listenerCall.Duration,
+                Error:        errorString(listenerCall.Err),
+            },
+        )
+    }
+}
+
+/*
+ * RegisterListeners copies the http.EventHttpRequestProfile data (request id, route, status), the services resolved
+ * through the request scope and the security token into the profile, and records the access decisions. It needs
+ * http.RegisterKernelHttpProfilerListener.
+ */
+func (instance *Profiler) RegisterListeners(eventDispatcher eventcontract.EventDispatcher) {
+    eventDispatcher.AddListener(
+        http.EventHttpRequestProfile,
+        func(runtimeInstance runtimecontract.Runtime, eventValue eventcontract.Event) error {
+            profile := FromRuntime(runtimeInstance)
+            if nil == profile {
+                return nil
+            }
+
+            requestProfile, ok := eventValue.Payload().(*http.HttpRequestProfile)
+            if true == ok && nil != requestProfile {
+                profile.setRequest(
+                    requestProfile.RequestId(),
+                    requestProfile.RouteName(),
+                    requestProfile.RoutePattern(),
+                    requestProfile.StatusCode(),
+                )
+            }
+
+            /* @important read the resolved services before looking up the security context, which resolves through the scope itself */
+            resolutionTracker, isResolutionTracker := runtimeInstance.Scope().(containercontract.ResolutionTracker)
+            if true == isResolutionTracker {
+                profile.setServices(resolutionTracker.ResolvedServices())
+            }
+
+            securityContext, exists := security.SecurityContextFromRuntime(runtimeInstance)
+            if true == exists && nil != securityContext {
+                profile.setSecurity(newSecurityRecord(securityContext))
+            }
+
+            return nil
+        },
+        ProfilerListenerPriority,
+    )
+
+    eventDispatcher.AddListener(
+        securitycontract.EventSecurityAuthorizationGranted,
+        func(runtimeInstance runtimecontract.Runtime, eventValue eventcontract.Event) error {
+            profile := FromRuntime(runtimeInstance)
+            if nil == profile {
+                return nil
+            }
+
+            grantedEvent, ok := eventValue.Payload().(*security.AuthorizationGrantedEvent)
+            if false == ok || nil == grantedEvent {
+                return nil
+            }
+
+            profile.RecordAccessDecision(
+                AccessDecisionRecord{
+                    Granted:    true,
+                    Attributes: grantedEvent.Attributes(),
+                },
+            )
+
+            return nil
+        },
+        ProfilerListenerPriority,
+    )
+
+    eventDispatcher.AddListener(
+        securitycontract.EventSecurityAuthorizationDenied,
+        func(runtimeInstance runtimecontract.Runtime, eventValue eventcontract.Event) error {
+            profile := FromRuntime(runtimeInstance)
+            if nil == profile {
+                return nil
+            }
+
+            deniedEvent, ok := eventValue.Payload().(*security.AuthorizationDeniedEvent)
+            if false == ok || nil == deniedEvent {
+                return nil
+            }
+
+            profile.RecordAccessDecision(
+                AccessDecisionRecord{
+                    Granted:    false,
+                    Attributes: deniedEvent.Attributes(),
+                    Reason:     errorString(deniedEvent.Err()),
+                },
+            )
+
+            return nil
+        },
+        ProfilerListenerPriority,
+    )
+}
+
+func newSecurityRecord(securityContext *security.SecurityContext) SecurityRecord {
+    record := SecurityRecord{
+        Roles: []string{},
+    }
+
+    if nil != securityContext.Firewall() {
+        record.Firewall = securityContext.Firewall().Name()
+    }
+
+    token := securityContext.Token()
+    if true == internal.IsNilInterface(token) {
+        return record
+    }
+
+    record.Authenticated = token.IsAuthenticated()
+    record.UserIdentifier = token.UserIdentifier()
+    record.Roles = append(record.Roles, token.Roles()...)
+
+    return record
+}
@@ -0,0 +1,50 @@
+package profiler
+
+import (
+    "github.com/precision-soft/melody/v3/internal"
+    loggingcontract "github.com/precision-soft/melody/v3/logging/contract"
+)
+
+/* NewProfileLogger returns a logger recording every entry into profile; the profiler middleware fans the request logger out to it. */
+func NewProfileLogger(profile *Profile) loggingcontract.Logger {
+    return &profileLogger{
+        profile: profile,
+    }
+}
+
+type profileLogger struct {
+    profile *Profile
+}
+
+func (instance *profileLogger) Log(level loggingcontract.Level, message string, context loggingcontract.Context) {
+    instance.profile.RecordLog(
+        LogRecord{
+            Level:   string(level),
+            Message: message,
+            Context: internal.CopyAnyMap(context),
+            Time:    instance.profile.clock.Now(),
+        },
+    )
+}
+
+func (instance *profileLogger) Debug(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelDebug, message, context)
+}
+
+func (instance *profileLogger) Info(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelInfo, message, context)
+}
+
+func (instance *profileLogger) Warning(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelWarning, message, context)
+}
+
+func (instance *profileLogger) Error(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelError, message, context)
+}
+
+func (instance *profileLogger) Emergency(message string, context loggingcontract.Context) {
+    instance.Log(loggingcontract.LevelEmergency, message, context)
+}
+
+var _ loggingcontract.Logger = (*profileLogger)(nil)
@@ -0,0 +1,342 @@
+package profiler
+
+import (
+    "encoding/json"
+    "sort"
+    "sync"
+    "time"
+
+    clockcontract "github.com/precision-soft/melody/v3/clock/contract"
+)
+
+func newProfile(token string, method string, url string, path string, clockInstance clockcontract.Clock, maxRecords int) *Profile {
+    return &Profile{
+        clock:      clockInstance,
+        token:      token,
+        method:     method,
+        url:        url,
+        path:       path,
+        startedAt:  clockInstance.Now(),
+        maxRecords: maxRecords,
+    }
+}
+
+/*
+ * Profile is the data collected for one request. The collectors append to it while the request is served (listeners,
+ * middleware, decorators, query hooks), so every method is safe for concurrent use; each collector keeps at most
+ * ProfilerConfig.MaxRecords entries and counts the rest in DroppedRecords.
+ */
+type Profile struct {
+    mutex sync.RWMutex
+    clock clockcontract.Clock
+
+    token        string
+    method       string
+    url          string
+    path         string
+    startedAt    time.Time
+    duration     time.Duration
+    requestId    string
+    routeName    string
+    routePattern string
+    statusCode   int
+
+    events          []EventRecord
+    middlewares     []MiddlewareRecord
+    services        []string
+    cacheRecords    []CacheRecord
+    queries         []QueryRecord
+    httpCalls       []HttpCallRecord
+    logs            []LogRecord
+    security        *SecurityRecord
+    accessDecisions []AccessDecisionRecord
+
+    maxRecords     int
+    droppedRecords int
+}
+
+func (instance *Profile) Token() string {
+    return instance.token
+}
+
+func (instance *Profile) Method() string {
+    return instance.method
+}
+
+func (instance *Profile) Url() string {
+    return instance.url
+}
+
+func (instance *Profile) Path() string {
+    return instance.path
+}
+
+func (instance *Profile) StartedAt() time.Time {
+    return instance.startedAt
+}
+
+func (instance *Profile) Duration() time.Duration {
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    return instance.duration
+}
+
+func (instance *Profile) RequestId() string {
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    return instance.requestId
+}
+
+func (instance *Profile) RouteName() string {
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    return instance.routeName
+}
+
+func (instance *Profile) RoutePattern() string {
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    return instance.routePattern
+}
+
+func (instance *Profile) StatusCode() int {
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    return instance.statusCode
+}
+
+/* Events returns the timeline ordered by start time; listeners dispatching nested events finish after them. */
+func (instance *Profile) Events() []EventRecord {
+    instance.mutex.RLock()
+    events := append([]EventRecord{}, instance.events...)
+    instance.mutex.RUnlock()
+
+    sort.SliceStable(
+        events,
+        func(left int, right int) bool {
+            return events[left].StartedAt.Before(events[right].StartedAt)
+        },
+    )
+
+    return events
+}
+
+func (instance *Profile) Middlewares() []MiddlewareRecord {
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    return append([]MiddlewareRecord{}, instance.middlewares...)
+}
+
+func (instance *Profile) Services() []string {
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    return append([]string{}, instance.services...)
+}
+
+func (instance *Profile) CacheRecords() []CacheRecord {
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    return append([]CacheRecord{}, instance.cacheRecords...)
+}
+
+func (instance *Profile) Queries() []QueryRecord {
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    return append([]QueryRecord{}, instance.queries...)
+}
+
+func (instance *Profile) HttpCalls() []HttpCallRecord {
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    return append([]HttpCallRecord{}, instance.httpCalls...)
+}
+
+func (instance *Profile) Logs() []LogRecord {
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    return append([]LogRecord{}, instance.logs...)
+}
+
+/* Security is nil when the request did not go through a firewall. */
+func (instance *Profile) Security() *SecurityRecord {
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    if nil == instance.security {
+        return nil
+    }
+
+    security := *instance.security
+    security.Roles = append([]string{}, instance.security.Roles...)
+
+    return &security
+}
+
+func (instance *Profile) AccessDecisions() []AccessDecisionRecord {
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    return append([]AccessDecisionRecord{}, instance.accessDecisions...)
+}
+
+func (instance *Profile) DroppedRecords() int {
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    return instance.droppedRecords
+}
+
+func (instance *Profile) RecordEvent(record EventRecord) {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.events = appendRecord(instance, instance.events, record)
+}
+
+func (instance *Profile) RecordMiddleware(record MiddlewareRecord) {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.middlewares = appendRecord(instance, instance.middlewares, record)
+}
+
+func (instance *Profile) RecordCache(record CacheRecord) {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.cacheRecords = appendRecord(instance, instance.cacheRecords, record)
+}
+
+/* RecordQuery is the entry point of the database integrations (e.g. the bunorm query hook). */
+func (instance *Profile) RecordQuery(record QueryRecord) {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.queries = appendRecord(instance, instance.queries, record)
+}
+
+func (instance *Profile) RecordHttpCall(record HttpCallRecord) {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.httpCalls = appendRecord(instance, instance.httpCalls, record)
+}
+
+func (instance *Profile) RecordLog(record LogRecord) {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.logs = appendRecord(instance, instance.logs, record)
+}
+
+func (instance *Profile) RecordAccessDecision(record AccessDecisionRecord) {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.accessDecisions = appendRecord(instance, instance.accessDecisions, record)
+}
+
+func (instance *Profile) setRequest(requestId string, routeName string, routePattern string, statusCode int) {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.requestId = requestId
+    instance.routeName = routeName
+    instance.routePattern = routePattern
+    instance.statusCode = statusCode
+}
+
+func (instance *Profile) setServices(services []string) {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.services = append([]string{}, services...)
+}
+
+func (instance *Profile) setSecurity(security SecurityRecord) {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.security = &security
+}
+
+func (instance *Profile) finish() {
+    finishedAt := instance.clock.Now()
+
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.duration = finishedAt.Sub(instance.startedAt)
+}
+
+func (instance *Profile) MarshalJSON() ([]byte, error) {
+    return json.Marshal(
+        profileJson{
+            Token:           instance.Token(),
+            Method:          instance.Method(),
+            Url:             instance.Url(),
+            Path:            instance.Path(),
+            StartedAt:       instance.StartedAt(),
+            Duration:        instance.Duration(),
+            RequestId:       instance.RequestId(),
+            RouteName:       instance.RouteName(),
+            RoutePattern:    instance.RoutePattern(),
+            StatusCode:      instance.StatusCode(),
+            Events:          instance.Events(),
+            Middlewares:     instance.Middlewares(),
+            Services:        instance.Services(),
+            CacheRecords:    instance.CacheRecords(),
+            Queries:         instance.Queries(),
+            HttpCalls:       instance.HttpCalls(),
+            Logs:            instance.Logs(),
+            Security:        instance.Security(),
+            AccessDecisions: instance.AccessDecisions(),
+            DroppedRecords:  instance.DroppedRecords(),
+        },
+    )
+}
+
+type profileJson struct {
+    Token           string                 `json:"token"`
+    Method          string                 `json:"method"`
+    Url             string                 `json:"url"`
+    Path            string                 `json:"path"`
+    StartedAt       time.Time              `json:"startedAt"`
+    Duration        time.Duration          `json:"duration"`
+    RequestId       string                 `json:"requestId"`
+    RouteName       string                 `json:"routeName"`
+    RoutePattern    string                 `json:"routePattern"`
+    StatusCode      int                    `json:"statusCode"`
+    Events          []EventRecord          `json:"events"`
+    Middlewares     []MiddlewareRecord     `json:"middlewares"`
+    Services        []string               `json:"services"`
+    CacheRecords    []CacheRecord          `json:"cache"`
+    Queries         []QueryRecord          `json:"queries"`
+    HttpCalls       []HttpCallRecord       `json:"httpCalls"`
+    Logs            []LogRecord            `json:"logs"`
+    Security        *SecurityRecord        `json:"security"`
+    AccessDecisions []AccessDecisionRecord `json:"accessDecisions"`
+    DroppedRecords  int                    `json:"droppedRecords"`
+}
+
+/* appendRecord must be called with the profile mutex held. */
+func appendRecord[T any](profile *Profile, records []T, record T) []T {
+    if 0 < profile.maxRecords && profile.maxRecords <= len(records) {
+        profile.droppedRecords++
+
+        return records
+    }
+
+    return append(records, record)
+}
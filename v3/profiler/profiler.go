@@ -0,0 +1,246 @@
+package profiler
+
+import (
+    "crypto/rand"
+    "encoding/hex"
+    nethttp "net/http"
+    "strings"
+    "time"
+
+    "github.com/precision-soft/melody/v3/cache"
+    cachecontract "github.com/precision-soft/melody/v3/cache/contract"
+    "github.com/precision-soft/melody/v3/container"
+    "github.com/precision-soft/melody/v3/exception"
+    httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    middlewarepipeline "github.com/precision-soft/melody/v3/http/middleware/pipeline"
+    "github.com/precision-soft/melody/v3/internal"
+    "github.com/precision-soft/melody/v3/logging"
+    loggingcontract "github.com/precision-soft/melody/v3/logging/contract"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+func NewProfiler(config *ProfilerConfig) *Profiler {
+    if nil == config {
+        exception.Panic(exception.NewError("config is required for profiler", nil, nil))
+    }
+
+    if true == internal.IsNilInterface(config.Storage()) {
+        exception.Panic(exception.NewError("storage is required for profiler", nil, nil))
+    }
+
+    if false == strings.HasPrefix(config.PathPrefix(), "/") || "/" == config.PathPrefix() {
+        exception.Panic(
+            exception.NewError(
+                "invalid profiler path prefix",
+                map[string]any{
+                    "pathPrefix": config.PathPrefix(),
+                },
+                nil,
+            ),
+        )
+    }
+
+    if false == logging.IsValidLevel(config.LogLevel()) {
+        exception.Panic(
+            exception.NewError(
+                "invalid profiler log level",
+                map[string]any{
+                    "level": string(config.LogLevel()),
+                },
+                nil,
+            ),
+        )
+    }
+
+    if true == internal.IsNilInterface(config.Clock()) {
+        exception.Panic(exception.NewError("clock is required for profiler", nil, nil))
+    }
+
+    return &Profiler{
+        config: config,
+    }
+}
+
+/*
+ * Profiler wires the collectors of one application: Handler creates the profile of every request, Middleware and
+ * ProfileMiddlewares instrument the kernel pipeline, ListenerObserver and RegisterListeners collect from the event
+ * dispatcher and RegisterRoutes serves the stored profiles. Application.EnableProfiler does the wiring in debug mode.
+ */
+type Profiler struct {
+    config *ProfilerConfig
+}
+
+func (instance *Profiler) Config() *ProfilerConfig {
+    return instance.config
+}
+
+func (instance *Profiler) Storage() Storage {
+    return instance.config.Storage()
+}
+
+/*
+ * Handler profiles every request served by next except the profiler pages: it creates the profile, exposes it through
+ * the request context (FromContext / FromRuntime), sets the X-Debug-Token and X-Debug-Token-Link headers and stores the
+ * profile once next returns.
+ */
+func (instance *Profiler) Handler(next nethttp.Handler) nethttp.Handler {
+    return nethttp.HandlerFunc(
+        func(writer nethttp.ResponseWriter, request *nethttp.Request) {
+            if true == instance.isProfilerPath(request.URL.Path) {
+                next.ServeHTTP(writer, request)
+
+                return
+            }
+
+            profile := newProfile(
+                newToken(),
+                request.Method,
+                request.URL.RequestURI(),
+                request.URL.Path,
+                instance.config.Clock(),
+                instance.config.MaxRecords(),
+            )
+
+            /* @important the headers are set before next runs: once the handler writes the response they can no longer be added */
+            writer.Header().Set(HeaderDebugToken, profile.Token())
+            writer.Header().Set(HeaderDebugTokenLink, instance.profileUrl(profile.Token()))
+
+            defer func() {
+                profile.finish()
+                instance.config.Storage().Save(profile)
+            }()
+
+            next.ServeHTTP(writer, request.WithContext(ContextWithProfile(request.Context(), profile)))
+        },
+    )
+}
+
+/*
+ * Middleware must be the outermost kernel middleware: it fans the request logger out to the profile, replaces the
+ * cache service of the request scope with a recording decorator and injects the toolbar into html responses.
+ */
+func (instance *Profiler) Middleware() httpcontract.Middleware {
+    return func(next httpcontract.Handler) httpcontract.Handler {
+        return func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            profile := FromRuntime(runtimeInstance)
+            if nil == profile {
+                return next(runtimeInstance, writer, request)
+            }
+
+            instance.instrumentScope(runtimeInstance, profile)
+
+            response, err := next(runtimeInstance, writer, request)
+            if nil == err && true == instance.config.Toolbar() {
+                injectToolbar(response, profile, request.RouteName(), instance.profileUrl(profile.Token()))
+            }
+
+            return response, err
+        }
+    }
+}
+
+/* ProfileMiddlewares wraps every middleware so the profile records the time spent in it, excluding the rest of the chain. */
+func (instance *Profiler) ProfileMiddlewares(middlewares []httpcontract.Middleware) []httpcontract.Middleware {
+    profiledMiddlewares := make([]httpcontract.Middleware, 0, len(middlewares))
+    for _, middleware := range middlewares {
+        profiledMiddlewares = append(
+            profiledMiddlewares,
+            profileMiddleware(middlewarepipeline.MiddlewareName(middleware), middleware),
+        )
+    }
+
+    return profiledMiddlewares
+}
+
+func (instance *Profiler) instrumentScope(runtimeInstance runtimecontract.Runtime, profile *Profile) {
+    scope := runtimeInstance.Scope()
+
+    logger := logging.LoggerFromRuntime(runtimeInstance)
+    if nil != logger {
+        overrideErr := scope.OverrideProtectedInstance(
+            logging.ServiceLogger,
+            logging.NewFanOutLogger(
+                logging.NewFanOutSink(logger, loggingcontract.LevelDebug),
+                logging.NewFanOutSink(NewProfileLogger(profile), instance.config.LogLevel()),
+            ),
+        )
+        if nil != overrideErr {
+            logger.Warning("failed to record logs in profiler", exception.LogContext(overrideErr))
+        }
+    }
+
+    serviceContainer := runtimeInstance.Container()
+    if false == serviceContainer.Has(cache.ServiceCache) {
+        return
+    }
+
+    /* @info the cache is resolved from the container, not the scope, so the profiler does not list it among the services of the request */
+    cacheInstance, cacheErr := container.FromResolver[cachecontract.Cache](serviceContainer, cache.ServiceCache)
+    if nil == cacheErr {
+        cacheErr = scope.OverrideProtectedInstance(cache.ServiceCache, NewProfilingCache(cacheInstance, profile))
+    }
+
+    if nil != cacheErr && nil != logger {
+        logger.Warning("failed to record cache operations in profiler", exception.LogContext(cacheErr))
+    }
+}
+
+func (instance *Profiler) isProfilerPath(path string) bool {
+    pathPrefix := instance.config.PathPrefix()
+
+    return pathPrefix == path || strings.HasPrefix(path, pathPrefix+"/")
+}
+
+func (instance *Profiler) profileUrl(token string) string {
+    return instance.config.PathPrefix() + "/" + token
+}
+
+/* @important the kernel builds the middleware chain for every request, so nextDuration is never shared between requests */
+func profileMiddleware(name string, middleware httpcontract.Middleware) httpcontract.Middleware {
+    return func(next httpcontract.Handler) httpcontract.Handler {
+        var nextDuration time.Duration
+
+        wrapped := middleware(
+            func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+                nextStartedAt := time.Now()
+                defer func() {
+                    nextDuration += time.Since(nextStartedAt)
+                }()
+
+                return next(runtimeInstance, writer, request)
+            },
+        )
+
+        return func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            profile := FromRuntime(runtimeInstance)
+            if nil == profile {
+                return wrapped(runtimeInstance, writer, request)
+            }
+
+            startedAt := time.Now()
+            defer func() {
+                totalDuration := time.Since(startedAt)
+
+                profile.RecordMiddleware(
+                    MiddlewareRecord{
+                        Name:          name,
+                        Duration:      totalDuration - nextDuration,
+                        TotalDuration: totalDuration,
+                    },
+                )
+            }()
+
+            return wrapped(runtimeInstance, writer, request)
+        }
+    }
+}
+
+func newToken() string {
+    bytes := make([]byte, 6)
+    _, err := rand.Read(bytes)
+    if nil != err {
+        exception.Panic(exception.NewError("failed to generate profiler token", nil, err))
+    }
+
+    return hex.EncodeToString(bytes)
+}
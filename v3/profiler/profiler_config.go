@@ -0,0 +1,74 @@
+package profiler
+
+import (
+    "github.com/precision-soft/melody/v3/clock"
+    clockcontract "github.com/precision-soft/melody/v3/clock/contract"
+    loggingcontract "github.com/precision-soft/melody/v3/logging/contract"
+)
+
+const (
+    HeaderDebugToken     = "X-Debug-Token"
+    HeaderDebugTokenLink = "X-Debug-Token-Link"
+
+    DefaultPathPrefix = "/_profiler"
+    DefaultCapacity   = 100
+    DefaultMaxRecords = 1000
+)
+
+type ProfilerConfig struct {
+    storage    Storage
+    pathPrefix string
+    maxRecords int
+    logLevel   loggingcontract.Level
+    toolbar    bool
+    clock      clockcontract.Clock
+}
+
+/* @info defaults: the last DefaultCapacity profiles in memory, DefaultMaxRecords entries per collector, logs from info up and the toolbar enabled. */
+func NewProfilerConfig() *ProfilerConfig {
+    return &ProfilerConfig{
+        storage:    NewInMemoryStorage(DefaultCapacity),
+        pathPrefix: DefaultPathPrefix,
+        maxRecords: DefaultMaxRecords,
+        logLevel:   loggingcontract.LevelInfo,
+        toolbar:    true,
+        clock:      clock.NewSystemClock(),
+    }
+}
+
+func (instance *ProfilerConfig) Storage() Storage { return instance.storage }
+
+func (instance *ProfilerConfig) SetStorage(storage Storage) {
+    instance.storage = storage
+}
+
+func (instance *ProfilerConfig) PathPrefix() string { return instance.pathPrefix }
+
+func (instance *ProfilerConfig) SetPathPrefix(pathPrefix string) {
+    instance.pathPrefix = pathPrefix
+}
+
+func (instance *ProfilerConfig) MaxRecords() int { return instance.maxRecords }
+
+/* SetMaxRecords caps every collector of a profile; 0 disables the cap. */
+func (instance *ProfilerConfig) SetMaxRecords(maxRecords int) {
+    instance.maxRecords = maxRecords
+}
+
+func (instance *ProfilerConfig) LogLevel() loggingcontract.Level { return instance.logLevel }
+
+func (instance *ProfilerConfig) SetLogLevel(logLevel loggingcontract.Level) {
+    instance.logLevel = logLevel
+}
+
+func (instance *ProfilerConfig) Toolbar() bool { return instance.toolbar }
+
+func (instance *ProfilerConfig) SetToolbar(toolbar bool) {
+    instance.toolbar = toolbar
+}
+
+func (instance *ProfilerConfig) Clock() clockcontract.Clock { return instance.clock }
+
+func (instance *ProfilerConfig) SetClock(clockInstance clockcontract.Clock) {
+    instance.clock = clockInstance
+}
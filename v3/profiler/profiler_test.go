@@ -0,0 +1,205 @@
+package profiler
+
+import (
+    "context"
+    "io"
+    nethttp "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/precision-soft/melody/v3/cache"
+    cachecontract "github.com/precision-soft/melody/v3/cache/contract"
+    "github.com/precision-soft/melody/v3/clock"
+    "github.com/precision-soft/melody/v3/container"
+    "github.com/precision-soft/melody/v3/http"
+    httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    "github.com/precision-soft/melody/v3/internal/testhelper"
+    "github.com/precision-soft/melody/v3/runtime"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+func newTestProfile() *Profile {
+    return newProfile("token", nethttp.MethodGet, "/users?page=2", "/users", clock.NewFrozenClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)), 2)
+}
+
+func TestInMemoryStorage_KeepsTheLastProfilesNewestFirst(t *testing.T) {
+    storage := NewInMemoryStorage(2)
+
+    for _, token := range []string{"a", "b", "c"} {
+        storage.Save(newProfile(token, nethttp.MethodGet, "/", "/", clock.NewSystemClock(), 0))
+    }
+
+    profiles := storage.List(0)
+    if 2 != len(profiles) || "c" != profiles[0].Token() || "b" != profiles[1].Token() {
+        t.Fatalf("unexpected profiles: %v", profiles)
+    }
+
+    if _, exists := storage.Find("a"); true == exists {
+        t.Fatalf("expected the oldest profile to be overwritten")
+    }
+
+    if profile, exists := storage.Find("b"); false == exists || "b" != profile.Token() {
+        t.Fatalf("expected to find profile b")
+    }
+
+    if 1 != len(storage.List(1)) {
+        t.Fatalf("expected list to honour the limit")
+    }
+}
+
+func TestProfile_CapsEveryCollector(t *testing.T) {
+    profile := newTestProfile()
+
+    for index := 0; index < 3; index++ {
+        profile.RecordQuery(QueryRecord{Query: "select 1"})
+    }
+    profile.RecordLog(LogRecord{Message: "kept"})
+
+    if 2 != len(profile.Queries()) || 1 != len(profile.Logs()) || 1 != profile.DroppedRecords() {
+        t.Fatalf("unexpected records: %d queries, %d logs, %d dropped", len(profile.Queries()), len(profile.Logs()), profile.DroppedRecords())
+    }
+}
+
+func TestNewProfilingCache_RecordsOperationsAndKeepsCapabilities(t *testing.T) {
+    backend := cache.NewInMemoryBackend(0, time.Minute, clock.NewSystemClock())
+    t.Cleanup(func() { _ = backend.Close() })
+
+    manager := cache.NewManager(backend, cache.NewJsonSerializer())
+    profile := newProfile("token", nethttp.MethodGet, "/", "/", clock.NewSystemClock(), 0)
+
+    profilingCache := NewProfilingCache(manager, profile)
+    if _, isTagAware := profilingCache.(cachecontract.TagAwareCache); true == isTagAware {
+        t.Fatalf("expected the decorator of a plain cache not to be tag aware")
+    }
+    if _, isPrefixClearer := profilingCache.(cachecontract.PrefixClearer); false == isPrefixClearer {
+        t.Fatalf("expected the decorator to keep the prefix clearer capability")
+    }
+
+    _, _, _ = profilingCache.Get("user")
+    _ = profilingCache.Set("user", "alice", time.Minute)
+    _, _, _ = profilingCache.Get("user")
+
+    records := profile.CacheRecords()
+    if 3 != len(records) {
+        t.Fatalf("expected 3 cache records, got %d", len(records))
+    }
+
+    if "get" != records[0].Operation || true == records[0].Hit || "set" != records[1].Operation || false == records[2].Hit {
+        t.Fatalf("unexpected cache records: %+v", records)
+    }
+
+    taggedCache := NewProfilingCache(cache.NewTaggedCache(manager), profile)
+    if _, isTagAware := taggedCache.(cachecontract.TagAwareCache); false == isTagAware {
+        t.Fatalf("expected the decorator of a tagged cache to be tag aware")
+    }
+}
+
+func TestProfileMiddlewares_RecordSelfAndTotalDuration(t *testing.T) {
+    profiler := NewProfiler(NewProfilerConfig())
+    profile := newTestProfile()
+
+    middlewares := profiler.ProfileMiddlewares(
+        []httpcontract.Middleware{
+            func(next httpcontract.Handler) httpcontract.Handler {
+                return func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+                    time.Sleep(5 * time.Millisecond)
+
+                    return next(runtimeInstance, writer, request)
+                }
+            },
+        },
+    )
+
+    handler := middlewares[0](
+        func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+            time.Sleep(10 * time.Millisecond)
+
+            return nil, nil
+        },
+    )
+
+    serviceContainer := container.NewContainer()
+    runtimeInstance := runtime.New(ContextWithProfile(context.Background(), profile), serviceContainer.NewScope(), serviceContainer)
+
+    _, _ = handler(runtimeInstance, httptest.NewRecorder(), testhelper.NewHttpTestRequest(nethttp.MethodGet, "/"))
+
+    records := profile.Middlewares()
+    if 1 != len(records) {
+        t.Fatalf("expected 1 middleware record, got %d", len(records))
+    }
+
+    if records[0].TotalDuration < 15*time.Millisecond || records[0].Duration >= 10*time.Millisecond {
+        t.Fatalf("unexpected durations: %+v", records[0])
+    }
+}
+
+func TestProfiler_HandlerSetsDebugTokenAndStoresProfile(t *testing.T) {
+    profiler := NewProfiler(NewProfilerConfig())
+
+    var handledProfile *Profile
+    handler := profiler.Handler(
+        nethttp.HandlerFunc(func(writer nethttp.ResponseWriter, request *nethttp.Request) {
+            handledProfile = FromContext(request.Context())
+        }),
+    )
+
+    recorder := httptest.NewRecorder()
+    handler.ServeHTTP(recorder, httptest.NewRequest(nethttp.MethodGet, "/users", nil))
+
+    token := recorder.Header().Get(HeaderDebugToken)
+    if "" == token || nil == handledProfile || token != handledProfile.Token() {
+        t.Fatalf("expected the debug token of the request profile, got %q", token)
+    }
+
+    if DefaultPathPrefix+"/"+token != recorder.Header().Get(HeaderDebugTokenLink) {
+        t.Fatalf("unexpected debug token link %q", recorder.Header().Get(HeaderDebugTokenLink))
+    }
+
+    if _, exists := profiler.Storage().Find(token); false == exists {
+        t.Fatalf("expected the profile to be stored")
+    }
+
+    profilerRecorder := httptest.NewRecorder()
+    handler.ServeHTTP(profilerRecorder, httptest.NewRequest(nethttp.MethodGet, DefaultPathPrefix+"/"+token, nil))
+
+    if "" != profilerRecorder.Header().Get(HeaderDebugToken) {
+        t.Fatalf("expected profiler pages not to be profiled")
+    }
+}
+
+func TestInjectToolbar_InsertsBeforeClosingBodyOfHtmlResponses(t *testing.T) {
+    profile := newTestProfile()
+
+    response := http.HtmlResponse(nethttp.StatusOK, "<html><body><p>hello</p></BODY></html>")
+    response.Headers().Set("Content-Length", "38")
+    injectToolbar(response, profile, "user_list", "/_profiler/token")
+
+    body := readBody(t, response)
+    if false == strings.Contains(body, `melody-profiler-toolbar`) || false == strings.HasSuffix(body, "</BODY></html>") {
+        t.Fatalf("expected toolbar before the closing body tag, got %q", body)
+    }
+
+    if "" != response.Headers().Get("Content-Length") {
+        t.Fatalf("expected content length to be removed")
+    }
+
+    jsonResponse, _ := http.JsonResponse(nethttp.StatusOK, map[string]any{"body": "</body>"})
+    injectToolbar(jsonResponse, profile, "user_list", "/_profiler/token")
+
+    if strings.Contains(readBody(t, jsonResponse), "melody-profiler-toolbar") {
+        t.Fatalf("expected json responses to be left untouched")
+    }
+}
+
+func readBody(t *testing.T, response httpcontract.Response) string {
+    t.Helper()
+
+    body, readErr := io.ReadAll(response.BodyReader())
+    if nil != readErr {
+        t.Fatalf("unexpected read error: %v", readErr)
+    }
+
+    return string(body)
+}
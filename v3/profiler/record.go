@@ -0,0 +1,76 @@
+package profiler
+
+import (
+    "time"
+)
+
+/* @info durations are serialized in nanoseconds (time.Duration), times in RFC 3339. */
+
+type EventRecord struct {
+    EventName    string        `json:"eventName"`
+    ListenerName string        `json:"listenerName"`
+    Priority     int           `json:"priority"`
+    StartedAt    time.Time     `json:"startedAt"`
+    Duration     time.Duration `json:"duration"`
+    Error        string        `json:"error,omitempty"`
+}
+
+/* MiddlewareRecord holds the time spent in the middleware itself: Duration excludes the rest of the chain and the handler, TotalDuration includes them. */
+type MiddlewareRecord struct {
+    Name          string        `json:"name"`
+    Duration      time.Duration `json:"duration"`
+    TotalDuration time.Duration `json:"totalDuration"`
+}
+
+type CacheRecord struct {
+    Operation string        `json:"operation"`
+    Key       string        `json:"key"`
+    Hit       bool          `json:"hit"`
+    Duration  time.Duration `json:"duration"`
+    Error     string        `json:"error,omitempty"`
+}
+
+type QueryRecord struct {
+    Connection string        `json:"connection"`
+    Query      string        `json:"query"`
+    StartedAt  time.Time     `json:"startedAt"`
+    Duration   time.Duration `json:"duration"`
+    Error      string        `json:"error,omitempty"`
+}
+
+type HttpCallRecord struct {
+    Method     string        `json:"method"`
+    Url        string        `json:"url"`
+    StatusCode int           `json:"statusCode"`
+    StartedAt  time.Time     `json:"startedAt"`
+    Duration   time.Duration `json:"duration"`
+    Error      string        `json:"error,omitempty"`
+}
+
+type LogRecord struct {
+    Level   string         `json:"level"`
+    Message string         `json:"message"`
+    Context map[string]any `json:"context,omitempty"`
+    Time    time.Time      `json:"time"`
+}
+
+type SecurityRecord struct {
+    Firewall       string   `json:"firewall"`
+    Authenticated  bool     `json:"authenticated"`
+    UserIdentifier string   `json:"userIdentifier"`
+    Roles          []string `json:"roles"`
+}
+
+type AccessDecisionRecord struct {
+    Granted    bool     `json:"granted"`
+    Attributes []string `json:"attributes"`
+    Reason     string   `json:"reason,omitempty"`
+}
+
+func errorString(err error) string {
+    if nil == err {
+        return ""
+    }
+
+    return err.Error()
+}
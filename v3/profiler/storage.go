@@ -0,0 +1,98 @@
+package profiler
+
+import (
+    "sync"
+
+    "github.com/precision-soft/melody/v3/exception"
+)
+
+type Storage interface {
+    Save(profile *Profile)
+
+    Find(token string) (*Profile, bool)
+
+    /* List returns at most limit profiles, newest first; 0 means all of them. */
+    List(limit int) []*Profile
+}
+
+func NewInMemoryStorage(capacity int) *InMemoryStorage {
+    if 0 >= capacity {
+        exception.Panic(
+            exception.NewError(
+                "in memory profiler storage capacity must be positive",
+                map[string]any{
+                    "capacity": capacity,
+                },
+                nil,
+            ),
+        )
+    }
+
+    return &InMemoryStorage{
+        profiles: make([]*Profile, capacity),
+    }
+}
+
+/* InMemoryStorage keeps the last capacity profiles in a ring buffer; older profiles are overwritten. */
+type InMemoryStorage struct {
+    mutex    sync.RWMutex
+    profiles []*Profile
+    next     int
+    count    int
+}
+
+func (instance *InMemoryStorage) Save(profile *Profile) {
+    if nil == profile {
+        return
+    }
+
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.profiles[instance.next] = profile
+    instance.next = (instance.next + 1) % len(instance.profiles)
+
+    if instance.count < len(instance.profiles) {
+        instance.count++
+    }
+}
+
+func (instance *InMemoryStorage) Find(token string) (*Profile, bool) {
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    for index := 0; index < instance.count; index++ {
+        profile := instance.at(index)
+        if token == profile.Token() {
+            return profile, true
+        }
+    }
+
+    return nil, false
+}
+
+func (instance *InMemoryStorage) List(limit int) []*Profile {
+    instance.mutex.RLock()
+    defer instance.mutex.RUnlock()
+
+    count := instance.count
+    if 0 < limit && limit < count {
+        count = limit
+    }
+
+    profiles := make([]*Profile, 0, count)
+    for index := 0; index < count; index++ {
+        profiles = append(profiles, instance.at(index))
+    }
+
+    return profiles
+}
+
+/* at returns the index-th newest profile; it must be called with the mutex held. */
+func (instance *InMemoryStorage) at(index int) *Profile {
+    capacity := len(instance.profiles)
+
+    return instance.profiles[(instance.next-1-index+capacity)%capacity]
+}
+
+var _ Storage = (*InMemoryStorage)(nil)
@@ -0,0 +1,62 @@
+package profiler
+
+import (
+    "bytes"
+    "fmt"
+    "html"
+    "io"
+    "strings"
+
+    httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    "github.com/precision-soft/melody/v3/internal"
+)
+
+const toolbarTemplate = `<div id="melody-profiler-toolbar" style="position:fixed;bottom:0;left:0;right:0;z-index:2147483647;padding:4px 12px;background:#222;color:#eee;font:12px/1.6 monospace">` +
+    `melody profiler · %s %s · route %s · <a href="%s" style="color:#8cf">%s</a></div>`
+
+/* injectToolbar inserts the toolbar before the closing body tag of an html response; other responses are left untouched. */
+func injectToolbar(response httpcontract.Response, profile *Profile, routeName string, profileUrl string) {
+    if true == internal.IsNilInterface(response) || nil == response.BodyReader() || nil == response.Headers() {
+        return
+    }
+
+    if false == strings.HasPrefix(strings.ToLower(response.Headers().Get("Content-Type")), "text/html") {
+        return
+    }
+
+    body, readErr := io.ReadAll(response.BodyReader())
+    if nil != readErr {
+        response.SetBodyReader(bytes.NewReader(body))
+
+        return
+    }
+
+    closingBodyIndex := bytes.LastIndex(bytes.ToLower(body), []byte("</body>"))
+    if -1 == closingBodyIndex {
+        response.SetBodyReader(bytes.NewReader(body))
+
+        return
+    }
+
+    if "" == routeName {
+        routeName = "-"
+    }
+
+    toolbar := fmt.Sprintf(
+        toolbarTemplate,
+        html.EscapeString(profile.Method()),
+        html.EscapeString(profile.Path()),
+        html.EscapeString(routeName),
+        html.EscapeString(profileUrl),
+        html.EscapeString(profile.Token()),
+    )
+
+    injected := make([]byte, 0, len(body)+len(toolbar))
+    injected = append(injected, body[:closingBodyIndex]...)
+    injected = append(injected, toolbar...)
+    injected = append(injected, body[closingBodyIndex:]...)
+
+    /* @important the body length changed, so a Content-Length set by the handler would truncate the response */
+    response.Headers().Del("Content-Length")
+    response.SetBodyReader(bytes.NewReader(injected))
+}
@@ -6,6 +6,8 @@
 
     "github.com/precision-soft/melody/v3/exception"
     exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+    "github.com/precision-soft/melody/v3/logging"
+    loggingcontract "github.com/precision-soft/melody/v3/logging/contract"
     securitycontract "github.com/precision-soft/melody/v3/security/contract"
 )
 
@@ -129,35 +131,52 @@ type AccessControlRule struct {
     isExact         bool
     isRegex         bool
     isSegmentPrefix bool
+    logLevel        loggingcontract.Level
+}
+
+/* WithLogLevel returns a copy of the rule that sets the minimum log level of every request it matches (e.g. debug for one endpoint). */
+func (instance AccessControlRule) WithLogLevel(level loggingcontract.Level) AccessControlRule {
+    if false == logging.IsValidLevel(level) {
+        exception.Panic(
+            exception.NewError(
+                "invalid access control log level",
+                exceptioncontract.Context{
+                    "level": string(level),
+                },
+                nil,
+            ),
+        )
+    }
+
+    instance.logLevel = level
+
+    return instance
+}
+
+func (instance AccessControlRule) LogLevel() loggingcontract.Level {
+    return instance.logLevel
 }
 
 func NewAccessControl(rules ...AccessControlRule) *AccessControl {
     normalizedRules := make([]AccessControlRule, 0, len(rules))
 
     for _, rule := range rules {
-        if true == rule.isRegex {
-            normalizedRules = append(normalizedRules, rule)
-            continue
-        }
-
-        if true == rule.isExact {
-            normalizedRule := NewAccessControlExactRule(rule.pathPrefix, rule.attributes...)
-            normalizedRules = append(normalizedRules, normalizedRule)
-            continue
+        var normalizedRule AccessControlRule
+
+        switch {
+        case true == rule.isRegex:
+            normalizedRule = rule
+        case true == rule.isExact:
+            normalizedRule = NewAccessControlExactRule(rule.pathPrefix, rule.attributes...)
+        case true == rule.isSegmentPrefix:
+            normalizedRule = NewAccessControlRuleWithSegmentPrefix(rule.pathPrefix, rule.attributes...)
+        default:
+            normalizedRule = NewAccessControlRule(rule.pathPrefix, rule.attributes...)
         }
 
-        if true == rule.isSegmentPrefix {
-            normalizedRules = append(
-                normalizedRules,
-                NewAccessControlRuleWithSegmentPrefix(rule.pathPrefix, rule.attributes...),
-            )
-            continue
-        }
+        normalizedRule.logLevel = rule.logLevel
 
-        normalizedRules = append(
-            normalizedRules,
-            NewAccessControlRule(rule.pathPrefix, rule.attributes...),
-        )
+        normalizedRules = append(normalizedRules, normalizedRule)
     }
 
     return &AccessControl{
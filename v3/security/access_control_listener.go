@@ -6,6 +6,7 @@
     exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
     "github.com/precision-soft/melody/v3/http"
     kernelcontract "github.com/precision-soft/melody/v3/kernel/contract"
+    "github.com/precision-soft/melody/v3/logging"
     runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
     securitycontract "github.com/precision-soft/melody/v3/security/contract"
 )
@@ -79,6 +80,13 @@ func(runtimeInstance runtimecontract.Runtime, eventValue eventcontract.Event) er
                 securityContext.SetMatchedRule(matchedRule)
             }
 
+            if "" != matchedRule.LogLevel() {
+                logContext := logging.LogContextFromRuntime(runtimeInstance)
+                if nil != logContext {
+                    logContext.SetLevelOverride(matchedRule.LogLevel())
+                }
+            }
+
             if true == containsPublicAccessAttribute(attributes) {
                 _, eventSecurityAuthorizationGrantedErr := eventDispatcher.DispatchName(
                     runtimeInstance,
@@ -302,6 +310,7 @@ func matchAccessControlRule(accessControl *AccessControl, path string, source So
         matchedIndex,
         firewallName,
     )
+    matchedRule.logLevel = matchedRuleValue.logLevel
 
     return matchedRule, matchedRule.Attributes(), true
 }
@@ -7,6 +7,7 @@
     eventcontract "github.com/precision-soft/melody/v3/event/contract"
     httpPkg "github.com/precision-soft/melody/v3/http"
     httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    loggingcontract "github.com/precision-soft/melody/v3/logging/contract"
     runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
     securitycontract "github.com/precision-soft/melody/v3/security/contract"
 )
@@ -79,6 +80,23 @@ func TestMatchAccessControlRule_SetsMetadataCorrectly(t *testing.T) {
     }
 }
 
+func TestMatchAccessControlRule_CarriesLogLevel(t *testing.T) {
+    control := NewAccessControl(
+        NewAccessControlExactRule("/checkout", securitycontract.AttributePublicAccess).WithLogLevel(loggingcontract.LevelDebug),
+        NewAccessControlRule("/", securitycontract.AttributePublicAccess),
+    )
+
+    matchedRule, _, matched := matchAccessControlRule(control, "/checkout", SourceGlobal, "")
+    if false == matched || loggingcontract.LevelDebug != matchedRule.LogLevel() {
+        t.Fatalf("expected debug log level on the matched rule")
+    }
+
+    matchedRule, _, matched = matchAccessControlRule(control, "/other", SourceGlobal, "")
+    if false == matched || "" != matchedRule.LogLevel() {
+        t.Fatalf("expected no log level on the fallback rule")
+    }
+}
+
 func TestMatchAccessControlRule_NormalizesEmptyPathToRoot(t *testing.T) {
     control := NewAccessControl(
         NewAccessControlRule("/", "ROLE_ROOT"),
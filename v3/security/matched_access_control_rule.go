@@ -1,5 +1,9 @@
 package security
 
+import (
+    loggingcontract "github.com/precision-soft/melody/v3/logging/contract"
+)
+
 func NewMatchedAccessControlRule(
     pathPrefix string,
     attributes []string,
@@ -22,6 +26,7 @@ type MatchedAccessControlRule struct {
     source     Source
     ruleIndex  int
     firewall   string
+    logLevel   loggingcontract.Level
 }
 
 func (instance *MatchedAccessControlRule) PathPrefix() string {
@@ -43,3 +48,8 @@ func (instance *MatchedAccessControlRule) RuleIndex() int {
 func (instance *MatchedAccessControlRule) Firewall() string {
     return instance.firewall
 }
+
+/* LogLevel is the log level set on the rule with AccessControlRule.WithLogLevel, or empty. */
+func (instance *MatchedAccessControlRule) LogLevel() loggingcontract.Level {
+    return instance.logLevel
+}
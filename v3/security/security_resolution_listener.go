@@ -105,6 +105,15 @@ func setSecurityContextOnRuntime(
     securityContext := NewSecurityContext(firewall, token)
 
     SecurityContextSetOnRuntime(runtimeInstance, securityContext)
+
+    if nil == token || false == token.IsAuthenticated() || "" == token.UserIdentifier() {
+        return
+    }
+
+    logContext := logging.LogContextFromRuntime(runtimeInstance)
+    if nil != logContext {
+        logContext.Set("userIdentifier", token.UserIdentifier())
+    }
 }
 
 func resolveTokenSourceSafely(
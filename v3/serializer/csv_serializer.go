@@ -0,0 +1,368 @@
+package serializer
+
+import (
+    "bytes"
+    "encoding/csv"
+    "fmt"
+    "reflect"
+    "sort"
+
+    "github.com/precision-soft/melody/v3/exception"
+    exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+    serializercontract "github.com/precision-soft/melody/v3/serializer/contract"
+)
+
+func NewCsvSerializer() *CsvSerializer {
+    return &CsvSerializer{
+        separator: ',',
+    }
+}
+
+func NewCsvSerializerWithSeparator(separator rune) *CsvSerializer {
+    return &CsvSerializer{
+        separator: separator,
+    }
+}
+
+/*
+ * CsvSerializer writes slices of structs (one row per element, header from the json field names), slices of
+ * map[string]X (header from the sorted union of keys) and [][]string as is; a single struct is a one row table.
+ * Deserialize reads the header row back into *[]T (struct or map[string]string elements) or *[][]string.
+ */
+type CsvSerializer struct {
+    separator rune
+}
+
+func (instance *CsvSerializer) Serialize(value any) ([]byte, error) {
+    records, recordsErr := csvRecords(reflect.ValueOf(value))
+    if nil != recordsErr {
+        return nil, recordsErr
+    }
+
+    buffer := &bytes.Buffer{}
+
+    writer := csv.NewWriter(buffer)
+    writer.Comma = instance.separator
+
+    writeErr := writer.WriteAll(records)
+    if nil != writeErr {
+        return nil, exception.NewError("failed to write csv", nil, writeErr)
+    }
+
+    return buffer.Bytes(), nil
+}
+
+func (instance *CsvSerializer) Deserialize(payload []byte, target any) error {
+    if nil == target {
+        return exception.NewError("deserialize target is nil", nil, nil)
+    }
+
+    reader := csv.NewReader(bytes.NewReader(payload))
+    reader.Comma = instance.separator
+
+    records, readErr := reader.ReadAll()
+    if nil != readErr {
+        return exception.NewError("failed to read csv", nil, readErr)
+    }
+
+    rawTarget, isRawTarget := target.(*[][]string)
+    if true == isRawTarget {
+        *rawTarget = records
+
+        return nil
+    }
+
+    targetValue := reflect.ValueOf(target)
+    if reflect.Pointer != targetValue.Kind() || true == targetValue.IsNil() || reflect.Slice != targetValue.Elem().Kind() {
+        return unsupportedCsvTargetError(target)
+    }
+
+    sliceValue := targetValue.Elem()
+    elementType := sliceValue.Type().Elem()
+
+    result := reflect.MakeSlice(sliceValue.Type(), 0, len(records))
+    if 0 == len(records) {
+        sliceValue.Set(result)
+
+        return nil
+    }
+
+    header := records[0]
+
+    for rowIndex, record := range records[1:] {
+        elementValue := reflect.New(elementType).Elem()
+
+        setErr := setCsvRow(elementValue, header, record)
+        if nil != setErr {
+            return exception.NewError(
+                "failed to deserialize csv row",
+                exceptioncontract.Context{
+                    "row": rowIndex + 1,
+                },
+                setErr,
+            )
+        }
+
+        result = reflect.Append(result, elementValue)
+    }
+
+    sliceValue.Set(result)
+
+    return nil
+}
+
+func (instance *CsvSerializer) ContentType() string {
+    return MimeTextCsv + "; charset=utf-8"
+}
+
+var _ serializercontract.Serializer = (*CsvSerializer)(nil)
+
+func csvRecords(value reflect.Value) ([][]string, error) {
+    for true == value.IsValid() && (reflect.Pointer == value.Kind() || reflect.Interface == value.Kind()) {
+        if true == value.IsNil() {
+            return [][]string{}, nil
+        }
+
+        value = value.Elem()
+    }
+
+    if false == value.IsValid() {
+        return [][]string{}, nil
+    }
+
+    if reflect.Struct == value.Kind() {
+        return csvStructRecords(value.Type(), []reflect.Value{value})
+    }
+
+    if reflect.Slice != value.Kind() && reflect.Array != value.Kind() {
+        return nil, unsupportedCsvValueError(value.Type())
+    }
+
+    rawRecords, isRawRecords := value.Interface().([][]string)
+    if true == isRawRecords {
+        return rawRecords, nil
+    }
+
+    elementType := value.Type().Elem()
+    for reflect.Pointer == elementType.Kind() {
+        elementType = elementType.Elem()
+    }
+
+    rows := make([]reflect.Value, 0, value.Len())
+    for index := 0; index < value.Len(); index++ {
+        rows = append(rows, value.Index(index))
+    }
+
+    switch {
+    case reflect.Struct == elementType.Kind() && elementType != timeType:
+        return csvStructRecords(elementType, rows)
+    case reflect.Map == elementType.Kind() && reflect.String == elementType.Key().Kind():
+        return csvMapRecords(rows)
+    default:
+        return nil, unsupportedCsvValueError(value.Type())
+    }
+}
+
+func csvStructRecords(structType reflect.Type, rows []reflect.Value) ([][]string, error) {
+    fields := structFields(structType)
+
+    header := make([]string, 0, len(fields))
+    for _, field := range fields {
+        header = append(header, field.name)
+    }
+
+    records := make([][]string, 0, len(rows)+1)
+    records = append(records, header)
+
+    for _, row := range rows {
+        for reflect.Pointer == row.Kind() || reflect.Interface == row.Kind() {
+            if true == row.IsNil() {
+                break
+            }
+
+            row = row.Elem()
+        }
+
+        record := make([]string, len(fields))
+        if reflect.Struct != row.Kind() {
+            records = append(records, record)
+            continue
+        }
+
+        for fieldIndex, field := range fields {
+            text, formatErr := formatTextValue(row.FieldByIndex(field.index))
+            if nil != formatErr {
+                return nil, exception.NewError(
+                    "failed to format csv value",
+                    exceptioncontract.Context{
+                        "field": field.name,
+                    },
+                    formatErr,
+                )
+            }
+
+            record[fieldIndex] = text
+        }
+
+        records = append(records, record)
+    }
+
+    return records, nil
+}
+
+func csvMapRecords(rows []reflect.Value) ([][]string, error) {
+    keySet := make(map[string]bool)
+    for _, row := range rows {
+        for reflect.Pointer == row.Kind() || reflect.Interface == row.Kind() {
+            if true == row.IsNil() {
+                break
+            }
+
+            row = row.Elem()
+        }
+
+        if reflect.Map != row.Kind() {
+            continue
+        }
+
+        for _, key := range row.MapKeys() {
+            keySet[key.String()] = true
+        }
+    }
+
+    header := make([]string, 0, len(keySet))
+    for key := range keySet {
+        header = append(header, key)
+    }
+
+    sort.Strings(header)
+
+    records := make([][]string, 0, len(rows)+1)
+    records = append(records, header)
+
+    for _, row := range rows {
+        for reflect.Pointer == row.Kind() || reflect.Interface == row.Kind() {
+            if true == row.IsNil() {
+                break
+            }
+
+            row = row.Elem()
+        }
+
+        record := make([]string, len(header))
+        if reflect.Map == row.Kind() {
+            for columnIndex, key := range header {
+                cell := row.MapIndex(reflect.ValueOf(key).Convert(row.Type().Key()))
+                if false == cell.IsValid() {
+                    continue
+                }
+
+                text, formatErr := formatTextValue(cell)
+                if nil != formatErr {
+                    return nil, exception.NewError(
+                        "failed to format csv value",
+                        exceptioncontract.Context{
+                            "field": key,
+                        },
+                        formatErr,
+                    )
+                }
+
+                record[columnIndex] = text
+            }
+        }
+
+        records = append(records, record)
+    }
+
+    return records, nil
+}
+
+func setCsvRow(elementValue reflect.Value, header []string, record []string) error {
+    if reflect.Pointer == elementValue.Kind() {
+        pointerValue := reflect.New(elementValue.Type().Elem())
+
+        setErr := setCsvRow(pointerValue.Elem(), header, record)
+        if nil != setErr {
+            return setErr
+        }
+
+        elementValue.Set(pointerValue)
+
+        return nil
+    }
+
+    switch elementValue.Kind() {
+    case reflect.Struct:
+        fieldsByName := make(map[string]structField)
+        for _, field := range structFields(elementValue.Type()) {
+            fieldsByName[field.name] = field
+        }
+
+        for columnIndex, column := range header {
+            if columnIndex >= len(record) {
+                break
+            }
+
+            field, exists := fieldsByName[column]
+            if false == exists {
+                continue
+            }
+
+            setErr := setTextValue(elementValue.FieldByIndex(field.index), record[columnIndex])
+            if nil != setErr {
+                return exception.NewError(
+                    "invalid csv value",
+                    exceptioncontract.Context{
+                        "column": column,
+                    },
+                    setErr,
+                )
+            }
+        }
+
+        return nil
+    case reflect.Map:
+        if reflect.String != elementValue.Type().Key().Kind() || reflect.String != elementValue.Type().Elem().Kind() {
+            return unsupportedTextTargetError(elementValue.Type())
+        }
+
+        mapValue := reflect.MakeMapWithSize(elementValue.Type(), len(header))
+        for columnIndex, column := range header {
+            if columnIndex >= len(record) {
+                break
+            }
+
+            mapValue.SetMapIndex(
+                reflect.ValueOf(column).Convert(elementValue.Type().Key()),
+                reflect.ValueOf(record[columnIndex]).Convert(elementValue.Type().Elem()),
+            )
+        }
+
+        elementValue.Set(mapValue)
+
+        return nil
+    default:
+        return unsupportedTextTargetError(elementValue.Type())
+    }
+}
+
+func unsupportedCsvValueError(valueType reflect.Type) error {
+    return exception.NewError(
+        "csv serializer supports structs, slices of structs or string keyed maps and [][]string",
+        exceptioncontract.Context{
+            "valueType": fmt.Sprintf("%v", valueType),
+        },
+        nil,
+    )
+}
+
+func unsupportedCsvTargetError(target any) error {
+    return exception.NewError(
+        "unsupported csv deserialize target type",
+        exceptioncontract.Context{
+            "targetType": fmt.Sprintf("%T", target),
+        },
+        nil,
+    )
+}
@@ -0,0 +1,86 @@
+package serializer
+
+import (
+    "reflect"
+    "testing"
+    "time"
+)
+
+type csvSerializerTestRow struct {
+    Id        int       `json:"id"`
+    Name      string    `json:"name"`
+    CreatedAt time.Time `json:"createdAt"`
+    Score     *float64  `json:"score,omitempty"`
+    Secret    string    `json:"-"`
+}
+
+func TestCsvSerializer_SerializesSliceOfStructsWithJsonHeader(t *testing.T) {
+    score := 1.5
+    rows := []csvSerializerTestRow{
+        {Id: 1, Name: "a, b", CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Score: &score, Secret: "x"},
+        {Id: 2, Name: "c"},
+    }
+
+    payload, err := NewCsvSerializer().Serialize(rows)
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    expected := "id,name,createdAt,score\n" +
+        "1,\"a, b\",2024-01-02T03:04:05Z,1.5\n" +
+        "2,c,0001-01-01T00:00:00Z,\n"
+    if expected != string(payload) {
+        t.Fatalf("unexpected csv:\n%s", string(payload))
+    }
+}
+
+func TestCsvSerializer_RoundTripsSliceOfStructs(t *testing.T) {
+    serializerInstance := NewCsvSerializerWithSeparator(';')
+
+    rows := []csvSerializerTestRow{{Id: 7, Name: "seven", CreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}}
+
+    payload, err := serializerInstance.Serialize(rows)
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    var decoded []csvSerializerTestRow
+    err = serializerInstance.Deserialize(payload, &decoded)
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    if false == reflect.DeepEqual(rows, decoded) {
+        t.Fatalf("unexpected rows: %#v", decoded)
+    }
+}
+
+func TestCsvSerializer_SerializesMapsWithSortedHeader(t *testing.T) {
+    payload, err := NewCsvSerializer().Serialize([]map[string]any{{"b": 1, "a": "x"}, {"c": true}})
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    if "a,b,c\nx,1,\n,,true\n" != string(payload) {
+        t.Fatalf("unexpected csv: %q", string(payload))
+    }
+}
+
+func TestCsvSerializer_RejectsScalars(t *testing.T) {
+    _, err := NewCsvSerializer().Serialize(42)
+    if nil == err {
+        t.Fatalf("expected error")
+    }
+}
+
+func TestCsvSerializer_DeserializesIntoMaps(t *testing.T) {
+    var decoded []map[string]string
+    err := NewCsvSerializer().Deserialize([]byte("id,name\n1,a\n"), &decoded)
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    if false == reflect.DeepEqual([]map[string]string{{"id": "1", "name": "a"}}, decoded) {
+        t.Fatalf("unexpected rows: %#v", decoded)
+    }
+}
@@ -0,0 +1,209 @@
+package serializer
+
+import (
+    "fmt"
+    "net/url"
+    "reflect"
+    "sort"
+
+    "github.com/precision-soft/melody/v3/exception"
+    exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+    serializercontract "github.com/precision-soft/melody/v3/serializer/contract"
+)
+
+func NewFormSerializer() *FormSerializer {
+    return &FormSerializer{}
+}
+
+/*
+ * FormSerializer reads and writes application/x-www-form-urlencoded payloads: url.Values, string keyed maps and
+ * structs (keys from the json field names, slices as repeated keys).
+ */
+type FormSerializer struct {
+}
+
+func (instance *FormSerializer) Serialize(value any) ([]byte, error) {
+    values, valuesErr := formValues(reflect.ValueOf(value))
+    if nil != valuesErr {
+        return nil, valuesErr
+    }
+
+    return []byte(values.Encode()), nil
+}
+
+func (instance *FormSerializer) Deserialize(payload []byte, target any) error {
+    if nil == target {
+        return exception.NewError("deserialize target is nil", nil, nil)
+    }
+
+    values, parseErr := url.ParseQuery(string(payload))
+    if nil != parseErr {
+        return exception.NewError("failed to parse form payload", nil, parseErr)
+    }
+
+    switch typedTarget := target.(type) {
+    case *url.Values:
+        *typedTarget = values
+        return nil
+    case *map[string][]string:
+        *typedTarget = values
+        return nil
+    case *map[string]string:
+        flattened := make(map[string]string, len(values))
+        for key := range values {
+            flattened[key] = values.Get(key)
+        }
+
+        *typedTarget = flattened
+        return nil
+    }
+
+    targetValue := reflect.ValueOf(target)
+    if reflect.Pointer != targetValue.Kind() || true == targetValue.IsNil() || reflect.Struct != targetValue.Elem().Kind() {
+        return exception.NewError(
+            "unsupported form deserialize target type",
+            exceptioncontract.Context{
+                "targetType": fmt.Sprintf("%T", target),
+            },
+            nil,
+        )
+    }
+
+    structValue := targetValue.Elem()
+
+    for _, field := range structFields(structValue.Type()) {
+        fieldValues, exists := values[field.name]
+        if false == exists || 0 == len(fieldValues) {
+            continue
+        }
+
+        fieldValue := structValue.FieldByIndex(field.index)
+
+        var setErr error
+        if reflect.Slice == fieldValue.Kind() && reflect.Uint8 != fieldValue.Type().Elem().Kind() && false == reflect.PointerTo(fieldValue.Type()).Implements(textUnmarshalerType) {
+            sliceValue := reflect.MakeSlice(fieldValue.Type(), len(fieldValues), len(fieldValues))
+            for index, text := range fieldValues {
+                setErr = setTextValue(sliceValue.Index(index), text)
+                if nil != setErr {
+                    break
+                }
+            }
+
+            if nil == setErr {
+                fieldValue.Set(sliceValue)
+            }
+        } else {
+            setErr = setTextValue(fieldValue, fieldValues[0])
+        }
+
+        if nil != setErr {
+            return exception.NewError(
+                "invalid form value",
+                exceptioncontract.Context{
+                    "field": field.name,
+                },
+                setErr,
+            )
+        }
+    }
+
+    return nil
+}
+
+func (instance *FormSerializer) ContentType() string {
+    return MimeApplicationFormUrlencoded
+}
+
+var _ serializercontract.Serializer = (*FormSerializer)(nil)
+
+func formValues(value reflect.Value) (url.Values, error) {
+    for true == value.IsValid() && (reflect.Pointer == value.Kind() || reflect.Interface == value.Kind()) {
+        if true == value.IsNil() {
+            return url.Values{}, nil
+        }
+
+        value = value.Elem()
+    }
+
+    values := url.Values{}
+    if false == value.IsValid() {
+        return values, nil
+    }
+
+    switch value.Kind() {
+    case reflect.Map:
+        if reflect.String != value.Type().Key().Kind() {
+            return nil, unsupportedFormValueError(value.Type())
+        }
+
+        keys := make([]string, 0, value.Len())
+        for _, key := range value.MapKeys() {
+            keys = append(keys, key.String())
+        }
+
+        sort.Strings(keys)
+
+        for _, key := range keys {
+            addErr := addFormValue(values, key, value.MapIndex(reflect.ValueOf(key).Convert(value.Type().Key())))
+            if nil != addErr {
+                return nil, addErr
+            }
+        }
+
+        return values, nil
+    case reflect.Struct:
+        for _, field := range structFields(value.Type()) {
+            fieldValue := value.FieldByIndex(field.index)
+            if true == field.omitEmpty && true == isEmptyValue(fieldValue) {
+                continue
+            }
+
+            addErr := addFormValue(values, field.name, fieldValue)
+            if nil != addErr {
+                return nil, addErr
+            }
+        }
+
+        return values, nil
+    default:
+        return nil, unsupportedFormValueError(value.Type())
+    }
+}
+
+func addFormValue(values url.Values, key string, value reflect.Value) error {
+    for reflect.Interface == value.Kind() && false == value.IsNil() {
+        value = value.Elem()
+    }
+
+    if (reflect.Slice == value.Kind() || reflect.Array == value.Kind()) && reflect.Uint8 != value.Type().Elem().Kind() && false == value.Type().Implements(textMarshalerType) {
+        for index := 0; index < value.Len(); index++ {
+            text, formatErr := formatTextValue(value.Index(index))
+            if nil != formatErr {
+                return formatErr
+            }
+
+            values.Add(key, text)
+        }
+
+        return nil
+    }
+
+    text, formatErr := formatTextValue(value)
+    if nil != formatErr {
+        return formatErr
+    }
+
+    values.Add(key, text)
+
+    return nil
+}
+
+func unsupportedFormValueError(valueType reflect.Type) error {
+    return exception.NewError(
+        "form serializer supports structs and string keyed maps",
+        exceptioncontract.Context{
+            "valueType": fmt.Sprintf("%v", valueType),
+        },
+        nil,
+    )
+}
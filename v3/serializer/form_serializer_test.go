@@ -0,0 +1,56 @@
+package serializer
+
+import (
+    "net/url"
+    "reflect"
+    "testing"
+)
+
+type formSerializerTestRequest struct {
+    Name   string   `json:"name"`
+    Age    int      `json:"age,omitempty"`
+    Tags   []string `json:"tags"`
+    Active bool     `json:"active"`
+}
+
+func TestFormSerializer_SerializesStructWithRepeatedKeys(t *testing.T) {
+    payload, err := NewFormSerializer().Serialize(formSerializerTestRequest{Name: "a b", Tags: []string{"x", "y"}, Active: true})
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    if "active=true&name=a+b&tags=x&tags=y" != string(payload) {
+        t.Fatalf("unexpected payload: %s", string(payload))
+    }
+}
+
+func TestFormSerializer_DeserializesIntoStruct(t *testing.T) {
+    var decoded formSerializerTestRequest
+    err := NewFormSerializer().Deserialize([]byte("name=a+b&age=3&tags=x&tags=y&active=true"), &decoded)
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    expected := formSerializerTestRequest{Name: "a b", Age: 3, Tags: []string{"x", "y"}, Active: true}
+    if false == reflect.DeepEqual(expected, decoded) {
+        t.Fatalf("unexpected value: %#v", decoded)
+    }
+}
+
+func TestFormSerializer_DeserializesIntoValuesAndRejectsInvalidNumbers(t *testing.T) {
+    var values url.Values
+    err := NewFormSerializer().Deserialize([]byte("a=1&a=2"), &values)
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    if false == reflect.DeepEqual([]string{"1", "2"}, values["a"]) {
+        t.Fatalf("unexpected values: %v", values)
+    }
+
+    var decoded formSerializerTestRequest
+    err = NewFormSerializer().Deserialize([]byte("age=old"), &decoded)
+    if nil == err {
+        t.Fatalf("expected error")
+    }
+}
@@ -0,0 +1,661 @@
+package serializer
+
+import (
+    "bytes"
+    "encoding"
+    "encoding/binary"
+    "encoding/json"
+    "fmt"
+    "math"
+    "reflect"
+    "sort"
+    "time"
+
+    "github.com/precision-soft/melody/v3/exception"
+    exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+    serializercontract "github.com/precision-soft/melody/v3/serializer/contract"
+)
+
+/* @info the timestamp extension type is -1, written as a two's complement byte. */
+const messagePackTimestampExtension byte = 0xff
+
+/* @important decoding recurses once per nesting level, so untrusted payloads are capped like encoding/json; a stack overflow cannot be recovered */
+const messagePackMaxNestingDepth = 10000
+
+func NewMessagePackSerializer() *MessagePackSerializer {
+    return &MessagePackSerializer{}
+}
+
+/*
+ * MessagePackSerializer encodes values with the MessagePack format: structs become maps keyed by their json field
+ * names (omitempty applies), []byte is bin and time.Time uses the timestamp extension. Deserialize decodes into a
+ * generic value and maps it onto target with encoding/json, so targets follow the same json tags.
+ */
+type MessagePackSerializer struct {
+}
+
+func (instance *MessagePackSerializer) Serialize(value any) ([]byte, error) {
+    buffer := &bytes.Buffer{}
+
+    encodeErr := encodeMessagePack(buffer, reflect.ValueOf(value))
+    if nil != encodeErr {
+        return nil, exception.NewError("failed to serialize message pack", nil, encodeErr)
+    }
+
+    return buffer.Bytes(), nil
+}
+
+func (instance *MessagePackSerializer) Deserialize(payload []byte, target any) error {
+    if nil == target {
+        return exception.NewError("deserialize target is nil", nil, nil)
+    }
+
+    decoder := &messagePackDecoder{payload: payload}
+
+    decoded, decodeErr := decoder.decode()
+    if nil != decodeErr {
+        return exception.NewError("failed to deserialize message pack", nil, decodeErr)
+    }
+
+    if decoder.offset != len(payload) {
+        return exception.NewError(
+            "message pack payload has extra data",
+            exceptioncontract.Context{
+                "offset": decoder.offset,
+            },
+            nil,
+        )
+    }
+
+    anyTarget, isAnyTarget := target.(*any)
+    if true == isAnyTarget {
+        *anyTarget = decoded
+
+        return nil
+    }
+
+    encoded, marshalErr := json.Marshal(decoded)
+    if nil != marshalErr {
+        return exception.NewError("failed to deserialize message pack", nil, marshalErr)
+    }
+
+    return json.Unmarshal(encoded, target)
+}
+
+func (instance *MessagePackSerializer) ContentType() string {
+    return MimeApplicationMessagePack
+}
+
+var _ serializercontract.Serializer = (*MessagePackSerializer)(nil)
+
+func encodeMessagePack(buffer *bytes.Buffer, value reflect.Value) error {
+    if false == value.IsValid() {
+        buffer.WriteByte(0xc0)
+
+        return nil
+    }
+
+    if reflect.Pointer == value.Kind() || reflect.Interface == value.Kind() {
+        if true == value.IsNil() {
+            buffer.WriteByte(0xc0)
+
+            return nil
+        }
+
+        return encodeMessagePack(buffer, value.Elem())
+    }
+
+    if value.Type() == timeType {
+        encodeMessagePackTimestamp(buffer, value.Interface().(time.Time))
+
+        return nil
+    }
+
+    if value.Kind() != reflect.String && true == value.Type().Implements(textMarshalerType) {
+        text, marshalErr := value.Interface().(encoding.TextMarshaler).MarshalText()
+        if nil != marshalErr {
+            return marshalErr
+        }
+
+        encodeMessagePackString(buffer, string(text))
+
+        return nil
+    }
+
+    switch value.Kind() {
+    case reflect.Bool:
+        if true == value.Bool() {
+            buffer.WriteByte(0xc3)
+        } else {
+            buffer.WriteByte(0xc2)
+        }
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+        encodeMessagePackInt(buffer, value.Int())
+    case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+        encodeMessagePackUint(buffer, value.Uint())
+    case reflect.Float32:
+        buffer.WriteByte(0xca)
+        writeMessagePackUint32(buffer, math.Float32bits(float32(value.Float())))
+    case reflect.Float64:
+        buffer.WriteByte(0xcb)
+        writeMessagePackUint64(buffer, math.Float64bits(value.Float()))
+    case reflect.String:
+        encodeMessagePackString(buffer, value.String())
+    case reflect.Slice, reflect.Array:
+        if reflect.Uint8 == value.Type().Elem().Kind() {
+            binary := make([]byte, value.Len())
+            reflect.Copy(reflect.ValueOf(binary), value)
+            encodeMessagePackBinary(buffer, binary)
+
+            return nil
+        }
+
+        if reflect.Slice == value.Kind() && true == value.IsNil() {
+            buffer.WriteByte(0xc0)
+
+            return nil
+        }
+
+        encodeMessagePackLength(buffer, value.Len(), 0x90, 0xdc, 0xdd)
+        for index := 0; index < value.Len(); index++ {
+            encodeErr := encodeMessagePack(buffer, value.Index(index))
+            if nil != encodeErr {
+                return encodeErr
+            }
+        }
+    case reflect.Map:
+        if true == value.IsNil() {
+            buffer.WriteByte(0xc0)
+
+            return nil
+        }
+
+        keys := value.MapKeys()
+        sort.Slice(keys, func(left int, right int) bool {
+            return fmt.Sprint(keys[left].Interface()) < fmt.Sprint(keys[right].Interface())
+        })
+
+        encodeMessagePackLength(buffer, len(keys), 0x80, 0xde, 0xdf)
+        for _, key := range keys {
+            encodeErr := encodeMessagePack(buffer, key)
+            if nil != encodeErr {
+                return encodeErr
+            }
+
+            encodeErr = encodeMessagePack(buffer, value.MapIndex(key))
+            if nil != encodeErr {
+                return encodeErr
+            }
+        }
+    case reflect.Struct:
+        fields := structFields(value.Type())
+
+        present := make([]structField, 0, len(fields))
+        for _, field := range fields {
+            if true == field.omitEmpty && true == isEmptyValue(value.FieldByIndex(field.index)) {
+                continue
+            }
+
+            present = append(present, field)
+        }
+
+        encodeMessagePackLength(buffer, len(present), 0x80, 0xde, 0xdf)
+        for _, field := range present {
+            encodeMessagePackString(buffer, field.name)
+
+            encodeErr := encodeMessagePack(buffer, value.FieldByIndex(field.index))
+            if nil != encodeErr {
+                return encodeErr
+            }
+        }
+    default:
+        return exception.NewError(
+            "unsupported message pack value type",
+            exceptioncontract.Context{
+                "valueType": value.Type().String(),
+            },
+            nil,
+        )
+    }
+
+    return nil
+}
+
+func encodeMessagePackInt(buffer *bytes.Buffer, value int64) {
+    if 0 <= value {
+        encodeMessagePackUint(buffer, uint64(value))
+
+        return
+    }
+
+    switch {
+    case -32 <= value:
+        buffer.WriteByte(byte(int8(value)))
+    case math.MinInt8 <= value:
+        buffer.WriteByte(0xd0)
+        buffer.WriteByte(byte(int8(value)))
+    case math.MinInt16 <= value:
+        buffer.WriteByte(0xd1)
+        writeMessagePackUint16(buffer, uint16(int16(value)))
+    case math.MinInt32 <= value:
+        buffer.WriteByte(0xd2)
+        writeMessagePackUint32(buffer, uint32(int32(value)))
+    default:
+        buffer.WriteByte(0xd3)
+        writeMessagePackUint64(buffer, uint64(value))
+    }
+}
+
+func encodeMessagePackUint(buffer *bytes.Buffer, value uint64) {
+    switch {
+    case value <= 0x7f:
+        buffer.WriteByte(byte(value))
+    case value <= math.MaxUint8:
+        buffer.WriteByte(0xcc)
+        buffer.WriteByte(byte(value))
+    case value <= math.MaxUint16:
+        buffer.WriteByte(0xcd)
+        writeMessagePackUint16(buffer, uint16(value))
+    case value <= math.MaxUint32:
+        buffer.WriteByte(0xce)
+        writeMessagePackUint32(buffer, uint32(value))
+    default:
+        buffer.WriteByte(0xcf)
+        writeMessagePackUint64(buffer, value)
+    }
+}
+
+func encodeMessagePackString(buffer *bytes.Buffer, value string) {
+    length := len(value)
+
+    switch {
+    case length < 32:
+        buffer.WriteByte(0xa0 | byte(length))
+    case length <= math.MaxUint8:
+        buffer.WriteByte(0xd9)
+        buffer.WriteByte(byte(length))
+    case length <= math.MaxUint16:
+        buffer.WriteByte(0xda)
+        writeMessagePackUint16(buffer, uint16(length))
+    default:
+        buffer.WriteByte(0xdb)
+        writeMessagePackUint32(buffer, uint32(length))
+    }
+
+    buffer.WriteString(value)
+}
+
+func encodeMessagePackBinary(buffer *bytes.Buffer, value []byte) {
+    length := len(value)
+
+    switch {
+    case length <= math.MaxUint8:
+        buffer.WriteByte(0xc4)
+        buffer.WriteByte(byte(length))
+    case length <= math.MaxUint16:
+        buffer.WriteByte(0xc5)
+        writeMessagePackUint16(buffer, uint16(length))
+    default:
+        buffer.WriteByte(0xc6)
+        writeMessagePackUint32(buffer, uint32(length))
+    }
+
+    buffer.Write(value)
+}
+
+func encodeMessagePackLength(buffer *bytes.Buffer, length int, fixPrefix byte, prefix16 byte, prefix32 byte) {
+    switch {
+    case length < 16:
+        buffer.WriteByte(fixPrefix | byte(length))
+    case length <= math.MaxUint16:
+        buffer.WriteByte(prefix16)
+        writeMessagePackUint16(buffer, uint16(length))
+    default:
+        buffer.WriteByte(prefix32)
+        writeMessagePackUint32(buffer, uint32(length))
+    }
+}
+
+func encodeMessagePackTimestamp(buffer *bytes.Buffer, value time.Time) {
+    seconds := value.Unix()
+    nanoseconds := int64(value.Nanosecond())
+
+    if 0 == seconds>>34 {
+        combined := uint64(nanoseconds)<<34 | uint64(seconds)
+        if 0 == combined&0xffffffff00000000 {
+            buffer.WriteByte(0xd6)
+            buffer.WriteByte(messagePackTimestampExtension)
+            writeMessagePackUint32(buffer, uint32(combined))
+
+            return
+        }
+
+        buffer.WriteByte(0xd7)
+        buffer.WriteByte(messagePackTimestampExtension)
+        writeMessagePackUint64(buffer, combined)
+
+        return
+    }
+
+    buffer.WriteByte(0xc7)
+    buffer.WriteByte(12)
+    buffer.WriteByte(messagePackTimestampExtension)
+    writeMessagePackUint32(buffer, uint32(nanoseconds))
+    writeMessagePackUint64(buffer, uint64(seconds))
+}
+
+func writeMessagePackUint16(buffer *bytes.Buffer, value uint16) {
+    buffer.Write(binary.BigEndian.AppendUint16(nil, value))
+}
+
+func writeMessagePackUint32(buffer *bytes.Buffer, value uint32) {
+    buffer.Write(binary.BigEndian.AppendUint32(nil, value))
+}
+
+func writeMessagePackUint64(buffer *bytes.Buffer, value uint64) {
+    buffer.Write(binary.BigEndian.AppendUint64(nil, value))
+}
+
+/* @info decodes into generic values: int64 (uint64 above math.MaxInt64), float64, string, []byte, []any, map[string]any and time.Time. */
+type messagePackDecoder struct {
+    payload []byte
+    offset  int
+    depth   int
+}
+
+func (instance *messagePackDecoder) decode() (any, error) {
+    prefix, readErr := instance.readByte()
+    if nil != readErr {
+        return nil, readErr
+    }
+
+    switch {
+    case prefix <= 0x7f:
+        return int64(prefix), nil
+    case prefix >= 0xe0:
+        return int64(int8(prefix)), nil
+    case prefix&0xf0 == 0x80:
+        return instance.decodeMap(int(prefix & 0x0f))
+    case prefix&0xf0 == 0x90:
+        return instance.decodeArray(int(prefix & 0x0f))
+    case prefix&0xe0 == 0xa0:
+        return instance.readString(int(prefix & 0x1f))
+    }
+
+    switch prefix {
+    case 0xc0:
+        return nil, nil
+    case 0xc2:
+        return false, nil
+    case 0xc3:
+        return true, nil
+    case 0xc4, 0xc5, 0xc6:
+        length, lengthErr := instance.readLength(prefix - 0xc4)
+        if nil != lengthErr {
+            return nil, lengthErr
+        }
+
+        data, dataErr := instance.read(length)
+        if nil != dataErr {
+            return nil, dataErr
+        }
+
+        return append([]byte(nil), data...), nil
+    case 0xc7, 0xc8, 0xc9:
+        length, lengthErr := instance.readLength(prefix - 0xc7)
+        if nil != lengthErr {
+            return nil, lengthErr
+        }
+
+        return instance.decodeExtension(length)
+    case 0xca:
+        data, dataErr := instance.read(4)
+        if nil != dataErr {
+            return nil, dataErr
+        }
+
+        return float64(math.Float32frombits(binary.BigEndian.Uint32(data))), nil
+    case 0xcb:
+        data, dataErr := instance.read(8)
+        if nil != dataErr {
+            return nil, dataErr
+        }
+
+        return math.Float64frombits(binary.BigEndian.Uint64(data)), nil
+    case 0xcc, 0xcd, 0xce, 0xcf:
+        data, dataErr := instance.read(1 << (prefix - 0xcc))
+        if nil != dataErr {
+            return nil, dataErr
+        }
+
+        unsigned := readMessagePackUnsigned(data)
+        if unsigned > math.MaxInt64 {
+            return unsigned, nil
+        }
+
+        return int64(unsigned), nil
+    case 0xd0, 0xd1, 0xd2, 0xd3:
+        data, dataErr := instance.read(1 << (prefix - 0xd0))
+        if nil != dataErr {
+            return nil, dataErr
+        }
+
+        unsigned := readMessagePackUnsigned(data)
+        switch len(data) {
+        case 1:
+            return int64(int8(unsigned)), nil
+        case 2:
+            return int64(int16(unsigned)), nil
+        case 4:
+            return int64(int32(unsigned)), nil
+        default:
+            return int64(unsigned), nil
+        }
+    case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+        return instance.decodeExtension(1 << (prefix - 0xd4))
+    case 0xd9, 0xda, 0xdb:
+        length, lengthErr := instance.readLength(prefix - 0xd9)
+        if nil != lengthErr {
+            return nil, lengthErr
+        }
+
+        return instance.readString(length)
+    case 0xdc, 0xdd:
+        length, lengthErr := instance.readLength(prefix - 0xdc + 1)
+        if nil != lengthErr {
+            return nil, lengthErr
+        }
+
+        return instance.decodeArray(length)
+    case 0xde, 0xdf:
+        length, lengthErr := instance.readLength(prefix - 0xde + 1)
+        if nil != lengthErr {
+            return nil, lengthErr
+        }
+
+        return instance.decodeMap(length)
+    default:
+        return nil, exception.NewError(
+            "invalid message pack prefix",
+            exceptioncontract.Context{
+                "prefix": fmt.Sprintf("0x%02x", prefix),
+                "offset": instance.offset - 1,
+            },
+            nil,
+        )
+    }
+}
+
+func (instance *messagePackDecoder) decodeArray(length int) (any, error) {
+    if length > len(instance.payload)-instance.offset {
+        return nil, instance.truncatedError()
+    }
+
+    enterErr := instance.enterNesting()
+    if nil != enterErr {
+        return nil, enterErr
+    }
+
+    defer instance.leaveNesting()
+
+    values := make([]any, 0, length)
+    for index := 0; index < length; index++ {
+        value, decodeErr := instance.decode()
+        if nil != decodeErr {
+            return nil, decodeErr
+        }
+
+        values = append(values, value)
+    }
+
+    return values, nil
+}
+
+func (instance *messagePackDecoder) decodeMap(length int) (any, error) {
+    if length > len(instance.payload)-instance.offset {
+        return nil, instance.truncatedError()
+    }
+
+    enterErr := instance.enterNesting()
+    if nil != enterErr {
+        return nil, enterErr
+    }
+
+    defer instance.leaveNesting()
+
+    values := make(map[string]any, length)
+    for index := 0; index < length; index++ {
+        key, keyErr := instance.decode()
+        if nil != keyErr {
+            return nil, keyErr
+        }
+
+        value, valueErr := instance.decode()
+        if nil != valueErr {
+            return nil, valueErr
+        }
+
+        stringKey, isStringKey := key.(string)
+        if false == isStringKey {
+            stringKey = fmt.Sprint(key)
+        }
+
+        values[stringKey] = value
+    }
+
+    return values, nil
+}
+
+func (instance *messagePackDecoder) decodeExtension(length int) (any, error) {
+    extensionType, typeErr := instance.readByte()
+    if nil != typeErr {
+        return nil, typeErr
+    }
+
+    data, dataErr := instance.read(length)
+    if nil != dataErr {
+        return nil, dataErr
+    }
+
+    if messagePackTimestampExtension != extensionType {
+        return append([]byte(nil), data...), nil
+    }
+
+    switch length {
+    case 4:
+        return time.Unix(int64(binary.BigEndian.Uint32(data)), 0).UTC(), nil
+    case 8:
+        combined := binary.BigEndian.Uint64(data)
+
+        return time.Unix(int64(combined&0x00000003ffffffff), int64(combined>>34)).UTC(), nil
+    case 12:
+        return time.Unix(int64(binary.BigEndian.Uint64(data[4:])), int64(binary.BigEndian.Uint32(data[:4]))).UTC(), nil
+    default:
+        return nil, exception.NewError(
+            "invalid message pack timestamp length",
+            exceptioncontract.Context{
+                "length": length,
+            },
+            nil,
+        )
+    }
+}
+
+/* @info sizeExponent selects a 1, 2 or 4 byte big endian length. */
+func (instance *messagePackDecoder) readLength(sizeExponent byte) (int, error) {
+    data, dataErr := instance.read(1 << sizeExponent)
+    if nil != dataErr {
+        return 0, dataErr
+    }
+
+    return int(readMessagePackUnsigned(data)), nil
+}
+
+func (instance *messagePackDecoder) readString(length int) (any, error) {
+    data, dataErr := instance.read(length)
+    if nil != dataErr {
+        return nil, dataErr
+    }
+
+    return string(data), nil
+}
+
+func (instance *messagePackDecoder) readByte() (byte, error) {
+    data, dataErr := instance.read(1)
+    if nil != dataErr {
+        return 0, dataErr
+    }
+
+    return data[0], nil
+}
+
+func (instance *messagePackDecoder) read(length int) ([]byte, error) {
+    if 0 > length || length > len(instance.payload)-instance.offset {
+        return nil, instance.truncatedError()
+    }
+
+    data := instance.payload[instance.offset : instance.offset+length]
+    instance.offset += length
+
+    return data, nil
+}
+
+func (instance *messagePackDecoder) enterNesting() error {
+    if messagePackMaxNestingDepth <= instance.depth {
+        return exception.NewError(
+            "message pack payload exceeds the maximum nesting depth",
+            exceptioncontract.Context{
+                "offset":   instance.offset,
+                "maxDepth": messagePackMaxNestingDepth,
+            },
+            nil,
+        )
+    }
+
+    instance.depth++
+
+    return nil
+}
+
+func (instance *messagePackDecoder) leaveNesting() {
+    instance.depth--
+}
+
+func (instance *messagePackDecoder) truncatedError() error {
+    return exception.NewError(
+        "message pack payload is truncated",
+        exceptioncontract.Context{
+            "offset": instance.offset,
+        },
+        nil,
+    )
+}
+
+func readMessagePackUnsigned(data []byte) uint64 {
+    var value uint64
+    for _, item := range data {
+        value = value<<8 | uint64(item)
+    }
+
+    return value
+}
@@ -0,0 +1,137 @@
+package serializer
+
+import (
+    "bytes"
+    "errors"
+    "reflect"
+    "strings"
+    "testing"
+    "time"
+)
+
+type messagePackSerializerTestPayload struct {
+    Id        int64             `json:"id"`
+    Name      string            `json:"name"`
+    Ratio     float64           `json:"ratio"`
+    Tags      []string          `json:"tags"`
+    Labels    map[string]string `json:"labels,omitempty"`
+    Data      []byte            `json:"data"`
+    CreatedAt time.Time         `json:"createdAt"`
+    Parent    *int              `json:"parent"`
+}
+
+func TestMessagePackSerializer_EncodesSpecExamples(t *testing.T) {
+    serializerInstance := NewMessagePackSerializer()
+
+    cases := []struct {
+        value    any
+        expected []byte
+    }{
+        {value: nil, expected: []byte{0xc0}},
+        {value: true, expected: []byte{0xc3}},
+        {value: 1, expected: []byte{0x01}},
+        {value: -1, expected: []byte{0xff}},
+        {value: 200, expected: []byte{0xcc, 0xc8}},
+        {value: -200, expected: []byte{0xd1, 0xff, 0x38}},
+        {value: "a", expected: []byte{0xa1, 'a'}},
+        {value: []int{1, 2}, expected: []byte{0x92, 0x01, 0x02}},
+        {value: map[string]int{"a": 1}, expected: []byte{0x81, 0xa1, 'a', 0x01}},
+        {value: time.Unix(1, 0), expected: []byte{0xd6, 0xff, 0x00, 0x00, 0x00, 0x01}},
+    }
+
+    for _, testCase := range cases {
+        payload, err := serializerInstance.Serialize(testCase.value)
+        if nil != err {
+            t.Fatalf("unexpected error for %v: %v", testCase.value, err)
+        }
+
+        if false == bytes.Equal(testCase.expected, payload) {
+            t.Fatalf("unexpected encoding for %v: % x", testCase.value, payload)
+        }
+    }
+}
+
+func TestMessagePackSerializer_RoundTripsStruct(t *testing.T) {
+    serializerInstance := NewMessagePackSerializer()
+
+    value := messagePackSerializerTestPayload{
+        Id:        -70000,
+        Name:      strings.Repeat("n", 40),
+        Ratio:     0.25,
+        Tags:      []string{"a", "b"},
+        Data:      []byte{0x00, 0x01},
+        CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 600, time.UTC),
+    }
+
+    payload, err := serializerInstance.Serialize(value)
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    var decoded messagePackSerializerTestPayload
+    err = serializerInstance.Deserialize(payload, &decoded)
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    if false == reflect.DeepEqual(value, decoded) {
+        t.Fatalf("unexpected value: %#v", decoded)
+    }
+}
+
+func TestMessagePackSerializer_DeserializesIntoAny(t *testing.T) {
+    var decoded any
+    err := NewMessagePackSerializer().Deserialize([]byte{0x82, 0xa1, 'a', 0x01, 0xa1, 'b', 0x92, 0xc2, 0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}, &decoded)
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    expected := map[string]any{"a": int64(1), "b": []any{false, 1.5}}
+    if false == reflect.DeepEqual(expected, decoded) {
+        t.Fatalf("unexpected value: %#v", decoded)
+    }
+}
+
+func TestMessagePackSerializer_RejectsTruncatedAndTrailingPayloads(t *testing.T) {
+    var decoded any
+
+    err := NewMessagePackSerializer().Deserialize([]byte{0x92, 0x01}, &decoded)
+    if nil == err {
+        t.Fatalf("expected error for truncated payload")
+    }
+
+    err = NewMessagePackSerializer().Deserialize([]byte{0x01, 0x02}, &decoded)
+    if nil == err {
+        t.Fatalf("expected error for trailing data")
+    }
+
+    err = NewMessagePackSerializer().Deserialize([]byte{0xc1}, &decoded)
+    if nil == err {
+        t.Fatalf("expected error for reserved prefix")
+    }
+}
+
+func TestMessagePackSerializer_RejectsPayloadsNestedBeyondTheMaximumDepth(t *testing.T) {
+    var decoded any
+
+    payload := bytes.Repeat([]byte{0x91}, 1<<20)
+    payload = append(payload, 0xc0)
+
+    err := NewMessagePackSerializer().Deserialize(payload, &decoded)
+    if nil == err {
+        t.Fatalf("expected error for deeply nested payload")
+    }
+
+    causeErr := errors.Unwrap(err)
+    if nil == causeErr || "message pack payload exceeds the maximum nesting depth" != causeErr.Error() {
+        t.Fatalf("expected the nesting depth error, got %v", causeErr)
+    }
+
+    shallowPayload := bytes.Repeat([]byte{0x91}, messagePackMaxNestingDepth)
+    shallowPayload = append(shallowPayload, 0xc0)
+
+    err = NewMessagePackSerializer().Deserialize(shallowPayload, &decoded)
+    if nil != err {
+        t.Fatalf("unexpected error at the maximum depth: %v", err)
+    }
+}
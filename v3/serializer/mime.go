@@ -7,8 +7,12 @@
 )
 
 const (
-    MimeApplicationJson = "application/json"
-    MimeTextPlain       = "text/plain"
+    MimeApplicationJson           = "application/json"
+    MimeTextPlain                 = "text/plain"
+    MimeApplicationXml            = "application/xml"
+    MimeApplicationMessagePack    = "application/msgpack"
+    MimeTextCsv                   = "text/csv"
+    MimeApplicationFormUrlencoded = "application/x-www-form-urlencoded"
 )
 
 func normalizeMime(mime string) string {
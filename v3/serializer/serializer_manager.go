@@ -63,7 +63,33 @@ func (instance *SerializerManager) Get(mime string) (serializercontract.Serializ
     return serializerInstance, true
 }
 
+func (instance *SerializerManager) Mimes() []string {
+    mimes := make([]string, 0, len(instance.serializersByMime))
+    for mime := range instance.serializersByMime {
+        mimes = append(mimes, mime)
+    }
+
+    sort.Strings(mimes)
+
+    return mimes
+}
+
 func (instance *SerializerManager) ResolveByAcceptHeader(acceptHeader string) (serializercontract.Serializer, error) {
+    serializerInstance, negotiateErr := instance.Negotiate(acceptHeader)
+    if nil == negotiateErr {
+        return serializerInstance, nil
+    }
+
+    serializerInstance, exists := instance.serializersByMime[MimeApplicationJson]
+    if true == exists {
+        return serializerInstance, nil
+    }
+
+    return nil, negotiateErr
+}
+
+/* @info like ResolveByAcceptHeader but without the json fallback when nothing listed in the header is registered. */
+func (instance *SerializerManager) Negotiate(acceptHeader string) (serializercontract.Serializer, error) {
     acceptHeader = strings.TrimSpace(acceptHeader)
     if "" == acceptHeader {
         serializerInstance, exists := instance.serializersByMime[MimeApplicationJson]
@@ -89,6 +115,11 @@ func (instance *SerializerManager) ResolveByAcceptHeader(acceptHeader string) (s
             if true == exists {
                 return serializerInstance, nil
             }
+
+            mimes := instance.Mimes()
+            if 0 < len(mimes) {
+                return instance.serializersByMime[mimes[0]], nil
+            }
             continue
         }
 
@@ -98,26 +129,20 @@ func (instance *SerializerManager) ResolveByAcceptHeader(acceptHeader string) (s
         }
 
         if true == isWildcardSubtype(acceptedMimeValue.mime) {
-            candidateMimes := make([]string, 0, len(instance.serializersByMime))
-            for candidateMime := range instance.serializersByMime {
-                candidateMimes = append(candidateMimes, candidateMime)
-            }
-
-            sort.Strings(candidateMimes)
-
-            for _, candidateMime := range candidateMimes {
-                candidateSerializer := instance.serializersByMime[candidateMime]
+            for _, candidateMime := range instance.Mimes() {
                 if true == matchWildcardSubtype(acceptedMimeValue.mime, candidateMime) {
-                    return candidateSerializer, nil
+                    return instance.serializersByMime[candidateMime], nil
                 }
             }
         }
     }
 
-    serializerInstance, exists := instance.serializersByMime[MimeApplicationJson]
-    if true == exists {
-        return serializerInstance, nil
-    }
-
-    return nil, exception.NewError("no serializer found for accept header", exceptioncontract.Context{"accept": acceptHeader}, nil)
+    return nil, exception.NewError(
+        "no serializer found for accept header",
+        exceptioncontract.Context{
+            "accept":    acceptHeader,
+            "supported": instance.Mimes(),
+        },
+        nil,
+    )
 }
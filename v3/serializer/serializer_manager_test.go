@@ -1,6 +1,7 @@
 package serializer
 
 import (
+    "strings"
     "testing"
 
     serializercontract "github.com/precision-soft/melody/v3/serializer/contract"
@@ -130,3 +131,37 @@ func TestSerializerManager_ResolveByAcceptHeader_WildcardSubtype_SelectsLexicalF
         t.Fatalf("expected lexical first content type to win for wildcard subtype")
     }
 }
+
+func TestSerializerManager_Negotiate_DoesNotFallBackToJson(t *testing.T) {
+    manager, err := NewSerializerManager(
+        map[string]serializercontract.Serializer{
+            MimeApplicationJson: &serializerTestSerializer{name: "json"},
+            MimeTextCsv:         &serializerTestSerializer{name: "csv"},
+        },
+    )
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    _, err = manager.Negotiate("application/xml")
+    if nil == err {
+        t.Fatalf("expected error")
+    }
+
+    serializerInstance, err := manager.Negotiate("application/xml, text/*;q=0.5")
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+    if "csv" != serializerInstance.(*serializerTestSerializer).name {
+        t.Fatalf("expected csv serializer")
+    }
+
+    serializerInstance, err = manager.ResolveByAcceptHeader("application/xml")
+    if nil != err || "json" != serializerInstance.(*serializerTestSerializer).name {
+        t.Fatalf("expected json fallback")
+    }
+
+    if "application/json,text/csv" != strings.Join(manager.Mimes(), ",") {
+        t.Fatalf("unexpected mimes: %v", manager.Mimes())
+    }
+}
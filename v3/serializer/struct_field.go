@@ -0,0 +1,258 @@
+package serializer
+
+import (
+    "encoding"
+    "encoding/json"
+    "fmt"
+    "reflect"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/precision-soft/melody/v3/exception"
+    exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+)
+
+var (
+    timeType            = reflect.TypeOf(time.Time{})
+    textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+    textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+    structFieldCache    sync.Map
+)
+
+/* @info a struct field as encoding/json sees it: the json tag name, omitempty and promoted fields of embedded structs. */
+type structField struct {
+    name      string
+    index     []int
+    omitEmpty bool
+}
+
+func structFields(structType reflect.Type) []structField {
+    cached, exists := structFieldCache.Load(structType)
+    if true == exists {
+        return cached.([]structField)
+    }
+
+    fields := collectStructFields(structType, nil, make(map[string]bool), make(map[reflect.Type]bool))
+
+    structFieldCache.Store(structType, fields)
+
+    return fields
+}
+
+func collectStructFields(structType reflect.Type, parentIndex []int, seenNames map[string]bool, visited map[reflect.Type]bool) []structField {
+    visited[structType] = true
+
+    var fields []structField
+    var embedded []reflect.StructField
+
+    for index := 0; index < structType.NumField(); index++ {
+        field := structType.Field(index)
+
+        tag := field.Tag.Get("json")
+        if "-" == tag {
+            continue
+        }
+
+        tagParts := strings.Split(tag, ",")
+        name := tagParts[0]
+
+        if true == field.Anonymous && "" == name && reflect.Struct == field.Type.Kind() && field.Type != timeType {
+            embedded = append(embedded, field)
+            continue
+        }
+
+        if false == field.IsExported() {
+            continue
+        }
+
+        if "" == name {
+            name = field.Name
+        }
+
+        if true == seenNames[name] {
+            continue
+        }
+
+        seenNames[name] = true
+
+        omitEmpty := false
+        for _, option := range tagParts[1:] {
+            if "omitempty" == option {
+                omitEmpty = true
+            }
+        }
+
+        fields = append(fields, structField{
+            name:      name,
+            index:     appendIndex(parentIndex, index),
+            omitEmpty: omitEmpty,
+        })
+    }
+
+    for _, field := range embedded {
+        if true == visited[field.Type] {
+            continue
+        }
+
+        fields = append(fields, collectStructFields(field.Type, appendIndex(parentIndex, field.Index[0]), seenNames, visited)...)
+    }
+
+    return fields
+}
+
+func appendIndex(parentIndex []int, index int) []int {
+    result := make([]int, 0, len(parentIndex)+1)
+    result = append(result, parentIndex...)
+
+    return append(result, index)
+}
+
+func isEmptyValue(value reflect.Value) bool {
+    switch value.Kind() {
+    case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+        return 0 == value.Len()
+    case reflect.Bool:
+        return false == value.Bool()
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+        return 0 == value.Int()
+    case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+        return 0 == value.Uint()
+    case reflect.Float32, reflect.Float64:
+        return 0 == value.Float()
+    case reflect.Interface, reflect.Pointer:
+        return true == value.IsNil()
+    default:
+        return false
+    }
+}
+
+/* @info renders a scalar the way a text based format (csv, form) writes it; composite values fall back to json. */
+func formatTextValue(value reflect.Value) (string, error) {
+    for reflect.Pointer == value.Kind() || reflect.Interface == value.Kind() {
+        if true == value.IsNil() {
+            return "", nil
+        }
+
+        value = value.Elem()
+    }
+
+    if value.Type() == timeType {
+        return value.Interface().(time.Time).Format(time.RFC3339Nano), nil
+    }
+
+    if true == value.Type().Implements(textMarshalerType) {
+        text, marshalErr := value.Interface().(encoding.TextMarshaler).MarshalText()
+        if nil != marshalErr {
+            return "", marshalErr
+        }
+
+        return string(text), nil
+    }
+
+    switch value.Kind() {
+    case reflect.String:
+        return value.String(), nil
+    case reflect.Bool:
+        return strconv.FormatBool(value.Bool()), nil
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+        return strconv.FormatInt(value.Int(), 10), nil
+    case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+        return strconv.FormatUint(value.Uint(), 10), nil
+    case reflect.Float32, reflect.Float64:
+        return strconv.FormatFloat(value.Float(), 'f', -1, value.Type().Bits()), nil
+    default:
+        encoded, marshalErr := json.Marshal(value.Interface())
+        if nil != marshalErr {
+            return "", marshalErr
+        }
+
+        return string(encoded), nil
+    }
+}
+
+/* @info the inverse of formatTextValue; an empty text leaves pointers nil and other values at their zero value. */
+func setTextValue(target reflect.Value, text string) error {
+    if reflect.Pointer == target.Kind() {
+        if "" == text {
+            target.Set(reflect.Zero(target.Type()))
+
+            return nil
+        }
+
+        pointerValue := reflect.New(target.Type().Elem())
+
+        setErr := setTextValue(pointerValue.Elem(), text)
+        if nil != setErr {
+            return setErr
+        }
+
+        target.Set(pointerValue)
+
+        return nil
+    }
+
+    if "" == text && reflect.String != target.Kind() {
+        target.Set(reflect.Zero(target.Type()))
+
+        return nil
+    }
+
+    if true == reflect.PointerTo(target.Type()).Implements(textUnmarshalerType) {
+        return target.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text))
+    }
+
+    switch target.Kind() {
+    case reflect.String:
+        target.SetString(text)
+    case reflect.Bool:
+        parsed, parseErr := strconv.ParseBool(text)
+        if nil != parseErr {
+            return parseErr
+        }
+
+        target.SetBool(parsed)
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+        parsed, parseErr := strconv.ParseInt(text, 10, target.Type().Bits())
+        if nil != parseErr {
+            return parseErr
+        }
+
+        target.SetInt(parsed)
+    case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+        parsed, parseErr := strconv.ParseUint(text, 10, target.Type().Bits())
+        if nil != parseErr {
+            return parseErr
+        }
+
+        target.SetUint(parsed)
+    case reflect.Float32, reflect.Float64:
+        parsed, parseErr := strconv.ParseFloat(text, target.Type().Bits())
+        if nil != parseErr {
+            return parseErr
+        }
+
+        target.SetFloat(parsed)
+    case reflect.Interface:
+        if 0 != target.NumMethod() {
+            return unsupportedTextTargetError(target.Type())
+        }
+
+        target.Set(reflect.ValueOf(text))
+    default:
+        return json.Unmarshal([]byte(text), target.Addr().Interface())
+    }
+
+    return nil
+}
+
+func unsupportedTextTargetError(targetType reflect.Type) error {
+    return exception.NewError(
+        "unsupported deserialize target type",
+        exceptioncontract.Context{
+            "targetType": fmt.Sprintf("%v", targetType),
+        },
+        nil,
+    )
+}
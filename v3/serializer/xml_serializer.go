@@ -0,0 +1,191 @@
+package serializer
+
+import (
+    "bytes"
+    "encoding/xml"
+    "fmt"
+    "reflect"
+    "sort"
+    "unicode"
+
+    "github.com/precision-soft/melody/v3/exception"
+    exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+    serializercontract "github.com/precision-soft/melody/v3/serializer/contract"
+)
+
+const (
+    defaultXmlRootName = "response"
+    defaultXmlItemName = "item"
+)
+
+func NewXmlSerializer() *XmlSerializer {
+    return &XmlSerializer{
+        rootName: defaultXmlRootName,
+        itemName: defaultXmlItemName,
+    }
+}
+
+func NewXmlSerializerWithNames(rootName string, itemName string) *XmlSerializer {
+    if "" == rootName || "" == itemName {
+        exception.Panic(exception.NewError("xml root and item names are required", nil, nil))
+    }
+
+    return &XmlSerializer{
+        rootName: rootName,
+        itemName: itemName,
+    }
+}
+
+/*
+ * XmlSerializer uses encoding/xml for structs (xml tags apply); slices are wrapped in a root element with one item
+ * element per value and string keyed maps become elements named after the sorted keys, since encoding/xml cannot
+ * encode either at the top level.
+ */
+type XmlSerializer struct {
+    rootName string
+    itemName string
+}
+
+func (instance *XmlSerializer) Serialize(value any) ([]byte, error) {
+    buffer := &bytes.Buffer{}
+    buffer.WriteString(xml.Header)
+
+    encoder := xml.NewEncoder(buffer)
+
+    encodeErr := instance.encode(encoder, reflect.ValueOf(value), instance.rootName, false)
+    if nil != encodeErr {
+        return nil, exception.NewError("failed to serialize xml", nil, encodeErr)
+    }
+
+    flushErr := encoder.Flush()
+    if nil != flushErr {
+        return nil, exception.NewError("failed to serialize xml", nil, flushErr)
+    }
+
+    return buffer.Bytes(), nil
+}
+
+func (instance *XmlSerializer) Deserialize(payload []byte, target any) error {
+    if nil == target {
+        return exception.NewError("deserialize target is nil", nil, nil)
+    }
+
+    return xml.Unmarshal(payload, target)
+}
+
+func (instance *XmlSerializer) ContentType() string {
+    return MimeApplicationXml + "; charset=utf-8"
+}
+
+var _ serializercontract.Serializer = (*XmlSerializer)(nil)
+
+/* @info named forces the element name (map keys); otherwise structs keep the name encoding/xml gives them. */
+func (instance *XmlSerializer) encode(encoder *xml.Encoder, value reflect.Value, name string, named bool) error {
+    for true == value.IsValid() && (reflect.Pointer == value.Kind() || reflect.Interface == value.Kind()) {
+        if true == value.IsNil() {
+            return encoder.EncodeElement("", xml.StartElement{Name: xml.Name{Local: name}})
+        }
+
+        value = value.Elem()
+    }
+
+    if false == value.IsValid() {
+        return encoder.EncodeElement("", xml.StartElement{Name: xml.Name{Local: name}})
+    }
+
+    start := xml.StartElement{Name: xml.Name{Local: name}}
+
+    switch value.Kind() {
+    case reflect.Slice, reflect.Array:
+        if reflect.Uint8 == value.Type().Elem().Kind() {
+            return encoder.EncodeElement(value.Interface(), start)
+        }
+
+        startErr := encoder.EncodeToken(start)
+        if nil != startErr {
+            return startErr
+        }
+
+        for index := 0; index < value.Len(); index++ {
+            encodeErr := instance.encode(encoder, value.Index(index), instance.itemName, false)
+            if nil != encodeErr {
+                return encodeErr
+            }
+        }
+
+        return encoder.EncodeToken(start.End())
+    case reflect.Map:
+        if reflect.String != value.Type().Key().Kind() {
+            return exception.NewError(
+                "xml serializer supports string keyed maps only",
+                exceptioncontract.Context{
+                    "valueType": fmt.Sprintf("%v", value.Type()),
+                },
+                nil,
+            )
+        }
+
+        keys := make([]string, 0, value.Len())
+        for _, key := range value.MapKeys() {
+            keys = append(keys, key.String())
+        }
+
+        sort.Strings(keys)
+
+        startErr := encoder.EncodeToken(start)
+        if nil != startErr {
+            return startErr
+        }
+
+        for _, key := range keys {
+            if false == isXmlName(key) {
+                return exception.NewError(
+                    "xml serializer map key is not a valid element name",
+                    exceptioncontract.Context{
+                        "key": key,
+                    },
+                    nil,
+                )
+            }
+        }
+
+        for _, key := range keys {
+            encodeErr := instance.encode(encoder, value.MapIndex(reflect.ValueOf(key).Convert(value.Type().Key())), key, true)
+            if nil != encodeErr {
+                return encodeErr
+            }
+        }
+
+        return encoder.EncodeToken(start.End())
+    case reflect.Struct:
+        _, hasXmlName := value.Type().FieldByName("XMLName")
+        if value.Type() == timeType || true == named || ("" == value.Type().Name() && false == hasXmlName) {
+            return encoder.EncodeElement(value.Interface(), start)
+        }
+
+        return encoder.Encode(value.Interface())
+    default:
+        return encoder.EncodeElement(value.Interface(), start)
+    }
+}
+
+/* @info checks the xml Name production without the namespace colon, since map keys become unqualified element names. */
+func isXmlName(name string) bool {
+    if "" == name {
+        return false
+    }
+
+    for index, character := range name {
+        if true == unicode.IsLetter(character) || '_' == character {
+            continue
+        }
+
+        if 0 < index && (true == unicode.IsDigit(character) || '-' == character || '.' == character) {
+            continue
+        }
+
+        return false
+    }
+
+    return true
+}
@@ -0,0 +1,79 @@
+package serializer
+
+import (
+    "encoding/xml"
+    "strings"
+    "testing"
+)
+
+type xmlSerializerTestUser struct {
+    XMLName xml.Name `xml:"user"`
+    Id      int      `xml:"id,attr"`
+    Name    string   `xml:"name"`
+}
+
+func TestXmlSerializer_SerializesStructWithXmlTags(t *testing.T) {
+    payload, err := NewXmlSerializer().Serialize(xmlSerializerTestUser{Id: 1, Name: "a"})
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    expected := xml.Header + `<user id="1"><name>a</name></user>`
+    if expected != string(payload) {
+        t.Fatalf("unexpected xml: %s", string(payload))
+    }
+
+    var decoded xmlSerializerTestUser
+    err = NewXmlSerializer().Deserialize(payload, &decoded)
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    if 1 != decoded.Id || "a" != decoded.Name {
+        t.Fatalf("unexpected value: %#v", decoded)
+    }
+}
+
+func TestXmlSerializer_WrapsSlicesAndMaps(t *testing.T) {
+    payload, err := NewXmlSerializerWithNames("users", "entry").Serialize([]any{
+        map[string]any{"b": 2, "a": "x"},
+        xmlSerializerTestUser{Id: 2, Name: "b"},
+    })
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    expected := `<users><entry><a>x</a><b>2</b></entry><user id="2"><name>b</name></user></users>`
+    if expected != strings.TrimPrefix(string(payload), xml.Header) {
+        t.Fatalf("unexpected xml: %s", string(payload))
+    }
+}
+
+func TestXmlSerializer_RejectsMapKeysThatAreNotElementNames(t *testing.T) {
+    for _, key := range []string{"user id", "1x", "a<b", ""} {
+        _, err := NewXmlSerializer().Serialize(map[string]any{key: 1})
+        if nil == err {
+            t.Fatalf("expected error for key %q", key)
+        }
+    }
+
+    payload, err := NewXmlSerializer().Serialize(map[string]any{"user_id": 1, "x-1.b": 2})
+    if nil != err {
+        t.Fatalf("unexpected error: %v", err)
+    }
+
+    expected := `<response><user_id>1</user_id><x-1.b>2</x-1.b></response>`
+    if expected != strings.TrimPrefix(string(payload), xml.Header) {
+        t.Fatalf("unexpected xml: %s", string(payload))
+    }
+}
+
+func TestXmlSerializer_PanicsOnEmptyNames(t *testing.T) {
+    defer func() {
+        if nil == recover() {
+            t.Fatalf("expected panic")
+        }
+    }()
+
+    NewXmlSerializerWithNames("", "item")
+}
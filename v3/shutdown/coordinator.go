@@ -0,0 +1,237 @@
+package shutdown
+
+import (
+    "context"
+    "fmt"
+    "sync"
+    "time"
+
+    "github.com/precision-soft/melody/v3/exception"
+    exceptioncontract "github.com/precision-soft/melody/v3/exception/contract"
+)
+
+type registeredHook struct {
+    name string
+    hook Hook
+}
+
+func NewCoordinator() *Coordinator {
+    phaseTimeouts := make(map[Phase]time.Duration, len(Phases()))
+    for _, phase := range Phases() {
+        phaseTimeouts[phase] = DefaultPhaseTimeout
+    }
+
+    return &Coordinator{
+        phaseTimeouts: phaseTimeouts,
+        hooks:         make(map[Phase][]registeredHook, len(Phases())),
+    }
+}
+
+/*
+ * Coordinator runs the shutdown phases in order; hooks within a phase run concurrently.
+ * A phase ends when all its hooks returned or its timeout expired; hooks still running at that point are abandoned and reported as timed out.
+ */
+type Coordinator struct {
+    mutex         sync.Mutex
+    phaseTimeouts map[Phase]time.Duration
+    hooks         map[Phase][]registeredHook
+    ran           bool
+    report        *Report
+}
+
+func (instance *Coordinator) SetPhaseTimeout(phase Phase, timeout time.Duration) {
+    assertValidPhase(phase)
+
+    if 0 >= timeout {
+        exception.Panic(
+            exception.NewError(
+                "shutdown phase timeout must be positive",
+                exceptioncontract.Context{"phase": string(phase), "timeout": timeout.String()},
+                nil,
+            ),
+        )
+    }
+
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.phaseTimeouts[phase] = timeout
+}
+
+func (instance *Coordinator) PhaseTimeout(phase Phase) time.Duration {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    return instance.phaseTimeouts[phase]
+}
+
+/* AddHook registers a named hook for the phase; hooks added after Run started are ignored. */
+func (instance *Coordinator) AddHook(phase Phase, name string, hook Hook) {
+    assertValidPhase(phase)
+
+    if "" == name {
+        exception.Panic(exception.NewError("shutdown hook name is required", exceptioncontract.Context{"phase": string(phase)}, nil))
+    }
+
+    if nil == hook {
+        exception.Panic(exception.NewError("shutdown hook is nil", exceptioncontract.Context{"phase": string(phase), "hook": name}, nil))
+    }
+
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    instance.hooks[phase] = append(instance.hooks[phase], registeredHook{name: name, hook: hook})
+}
+
+func (instance *Coordinator) HookNames(phase Phase) []string {
+    instance.mutex.Lock()
+    defer instance.mutex.Unlock()
+
+    names := make([]string, 0, len(instance.hooks[phase]))
+    for _, hook := range instance.hooks[phase] {
+        names = append(names, hook.name)
+    }
+
+    return names
+}
+
+/*
+ * Run executes every phase once; later calls return the first report.
+ * Cancelling ctx does not skip phases: every phase still gets its own timeout so connections are closed even when the parent context is already done.
+ */
+func (instance *Coordinator) Run(ctx context.Context) *Report {
+    instance.mutex.Lock()
+    if true == instance.ran {
+        report := instance.report
+        instance.mutex.Unlock()
+
+        return report
+    }
+
+    instance.ran = true
+
+    hooks := make(map[Phase][]registeredHook, len(instance.hooks))
+    for phase, phaseHooks := range instance.hooks {
+        hooks[phase] = append([]registeredHook(nil), phaseHooks...)
+    }
+
+    phaseTimeouts := make(map[Phase]time.Duration, len(instance.phaseTimeouts))
+    for phase, timeout := range instance.phaseTimeouts {
+        phaseTimeouts[phase] = timeout
+    }
+    instance.mutex.Unlock()
+
+    /* @important the parent is usually the already cancelled signal context; only its values are kept. */
+    baseCtx := context.WithoutCancel(ctx)
+
+    startedAt := time.Now()
+    report := &Report{
+        Phases: make([]PhaseReport, 0, len(Phases())),
+    }
+
+    for _, phase := range Phases() {
+        report.Phases = append(report.Phases, runPhase(baseCtx, phase, phaseTimeouts[phase], hooks[phase]))
+    }
+
+    report.Duration = time.Since(startedAt)
+
+    instance.mutex.Lock()
+    instance.report = report
+    instance.mutex.Unlock()
+
+    return report
+}
+
+func runPhase(ctx context.Context, phase Phase, timeout time.Duration, hooks []registeredHook) PhaseReport {
+    phaseReport := PhaseReport{
+        Phase:   phase,
+        Timeout: timeout,
+        Hooks:   make([]HookReport, len(hooks)),
+    }
+
+    if 0 == len(hooks) {
+        return phaseReport
+    }
+
+    phaseCtx, cancel := context.WithTimeout(ctx, timeout)
+    defer cancel()
+
+    startedAt := time.Now()
+
+    type hookResult struct {
+        index    int
+        err      error
+        duration time.Duration
+    }
+
+    /* @important buffered so abandoned hooks never block once the phase moved on. */
+    results := make(chan hookResult, len(hooks))
+
+    for index, hook := range hooks {
+        phaseReport.Hooks[index] = HookReport{Name: hook.name, TimedOut: true}
+
+        go func(index int, hook registeredHook) {
+            hookStartedAt := time.Now()
+            err := runHook(phaseCtx, phase, hook)
+            results <- hookResult{index: index, err: err, duration: time.Since(hookStartedAt)}
+        }(index, hook)
+    }
+
+    pending := len(hooks)
+    for 0 < pending {
+        select {
+        case result := <-results:
+            phaseReport.Hooks[result.index].Err = result.err
+            phaseReport.Hooks[result.index].Duration = result.duration
+            phaseReport.Hooks[result.index].TimedOut = false
+            pending--
+        case <-phaseCtx.Done():
+            for index := range phaseReport.Hooks {
+                if true == phaseReport.Hooks[index].TimedOut {
+                    phaseReport.Hooks[index].Duration = time.Since(startedAt)
+                }
+            }
+            pending = 0
+        }
+    }
+
+    phaseReport.Duration = time.Since(startedAt)
+
+    return phaseReport
+}
+
+func runHook(ctx context.Context, phase Phase, hook registeredHook) (err error) {
+    defer func() {
+        recovered := recover()
+        if nil == recovered {
+            return
+        }
+
+        recoveredErr, isError := recovered.(error)
+        if false == isError {
+            recoveredErr = fmt.Errorf("%v", recovered)
+        }
+
+        err = exception.NewError(
+            "shutdown hook panicked",
+            exceptioncontract.Context{"phase": string(phase), "hook": hook.name},
+            recoveredErr,
+        )
+    }()
+
+    return hook.hook(ctx)
+}
+
+func assertValidPhase(phase Phase) {
+    if true == IsValidPhase(phase) {
+        return
+    }
+
+    exception.Panic(
+        exception.NewError(
+            "unknown shutdown phase",
+            exceptioncontract.Context{"phase": string(phase)},
+            nil,
+        ),
+    )
+}
@@ -0,0 +1,191 @@
+package shutdown
+
+import (
+    "context"
+    "errors"
+    "strings"
+    "sync"
+    "testing"
+    "time"
+
+    "github.com/precision-soft/melody/v3/internal/testhelper"
+)
+
+func TestCoordinator_RunsPhasesInOrderAndHooksOfAPhaseConcurrently(t *testing.T) {
+    coordinator := NewCoordinator()
+
+    var mutex sync.Mutex
+    order := make([]string, 0)
+    record := func(name string) {
+        mutex.Lock()
+        defer mutex.Unlock()
+
+        order = append(order, name)
+    }
+
+    /* @info registered in reverse to prove the phase order does not depend on registration order */
+    coordinator.AddHook(PhaseCloseConnections, "database", func(ctx context.Context) error {
+        record("database")
+
+        return nil
+    })
+
+    release := make(chan struct{})
+    coordinator.AddHook(PhaseDrainHttp, "waits-for-sibling", func(ctx context.Context) error {
+        <-release
+        record("waits-for-sibling")
+
+        return nil
+    })
+    coordinator.AddHook(PhaseDrainHttp, "releases-sibling", func(ctx context.Context) error {
+        close(release)
+        record("releases-sibling")
+
+        return nil
+    })
+    coordinator.AddHook(PhaseStopAccepting, "readiness", func(ctx context.Context) error {
+        record("readiness")
+
+        return nil
+    })
+
+    report := coordinator.Run(context.Background())
+
+    expected := "readiness,releases-sibling,waits-for-sibling,database"
+    if actual := strings.Join(order, ","); expected != actual {
+        t.Fatalf("expected %q, got %q", expected, actual)
+    }
+
+    if len(Phases()) != len(report.Phases) {
+        t.Fatalf("expected a report entry for every phase, got %d", len(report.Phases))
+    }
+
+    if false == report.Clean() {
+        t.Fatalf("expected a clean report, got %+v", report.LogContext())
+    }
+}
+
+func TestCoordinator_AbandonsHooksThatOutliveThePhaseTimeout(t *testing.T) {
+    coordinator := NewCoordinator()
+    coordinator.SetPhaseTimeout(PhaseStopConsumers, 20*time.Millisecond)
+
+    unblock := make(chan struct{})
+    defer close(unblock)
+
+    coordinator.AddHook(PhaseStopConsumers, "stuck", func(ctx context.Context) error {
+        <-unblock
+
+        return nil
+    })
+    coordinator.AddHook(PhaseStopConsumers, "quick", func(ctx context.Context) error {
+        return nil
+    })
+
+    closed := false
+    coordinator.AddHook(PhaseCloseConnections, "database", func(ctx context.Context) error {
+        closed = true
+
+        return nil
+    })
+
+    report := coordinator.Run(context.Background())
+
+    if timedOut := report.TimedOutHooks(); 1 != len(timedOut) || "stop_consumers/stuck" != timedOut[0] {
+        t.Fatalf("expected only the stuck hook to time out, got %v", timedOut)
+    }
+
+    if false == closed {
+        t.Fatalf("expected the later phases to run after a timed out phase")
+    }
+
+    if true == report.Clean() {
+        t.Fatalf("expected a report with a timed out hook not to be clean")
+    }
+}
+
+func TestCoordinator_ReportsErrorsAndRecoversPanics(t *testing.T) {
+    coordinator := NewCoordinator()
+
+    coordinator.AddHook(PhaseFlush, "audit", func(ctx context.Context) error {
+        return errors.New("audit backend unavailable")
+    })
+    coordinator.AddHook(PhaseFlush, "metrics", func(ctx context.Context) error {
+        panic("boom")
+    })
+
+    report := coordinator.Run(context.Background())
+
+    failed := report.FailedHooks()
+    if 2 != len(failed) {
+        t.Fatalf("expected two failed hooks, got %v", failed)
+    }
+
+    for _, phaseReport := range report.Phases {
+        if PhaseFlush != phaseReport.Phase {
+            continue
+        }
+
+        if "audit backend unavailable" != phaseReport.Hooks[0].Err.Error() {
+            t.Fatalf("unexpected audit error: %v", phaseReport.Hooks[0].Err)
+        }
+
+        if false == strings.Contains(phaseReport.Hooks[1].Err.Error(), "panicked") {
+            t.Fatalf("expected the panic to be reported, got %v", phaseReport.Hooks[1].Err)
+        }
+    }
+}
+
+func TestCoordinator_RunsPhasesWithAFreshTimeoutWhenTheParentContextIsCancelled(t *testing.T) {
+    coordinator := NewCoordinator()
+
+    var hookErr error
+    coordinator.AddHook(PhaseCloseConnections, "database", func(ctx context.Context) error {
+        hookErr = ctx.Err()
+
+        return nil
+    })
+
+    parent, cancel := context.WithCancel(context.Background())
+    cancel()
+
+    coordinator.Run(parent)
+
+    if nil != hookErr {
+        t.Fatalf("expected the phase context to be alive, got %v", hookErr)
+    }
+}
+
+func TestCoordinator_RunsOnce(t *testing.T) {
+    coordinator := NewCoordinator()
+
+    calls := 0
+    coordinator.AddHook(PhaseFlush, "counter", func(ctx context.Context) error {
+        calls++
+
+        return nil
+    })
+
+    first := coordinator.Run(context.Background())
+    second := coordinator.Run(context.Background())
+
+    if 1 != calls || first != second {
+        t.Fatalf("expected a single run returning the same report, got %d calls", calls)
+    }
+}
+
+func TestCoordinator_RejectsInvalidRegistrations(t *testing.T) {
+    coordinator := NewCoordinator()
+    noop := func(ctx context.Context) error { return nil }
+
+    for name, callback := range map[string]func(){
+        "unknown phase":   func() { coordinator.AddHook(Phase("later"), "x", noop) },
+        "empty name":      func() { coordinator.AddHook(PhaseFlush, "", noop) },
+        "nil hook":        func() { coordinator.AddHook(PhaseFlush, "x", nil) },
+        "zero timeout":    func() { coordinator.SetPhaseTimeout(PhaseFlush, 0) },
+        "unknown timeout": func() { coordinator.SetPhaseTimeout(Phase("later"), time.Second) },
+    } {
+        t.Run(name, func(t *testing.T) {
+            testhelper.AssertPanics(t, callback)
+        })
+    }
+}
@@ -0,0 +1,4 @@
+/*
+Package shutdown orchestrates graceful shutdown in named, ordered phases (stop accepting, drain http, stop consumers, flush, close connections) with per-phase timeouts, hooks registered by the application and its modules, a readiness probe withdrawn first and a report of what failed or timed out.
+*/
+package shutdown
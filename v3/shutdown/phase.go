@@ -0,0 +1,47 @@
+package shutdown
+
+import (
+    "context"
+    "time"
+)
+
+type Phase string
+
+const (
+    /* @info withdraw readiness and tell long-lived clients (server-sent events, websockets) to reconnect elsewhere. */
+    PhaseStopAccepting Phase = "stop_accepting"
+    /* @info stop the http server and wait for in-flight requests. */
+    PhaseDrainHttp Phase = "drain_http"
+    /* @info stop message bus consumers and workers. */
+    PhaseStopConsumers Phase = "stop_consumers"
+    /* @info drain async event listeners and flush buffered loggers, audit trails, metrics. */
+    PhaseFlush Phase = "flush"
+    /* @info close the service container: database pools, cache clients, files. */
+    PhaseCloseConnections Phase = "close_connections"
+)
+
+const DefaultPhaseTimeout = 5 * time.Second
+
+/* Phases returns the phases in the order they run. */
+func Phases() []Phase {
+    return []Phase{
+        PhaseStopAccepting,
+        PhaseDrainHttp,
+        PhaseStopConsumers,
+        PhaseFlush,
+        PhaseCloseConnections,
+    }
+}
+
+func IsValidPhase(phase Phase) bool {
+    for _, knownPhase := range Phases() {
+        if knownPhase == phase {
+            return true
+        }
+    }
+
+    return false
+}
+
+/* Hook runs during its phase; ctx is cancelled when the phase timeout expires. */
+type Hook func(ctx context.Context) error
@@ -0,0 +1,64 @@
+package shutdown
+
+import (
+    "context"
+    nethttp "net/http"
+    "sync/atomic"
+    "time"
+
+    "github.com/precision-soft/melody/v3/http"
+    httpcontract "github.com/precision-soft/melody/v3/http/contract"
+    runtimecontract "github.com/precision-soft/melody/v3/runtime/contract"
+)
+
+func NewReadiness() *Readiness {
+    return &Readiness{}
+}
+
+/* Readiness is the flag a load balancer probe reads; it starts ready and is withdrawn once, at the start of shutdown. */
+type Readiness struct {
+    notReady atomic.Bool
+}
+
+func (instance *Readiness) IsReady() bool {
+    return false == instance.notReady.Load()
+}
+
+func (instance *Readiness) MarkNotReady() {
+    instance.notReady.Store(true)
+}
+
+/*
+ * Hook withdraws readiness and then waits for delay, so the load balancer has time to observe the failing probe and stop
+ * routing new requests before the http server stops accepting them. The wait ends early when the phase times out.
+ */
+func (instance *Readiness) Hook(delay time.Duration) Hook {
+    return func(ctx context.Context) error {
+        instance.MarkNotReady()
+
+        if 0 >= delay {
+            return nil
+        }
+
+        timer := time.NewTimer(delay)
+        defer timer.Stop()
+
+        select {
+        case <-timer.C:
+        case <-ctx.Done():
+        }
+
+        return nil
+    }
+}
+
+/* Handler answers 200 while ready and 503 once shutdown started. */
+func (instance *Readiness) Handler() httpcontract.Handler {
+    return func(runtimeInstance runtimecontract.Runtime, writer nethttp.ResponseWriter, request httpcontract.Request) (httpcontract.Response, error) {
+        if false == instance.IsReady() {
+            return http.JsonResponse(nethttp.StatusServiceUnavailable, map[string]any{"status": "shutting_down"})
+        }
+
+        return http.JsonResponse(nethttp.StatusOK, map[string]any{"status": "ready"})
+    }
+}
@@ -0,0 +1,60 @@
+package shutdown
+
+import (
+    "context"
+    nethttp "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+
+    "github.com/precision-soft/melody/v3/container"
+    "github.com/precision-soft/melody/v3/http"
+    "github.com/precision-soft/melody/v3/runtime"
+)
+
+func TestReadiness_HandlerAnswersServiceUnavailableOnceWithdrawn(t *testing.T) {
+    readiness := NewReadiness()
+    handler := readiness.Handler()
+
+    serviceContainer := container.NewContainer()
+    runtimeInstance := runtime.New(context.Background(), serviceContainer.NewScope(), serviceContainer)
+
+    statusCode := func() int {
+        request := http.NewRequest(httptest.NewRequest(nethttp.MethodGet, "/ready", nil), nil, nil, nil)
+
+        response, handlerErr := handler(runtimeInstance, httptest.NewRecorder(), request)
+        if nil != handlerErr {
+            t.Fatalf("unexpected error: %v", handlerErr)
+        }
+
+        return response.StatusCode()
+    }
+
+    if nethttp.StatusOK != statusCode() {
+        t.Fatalf("expected 200 while ready")
+    }
+
+    readiness.MarkNotReady()
+
+    if nethttp.StatusServiceUnavailable != statusCode() {
+        t.Fatalf("expected 503 once withdrawn")
+    }
+}
+
+func TestReadiness_HookWithdrawsThenWaitsForTheDelayOrThePhaseTimeout(t *testing.T) {
+    readiness := NewReadiness()
+
+    ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+    defer cancel()
+
+    startedAt := time.Now()
+    _ = readiness.Hook(time.Hour)(ctx)
+
+    if true == readiness.IsReady() {
+        t.Fatalf("expected the hook to withdraw readiness")
+    }
+
+    if time.Second < time.Since(startedAt) {
+        t.Fatalf("expected the delay to end with the phase context")
+    }
+}
@@ -0,0 +1,104 @@
+package shutdown
+
+import (
+    "time"
+
+    loggingcontract "github.com/precision-soft/melody/v3/logging/contract"
+)
+
+type HookReport struct {
+    Name     string
+    Duration time.Duration
+    Err      error
+    TimedOut bool
+}
+
+type PhaseReport struct {
+    Phase    Phase
+    Timeout  time.Duration
+    Duration time.Duration
+    Hooks    []HookReport
+}
+
+func (instance PhaseReport) TimedOut() bool {
+    for _, hookReport := range instance.Hooks {
+        if true == hookReport.TimedOut {
+            return true
+        }
+    }
+
+    return false
+}
+
+type Report struct {
+    Duration time.Duration
+    Phases   []PhaseReport
+}
+
+/* TimedOutHooks lists the hooks still running when their phase timed out, as "<phase>/<hook>". */
+func (instance *Report) TimedOutHooks() []string {
+    hooks := make([]string, 0)
+    for _, phaseReport := range instance.Phases {
+        for _, hookReport := range phaseReport.Hooks {
+            if true == hookReport.TimedOut {
+                hooks = append(hooks, string(phaseReport.Phase)+"/"+hookReport.Name)
+            }
+        }
+    }
+
+    return hooks
+}
+
+/* FailedHooks lists the hooks that returned an error or panicked, as "<phase>/<hook>". */
+func (instance *Report) FailedHooks() []string {
+    hooks := make([]string, 0)
+    for _, phaseReport := range instance.Phases {
+        for _, hookReport := range phaseReport.Hooks {
+            if nil != hookReport.Err {
+                hooks = append(hooks, string(phaseReport.Phase)+"/"+hookReport.Name)
+            }
+        }
+    }
+
+    return hooks
+}
+
+func (instance *Report) Clean() bool {
+    return 0 == len(instance.TimedOutHooks()) && 0 == len(instance.FailedHooks())
+}
+
+func (instance *Report) LogContext() loggingcontract.Context {
+    phases := make([]map[string]any, 0, len(instance.Phases))
+    for _, phaseReport := range instance.Phases {
+        hooks := make([]map[string]any, 0, len(phaseReport.Hooks))
+        for _, hookReport := range phaseReport.Hooks {
+            hook := map[string]any{
+                "name":       hookReport.Name,
+                "durationMs": hookReport.Duration.Milliseconds(),
+                "timedOut":   hookReport.TimedOut,
+            }
+            if nil != hookReport.Err {
+                hook["error"] = hookReport.Err.Error()
+            }
+
+            hooks = append(hooks, hook)
+        }
+
+        phases = append(
+            phases,
+            map[string]any{
+                "phase":      string(phaseReport.Phase),
+                "durationMs": phaseReport.Duration.Milliseconds(),
+                "timeoutMs":  phaseReport.Timeout.Milliseconds(),
+                "hooks":      hooks,
+            },
+        )
+    }
+
+    return loggingcontract.Context{
+        "durationMs": instance.Duration.Milliseconds(),
+        "phases":     phases,
+        "timedOut":   instance.TimedOutHooks(),
+        "failed":     instance.FailedHooks(),
+    }
+}
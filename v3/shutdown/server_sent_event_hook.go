@@ -0,0 +1,19 @@
+package shutdown
+
+import (
+    "context"
+
+    "github.com/precision-soft/melody/v3/http"
+)
+
+/*
+ * ServerSentEventHubHook closes hub for the stop accepting phase, queueing event to every subscriber first so server-sent
+ * event and websocket clients reconnect (to another instance) instead of failing.
+ */
+func ServerSentEventHubHook(hub *http.ServerSentEventHub, event http.ServerSentEvent) Hook {
+    return func(ctx context.Context) error {
+        hub.ShutdownWithEvent(event)
+
+        return nil
+    }
+}